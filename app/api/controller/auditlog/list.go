@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListSpace lists the audit logs of a space and all its subspaces and repositories.
+func (c *Controller) ListSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, int64, error) {
+	spaceCore, err := space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef,
+		enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	filter.SpacePath = spaceCore.Path
+
+	list, count, err := c.auditLogSvc.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list space audit logs: %w", err)
+	}
+
+	return list, count, nil
+}
+
+// List lists all audit logs of the system. Only allowed for admins.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, int64, error) {
+	if !session.Principal.Admin {
+		return nil, 0, usererror.ErrForbidden
+	}
+
+	filter.SpacePath = ""
+
+	list, count, err := c.auditLogSvc.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return list, count, nil
+}
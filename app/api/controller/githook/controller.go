@@ -25,6 +25,7 @@ import (
 	gitevents "github.com/harness/gitness/app/events/git"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
@@ -57,6 +58,7 @@ type Controller struct {
 	postReceiveExtender PostReceiveExtender
 	sseStreamer         sse.Streamer
 	lfsStore            store.LFSObjectStore
+	signatureVerifier   *publickey.SignatureVerifier
 }
 
 func NewController(
@@ -77,6 +79,7 @@ func NewController(
 	postReceiveExtender PostReceiveExtender,
 	sseStreamer sse.Streamer,
 	lfsStore store.LFSObjectStore,
+	signatureVerifier *publickey.SignatureVerifier,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -96,6 +99,7 @@ func NewController(
 		postReceiveExtender: postReceiveExtender,
 		sseStreamer:         sseStreamer,
 		lfsStore:            lfsStore,
+		signatureVerifier:   signatureVerifier,
 	}
 }
 
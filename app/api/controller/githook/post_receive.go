@@ -89,11 +89,24 @@ func (c *Controller) PostReceive(
 			RepoID:      in.RepoID,
 			PrincipalID: in.PrincipalID,
 		},
+		InternalRefsOnly: !hasBranchOrTagUpdate(in.RefUpdates),
 	})
 
 	return out, nil
 }
 
+// hasBranchOrTagUpdate returns true if any of the reference updates is an update of a branch or a tag.
+func hasBranchOrTagUpdate(refUpdates []hook.ReferenceUpdate) bool {
+	for _, refUpdate := range refUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) ||
+			strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // reportReferenceEvents is reporting reference events to the event system.
 // NOTE: keep best effort for now as it doesn't change the outcome of the git operation.
 // TODO: in the future we might want to think about propagating errors so user is aware of events not being triggered.
@@ -24,6 +24,8 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -96,7 +98,7 @@ func (c *Controller) PreReceive(
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		err = c.checkProtectionRules(ctx, rgit, dummySession, repo, refUpdates, in, &output)
 		if output.Error != nil {
 			return output, nil
 		}
@@ -145,9 +147,11 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.RepositoryCore,
 	refUpdates changedRefs,
+	in types.GithookPreReceiveInput,
 	output *hook.Output,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
@@ -163,7 +167,6 @@ func (c *Controller) checkProtectionRules(
 	var ruleViolations []types.RuleViolations
 	var errCheckAction error
 
-	//nolint:unparam
 	checkAction := func(refAction protection.RefAction, refType protection.RefType, names []string) {
 		if errCheckAction != nil || len(names) == 0 {
 			return
@@ -190,11 +193,54 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
 	checkAction(protection.RefActionUpdateForce, protection.RefTypeBranch, refUpdates.branches.forced)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
+	checkAction(protection.RefActionCreate, protection.RefTypeRaw, refUpdates.other.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeRaw, refUpdates.other.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeRaw, refUpdates.other.updated)
 
 	if errCheckAction != nil {
 		return errCheckAction
 	}
 
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New.IsNil() {
+			continue
+		}
+
+		refType, refName := protection.RefTypeRaw, refUpdate.Ref
+		if name, ok := strings.CutPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch); ok {
+			refType, refName = protection.RefTypeBranch, name
+		} else if name, ok := strings.CutPrefix(refUpdate.Ref, gitReferenceNamePrefixTag); ok {
+			refType, refName = protection.RefTypeTag, name
+		}
+
+		newSHA := refUpdate.New.String()
+		violations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+			Actor:       &session.Principal,
+			AllowBypass: true,
+			IsRepoOwner: isRepoOwner,
+			Repo:        repo,
+			RefType:     refType,
+			RefName:     refName,
+			Commits: func(ctx context.Context) ([]protection.PushCommit, error) {
+				return listPushCommits(ctx, rgit, repo, in.Environment.AlternateObjectDirs, newSHA)
+			},
+			SignatureStatuses: func(ctx context.Context, commitSHAs []string) (map[string]enum.GitSignatureStatus, error) {
+				return c.signatureVerifier.VerifyCommits(ctx, git.ReadParams{
+					RepoUID:             repo.GitUID,
+					AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+				}, commitSHAs)
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to verify push rules for git push: %w", err)
+		}
+
+		ruleViolations = append(ruleViolations, violations...)
+	}
+
 	var criticalViolation bool
 
 	for _, ruleViolation := range ruleViolations {
@@ -217,6 +263,49 @@ func (c *Controller) checkProtectionRules(
 	return nil
 }
 
+// listPushCommits returns the commits reachable from the new SHA of a pushed reference that are new to the repository.
+// At most protection.MaxPushCommits+1 commits are returned, so the rules can reject pushes that are too large.
+func listPushCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	alternateObjectDirs []string,
+	newSHA string,
+) ([]protection.PushCommit, error) {
+	out, err := rgit.ListNewCommits(ctx, &git.ListNewCommitsParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: alternateObjectDirs,
+		},
+		Rev:   newSHA,
+		Limit: protection.MaxPushCommits + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	commits := make([]protection.PushCommit, len(out.Commits))
+	for i, commit := range out.Commits {
+		var paths []string
+		for _, fileStat := range commit.FileStats {
+			if fileStat.Status == gitenum.FileDiffStatusDeleted {
+				continue
+			}
+			paths = append(paths, fileStat.Path)
+		}
+
+		commits[i] = protection.PushCommit{
+			SHA:            commit.SHA.String(),
+			Message:        commit.Message,
+			AuthorEmail:    commit.Author.Identity.Email,
+			CommitterEmail: commit.Committer.Identity.Email,
+			Paths:          paths,
+		}
+	}
+
+	return commits, nil
+}
+
 type changes struct {
 	created []string
 	deleted []string
@@ -20,6 +20,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
@@ -61,6 +62,7 @@ func ProvideController(
 	postReceiveExtender PostReceiveExtender,
 	sseStreamer sse.Streamer,
 	lfsStore store.LFSObjectStore,
+	signatureVerifier *publickey.SignatureVerifier,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -80,6 +82,7 @@ func ProvideController(
 		postReceiveExtender,
 		sseStreamer,
 		lfsStore,
+		signatureVerifier,
 	)
 
 	// TODO: improve wiring if possible
@@ -0,0 +1,399 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AutoMergeInput struct {
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+
+	// CancelOnPush disarms the auto-merge if new commits are pushed to the source branch.
+	CancelOnPush bool `json:"cancel_on_push"`
+}
+
+func (in *AutoMergeInput) sanitize() error {
+	if in.Method == "" {
+		return usererror.BadRequest("merge method must be provided")
+	}
+
+	mergeIn := &MergeInput{
+		Method:    in.Method,
+		SourceSHA: "-", // not used for auto-merge, the latest commit always gets merged.
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+	if err := mergeIn.sanitize(); err != nil {
+		return err
+	}
+
+	in.Method = mergeIn.Method
+	in.Title = mergeIn.Title
+	in.Message = mergeIn.Message
+
+	return nil
+}
+
+// AutoMergeFind returns the armed auto-merge of a pull request.
+func (c *Controller) AutoMergeFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqAutoMerge, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	return autoMerge, nil
+}
+
+// AutoMergeArm arms the auto-merge of a pull request. The pull request is merged as soon as
+// it's mergeable and no protection rule blocks the merge. If that's already the case,
+// the pull request gets merged immediately.
+func (c *Controller) AutoMergeArm(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeInput,
+) (*types.PullReqAutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.IsDraft {
+		return nil, usererror.BadRequest("Auto-merge can't be enabled for draft pull requests.")
+	}
+
+	now := time.Now().UnixMilli()
+	autoMerge := &types.PullReqAutoMerge{
+		PullReqID:    pr.ID,
+		CreatedBy:    session.Principal.ID,
+		Created:      now,
+		Updated:      now,
+		MergeMethod:  in.Method,
+		Title:        in.Title,
+		Message:      in.Message,
+		CancelOnPush: in.CancelOnPush,
+		Author:       *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.autoMergeStore.Upsert(ctx, autoMerge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to arm pull request auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, pr, session.Principal.ID, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionArmed,
+		MergeMethod: in.Method,
+	})
+
+	if err = c.AutoMergeAttempt(ctx, repo.ID, pr.Number); err != nil {
+		// non-critical error, the merge will be attempted again on the next pull request update.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to attempt pull request auto-merge")
+	}
+
+	return autoMerge, nil
+}
+
+// AutoMergeDisarm disarms the auto-merge of a pull request.
+func (c *Controller) AutoMergeDisarm(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	return c.autoMergeDisarm(ctx, pr, session.Principal.ID, "")
+}
+
+// AutoMergeCancelOnPush disarms the auto-merge of a pull request if it's configured to be canceled on new pushes.
+func (c *Controller) AutoMergeCancelOnPush(
+	ctx context.Context,
+	repoID int64,
+	pullreqNum int64,
+	principalID int64,
+) error {
+	pr, err := c.pullreqStore.FindByNumber(ctx, repoID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	if !autoMerge.CancelOnPush {
+		return nil
+	}
+
+	err = c.autoMergeDisarm(ctx, pr, principalID, "New commits have been pushed to the source branch.")
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// AutoMergeCancelOnClose disarms the auto-merge of a closed pull request.
+func (c *Controller) AutoMergeCancelOnClose(
+	ctx context.Context,
+	repoID int64,
+	pullreqNum int64,
+	principalID int64,
+) error {
+	pr, err := c.pullreqStore.FindByNumber(ctx, repoID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	err = c.autoMergeDisarm(ctx, pr, principalID, "Pull request has been closed.")
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// AutoMergeAttempt merges the pull request if it has auto-merge armed and nothing blocks the merge.
+// The merge is performed on behalf of the principal that armed the auto-merge.
+// Rule violations only postpone the merge, but any other failure disarms the auto-merge.
+func (c *Controller) AutoMergeAttempt(
+	ctx context.Context,
+	repoID int64,
+	pullreqNum int64,
+) error {
+	pr, err := c.pullreqStore.FindByNumber(ctx, repoID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		// the pull request has been merged or closed in the meantime.
+		if err = c.autoMergeStore.Delete(ctx, pr.ID); err != nil &&
+			!errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+		}
+		return nil
+	}
+
+	if pr.IsDraft || pr.MergeCheckStatus != enum.MergeCheckStatusMergeable {
+		// wait for the pull request to become mergeable.
+		return nil
+	}
+
+	principal, err := c.principalStore.Find(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal that armed the auto-merge: %w", err)
+	}
+
+	repo, err := c.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal, Metadata: &auth.EmptyMetadata{}}
+
+	out, violations, err := c.Merge(ctx, session, repo.Path, pr.Number, &MergeInput{
+		Method:    autoMerge.MergeMethod,
+		SourceSHA: pr.SourceSHA,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+	})
+
+	var uErr *usererror.Error
+	if errors.As(err, &uErr) || apiauth.IsNoAccess(err) {
+		// the pull request might have been merged or updated in the meantime.
+		prLatest, errFind := c.pullreqStore.Find(ctx, pr.ID)
+		if errFind != nil {
+			return fmt.Errorf("failed to find pull request: %w", errFind)
+		}
+
+		if prLatest.State != enum.PullReqStateOpen || prLatest.SourceSHA != pr.SourceSHA {
+			return nil
+		}
+
+		reason := "The user that enabled auto-merge isn't allowed to merge the pull request."
+		if uErr != nil {
+			reason = uErr.Message
+		}
+
+		return c.autoMergeFail(ctx, prLatest, autoMerge, reason)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to merge pull request: %w", err)
+	}
+
+	if violations != nil {
+		if len(violations.ConflictFiles) > 0 {
+			return c.autoMergeFail(ctx, pr, autoMerge, violations.Message)
+		}
+
+		// blocked by protection rules, e.g. by a pending status check or a missing approval.
+		log.Ctx(ctx).Debug().Msgf("auto-merge of pull request postponed: %s", violations.Message)
+
+		return nil
+	}
+
+	err = c.autoMergeStore.Delete(ctx, pr.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete pull request auto-merge: %w", err)
+	}
+
+	pr, err = c.pullreqStore.Find(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, pr, autoMerge.CreatedBy, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionMerged,
+		MergeMethod: autoMerge.MergeMethod,
+	})
+
+	log.Ctx(ctx).Info().Msgf("pull request auto-merged with SHA %s", out.SHA)
+
+	return nil
+}
+
+func (c *Controller) autoMergeDisarm(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	reason string,
+) error {
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request auto-merge: %w", err)
+	}
+
+	err = c.autoMergeStore.Delete(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to disarm pull request auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionDisarmed,
+		MergeMethod: autoMerge.MergeMethod,
+		Reason:      reason,
+	})
+
+	return nil
+}
+
+func (c *Controller) autoMergeFail(
+	ctx context.Context,
+	pr *types.PullReq,
+	autoMerge *types.PullReqAutoMerge,
+	reason string,
+) error {
+	err := c.autoMergeStore.Delete(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil // already disarmed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disarm pull request auto-merge: %w", err)
+	}
+
+	c.writeAutoMergeActivity(ctx, pr, autoMerge.CreatedBy, &types.PullRequestActivityPayloadAutoMerge{
+		Action:      enum.PullReqAutoMergeActionFailed,
+		MergeMethod: autoMerge.MergeMethod,
+		Reason:      reason,
+	})
+
+	return nil
+}
+
+func (c *Controller) writeAutoMergeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadAutoMerge,
+) {
+	err := func() error {
+		pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil)
+		return err
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request auto-merge activity")
+	}
+}
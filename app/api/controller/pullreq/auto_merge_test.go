@@ -0,0 +1,542 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSourceSHA = "1111111111111111111111111111111111111111"
+	testTargetSHA = "2222222222222222222222222222222222222222"
+
+	// ruleRequireCI is a default branch rule definition that requires the "ci" status check.
+	ruleRequireCI = `{"pullreq":{"status_checks":{"require_identifiers":["ci"]}}}`
+)
+
+type autoMergeStore struct {
+	appstore.PullReqAutoMergeStore
+	autoMerges map[int64]*types.PullReqAutoMerge
+}
+
+func (s *autoMergeStore) Find(_ context.Context, pullReqID int64) (*types.PullReqAutoMerge, error) {
+	autoMerge, ok := s.autoMerges[pullReqID]
+	if !ok {
+		return nil, store.ErrResourceNotFound
+	}
+	return autoMerge, nil
+}
+
+func (s *autoMergeStore) Upsert(_ context.Context, autoMerge *types.PullReqAutoMerge) error {
+	s.autoMerges[autoMerge.PullReqID] = autoMerge
+	return nil
+}
+
+func (s *autoMergeStore) Delete(_ context.Context, pullReqID int64) error {
+	if _, ok := s.autoMerges[pullReqID]; !ok {
+		return store.ErrResourceNotFound
+	}
+	delete(s.autoMerges, pullReqID)
+	return nil
+}
+
+type pullReqMapStore struct {
+	appstore.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s *pullReqMapStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, store.ErrResourceNotFound
+	}
+	prCopy := *pr
+	return &prCopy, nil
+}
+
+func (s *pullReqMapStore) FindByNumber(_ context.Context, repoID, number int64) (*types.PullReq, error) {
+	for _, pr := range s.prs {
+		if pr.TargetRepoID == repoID && pr.Number == number {
+			prCopy := *pr
+			return &prCopy, nil
+		}
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+func (s *pullReqMapStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	prCopy := *s.prs[pr.ID]
+	if err := mutateFn(&prCopy); err != nil {
+		return nil, err
+	}
+	s.prs[pr.ID] = &prCopy
+	return &prCopy, nil
+}
+
+func (s *pullReqMapStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	s.prs[pr.ID].ActivitySeq++
+	prCopy := *s.prs[pr.ID]
+	return &prCopy, nil
+}
+
+type activityRecorder struct {
+	appstore.PullReqActivityStore
+	payloads []types.PullReqActivityPayload
+}
+
+func (s *activityRecorder) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.PullReqActivity{PullReqID: pr.ID}, nil
+}
+
+func (s *activityRecorder) autoMergeActions() []enum.PullReqAutoMergeAction {
+	var actions []enum.PullReqAutoMergeAction
+	for _, payload := range s.payloads {
+		if p, ok := payload.(*types.PullRequestActivityPayloadAutoMerge); ok {
+			actions = append(actions, p.Action)
+		}
+	}
+	return actions
+}
+
+type principalFinder struct {
+	appstore.PrincipalStore
+	principal *types.Principal
+}
+
+func (s principalFinder) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if s.principal.ID != id {
+		return nil, store.ErrResourceNotFound
+	}
+	return s.principal, nil
+}
+
+func (principalFinder) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 2, UID: uid, Admin: true}, nil
+}
+
+type emptyReviewerStore struct {
+	appstore.PullReqReviewerStore
+}
+
+func (emptyReviewerStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return nil, nil
+}
+
+// checkResultStore holds the status check results per commit SHA.
+type checkResultStore struct {
+	appstore.CheckStore
+	results map[string][]types.CheckResult
+}
+
+func (s *checkResultStore) ListResults(_ context.Context, _ int64, commitSHA string) ([]types.CheckResult, error) {
+	return s.results[commitSHA], nil
+}
+
+func (s *checkResultStore) report(commitSHA string, status enum.CheckStatus) {
+	s.results[commitSHA] = []types.CheckResult{{Identifier: "ci", Status: status}}
+}
+
+// defaultBranchRuleStore returns a single rule for the default branch with the provided definition.
+type defaultBranchRuleStore struct {
+	appstore.RuleStore
+	definition string
+}
+
+func (s defaultBranchRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return []types.RuleInfoInternal{
+		{
+			RuleInfo: types.RuleInfo{
+				RepoPath:   "space/repo",
+				ID:         1,
+				Identifier: "default",
+				Type:       protection.TypeBranch,
+				State:      enum.RuleStateActive,
+			},
+			Pattern:    []byte(`{"default":true}`),
+			Definition: []byte(s.definition),
+		},
+	}, nil
+}
+
+type spacePathCache struct {
+	appstore.SpacePathCache
+}
+
+func (spacePathCache) Get(_ context.Context, path string) (*types.SpacePath, error) {
+	return &types.SpacePath{Value: path, SpaceID: 1}, nil
+}
+
+type repoRefCache struct {
+	appstore.RepoRefCache
+	repos map[string]int64
+}
+
+func (c repoRefCache) Get(_ context.Context, key types.RepoCacheKey) (int64, error) {
+	repoID, ok := c.repos[key.RepoIdentifier]
+	if !ok {
+		return 0, store.ErrResourceNotFound
+	}
+	return repoID, nil
+}
+
+type internalURLProvider struct {
+	url.Provider
+}
+
+func (internalURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000/api"
+}
+
+type discardStreamer struct {
+	sse.Streamer
+}
+
+func (discardStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
+
+// mergeGit creates a new merge commit for every merge on top of the target branch or the provided base commit.
+type mergeGit struct {
+	git.Interface
+	branchSHA sha.SHA
+	merges    []*git.MergeParams
+	refs      map[string]sha.SHA
+}
+
+func (g *mergeGit) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	return &git.GetBranchOutput{Branch: git.Branch{Name: params.BranchName, SHA: g.branchSHA}}, nil
+}
+
+func (g *mergeGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.merges = append(g.merges, params)
+
+	baseSHA := params.BaseSHA
+	if baseSHA.IsEmpty() {
+		baseSHA = g.branchSHA
+	}
+
+	mergeSHA := sha.Must(fmt.Sprintf("%040x", len(g.merges)))
+	for _, ref := range params.Refs {
+		if ref.New.IsNil() {
+			delete(g.refs, ref.Name)
+			continue
+		}
+		g.refs[ref.Name] = mergeSHA
+	}
+
+	return git.MergeOutput{
+		BaseSHA:      baseSHA,
+		HeadSHA:      params.HeadExpectedSHA,
+		MergeBaseSHA: baseSHA,
+		MergeSHA:     mergeSHA,
+	}, nil
+}
+
+func (g *mergeGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	if params.Type == gitenum.RefTypeBranch {
+		g.branchSHA = params.NewValue
+		return nil
+	}
+
+	refName, err := git.GetRefPath(params.Name, params.Type)
+	if err != nil {
+		return err
+	}
+
+	if params.NewValue.IsNil() {
+		delete(g.refs, refName)
+		return nil
+	}
+
+	g.refs[refName] = params.NewValue
+	return nil
+}
+
+type mergeTest struct {
+	c          *Controller
+	session    *auth.Session
+	pullreqs   *pullReqMapStore
+	activities *activityRecorder
+	checks     *checkResultStore
+	git        *mergeGit
+	autoMerges *autoMergeStore
+	queue      *mergeQueueStore
+}
+
+func setupMergeTest(
+	t *testing.T,
+	permissions []enum.Permission,
+	ruleDefinition string,
+	prs ...*types.PullReq,
+) *mergeTest {
+	t.Helper()
+
+	repo := &types.RepositoryCore{
+		ID:            1,
+		ParentID:      1,
+		Identifier:    "repo",
+		Path:          "space/repo",
+		GitUID:        "repo-uid",
+		DefaultBranch: "main",
+	}
+
+	principal := &types.Principal{ID: 1, UID: "user", Type: enum.PrincipalTypeUser}
+	principalStore := principalFinder{principal: principal}
+
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+
+	err := bootstrap.SystemService(context.Background(), config, service.NewController(nil, nil, principalStore))
+	if err != nil {
+		t.Fatalf("failed to set up system service: %v", err)
+	}
+
+	eventSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create event system: %v", err)
+	}
+	eventReporter, err := pullreqevents.NewReporter(eventSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+
+	protectionManager, err := protection.ProvideManager(defaultBranchRuleStore{definition: ruleDefinition})
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %v", err)
+	}
+
+	test := &mergeTest{
+		session:    &auth.Session{Principal: *principal},
+		pullreqs:   &pullReqMapStore{prs: map[int64]*types.PullReq{}},
+		activities: &activityRecorder{},
+		checks:     &checkResultStore{results: map[string][]types.CheckResult{}},
+		git:        &mergeGit{branchSHA: sha.Must(testTargetSHA), refs: map[string]sha.SHA{}},
+		autoMerges: &autoMergeStore{autoMerges: map[int64]*types.PullReqAutoMerge{}},
+		queue:      &mergeQueueStore{entries: map[int64]*types.MergeQueueEntry{}},
+	}
+
+	for _, pr := range prs {
+		test.pullreqs.prs[pr.ID] = pr
+	}
+
+	test.c = &Controller{
+		urlProvider:    internalURLProvider{},
+		authorizer:     repoPermissionAuthorizer{permissions: map[string][]enum.Permission{"repo": permissions}},
+		pullreqStore:   test.pullreqs,
+		activityStore:  test.activities,
+		reviewerStore:  emptyReviewerStore{},
+		principalStore: principalStore,
+		checkStore:     test.checks,
+		git:            test.git,
+		repoFinder: refcache.NewRepoFinder(nil, spacePathCache{},
+			repoIDCache{repos: map[int64]*types.RepositoryCore{1: repo}},
+			repoRefCache{repos: map[string]int64{"repo": 1}},
+			cache.Evictor[*types.RepositoryCore]{}),
+		eventReporter:     eventReporter,
+		protectionManager: protectionManager,
+		sseStreamer:       discardStreamer{},
+		codeOwners:        codeowners.New(nil, nil, codeowners.Config{}, nil, nil),
+		locker: locker.NewLocker(lock.NewInMemory(lock.Config{
+			App:        "gitness",
+			Namespace:  "test",
+			Expiry:     time.Second,
+			Tries:      1,
+			RetryDelay: time.Millisecond,
+		})),
+		instrumentation:  instrument.Noop{},
+		userGroupService: usergroup.ProvideSearchService(),
+		autoMergeStore:   test.autoMerges,
+		mergeQueueStore:  test.queue,
+	}
+
+	return test
+}
+
+func newOpenPullReq(id int64, sourceSHA string, mergeCheckStatus enum.MergeCheckStatus) *types.PullReq {
+	return &types.PullReq{
+		ID:               id,
+		Number:           id,
+		State:            enum.PullReqStateOpen,
+		SourceRepoID:     1,
+		SourceBranch:     fmt.Sprintf("feature-%d", id),
+		SourceSHA:        sourceSHA,
+		TargetRepoID:     1,
+		TargetBranch:     "main",
+		MergeBaseSHA:     testTargetSHA,
+		MergeCheckStatus: mergeCheckStatus,
+	}
+}
+
+func assertAutoMergeActions(t *testing.T, test *mergeTest, want ...enum.PullReqAutoMergeAction) {
+	t.Helper()
+
+	got := test.activities.autoMergeActions()
+	if fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Fatalf("auto-merge activities: want=%v got=%v", want, got)
+	}
+}
+
+func TestController_AutoMergeArmNotMergeable(t *testing.T) {
+	ctx := context.Background()
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	test := setupMergeTest(t, push, ruleRequireCI,
+		newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusUnchecked))
+
+	autoMerge, err := test.c.AutoMergeArm(ctx, test.session, "space/repo", 1,
+		&AutoMergeInput{Method: enum.MergeMethodSquash})
+	if err != nil {
+		t.Fatalf("failed to arm auto-merge: %v", err)
+	}
+
+	if want, got := enum.MergeMethodSquash, autoMerge.MergeMethod; want != got {
+		t.Errorf("merge method: want=%s got=%s", want, got)
+	}
+
+	if _, ok := test.autoMerges.autoMerges[1]; !ok {
+		t.Error("expected the auto-merge to remain armed")
+	}
+
+	if len(test.git.merges) != 0 {
+		t.Errorf("expected no merge of a pull request that isn't mergeable, got %d", len(test.git.merges))
+	}
+
+	assertAutoMergeActions(t, test, enum.PullReqAutoMergeActionArmed)
+}
+
+func TestController_AutoMergeArmDraft(t *testing.T) {
+	ctx := context.Background()
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	pr := newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable)
+	pr.IsDraft = true
+
+	test := setupMergeTest(t, push, ruleRequireCI, pr)
+
+	_, err := test.c.AutoMergeArm(ctx, test.session, "space/repo", 1,
+		&AutoMergeInput{Method: enum.MergeMethodMerge})
+	if err == nil {
+		t.Fatal("expected an error for a draft pull request")
+	}
+
+	if len(test.autoMerges.autoMerges) != 0 {
+		t.Error("expected the auto-merge not to be armed")
+	}
+}
+
+func TestController_AutoMergeAttemptAfterChecksPass(t *testing.T) {
+	ctx := context.Background()
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	test := setupMergeTest(t, push, ruleRequireCI,
+		newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable))
+	test.checks.report(testSourceSHA, enum.CheckStatusRunning)
+
+	_, err := test.c.AutoMergeArm(ctx, test.session, "space/repo", 1,
+		&AutoMergeInput{Method: enum.MergeMethodMerge})
+	if err != nil {
+		t.Fatalf("failed to arm auto-merge: %v", err)
+	}
+
+	if len(test.git.merges) != 0 {
+		t.Fatalf("expected the merge to wait for the required check, got %d merges", len(test.git.merges))
+	}
+	if _, ok := test.autoMerges.autoMerges[1]; !ok {
+		t.Fatal("expected the auto-merge to remain armed while the check is running")
+	}
+
+	test.checks.report(testSourceSHA, enum.CheckStatusSuccess)
+
+	if err = test.c.AutoMergeAttempt(ctx, 1, 1); err != nil {
+		t.Fatalf("failed to attempt auto-merge: %v", err)
+	}
+
+	if want, got := 1, len(test.git.merges); want != got {
+		t.Fatalf("merges: want=%d got=%d", want, got)
+	}
+	if want, got := enum.PullReqStateMerged, test.pullreqs.prs[1].State; want != got {
+		t.Errorf("pull request state: want=%s got=%s", want, got)
+	}
+	if _, ok := test.autoMerges.autoMerges[1]; ok {
+		t.Error("expected the auto-merge to be removed after the merge")
+	}
+
+	assertAutoMergeActions(t, test, enum.PullReqAutoMergeActionArmed, enum.PullReqAutoMergeActionMerged)
+}
+
+func TestController_AutoMergeAttemptPermissionLost(t *testing.T) {
+	ctx := context.Background()
+	view := []enum.Permission{enum.PermissionRepoView}
+
+	test := setupMergeTest(t, view, ruleRequireCI,
+		newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable))
+	test.checks.report(testSourceSHA, enum.CheckStatusSuccess)
+	test.autoMerges.autoMerges[1] = &types.PullReqAutoMerge{
+		PullReqID:   1,
+		CreatedBy:   test.session.Principal.ID,
+		MergeMethod: enum.MergeMethodMerge,
+	}
+
+	if err := test.c.AutoMergeAttempt(ctx, 1, 1); err != nil {
+		t.Fatalf("failed to attempt auto-merge: %v", err)
+	}
+
+	if len(test.git.merges) != 0 {
+		t.Errorf("expected no merge without push permission, got %d", len(test.git.merges))
+	}
+	if _, ok := test.autoMerges.autoMerges[1]; ok {
+		t.Error("expected the auto-merge to be disarmed")
+	}
+
+	assertAutoMergeActions(t, test, enum.PullReqAutoMergeActionFailed)
+}
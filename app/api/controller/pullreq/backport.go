@@ -0,0 +1,347 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxBackportTargetBranches is the maximum number of branches a pull request can be backported to at once.
+	maxBackportTargetBranches = 10
+	// maxBackportCommits is the maximum number of commits of a rebased pull request that can be backported.
+	maxBackportCommits = 250
+)
+
+type BackportInput struct {
+	// TargetBranches are the branches to which the changes of the pull request are backported.
+	TargetBranches []string `json:"target_branches"`
+
+	IsDraft bool `json:"is_draft"`
+
+	// BypassRules allows the creation of the backport branches to bypass the protection rules.
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *BackportInput) sanitize() error {
+	branches := make([]string, 0, len(in.TargetBranches))
+	seen := make(map[string]struct{}, len(in.TargetBranches))
+	for _, branch := range in.TargetBranches {
+		branch = strings.TrimSpace(branch)
+		if branch == "" {
+			continue
+		}
+		if _, ok := seen[branch]; ok {
+			continue
+		}
+		seen[branch] = struct{}{}
+		branches = append(branches, branch)
+	}
+
+	if len(branches) == 0 {
+		return usererror.BadRequest("At least one target branch must be provided.")
+	}
+
+	if len(branches) > maxBackportTargetBranches {
+		return usererror.BadRequestf("At most %d target branches can be provided.", maxBackportTargetBranches)
+	}
+
+	in.TargetBranches = branches
+
+	return nil
+}
+
+// BackportResult describes the outcome of backporting the pull request to a single target branch.
+type BackportResult struct {
+	TargetBranch string `json:"target_branch"`
+
+	// Branch is the branch with the backported commits. The pull request is opened from it.
+	Branch  string         `json:"branch,omitempty"`
+	PullReq *types.PullReq `json:"pullreq,omitempty"`
+
+	// RuleViolations are the protection rule violations of the creation of the backport branch.
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+
+	ConflictCommitSHA string   `json:"conflict_commit_sha,omitempty"`
+	ConflictFiles     []string `json:"conflict_files,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// backportParams holds the parameters shared by the backports of a pull request to all target branches.
+type backportParams struct {
+	rules       protection.Protection
+	isRepoOwner bool
+	writeParams git.WriteParams
+	committer   *git.Identity
+	commitSHAs  []sha.SHA
+}
+
+type BackportOutput struct {
+	Results []BackportResult `json:"results"`
+}
+
+// Backport applies the changes of a merged pull request to the provided target branches.
+// For every target branch a backport branch is created and a pull request is opened from it.
+// Failure to backport to one target branch doesn't prevent backporting to the others.
+func (c *Controller) Backport(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *BackportInput,
+) (*BackportOutput, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeTargetSHA == nil {
+		return nil, usererror.BadRequest("Only merged pull requests can be backported.")
+	}
+
+	commitSHAs, err := c.listBackportCommits(ctx, repo, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	params := &backportParams{
+		rules:       rules,
+		isRepoOwner: isRepoOwner,
+		writeParams: writeParams,
+		committer:   controller.SystemServicePrincipalInfo(),
+		commitSHAs:  commitSHAs,
+	}
+
+	out := &BackportOutput{
+		Results: make([]BackportResult, len(in.TargetBranches)),
+	}
+
+	for i, targetBranch := range in.TargetBranches {
+		result := BackportResult{
+			TargetBranch: targetBranch,
+		}
+
+		err = c.backport(ctx, session, repo, pr, params, in, &result)
+		if err != nil {
+			uErr := usererror.Translate(ctx, err)
+			if uErr.Status >= http.StatusInternalServerError {
+				return nil, fmt.Errorf("failed to backport pull request to branch %q: %w", targetBranch, err)
+			}
+			result.Error = uErr.Message
+		}
+
+		out.Results[i] = result
+	}
+
+	return out, nil
+}
+
+// listBackportCommits returns commits that the pull request added to its target branch, oldest first.
+// Merge and squash merges added a single commit. For the rebase and the fast-forward merges
+// all non-merge commits between the target branch SHA and the merge SHA are returned.
+func (c *Controller) listBackportCommits(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) ([]sha.SHA, error) {
+	mergeSHA := sha.Must(*pr.MergeSHA)
+
+	if pr.MergeMethod != nil &&
+		(*pr.MergeMethod == enum.MergeMethodMerge || *pr.MergeMethod == enum.MergeMethodSquash) {
+		return []sha.SHA{mergeSHA}, nil
+	}
+
+	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     mergeSHA.String(),
+		After:      *pr.MergeTargetSHA,
+		Limit:      maxBackportCommits + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	if len(output.Commits) > maxBackportCommits {
+		return nil, usererror.BadRequestf("Pull requests with more than %d commits can't be backported.",
+			maxBackportCommits)
+	}
+
+	commitSHAs := make([]sha.SHA, 0, len(output.Commits))
+	for i := len(output.Commits) - 1; i >= 0; i-- {
+		if len(output.Commits[i].ParentSHAs) > 1 {
+			continue
+		}
+		commitSHAs = append(commitSHAs, output.Commits[i].SHA)
+	}
+
+	if len(commitSHAs) == 0 {
+		return nil, usererror.BadRequest("Pull request doesn't contain any commits that can be backported.")
+	}
+
+	return commitSHAs, nil
+}
+
+func (c *Controller) backport(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	params *backportParams,
+	in *BackportInput,
+	result *BackportResult,
+) error {
+	if result.TargetBranch == pr.TargetBranch {
+		return usererror.BadRequestf("Pull request is already merged to branch %q.", result.TargetBranch)
+	}
+
+	if _, err := c.verifyBranchExistence(ctx, repo, result.TargetBranch); err != nil {
+		return err
+	}
+
+	branch := "backport-pullreq-" + strconv.FormatInt(pr.Number, 10) + "-to-" + result.TargetBranch
+
+	branchRef, err := git.GetRefPath(branch, gitenum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to generate backport branch ref name: %w", err)
+	}
+
+	// the branch is created with internal write params, so the branch rules must be verified here.
+	violations, err := params.rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: params.isRepoOwner,
+		Repo:        repo,
+		RefAction:   protection.RefActionCreate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branch},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		result.RuleViolations = violations
+		return usererror.BadRequestf("Creation of branch %q is blocked by protection rules.", branch)
+	}
+
+	now := time.Now()
+
+	cherryPickOut, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:   params.writeParams,
+		BaseBranch:    result.TargetBranch,
+		CommitSHAs:    params.commitSHAs,
+		AppendOrigin:  true,
+		Committer:     params.committer,
+		CommitterDate: &now,
+		Refs: []git.RefUpdate{
+			{
+				Name: branchRef,
+				Old:  sha.Nil, // the backport branch must not exist
+			},
+		},
+	})
+	if errors.IsConflict(err) {
+		return usererror.Conflict(fmt.Sprintf("Branch %q already exists.", branch))
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(cherryPickOut.ConflictFiles) > 0 {
+		result.ConflictCommitSHA = cherryPickOut.ConflictCommitSHA.String()
+		result.ConflictFiles = cherryPickOut.ConflictFiles
+		return nil
+	}
+
+	if cherryPickOut.CommitCount == 0 {
+		return usererror.BadRequestf("Changes of the pull request already exist on branch %q.", result.TargetBranch)
+	}
+
+	result.Branch = branch
+
+	result.PullReq, err = c.Create(ctx, session, repo.Path, &CreateInput{
+		IsDraft:      in.IsDraft,
+		Title:        fmt.Sprintf("[%s] %s", result.TargetBranch, pr.Title),
+		Description:  fmt.Sprintf("Backport of #%d to `%s`.", pr.Number, result.TargetBranch),
+		SourceBranch: branch,
+		TargetBranch: result.TargetBranch,
+	})
+	if err != nil {
+		// the branch would otherwise block any retry of the backport
+		c.deleteBackportBranch(ctx, params.writeParams, branch, cherryPickOut.CommitSHA)
+		result.Branch = ""
+		return fmt.Errorf("failed to create backport pull request: %w", err)
+	}
+
+	return nil
+}
+
+// deleteBackportBranch deletes the backport branch, unless it got updated since it was created.
+func (c *Controller) deleteBackportBranch(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	branch string,
+	commitSHA sha.SHA,
+) {
+	err := c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        branch,
+		NewValue:    sha.Nil,
+		OldValue:    commitSHA,
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete backport branch %q", branch)
+	}
+}
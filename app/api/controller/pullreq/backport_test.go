@@ -0,0 +1,250 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestBackportInput_sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "no branches",
+			in:      []string{" ", ""},
+			wantErr: true,
+		},
+		{
+			name: "trimmed and deduplicated",
+			in:   []string{" release/1.0", "release/1.1", "release/1.0 "},
+			want: []string{"release/1.0", "release/1.1"},
+		},
+		{
+			name:    "too many branches",
+			in:      []string{"b1", "b2", "b3", "b4", "b5", "b6", "b7", "b8", "b9", "b10", "b11"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &BackportInput{TargetBranches: test.in}
+
+			err := in.sanitize()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("sanitize() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(in.TargetBranches, test.want) {
+				t.Errorf("sanitize() got = %v, want %v", in.TargetBranches, test.want)
+			}
+		})
+	}
+}
+
+type backportGit struct {
+	git.Interface
+	cherryPicks []*git.CherryPickParams
+}
+
+func (g *backportGit) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{SHA: sha.EmptyTree}, nil
+}
+
+func (g *backportGit) CherryPick(_ context.Context, in *git.CherryPickParams) (git.CherryPickOutput, error) {
+	g.cherryPicks = append(g.cherryPicks, in)
+	return git.CherryPickOutput{
+		ConflictCommitSHA: in.CommitSHAs[0],
+		ConflictFiles:     []string{"file.txt"},
+	}, nil
+}
+
+type backportRules struct {
+	protection.Protection
+	violations []types.RuleViolations
+	in         protection.RefChangeVerifyInput
+}
+
+func (r *backportRules) RefChangeVerify(
+	_ context.Context,
+	in protection.RefChangeVerifyInput,
+) ([]types.RuleViolations, error) {
+	r.in = in
+	return r.violations, nil
+}
+
+func TestController_backport(t *testing.T) {
+	blocked := []types.RuleViolations{{
+		Rule:       types.RuleInfo{State: enum.RuleStateActive},
+		Violations: []types.Violation{{Code: "lifecycle.create"}},
+	}}
+	commitSHA := sha.Must("f8312d7ff0b064a81ec3f2dc8250e8c0575a0d2e")
+
+	tests := []struct {
+		name           string
+		targetBranch   string
+		violations     []types.RuleViolations
+		wantBadRequest bool
+		wantCherryPick bool
+	}{
+		{
+			name:           "already merged to target branch",
+			targetBranch:   "main",
+			wantBadRequest: true,
+		},
+		{
+			name:           "blocked by branch rules",
+			targetBranch:   "release",
+			violations:     blocked,
+			wantBadRequest: true,
+		},
+		{
+			name:           "cherry-picked",
+			targetBranch:   "release",
+			wantCherryPick: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gitService := &backportGit{}
+			rules := &backportRules{violations: test.violations}
+			c := &Controller{git: gitService}
+
+			session := &auth.Session{Principal: types.Principal{ID: 1}}
+			repo := &types.RepositoryCore{ID: 1, GitUID: "repo", DefaultBranch: "main"}
+			pr := &types.PullReq{Number: 7, TargetBranch: "main"}
+			result := &BackportResult{TargetBranch: test.targetBranch}
+
+			params := &backportParams{
+				rules:      rules,
+				committer:  &git.Identity{Name: "system", Email: "system@example.com"},
+				commitSHAs: []sha.SHA{commitSHA},
+			}
+
+			err := c.backport(context.Background(), session, repo, pr, params, &BackportInput{}, result)
+
+			var uErr *usererror.Error
+			if test.wantBadRequest != (errors.As(err, &uErr) && uErr.Status == http.StatusBadRequest) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !test.wantBadRequest && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.violations != nil && !reflect.DeepEqual(result.RuleViolations, test.violations) {
+				t.Errorf("want rule violations %v, got %v", test.violations, result.RuleViolations)
+			}
+
+			if !test.wantCherryPick {
+				if len(gitService.cherryPicks) > 0 {
+					t.Fatal("cherry-pick must not be called")
+				}
+				return
+			}
+
+			if rules.in.RefAction != protection.RefActionCreate ||
+				!reflect.DeepEqual(rules.in.RefNames, []string{"backport-pullreq-7-to-release"}) {
+				t.Errorf("unexpected rule verification input: %+v", rules.in)
+			}
+
+			if len(gitService.cherryPicks) != 1 {
+				t.Fatalf("want one cherry-pick, got %d", len(gitService.cherryPicks))
+			}
+			cherryPick := gitService.cherryPicks[0]
+			if cherryPick.BaseBranch != "release" || len(cherryPick.Refs) != 1 ||
+				cherryPick.Refs[0].Name != "refs/heads/backport-pullreq-7-to-release" ||
+				!cherryPick.Refs[0].Old.IsNil() {
+				t.Errorf("unexpected cherry-pick params: %+v", cherryPick)
+			}
+
+			if result.ConflictCommitSHA != commitSHA.String() || len(result.ConflictFiles) != 1 {
+				t.Errorf("unexpected conflict result: %+v", result)
+			}
+		})
+	}
+}
+
+type backportBranchGit struct {
+	backportGit
+	refUpdates []git.UpdateRefParams
+}
+
+func (g *backportBranchGit) CherryPick(_ context.Context, in *git.CherryPickParams) (git.CherryPickOutput, error) {
+	g.cherryPicks = append(g.cherryPicks, in)
+	return git.CherryPickOutput{
+		BaseSHA:     sha.Must(testTargetSHA),
+		CommitSHA:   sha.Must(testSourceSHA),
+		CommitCount: 1,
+	}, nil
+}
+
+func (g *backportBranchGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	g.refUpdates = append(g.refUpdates, params)
+	return nil
+}
+
+func TestController_backportDeletesBranchOnFailure(t *testing.T) {
+	// without push access the backport pull request can't be created.
+	test := setupMergeTest(t, []enum.Permission{enum.PermissionRepoView}, "")
+	gitService := &backportBranchGit{}
+	test.c.git = gitService
+
+	repo := &types.RepositoryCore{ID: 1, Identifier: "repo", Path: "space/repo", GitUID: "repo-uid"}
+	pr := &types.PullReq{Number: 7, TargetBranch: "main", Title: "fix"}
+	result := &BackportResult{TargetBranch: "release"}
+
+	params := &backportParams{
+		rules:      &backportRules{},
+		committer:  &git.Identity{Name: "system", Email: "system@example.com"},
+		commitSHAs: []sha.SHA{sha.Must(testSourceSHA)},
+	}
+
+	err := test.c.backport(context.Background(), test.session, repo, pr, params, &BackportInput{}, result)
+	if !errors.Is(err, apiauth.ErrForbidden) {
+		t.Fatalf("expected the creation of the backport pull request to be forbidden, got: %v", err)
+	}
+
+	if len(gitService.refUpdates) != 1 {
+		t.Fatalf("want the backport branch to be deleted, got %d ref updates", len(gitService.refUpdates))
+	}
+	update := gitService.refUpdates[0]
+	if update.Name != "backport-pullreq-7-to-release" || !update.NewValue.IsNil() ||
+		update.OldValue.String() != testSourceSHA {
+		t.Errorf("unexpected ref update: %+v", update)
+	}
+
+	if result.Branch != "" {
+		t.Errorf("want no backport branch in the result, got %q", result.Branch)
+	}
+}
@@ -18,13 +18,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/errors"
-	"github.com/harness/gitness/git"
-	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -64,60 +60,9 @@ func (c *Controller) ChangeTargetBranch(ctx context.Context,
 			errors.InvalidArgument("source branch %q is same as new target branch", pr.SourceBranch)
 	}
 
-	ref1, err := git.GetRefPath(pr.SourceBranch, gitenum.RefTypeBranch)
+	pr, err = c.pullreqService.ChangeTargetBranch(ctx, repo, pr, session.Principal.ID, in.BranchName, 0)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ref path: %w", err)
-	}
-	ref2, err := git.GetRefPath(in.BranchName, gitenum.RefTypeBranch)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get ref path: %w", err)
-	}
-	mergeBase, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
-		Ref1:       ref1,
-		Ref2:       ref2,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to find merge base: %w", err)
-	}
-
-	if mergeBase.MergeBaseSHA.String() == pr.SourceSHA {
-		return nil,
-			usererror.BadRequest("The source branch doesn't contain any new commits")
-	}
-
-	oldTargetBranch := pr.TargetBranch
-	oldMergeBaseSHA := pr.MergeBaseSHA
-
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-		// clear merge and stats related fields
-		pr.MergeSHA = nil
-		pr.MergeTargetSHA = nil
-		pr.Stats.DiffStats = types.DiffStats{}
-
-		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
-		pr.TargetBranch = in.BranchName
-
-		pr.MarkAsMergeUnchecked()
-
-		pr.ActivitySeq++
-
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to update PR target branch in db with error: %w", err)
-	}
-
-	_, err = c.activityStore.CreateWithPayload(
-		ctx, pr, session.Principal.ID,
-		&types.PullRequestActivityPayloadBranchChangeTarget{
-			Old: oldTargetBranch,
-			New: in.BranchName,
-		},
-		nil,
-	)
-	if err != nil {
-		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for successful branch restore")
+		return nil, err
 	}
 
 	err = c.instrumentation.Track(ctx, instrument.Event{
@@ -135,14 +80,5 @@ func (c *Controller) ChangeTargetBranch(ctx context.Context,
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for create branch operation: %s", err)
 	}
 
-	c.eventReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
-		Base:            eventBase(pr, &session.Principal),
-		SourceSHA:       pr.SourceSHA,
-		OldTargetBranch: oldTargetBranch,
-		NewTargetBranch: in.BranchName,
-		OldMergeBaseSHA: oldMergeBaseSHA,
-		NewMergeBaseSHA: mergeBase.MergeBaseSHA.String(),
-	})
-
 	return pr, nil
 }
@@ -51,15 +51,36 @@ func (c *Controller) CodeOwners(
 		return types.CodeOwnerEvaluation{}, err
 	}
 
+	sections := mapCodeOwnerEvaluationSections(ownerEvaluation)
+
+	entries := make([]types.CodeOwnerEvaluationEntry, 0)
+	for _, section := range sections {
+		entries = append(entries, section.EvaluationEntries...)
+	}
+
 	return types.CodeOwnerEvaluation{
-		EvaluationEntries: mapCodeOwnerEvaluation(ownerEvaluation),
+		EvaluationEntries: entries,
+		Sections:          sections,
 		FileSha:           ownerEvaluation.FileSha,
 	}, nil
 }
 
-func mapCodeOwnerEvaluation(ownerEvaluation *codeowners.Evaluation) []types.CodeOwnerEvaluationEntry {
-	codeOwnerEvaluationEntries := make([]types.CodeOwnerEvaluationEntry, len(ownerEvaluation.EvaluationEntries))
-	for i, entry := range ownerEvaluation.EvaluationEntries {
+func mapCodeOwnerEvaluationSections(ownerEvaluation *codeowners.Evaluation) []types.CodeOwnerEvaluationSection {
+	sections := make([]types.CodeOwnerEvaluationSection, len(ownerEvaluation.EvaluationSections))
+	for i, section := range ownerEvaluation.EvaluationSections {
+		sections[i] = types.CodeOwnerEvaluationSection{
+			Name:              section.Name,
+			Optional:          section.Optional,
+			RequiredApprovals: section.RequiredApprovals,
+			EvaluationEntries: mapCodeOwnerEvaluation(section.EvaluationEntries),
+		}
+	}
+	return sections
+}
+
+func mapCodeOwnerEvaluation(evaluationEntries []codeowners.EvaluationEntry) []types.CodeOwnerEvaluationEntry {
+	codeOwnerEvaluationEntries := make([]types.CodeOwnerEvaluationEntry, len(evaluationEntries))
+	for i, entry := range evaluationEntries {
 		ownerEvaluations := make([]types.OwnerEvaluation, len(entry.OwnerEvaluations))
 		userGroupOwnerEvaluations := make([]types.UserGroupOwnerEvaluation, len(entry.UserGroupOwnerEvaluations))
 		for j, owner := range entry.OwnerEvaluations {
@@ -159,6 +159,11 @@ func (c *Controller) CommentApplySuggestions(
 				"failed to find activity %d: %w", suggestionEntry.CommentID, err)
 		}
 
+		if activity.Pending {
+			return CommentApplySuggestionsOutput{}, nil, usererror.BadRequestf(
+				"Suggestions of pending comment %d can't be applied.", suggestionEntry.CommentID)
+		}
+
 		var ccActivity *types.PullReqActivity
 		if activity.IsValidCodeComment() {
 			ccActivity = activity
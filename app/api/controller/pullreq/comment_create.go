@@ -47,6 +47,8 @@ type CommentCreateInput struct {
 	LineStartNew    bool   `json:"line_start_new"`
 	LineEnd         int    `json:"line_end"`
 	LineEndNew      bool   `json:"line_end_new"`
+	// Pending comments are visible only to their author until published with a review.
+	Pending bool `json:"pending"`
 }
 
 func (in *CommentCreateInput) IsReply() bool {
@@ -119,10 +121,19 @@ func (c *Controller) CommentCreate(
 
 	var parentAct *types.PullReqActivity
 	if in.IsReply() {
-		parentAct, err = c.checkIsReplyable(ctx, pr, in.ParentID)
+		parentAct, err = c.checkIsReplyable(ctx, session, pr, in.ParentID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to verify reply: %w", err)
 		}
+
+		// replies to pending comments can only be published together with the parent comment.
+		if parentAct.Pending {
+			in.Pending = true
+		}
+	}
+
+	if in.Pending && pr.CreatedBy == session.Principal.ID {
+		return nil, usererror.BadRequest("Can't create pending review comments on own pull requests.")
 	}
 
 	// fetch code snippet from git for code comments
@@ -161,6 +172,7 @@ func (c *Controller) CommentCreate(
 		}
 
 		act = getCommentActivity(session, pr, in, metadataUpdates)
+		act.Pending = in.Pending
 
 		// In the switch the pull request activity (the code comment)
 		// is written to the DB (as code comment, a reply, or ordinary comment).
@@ -190,6 +202,11 @@ func (c *Controller) CommentCreate(
 			return fmt.Errorf("failed to write pull request comment: %w", err)
 		}
 
+		if act.Pending {
+			// pending comments are counted once they are published
+			return nil
+		}
+
 		pr.CommentCount++
 		if act.IsBlocking() {
 			pr.UnresolvedCount++
@@ -216,10 +233,14 @@ func (c *Controller) CommentCreate(
 		c.migrateCodeComment(ctx, repo, pr, in, act.AsCodeComment(), cut)
 	}
 
-	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+	// pending comments are announced with the review they are published with.
+	if !act.Pending {
+		c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+	}
 
 	// publish event for all comments
-	if act.Type == enum.PullReqActivityTypeComment || act.Type == enum.PullReqActivityTypeCodeComment {
+	if !act.Pending &&
+		(act.Type == enum.PullReqActivityTypeComment || act.Type == enum.PullReqActivityTypeCodeComment) {
 		c.reportCommentCreated(
 			ctx,
 			pr,
@@ -249,6 +270,7 @@ func (c *Controller) CommentCreate(
 
 func (c *Controller) checkIsReplyable(
 	ctx context.Context,
+	session *auth.Session,
 	pr *types.PullReq,
 	parentID int64,
 ) (*types.PullReqActivity, error) {
@@ -261,6 +283,10 @@ func (c *Controller) checkIsReplyable(
 		return nil, fmt.Errorf("failed to find parent pull request activity: %w", err)
 	}
 
+	if parentAct.Pending && parentAct.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Parent pull request activity not found.")
+	}
+
 	if parentAct.PullReqID != pr.ID || parentAct.RepoID != pr.TargetRepoID {
 		return nil, usererror.BadRequest("Parent pull request activity doesn't belong to the same pull request.")
 	}
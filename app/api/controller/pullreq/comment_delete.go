@@ -67,6 +67,11 @@ func (c *Controller) CommentDelete(
 			return fmt.Errorf("failed to mark comment as deleted: %w", err)
 		}
 
+		if act.Pending {
+			// pending comments aren't included in the pull request comment counters
+			return nil
+		}
+
 		pr.CommentCount--
 		if isBlocking {
 			pr.UnresolvedCount--
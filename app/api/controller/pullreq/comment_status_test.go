@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type pendingCommentsActivityStore struct {
+	store.PullReqActivityStore
+	comments []*types.PullReqActivity
+}
+
+func (s *pendingCommentsActivityStore) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	for _, comment := range s.comments {
+		if comment.ID == id {
+			return comment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *pendingCommentsActivityStore) PublishPending(
+	context.Context,
+	int64,
+	int64,
+) ([]*types.PullReqActivity, error) {
+	return s.comments, nil
+}
+
+type pendingCommentsPullReqStore struct {
+	store.PullReqStore
+}
+
+func (s *pendingCommentsPullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	updated := *pr
+	if err := mutateFn(&updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func TestController_getCommentCheckChangeStatusAccess(t *testing.T) {
+	pr := &types.PullReq{ID: 1, TargetRepoID: 2}
+	comment := &types.PullReqActivity{
+		ID:        3,
+		CreatedBy: 4,
+		RepoID:    pr.TargetRepoID,
+		PullReqID: pr.ID,
+		Kind:      enum.PullReqActivityKindComment,
+		Type:      enum.PullReqActivityTypeComment,
+		Pending:   true,
+	}
+
+	c := &Controller{activityStore: &pendingCommentsActivityStore{comments: []*types.PullReqActivity{comment}}}
+
+	author := &auth.Session{Principal: types.Principal{ID: comment.CreatedBy}}
+	_, err := c.getCommentCheckChangeStatusAccess(context.Background(), author, pr, comment.ID)
+	if err == nil || errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("expected the author to get a bad request error, got: %v", err)
+	}
+
+	other := &auth.Session{Principal: types.Principal{ID: 5}}
+	_, err = c.getCommentCheckChangeStatusAccess(context.Background(), other, pr, comment.ID)
+	if !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("expected other principals to get a not found error, got: %v", err)
+	}
+}
+
+func TestController_publishPendingComments(t *testing.T) {
+	deleted := int64(1)
+	comments := []*types.PullReqActivity{
+		{ID: 1},
+		{ID: 2, Deleted: &deleted},
+		{ID: 3},
+	}
+
+	c := &Controller{
+		activityStore: &pendingCommentsActivityStore{comments: comments},
+		pullreqStore:  &pendingCommentsPullReqStore{},
+	}
+
+	pr := &types.PullReq{ID: 1}
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	published, err := c.publishPendingComments(context.Background(), session, pr)
+	if err != nil {
+		t.Fatalf("failed to publish pending comments: %v", err)
+	}
+
+	if len(published) != 2 || published[0].ID != 1 || published[1].ID != 3 {
+		t.Errorf("expected only comments 1 and 3 to be published, got: %v", published)
+	}
+	if pr.CommentCount != 2 {
+		t.Errorf("expected comment count 2, got: %d", pr.CommentCount)
+	}
+}
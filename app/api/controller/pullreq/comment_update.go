@@ -114,6 +114,10 @@ func (c *Controller) CommentUpdate(
 	// Populate activity mentions (used only for response purposes).
 	act.Mentions = principalInfos
 
+	if act.Pending {
+		return act, nil
+	}
+
 	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
 
 	c.reportCommentUpdated(ctx, pr, session.Principal.ID, act.ID, act.IsReply())
@@ -31,7 +31,9 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -79,6 +81,13 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	signatureVerifier      *publickey.SignatureVerifier
+	autoMergeStore         store.PullReqAutoMergeStore
+	mergeQueueStore        store.MergeQueueEntryStore
+	templateService        *pullreqtemplate.Service
+	watchStore             store.NotificationWatchStore
+	reactionStore          store.PullReqReactionStore
+	outOfOfficeStore       store.OutOfOfficeStore
 }
 
 func NewController(
@@ -113,6 +122,13 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	autoMergeStore store.PullReqAutoMergeStore,
+	mergeQueueStore store.MergeQueueEntryStore,
+	templateService *pullreqtemplate.Service,
+	watchStore store.NotificationWatchStore,
+	reactionStore store.PullReqReactionStore,
+	outOfOfficeStore store.OutOfOfficeStore,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -146,6 +162,13 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		signatureVerifier:      signatureVerifier,
+		autoMergeStore:         autoMergeStore,
+		mergeQueueStore:        mergeQueueStore,
+		templateService:        templateService,
+		watchStore:             watchStore,
+		reactionStore:          reactionStore,
+		outOfOfficeStore:       outOfOfficeStore,
 	}
 }
 
@@ -279,17 +302,26 @@ func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 }
 
 func (c *Controller) getCommentCheckChangeStatusAccess(ctx context.Context,
-	pr *types.PullReq, commentID int64,
+	session *auth.Session, pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
 	comment, err := c.getCommentForPR(ctx, pr, commentID)
 	if err != nil {
 		return nil, err
 	}
 
+	// pending comments are visible only to their author.
+	if comment.Pending && comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.ErrNotFound
+	}
+
 	if comment.SubOrder != 0 {
 		return nil, usererror.BadRequest("Can't change status of replies.")
 	}
 
+	if comment.Pending {
+		return nil, usererror.BadRequest("Can't change status of pending comments.")
+	}
+
 	return comment, nil
 }
 
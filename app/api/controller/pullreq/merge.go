@@ -180,14 +180,14 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
@@ -243,6 +243,7 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
@@ -374,6 +375,7 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
@@ -409,38 +411,11 @@ func (c *Controller) Merge(
 
 	// commit details: author, committer and message
 
-	var author *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		author = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodSquash:
-		author = controller.IdentityFromPrincipalInfo(pr.Author)
-	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
-	}
-
-	var committer *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = controller.SystemServicePrincipalInfo()
-	case enum.MergeMethodRebase:
-		committer = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodFastForward:
-		committer = nil // Not important for fast-forward merge
-	}
+	author, committer := mergeCommitIdentities(in.Method, &session.Principal, pr)
 
 	// backfill commit title if none provided
 	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-			// Not used.
-		}
+		in.Title = defaultMergeCommitTitle(in.Method, pr, sourceRepo)
 	}
 
 	// create merge commit(s)
@@ -691,3 +666,48 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// mergeCommitIdentities returns the author and the committer of the commit created by the merge.
+func mergeCommitIdentities(
+	method enum.MergeMethod,
+	merger *types.Principal,
+	pr *types.PullReq,
+) (author *git.Identity, committer *git.Identity) {
+	switch method {
+	case enum.MergeMethodMerge:
+		author = controller.IdentityFromPrincipalInfo(*merger.ToPrincipalInfo())
+	case enum.MergeMethodSquash:
+		author = controller.IdentityFromPrincipalInfo(pr.Author)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
+	}
+
+	switch method {
+	case enum.MergeMethodMerge, enum.MergeMethodSquash:
+		committer = controller.SystemServicePrincipalInfo()
+	case enum.MergeMethodRebase:
+		committer = controller.IdentityFromPrincipalInfo(*merger.ToPrincipalInfo())
+	case enum.MergeMethodFastForward:
+		committer = nil // Not important for fast-forward merge
+	}
+
+	return author, committer
+}
+
+// defaultMergeCommitTitle returns the commit title used if none is provided for the merge.
+func defaultMergeCommitTitle(
+	method enum.MergeMethod,
+	pr *types.PullReq,
+	sourceRepo *types.RepositoryCore,
+) string {
+	switch method {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+		// Not used.
+	}
+
+	return ""
+}
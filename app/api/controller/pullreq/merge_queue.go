@@ -0,0 +1,889 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// mergeQueueTimeout is the max time we give processing of a merge queue to succeed.
+const mergeQueueTimeout = 5 * time.Minute
+
+type MergeQueueEnqueueInput struct {
+	Method  enum.MergeMethod `json:"method"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+}
+
+func (in *MergeQueueEnqueueInput) sanitize() error {
+	if in.Method == "" {
+		return usererror.BadRequest("merge method must be provided")
+	}
+
+	mergeIn := &MergeInput{
+		Method:    in.Method,
+		SourceSHA: "-", // not used for the merge queue, the latest commit always gets merged.
+		Title:     in.Title,
+		Message:   in.Message,
+	}
+	if err := mergeIn.sanitize(); err != nil {
+		return err
+	}
+
+	if mergeIn.Method == enum.MergeMethodFastForward {
+		return usererror.BadRequest("The fast-forward merge method can't be used with the merge queue.")
+	}
+
+	in.Method = mergeIn.Method
+	in.Title = mergeIn.Title
+	in.Message = mergeIn.Message
+
+	return nil
+}
+
+// MergeQueueList returns the entries of the merge queue of the branch in the order of merging.
+// If no branch is provided, the merge queue of the default branch is returned.
+func (c *Controller) MergeQueueList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	entries, err := c.mergeQueueStore.ListByBranch(ctx, repo.ID, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MergeQueueFind returns the merge queue entry of a pull request.
+func (c *Controller) MergeQueueFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.MergeQueueEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.Find(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// MergeQueueEnqueue adds a pull request to the merge queue of its target branch.
+// The pull request must satisfy all protection rules, except the requirement to use the merge queue.
+func (c *Controller) MergeQueueEnqueue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueEnqueueInput,
+) (*types.MergeQueueEntry, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be added to the merge queue. Clear the draft flag first.")
+	}
+
+	if pr.MergeCheckStatus == enum.MergeCheckStatusConflict {
+		return nil, nil, usererror.BadRequest(
+			"Pull requests with merge conflicts can't be added to the merge queue.")
+	}
+
+	violations, err := c.mergeQueueVerify(ctx, session, repo, pr, in.Method)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		RepoID:        repo.ID,
+		Branch:        pr.TargetBranch,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+		State:         enum.MergeQueueEntryStateQueued,
+		MergeMethod:   in.Method,
+		Title:         in.Title,
+		Message:       in.Message,
+		SourceSHA:     pr.SourceSHA,
+		Author:        *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.mergeQueueStore.Create(ctx, entry)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, nil, usererror.Conflict("Pull request is already in the merge queue.")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add pull request to the merge queue: %w", err)
+	}
+
+	c.writeMergeQueueActivity(ctx, pr, session.Principal.ID, &types.PullRequestActivityPayloadMergeQueue{
+		Action:      enum.PullReqMergeQueueActionEnqueued,
+		MergeMethod: in.Method,
+	})
+
+	if err = c.MergeQueueProcess(ctx, repo.ID, pr.TargetBranch); err != nil {
+		// non-critical error, the merge queue will be processed again on the next update.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to process merge queue")
+	}
+
+	return entry, nil, nil
+}
+
+// MergeQueueDequeue removes a pull request from the merge queue.
+func (c *Controller) MergeQueueDequeue(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.Find(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	err = c.mergeQueueRemove(ctx, repo, pr, entry, session.Principal.ID, enum.PullReqMergeQueueActionDequeued, "")
+	if err != nil {
+		return err
+	}
+
+	// the speculative merge commits of the entries behind need to be recreated.
+	if err = c.MergeQueueProcess(ctx, repo.ID, entry.Branch); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to process merge queue")
+	}
+
+	return nil
+}
+
+// MergeQueueEjectOnBranchUpdate ejects the pull request from the merge queue
+// because new commits have been pushed to its source branch.
+func (c *Controller) MergeQueueEjectOnBranchUpdate(
+	ctx context.Context,
+	repoID int64,
+	pullreqNum int64,
+	principalID int64,
+) error {
+	return c.mergeQueueRemoveByNumber(ctx, repoID, pullreqNum, principalID,
+		enum.PullReqMergeQueueActionEjected, "New commits have been pushed to the source branch.")
+}
+
+// MergeQueueDequeueOnClose removes the closed pull request from the merge queue.
+func (c *Controller) MergeQueueDequeueOnClose(
+	ctx context.Context,
+	repoID int64,
+	pullreqNum int64,
+	principalID int64,
+) error {
+	return c.mergeQueueRemoveByNumber(ctx, repoID, pullreqNum, principalID,
+		enum.PullReqMergeQueueActionDequeued, "Pull request has been closed.")
+}
+
+// MergeQueueProcessByMergeSHA processes all merge queues of the repository
+// with an entry that has the provided speculative merge commit.
+func (c *Controller) MergeQueueProcessByMergeSHA(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+) error {
+	branches, err := c.mergeQueueStore.ListBranchesByMergeSHA(ctx, repoID, mergeSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue branches: %w", err)
+	}
+
+	for _, branch := range branches {
+		if err = c.MergeQueueProcess(ctx, repoID, branch); err != nil {
+			return fmt.Errorf("failed to process merge queue of branch %q: %w", branch, err)
+		}
+	}
+
+	return nil
+}
+
+// MergeQueueProcess brings the merge queue of the branch up to date.
+//
+// Each entry is merged speculatively on top of the target branch and all entries ahead of it.
+// The speculative merge commits are stored in temporary references and the checks run on them.
+// Once all required status checks of the first entry succeed, the target branch is fast-forwarded
+// to its merge commit. Entries with merge conflicts or failed checks are ejected from the queue.
+func (c *Controller) MergeQueueProcess(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) error {
+	// the same lock is used by the pull request merge API, so the target branch can't be updated by it meanwhile.
+	unlock, err := c.locker.LockPR(ctx, repoID, 0, mergeQueueTimeout+30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for merge queue processing: %w", err)
+	}
+	defer unlock()
+
+	ctx, cancel := contextutil.WithNewTimeout(ctx, mergeQueueTimeout)
+	defer cancel()
+
+	repo, err := c.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	for {
+		advanced, err := c.mergeQueueProcessOnce(ctx, repo, branch)
+		if err != nil {
+			return err
+		}
+
+		if !advanced {
+			return nil
+		}
+	}
+}
+
+// mergeQueueProcessOnce updates the speculative merge commits of the merge queue and finalizes its first entry.
+// It returns true if the first entry has been merged or ejected, meaning that the queue should be processed again.
+//
+//nolint:gocognit
+func (c *Controller) mergeQueueProcessOnce(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	branch string,
+) (bool, error) {
+	entries, err := c.mergeQueueStore.ListByBranch(ctx, repo.ID, branch)
+	if err != nil {
+		return false, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	branchOut, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: branch,
+	})
+	if errors.IsNotFound(err) {
+		for _, entry := range entries {
+			if err = c.mergeQueueRemoveEntry(ctx, repo, entry, systemPrincipal.ID,
+				enum.PullReqMergeQueueActionEjected, "The target branch doesn't exist."); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	baseSHA := branchOut.Branch.SHA
+
+	var head *types.MergeQueueEntry
+	var headPR *types.PullReq
+
+	for _, entry := range entries {
+		pr, err := c.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateOpen {
+			// the pull request has been merged or closed in the meantime.
+			if err = c.mergeQueueRemove(ctx, repo, pr, entry, systemPrincipal.ID,
+				enum.PullReqMergeQueueActionDequeued, "Pull request is no longer open."); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		if pr.SourceSHA != entry.SourceSHA {
+			if err = c.mergeQueueRemove(ctx, repo, pr, entry, systemPrincipal.ID,
+				enum.PullReqMergeQueueActionEjected, "New commits have been pushed to the source branch."); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		if entry.State != enum.MergeQueueEntryStateChecking || entry.BaseSHA != baseSHA.String() {
+			mergeSHA, conflicts, err := c.mergeQueueCreateMergeCommit(ctx, repo, pr, entry, baseSHA)
+			if err != nil {
+				return false, err
+			}
+
+			if len(conflicts) > 0 {
+				reason := fmt.Sprintf("Merge conflicts with the target branch "+
+					"or with the pull requests ahead in the merge queue: %s", strings.Join(conflicts, ", "))
+				if err = c.mergeQueueRemove(ctx, repo, pr, entry, systemPrincipal.ID,
+					enum.PullReqMergeQueueActionEjected, reason); err != nil {
+					return false, err
+				}
+				continue
+			}
+
+			entry.State = enum.MergeQueueEntryStateChecking
+			entry.BaseSHA = baseSHA.String()
+			entry.MergeSHA = mergeSHA.String()
+			entry.Updated = time.Now().UnixMilli()
+
+			err = c.mergeQueueStore.Update(ctx, entry)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				continue // removed from the queue in the meantime
+			}
+			if err != nil {
+				return false, fmt.Errorf("failed to update merge queue entry: %w", err)
+			}
+
+			refQueue, err := git.GetRefPath(strconv.FormatInt(pr.Number, 10), gitenum.RefTypePullReqQueue)
+			if err != nil {
+				return false, fmt.Errorf("failed to generate pull request queue ref name: %w", err)
+			}
+
+			c.eventReporter.MergeQueueChecksRequested(ctx, &pullreqevents.MergeQueueChecksRequestedPayload{
+				Base:      eventBase(pr, &systemPrincipal),
+				Ref:       refQueue,
+				SourceSHA: entry.SourceSHA,
+				BaseSHA:   entry.BaseSHA,
+				MergeSHA:  entry.MergeSHA,
+			})
+		}
+
+		if head == nil {
+			head = entry
+			headPR = pr
+		}
+
+		baseSHA = sha.Must(entry.MergeSHA)
+	}
+
+	if head == nil {
+		return false, nil
+	}
+
+	failedChecks, pending, err := c.mergeQueueCheckStatus(ctx, repo, headPR, head)
+	if err != nil {
+		return false, err
+	}
+
+	if len(failedChecks) > 0 {
+		reason := fmt.Sprintf("The following required status checks failed on the merge queue commit: %s",
+			strings.Join(failedChecks, ", "))
+		if err = c.mergeQueueRemove(ctx, repo, headPR, head, systemPrincipal.ID,
+			enum.PullReqMergeQueueActionEjected, reason); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if pending {
+		return false, nil
+	}
+
+	if err = c.mergeQueueMerge(ctx, repo, headPR, head); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// mergeQueueCreateMergeCommit merges the pull request on top of the provided base commit.
+// The resulting commit is stored in the pull request's queue reference.
+func (c *Controller) mergeQueueCreateMergeCommit(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	baseSHA sha.SHA,
+) (sha.SHA, []string, error) {
+	merger, err := c.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to find principal that added the pull request to the queue: %w", err)
+	}
+
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		if err != nil {
+			return sha.None, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	// only the system reference of the pull request gets updated - skip git hooks.
+	writeParams, err := controller.CreateRPCSystemReferencesWriteParams(ctx, c.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	refQueue, err := git.GetRefPath(strconv.FormatInt(pr.Number, 10), gitenum.RefTypePullReqQueue)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to generate pull request queue ref name: %w", err)
+	}
+
+	author, committer := mergeCommitIdentities(entry.MergeMethod, merger, pr)
+
+	title := entry.Title
+	if title == "" {
+		title = defaultMergeCommitTitle(entry.MergeMethod, pr, sourceRepo)
+	}
+
+	now := time.Now()
+	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
+		WriteParams:   writeParams,
+		BaseSHA:       baseSHA,
+		HeadRepoUID:   sourceRepo.GitUID,
+		HeadBranch:    pr.SourceBranch,
+		Message:       git.CommitMessage(title, entry.Message),
+		Committer:     committer,
+		CommitterDate: &now,
+		Author:        author,
+		AuthorDate:    &now,
+		Refs: []git.RefUpdate{
+			{
+				Name: refQueue,
+				Old:  sha.SHA{}, // don't care about the old value.
+				New:  sha.SHA{}, // update to the result of the merge.
+			},
+		},
+		HeadExpectedSHA: sha.Must(entry.SourceSHA),
+		Method:          gitenum.MergeMethod(entry.MergeMethod),
+	})
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to create merge queue commit: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		return sha.None, mergeOutput.ConflictFiles, nil
+	}
+
+	return mergeOutput.MergeSHA, nil, nil
+}
+
+// mergeQueueCheckStatus evaluates the required status checks on the speculative merge commit of the entry.
+// It returns identifiers of the failed checks and whether any of the required checks isn't completed yet.
+func (c *Controller) mergeQueueCheckStatus(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) ([]string, bool, error) {
+	merger, err := c.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find principal that added the pull request to the queue: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              merger,
+		Repo:               repo,
+		PullReq:            pr,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get identifiers of required checks: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(checkResults))
+	for _, result := range checkResults {
+		statuses[result.Identifier] = result.Status
+	}
+
+	// the merge queue doesn't allow bypassing of the required checks.
+	required := make(map[string]struct{}, len(reqChecks.RequiredIdentifiers)+len(reqChecks.BypassableIdentifiers))
+	for identifier := range reqChecks.RequiredIdentifiers {
+		required[identifier] = struct{}{}
+	}
+	for identifier := range reqChecks.BypassableIdentifiers {
+		required[identifier] = struct{}{}
+	}
+
+	var failed []string
+	var pending bool
+
+	for identifier := range required {
+		status, ok := statuses[identifier]
+		switch {
+		case !ok || !status.IsCompleted():
+			pending = true
+		case !status.IsSuccess():
+			failed = append(failed, identifier)
+		}
+	}
+
+	sort.Strings(failed)
+
+	return failed, pending, nil
+}
+
+// mergeQueueMerge fast-forwards the target branch to the speculative merge commit of the entry
+// and marks the pull request as merged.
+func (c *Controller) mergeQueueMerge(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+) error {
+	merger, err := c.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal that added the pull request to the queue: %w", err)
+	}
+
+	session := &auth.Session{Principal: *merger, Metadata: &auth.EmptyMetadata{}}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        entry.Branch,
+		NewValue:    sha.Must(entry.MergeSHA),
+		OldValue:    sha.Must(entry.BaseSHA),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward target branch to the merge queue commit: %w", err)
+	}
+
+	err = c.mergeQueueStore.Delete(ctx, pr.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	c.mergeQueueDeleteRefs(ctx, repo, pr, gitenum.RefTypePullReqQueue, gitenum.RefTypePullReqMerge)
+
+	mergedBy := merger.ID
+	mergeMethod := entry.MergeMethod
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		nowMilli := time.Now().UnixMilli()
+
+		pr.State = enum.PullReqStateMerged
+		pr.Merged = &nowMilli
+		pr.MergedBy = &mergedBy
+		pr.MergeMethod = &mergeMethod
+
+		pr.MergeTargetSHA = ptr.String(entry.BaseSHA)
+		pr.MergeSHA = ptr.String(entry.MergeSHA)
+		pr.MarkAsMerged()
+
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod: entry.MergeMethod,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base:        eventBase(pr, merger),
+		MergeMethod: entry.MergeMethod,
+		MergeSHA:    entry.MergeSHA,
+		TargetSHA:   entry.BaseSHA,
+		SourceSHA:   entry.SourceSHA,
+	})
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	log.Ctx(ctx).Info().Msgf("pull request merged via the merge queue with SHA %s", entry.MergeSHA)
+
+	return nil
+}
+
+// mergeQueueVerify verifies the protection rules for a pull request that is going to be merged via the merge queue.
+func (c *Controller) mergeQueueVerify(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) ([]types.RuleViolations, error) {
+	sourceRepo := repo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		var err error
+		sourceRepo, err = c.repoFinder.FindByID(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	checkResults, err := c.checkStore.ListResults(ctx, repo.ID, pr.SourceSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		IsRepoOwner:        isRepoOwner,
+		TargetRepo:         repo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Reviewers:          reviewers,
+		Method:             method,
+		CheckResults:       checkResults,
+		CodeOwners:         codeOwnerWithApproval,
+		MergeQueue:         true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violations, nil
+}
+
+func (c *Controller) mergeQueueRemoveByNumber(
+	ctx context.Context,
+	repoID int64,
+	pullreqNum int64,
+	principalID int64,
+	action enum.PullReqMergeQueueAction,
+	reason string,
+) error {
+	pr, err := c.pullreqStore.FindByNumber(ctx, repoID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.Find(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry: %w", err)
+	}
+
+	repo, err := c.repoFinder.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = c.mergeQueueRemove(ctx, repo, pr, entry, principalID, action, reason); err != nil {
+		return err
+	}
+
+	// the speculative merge commits of the entries behind need to be recreated.
+	return c.MergeQueueProcess(ctx, repoID, entry.Branch)
+}
+
+func (c *Controller) mergeQueueRemoveEntry(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	entry *types.MergeQueueEntry,
+	principalID int64,
+	action enum.PullReqMergeQueueAction,
+	reason string,
+) error {
+	pr, err := c.pullreqStore.Find(ctx, entry.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return c.mergeQueueRemove(ctx, repo, pr, entry, principalID, action, reason)
+}
+
+// mergeQueueRemove removes the pull request from the merge queue and records it as a pull request activity.
+func (c *Controller) mergeQueueRemove(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	principalID int64,
+	action enum.PullReqMergeQueueAction,
+	reason string,
+) error {
+	err := c.mergeQueueStore.Delete(ctx, pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil // already removed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove pull request from the merge queue: %w", err)
+	}
+
+	c.mergeQueueDeleteRefs(ctx, repo, pr, gitenum.RefTypePullReqQueue)
+
+	c.writeMergeQueueActivity(ctx, pr, principalID, &types.PullRequestActivityPayloadMergeQueue{
+		Action:      action,
+		MergeMethod: entry.MergeMethod,
+		Reason:      reason,
+	})
+
+	return nil
+}
+
+// mergeQueueDeleteRefs deletes the provided system references of the pull request.
+func (c *Controller) mergeQueueDeleteRefs(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	refTypes ...gitenum.RefType,
+) {
+	writeParams, err := controller.CreateRPCSystemReferencesWriteParams(ctx, c.urlProvider,
+		bootstrap.NewSystemServiceSession(), repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params")
+		return
+	}
+
+	for _, refType := range refTypes {
+		err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+			WriteParams: writeParams,
+			Name:        strconv.FormatInt(pr.Number, 10),
+			Type:        refType,
+			NewValue:    sha.Nil,
+			OldValue:    sha.None, // we don't care about the old value
+		})
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete pull request %s reference", refType)
+		}
+	}
+}
+
+func (c *Controller) writeMergeQueueActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload *types.PullRequestActivityPayloadMergeQueue,
+) {
+	err := func() error {
+		pr, err := c.pullreqStore.UpdateActivitySeq(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil)
+		return err
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request merge queue activity")
+	}
+}
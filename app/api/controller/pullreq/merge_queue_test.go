@@ -0,0 +1,391 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSourceSHA2 = "4444444444444444444444444444444444444444"
+
+	// ruleRequireCIAndQueue is a default branch rule definition that requires the "ci" status check
+	// and merging via the merge queue.
+	ruleRequireCIAndQueue = `{"pullreq":{` +
+		`"status_checks":{"require_identifiers":["ci"]},` +
+		`"merge":{"require_merge_queue":true}}}`
+)
+
+type mergeQueueStore struct {
+	entries map[int64]*types.MergeQueueEntry
+}
+
+func (s *mergeQueueStore) Find(_ context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	entry, ok := s.entries[pullReqID]
+	if !ok {
+		return nil, store.ErrResourceNotFound
+	}
+	entryCopy := *entry
+	return &entryCopy, nil
+}
+
+func (s *mergeQueueStore) Create(_ context.Context, entry *types.MergeQueueEntry) error {
+	if _, ok := s.entries[entry.PullReqID]; ok {
+		return store.ErrDuplicate
+	}
+	entryCopy := *entry
+	s.entries[entry.PullReqID] = &entryCopy
+	return nil
+}
+
+func (s *mergeQueueStore) Update(_ context.Context, entry *types.MergeQueueEntry) error {
+	if _, ok := s.entries[entry.PullReqID]; !ok {
+		return store.ErrResourceNotFound
+	}
+	entryCopy := *entry
+	s.entries[entry.PullReqID] = &entryCopy
+	return nil
+}
+
+func (s *mergeQueueStore) Delete(_ context.Context, pullReqID int64) error {
+	if _, ok := s.entries[pullReqID]; !ok {
+		return store.ErrResourceNotFound
+	}
+	delete(s.entries, pullReqID)
+	return nil
+}
+
+func (s *mergeQueueStore) ListByBranch(
+	_ context.Context,
+	repoID int64,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	var entries []*types.MergeQueueEntry
+	for _, entry := range s.entries {
+		if entry.RepoID == repoID && entry.Branch == branch {
+			entryCopy := *entry
+			entries = append(entries, &entryCopy)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Created != entries[j].Created {
+			return entries[i].Created < entries[j].Created
+		}
+		return entries[i].PullReqID < entries[j].PullReqID
+	})
+
+	return entries, nil
+}
+
+func (s *mergeQueueStore) ListBranchesByMergeSHA(_ context.Context, repoID int64, mergeSHA string) ([]string, error) {
+	var branches []string
+	for _, entry := range s.entries {
+		if entry.RepoID == repoID && entry.MergeSHA == mergeSHA {
+			branches = append(branches, entry.Branch)
+		}
+	}
+	return branches, nil
+}
+
+func (s *activityRecorder) mergeQueueActions() []enum.PullReqMergeQueueAction {
+	var actions []enum.PullReqMergeQueueAction
+	for _, payload := range s.payloads {
+		if p, ok := payload.(*types.PullRequestActivityPayloadMergeQueue); ok {
+			actions = append(actions, p.Action)
+		}
+	}
+	return actions
+}
+
+func assertMergeQueueActions(t *testing.T, test *mergeTest, want ...enum.PullReqMergeQueueAction) {
+	t.Helper()
+
+	got := test.activities.mergeQueueActions()
+	if fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Fatalf("merge queue activities: want=%v got=%v", want, got)
+	}
+}
+
+func assertQueue(t *testing.T, test *mergeTest, want ...int64) {
+	t.Helper()
+
+	entries, _ := test.queue.ListByBranch(context.Background(), 1, "main")
+
+	got := make([]int64, len(entries))
+	for i, entry := range entries {
+		got[i] = entry.PullReqNumber
+	}
+
+	if fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Fatalf("merge queue: want=%v got=%v", want, got)
+	}
+}
+
+func TestController_MergeQueueEnqueueRules(t *testing.T) {
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	tests := []struct {
+		name       string
+		modifyPR   func(pr *types.PullReq)
+		method     enum.MergeMethod
+		checks     enum.CheckStatus
+		queued     bool
+		wantErr    bool
+		wantViol   bool
+		wantQueued bool
+	}{
+		{
+			name:       "passing pull request",
+			method:     enum.MergeMethodSquash,
+			checks:     enum.CheckStatusSuccess,
+			wantQueued: true,
+		},
+		{
+			name:     "draft pull request",
+			modifyPR: func(pr *types.PullReq) { pr.IsDraft = true },
+			method:   enum.MergeMethodSquash,
+			checks:   enum.CheckStatusSuccess,
+			wantErr:  true,
+		},
+		{
+			name:     "closed pull request",
+			modifyPR: func(pr *types.PullReq) { pr.State = enum.PullReqStateClosed },
+			method:   enum.MergeMethodSquash,
+			checks:   enum.CheckStatusSuccess,
+			wantErr:  true,
+		},
+		{
+			name: "conflicting pull request",
+			modifyPR: func(pr *types.PullReq) {
+				pr.MergeCheckStatus = enum.MergeCheckStatusConflict
+			},
+			method:  enum.MergeMethodSquash,
+			checks:  enum.CheckStatusSuccess,
+			wantErr: true,
+		},
+		{
+			name:    "fast-forward method",
+			method:  enum.MergeMethodFastForward,
+			checks:  enum.CheckStatusSuccess,
+			wantErr: true,
+		},
+		{
+			name:     "failing required check",
+			method:   enum.MergeMethodSquash,
+			checks:   enum.CheckStatusFailure,
+			wantViol: true,
+		},
+		{
+			name:       "already queued",
+			method:     enum.MergeMethodSquash,
+			checks:     enum.CheckStatusSuccess,
+			queued:     true,
+			wantErr:    true,
+			wantQueued: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			pr := newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable)
+			if test.modifyPR != nil {
+				test.modifyPR(pr)
+			}
+
+			mt := setupMergeTest(t, push, ruleRequireCIAndQueue, pr)
+			mt.checks.report(testSourceSHA, test.checks)
+
+			if test.queued {
+				mt.queue.entries[1] = &types.MergeQueueEntry{
+					PullReqID:     1,
+					PullReqNumber: 1,
+					RepoID:        1,
+					Branch:        "main",
+					State:         enum.MergeQueueEntryStateQueued,
+					MergeMethod:   enum.MergeMethodSquash,
+					SourceSHA:     testSourceSHA,
+				}
+			}
+
+			_, violations, err := mt.c.MergeQueueEnqueue(ctx, mt.session, "space/repo", 1,
+				&MergeQueueEnqueueInput{Method: test.method})
+
+			var uErr *usererror.Error
+			if test.wantErr != errors.As(err, &uErr) {
+				t.Fatalf("want user error=%t, got: %v", test.wantErr, err)
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("failed to enqueue pull request: %v", err)
+			}
+
+			if test.wantViol != (violations != nil) {
+				t.Fatalf("want violations=%t, got: %+v", test.wantViol, violations)
+			}
+
+			if test.wantQueued {
+				assertQueue(t, mt, 1)
+			} else {
+				assertQueue(t, mt)
+			}
+
+			if test.wantQueued && !test.queued {
+				assertMergeQueueActions(t, mt, enum.PullReqMergeQueueActionEnqueued)
+			} else {
+				assertMergeQueueActions(t, mt)
+			}
+		})
+	}
+}
+
+func TestController_MergeQueueDequeue(t *testing.T) {
+	ctx := context.Background()
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	test := setupMergeTest(t, push, ruleRequireCIAndQueue,
+		newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable))
+	test.checks.report(testSourceSHA, enum.CheckStatusSuccess)
+
+	_, _, err := test.c.MergeQueueEnqueue(ctx, test.session, "space/repo", 1,
+		&MergeQueueEnqueueInput{Method: enum.MergeMethodMerge})
+	if err != nil {
+		t.Fatalf("failed to enqueue pull request: %v", err)
+	}
+
+	if _, ok := test.git.refs["refs/pullreq/1/queue"]; !ok {
+		t.Fatal("expected the speculative merge commit to be stored in the queue reference")
+	}
+
+	if err = test.c.MergeQueueDequeue(ctx, test.session, "space/repo", 1); err != nil {
+		t.Fatalf("failed to dequeue pull request: %v", err)
+	}
+
+	assertQueue(t, test)
+	assertMergeQueueActions(t, test,
+		enum.PullReqMergeQueueActionEnqueued, enum.PullReqMergeQueueActionDequeued)
+
+	if _, ok := test.git.refs["refs/pullreq/1/queue"]; ok {
+		t.Error("expected the queue reference to be deleted")
+	}
+
+	err = test.c.MergeQueueDequeue(ctx, test.session, "space/repo", 1)
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		t.Errorf("want not found error for a pull request that isn't queued, got: %v", err)
+	}
+}
+
+func TestController_MergeQueueProcess(t *testing.T) {
+	ctx := context.Background()
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	pr1 := newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable)
+	pr2 := newOpenPullReq(2, testSourceSHA2, enum.MergeCheckStatusMergeable)
+
+	test := setupMergeTest(t, push, ruleRequireCIAndQueue, pr1, pr2)
+
+	// the pull request #2 is enqueued first, so it's ahead of the pull request #1.
+	for _, entry := range []*types.MergeQueueEntry{
+		{PullReqID: 2, PullReqNumber: 2, Created: 1, SourceSHA: testSourceSHA2},
+		{PullReqID: 1, PullReqNumber: 1, Created: 2, SourceSHA: testSourceSHA},
+	} {
+		entry.RepoID = 1
+		entry.Branch = "main"
+		entry.CreatedBy = test.session.Principal.ID
+		entry.State = enum.MergeQueueEntryStateQueued
+		entry.MergeMethod = enum.MergeMethodMerge
+		test.queue.entries[entry.PullReqID] = entry
+	}
+
+	if err := test.c.MergeQueueProcess(ctx, 1, "main"); err != nil {
+		t.Fatalf("failed to process merge queue: %v", err)
+	}
+
+	assertQueue(t, test, 2, 1)
+
+	head := test.queue.entries[2]
+	second := test.queue.entries[1]
+
+	if want, got := testTargetSHA, head.BaseSHA; want != got {
+		t.Errorf("head entry base: want=%s got=%s", want, got)
+	}
+	if want, got := head.MergeSHA, second.BaseSHA; want != got {
+		t.Errorf("second entry must be merged on top of the head entry: want=%s got=%s", want, got)
+	}
+	for _, entry := range []*types.MergeQueueEntry{head, second} {
+		if want, got := enum.MergeQueueEntryStateChecking, entry.State; want != got {
+			t.Errorf("entry #%d state: want=%s got=%s", entry.PullReqNumber, want, got)
+		}
+	}
+
+	// the checks of the pull request behind the head don't matter while the head is pending.
+	test.checks.report(second.MergeSHA, enum.CheckStatusSuccess)
+
+	if err := test.c.MergeQueueProcess(ctx, 1, "main"); err != nil {
+		t.Fatalf("failed to process merge queue: %v", err)
+	}
+
+	assertQueue(t, test, 2, 1)
+	if want, got := testTargetSHA, test.git.branchSHA.String(); want != got {
+		t.Fatalf("target branch must not advance: want=%s got=%s", want, got)
+	}
+
+	// the failing head is ejected and the speculative merge commit of the next entry is recreated.
+	test.checks.report(head.MergeSHA, enum.CheckStatusFailure)
+
+	if err := test.c.MergeQueueProcess(ctx, 1, "main"); err != nil {
+		t.Fatalf("failed to process merge queue: %v", err)
+	}
+
+	assertQueue(t, test, 1)
+	assertMergeQueueActions(t, test, enum.PullReqMergeQueueActionEjected)
+
+	if want, got := enum.PullReqStateOpen, test.pullreqs.prs[2].State; want != got {
+		t.Errorf("ejected pull request state: want=%s got=%s", want, got)
+	}
+
+	newHead := test.queue.entries[1]
+	if want, got := testTargetSHA, newHead.BaseSHA; want != got {
+		t.Errorf("new head entry base: want=%s got=%s", want, got)
+	}
+	if newHead.MergeSHA == second.MergeSHA {
+		t.Error("expected a new speculative merge commit for the new head entry")
+	}
+
+	test.checks.report(newHead.MergeSHA, enum.CheckStatusSuccess)
+
+	if err := test.c.MergeQueueProcessByMergeSHA(ctx, 1, newHead.MergeSHA); err != nil {
+		t.Fatalf("failed to process merge queue: %v", err)
+	}
+
+	assertQueue(t, test)
+
+	if want, got := newHead.MergeSHA, test.git.branchSHA.String(); want != got {
+		t.Errorf("target branch: want=%s got=%s", want, got)
+	}
+	if want, got := enum.PullReqStateMerged, test.pullreqs.prs[1].State; want != got {
+		t.Errorf("merged pull request state: want=%s got=%s", want, got)
+	}
+}
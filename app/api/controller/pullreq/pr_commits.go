@@ -67,5 +67,7 @@ func (c *Controller) Commits(
 		commits[i] = *commit
 	}
 
+	c.signatureVerifier.FillCommitSignatureStatuses(ctx, git.CreateReadParams(repo), commits)
+
 	return commits, nil
 }
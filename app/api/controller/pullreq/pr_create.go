@@ -47,6 +47,10 @@ type CreateInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
+	// Template is the name of the pull request template used for the description if it's empty.
+	// If not provided, the default template of the target branch is used.
+	Template string `json:"template"`
+
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
@@ -61,6 +65,7 @@ type CreateInput struct {
 func (in *CreateInput) Sanitize() error {
 	in.Title = strings.TrimSpace(in.Title)
 	in.Description = strings.TrimSpace(in.Description)
+	in.Template = strings.TrimSpace(in.Template)
 
 	if err := validateTitle(in.Title); err != nil {
 		return err
@@ -84,7 +89,7 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -97,6 +102,23 @@ func (c *Controller) Create(
 		}
 	}
 
+	// For pull requests opened from a fork into its upstream repository,
+	// it's enough to have read access to the target repository.
+	if sourceRepo.ForkID != targetRepo.ID {
+		if err = apiauth.CheckRepoState(ctx, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, err
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		}
+	}
+
+	if sourceRepo.ID != targetRepo.ID && sourceRepo.ForkID != targetRepo.ID && targetRepo.ForkID != sourceRepo.ID {
+		return nil, usererror.BadRequest(
+			"Pull requests across repositories are only allowed between a fork and its upstream repository")
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -111,6 +133,10 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.applyTemplate(ctx, targetRepo, in); err != nil {
+		return nil, err
+	}
+
 	if err = c.checkIfAlreadyExists(ctx, targetRepo.ID, sourceRepo.ID, in.TargetBranch, in.SourceBranch); err != nil {
 		return nil, err
 	}
@@ -122,9 +148,21 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	if sourceRepo.ID != targetRepo.ID {
+		// The source commits must be present in the target repository for the diff and the PR head reference.
+		err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+			WriteParams:   targetWriteParams,
+			SourceRepoUID: sourceRepo.GitUID,
+			ObjectSHAs:    []sha.SHA{sourceSHA},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch source commits from source repo: %w", err)
+		}
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA.String(),
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -167,7 +205,7 @@ func (c *Controller) Create(
 
 	// Rules based reviewers
 
-	codeownerReviewers, defaultReviewers, err := c.prepareRuleReviewers(
+	codeownerReviewers, defaultReviewers, autoAssignedReviewers, err := c.prepareRuleReviewers(
 		ctx, session, targetRepo, in, mergeBaseSHA.String(), sourceSHA.String(),
 	)
 	if err != nil {
@@ -177,7 +215,7 @@ func (c *Controller) Create(
 	if len(codeownerReviewers) > 0 {
 		activitySeq++
 	}
-	if len(defaultReviewers) > 0 {
+	if len(defaultReviewers) > 0 || len(autoAssignedReviewers) > 0 {
 		activitySeq++
 	}
 
@@ -247,10 +285,18 @@ func (c *Controller) Create(
 
 		// Create reviewers and assign labels
 
-		if err = c.createReviewers(ctx, session, reviewerInputMap, targetRepo, pr); err != nil {
+		if err = c.createReviewers(
+			ctx, session, reviewerInputMap, targetRepo, pr, enum.PullReqReviewerTypeRequested,
+		); err != nil {
 			return fmt.Errorf("failed to create reviewers: %w", err)
 		}
 
+		if err = c.createReviewers(
+			ctx, session, autoAssignedReviewers, targetRepo, pr, enum.PullReqReviewerTypeDefault,
+		); err != nil {
+			return fmt.Errorf("failed to create automatically assigned reviewers: %w", err)
+		}
+
 		if labelAssignOuts, err = c.assignLabels(ctx, pr, session.Principal.ID, labelAssignInputMap); err != nil {
 			return fmt.Errorf("failed to assign labels: %w", err)
 		}
@@ -281,7 +327,9 @@ func (c *Controller) Create(
 		ctx, pr, session.Principal.ID, maps.Keys(codeownerReviewers), enum.PullReqReviewerTypeCodeOwners,
 	)
 	c.storeCreateReviewerActivity(
-		ctx, pr, session.Principal.ID, maps.Keys(defaultReviewers), enum.PullReqReviewerTypeDefault,
+		ctx, pr, session.Principal.ID,
+		append(maps.Keys(defaultReviewers), maps.Keys(autoAssignedReviewers)...),
+		enum.PullReqReviewerTypeDefault,
 	)
 
 	backfillWithLabelAssignInfo(pr, labelAssignOuts)
@@ -292,7 +340,7 @@ func (c *Controller) Create(
 		SourceBranch: in.SourceBranch,
 		TargetBranch: in.TargetBranch,
 		SourceSHA:    sourceSHA.String(),
-		ReviewerIDs:  maps.Keys(reviewerInputMap),
+		ReviewerIDs:  append(maps.Keys(reviewerInputMap), maps.Keys(autoAssignedReviewers)...),
 	})
 
 	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
@@ -364,6 +412,8 @@ func (c *Controller) preparePayloadReviewers(
 	return principalEmailMap, nil
 }
 
+// prepareRuleReviewers returns the code owner reviewers, the default reviewers
+// and the automatically assigned reviewers required by the protection rules.
 func (c *Controller) prepareRuleReviewers(
 	ctx context.Context,
 	session *auth.Session,
@@ -371,10 +421,10 @@ func (c *Controller) prepareRuleReviewers(
 	in *CreateInput,
 	mergeBaseSHA string,
 	sourceSHA string,
-) (map[int64]*types.PrincipalInfo, map[int64]*types.PrincipalInfo, error) {
+) (map[int64]*types.PrincipalInfo, map[int64]*types.PrincipalInfo, map[int64]*types.PrincipalInfo, error) {
 	rules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch protection rules: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch protection rules: %w", err)
 	}
 
 	out, _, err := rules.CreatePullReqVerify(ctx, protection.CreatePullReqVerifyInput{
@@ -386,38 +436,88 @@ func (c *Controller) prepareRuleReviewers(
 		TargetBranch:       in.TargetBranch,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
-	if !out.RequestCodeOwners && len(out.DefaultReviewerIDs) == 0 {
-		return map[int64]*types.PrincipalInfo{}, map[int64]*types.PrincipalInfo{}, nil
+	if !out.RequestCodeOwners && len(out.DefaultReviewerIDs) == 0 && len(out.ReviewerAutoAssign) == 0 {
+		return map[int64]*types.PrincipalInfo{}, map[int64]*types.PrincipalInfo{}, map[int64]*types.PrincipalInfo{}, nil
 	}
 
-	codeownerReviewers := make(map[int64]*types.PrincipalInfo)
-	if out.RequestCodeOwners {
-		codeownerReviewers, err = c.getApplicableCodeOwners(
+	var applicableCodeOwners map[int64]*types.PrincipalInfo
+	getCodeOwners := func() (map[int64]*types.PrincipalInfo, error) {
+		if applicableCodeOwners != nil {
+			return applicableCodeOwners, nil
+		}
+
+		codeOwners, err := c.getApplicableCodeOwners(
 			ctx, targetRepo, in.TargetBranch, mergeBaseSHA, sourceSHA,
 		)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to prepare code owner reviewers: %w", err)
+			return nil, fmt.Errorf("failed to prepare code owner reviewers: %w", err)
 		}
 
 		// ensure we remove author from list
-		delete(codeownerReviewers, session.Principal.ID)
+		delete(codeOwners, session.Principal.ID)
+
+		applicableCodeOwners = codeOwners
+
+		return applicableCodeOwners, nil
+	}
+
+	codeownerReviewers := make(map[int64]*types.PrincipalInfo)
+	if out.RequestCodeOwners {
+		codeownerReviewers, err = getCodeOwners()
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	defaultReviewers := make(map[int64]*types.PrincipalInfo, len(out.DefaultReviewerIDs))
 	if len(out.DefaultReviewerIDs) > 0 {
 		defaultReviewers, err = c.getDefaultReviewers(ctx, out.DefaultReviewerIDs)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to prepare default reviewers: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to prepare default reviewers: %w", err)
 		}
 
 		// ensure we remove author from list
 		delete(defaultReviewers, session.Principal.ID)
 	}
 
-	return codeownerReviewers, defaultReviewers, nil
+	autoAssignedReviewers := make(map[int64]*types.PrincipalInfo)
+	if len(out.ReviewerAutoAssign) > 0 {
+		existing := make(map[int64]struct{})
+		for _, id := range in.ReviewerIDs {
+			existing[id] = struct{}{}
+		}
+		for id := range codeownerReviewers {
+			existing[id] = struct{}{}
+		}
+		for id := range defaultReviewers {
+			existing[id] = struct{}{}
+		}
+
+		excluded := map[int64]struct{}{session.Principal.ID: {}}
+
+		ids, err := c.autoAssignReviewers(ctx, out.ReviewerAutoAssign, existing, excluded, func() ([]int64, error) {
+			codeOwners, err := getCodeOwners()
+			if err != nil {
+				return nil, err
+			}
+			return maps.Keys(codeOwners), nil
+		})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to automatically assign reviewers: %w", err)
+		}
+
+		if len(ids) > 0 {
+			autoAssignedReviewers, err = c.principalInfoCache.Map(ctx, ids)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to find principal infos by ids: %w", err)
+			}
+		}
+	}
+
+	return codeownerReviewers, defaultReviewers, autoAssignedReviewers, nil
 }
 
 func (c *Controller) getApplicableCodeOwners(
@@ -473,6 +573,7 @@ func (c *Controller) createReviewers(
 	principalInfos map[int64]*types.PrincipalInfo,
 	repo *types.RepositoryCore,
 	pr *types.PullReq,
+	reviewerType enum.PullReqReviewerType,
 ) error {
 	if len(principalInfos) == 0 {
 		return nil
@@ -486,7 +587,7 @@ func (c *Controller) createReviewers(
 			session, pr, repo,
 			principalInfo,
 			session.Principal.ToPrincipalInfo(),
-			enum.PullReqReviewerTypeRequested,
+			reviewerType,
 			&ReviewerAddInput{
 				ReviewerID: principalInfo.ID,
 			},
@@ -682,3 +783,41 @@ func newPullReq(
 		Merger:            nil,
 	}
 }
+
+// applyTemplate sets the pull request description to the selected pull request template
+// found in the target branch. The template is used only if the description is empty.
+// The default template is optional, so in case of an error the description is left empty,
+// but an explicitly selected template has to exist and be valid.
+func (c *Controller) applyTemplate(ctx context.Context, targetRepo *types.RepositoryCore, in *CreateInput) error {
+	if in.Description != "" {
+		return nil
+	}
+
+	template, err := c.templateService.Find(ctx, targetRepo, in.TargetBranch, in.Template)
+	if err != nil && in.Template != "" {
+		return fmt.Errorf("failed to find pull request template %q: %w", in.Template, err)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find pull request template for repo %d", targetRepo.ID)
+		return nil
+	}
+
+	if template == nil {
+		return nil
+	}
+
+	description := strings.TrimSpace(template.Content)
+
+	if err := validateDescription(description); err != nil {
+		if in.Template != "" {
+			return usererror.BadRequestf("Pull request template %q is too long.", template.Name)
+		}
+		log.Ctx(ctx).Warn().Err(err).Msgf("pull request template %q of repo %d is invalid",
+			template.Name, targetRepo.ID)
+		return nil
+	}
+
+	in.Description = description
+
+	return nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store/cache"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var errCreateStop = errors.New("stop after access checks")
+
+// repoPermissionAuthorizer grants the listed permissions per repository identifier.
+type repoPermissionAuthorizer struct {
+	authz.Authorizer
+	permissions map[string][]enum.Permission
+}
+
+func (a repoPermissionAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	_ *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	for _, p := range a.permissions[resource.Identifier] {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type repoIDCache struct {
+	repos map[int64]*types.RepositoryCore
+}
+
+func (c repoIDCache) Stats() (int64, int64) { return 0, 0 }
+
+func (c repoIDCache) Evict(context.Context, int64) {}
+
+func (c repoIDCache) Get(_ context.Context, id int64) (*types.RepositoryCore, error) {
+	repo, ok := c.repos[id]
+	if !ok {
+		return nil, store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+type createGit struct {
+	git.Interface
+}
+
+func (createGit) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{}, errCreateStop
+}
+
+func TestController_CreateAccess(t *testing.T) {
+	view := []enum.Permission{enum.PermissionRepoView}
+	push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+
+	upstream := &types.RepositoryCore{ID: 1, Identifier: "upstream", Path: "space/upstream"}
+	fork := &types.RepositoryCore{ID: 2, Identifier: "fork", Path: "space/fork", ForkID: 1}
+
+	tests := []struct {
+		name          string
+		repoRef       string
+		sourceRepoRef string
+		permissions   map[string][]enum.Permission
+		wantForbidden bool
+	}{
+		{
+			name:          "same repo with push",
+			repoRef:       "1",
+			permissions:   map[string][]enum.Permission{"upstream": push},
+			wantForbidden: false,
+		},
+		{
+			name:          "same repo with view",
+			repoRef:       "1",
+			permissions:   map[string][]enum.Permission{"upstream": view},
+			wantForbidden: true,
+		},
+		{
+			name:          "fork into upstream with view",
+			repoRef:       "1",
+			sourceRepoRef: "2",
+			permissions:   map[string][]enum.Permission{"upstream": view, "fork": push},
+			wantForbidden: false,
+		},
+		{
+			name:          "upstream into fork with view",
+			repoRef:       "2",
+			sourceRepoRef: "1",
+			permissions:   map[string][]enum.Permission{"upstream": push, "fork": view},
+			wantForbidden: true,
+		},
+		{
+			name:          "upstream into fork with push",
+			repoRef:       "2",
+			sourceRepoRef: "1",
+			permissions:   map[string][]enum.Permission{"upstream": push, "fork": push},
+			wantForbidden: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				authorizer: repoPermissionAuthorizer{permissions: test.permissions},
+				repoFinder: refcache.NewRepoFinder(nil, nil,
+					repoIDCache{repos: map[int64]*types.RepositoryCore{1: upstream, 2: fork}}, nil,
+					cache.Evictor[*types.RepositoryCore]{}),
+				git: createGit{},
+			}
+
+			session := &auth.Session{Principal: types.Principal{ID: 1, Type: enum.PrincipalTypeUser}}
+			in := &CreateInput{
+				SourceRepoRef: test.sourceRepoRef,
+				SourceBranch:  "feature",
+				TargetBranch:  "main",
+				Title:         "title",
+			}
+
+			_, err := c.Create(context.Background(), session, test.repoRef, in)
+
+			if test.wantForbidden {
+				if !errors.Is(err, apiauth.ErrForbidden) {
+					t.Errorf("want forbidden error, got: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, errCreateStop) {
+				t.Errorf("want access checks to pass, got: %v", err)
+			}
+		})
+	}
+}
+
+// templateGit serves the default template and a "bugfix" template from the ".harness" directory.
+// NOTE: The SHA of the template files is their content.
+type templateGit struct {
+	git.Interface
+	err error
+}
+
+func (g templateGit) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	switch params.Path {
+	case ".harness":
+		return &git.ListTreeNodeOutput{Nodes: []git.TreeNode{
+			{Type: git.TreeNodeTypeBlob, Name: "pull_request_template.md", SHA: "default template"},
+			{Type: git.TreeNodeTypeTree, Name: "pull_request_template", Path: ".harness/pull_request_template"},
+		}}, nil
+	case ".harness/pull_request_template":
+		return &git.ListTreeNodeOutput{Nodes: []git.TreeNode{
+			{Type: git.TreeNodeTypeBlob, Name: "bugfix.md", SHA: "bugfix template"},
+		}}, nil
+	default:
+		return nil, gitnesserrors.NotFound("path %q not found", params.Path)
+	}
+}
+
+func (g templateGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content := params.SHA
+	return &git.GetBlobOutput{
+		Size:    int64(len(content)),
+		Content: io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func TestController_applyTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		description    string
+		template       string
+		gitErr         error
+		expDescription string
+		expErr         bool
+	}{
+		{
+			name:           "description-provided",
+			description:    "description",
+			template:       "missing",
+			expDescription: "description",
+		},
+		{
+			name:           "default",
+			expDescription: "default template",
+		},
+		{
+			name:           "selected",
+			template:       "BugFix",
+			expDescription: "bugfix template",
+		},
+		{
+			name:     "selected-missing",
+			template: "missing",
+			expErr:   true,
+		},
+		{
+			name:           "default-lookup-failed",
+			gitErr:         errors.New("git is unavailable"),
+			expDescription: "",
+		},
+		{
+			name:     "selected-lookup-failed",
+			template: "bugfix",
+			gitErr:   errors.New("git is unavailable"),
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				templateService: pullreqtemplate.NewService(templateGit{err: test.gitErr}, refcache.SpaceFinder{}, nil),
+			}
+			in := &CreateInput{
+				Description:  test.description,
+				Template:     test.template,
+				TargetBranch: "main",
+			}
+
+			err := c.applyTemplate(context.Background(), &types.RepositoryCore{ID: 1, GitUID: "repo"}, in)
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t, got: %v", test.expErr, err)
+			}
+			var uErr *usererror.Error
+			if test.template == "missing" && err != nil &&
+				(!errors.As(err, &uErr) || uErr.Status != http.StatusNotFound) {
+				t.Errorf("expected not found error, got: %v", err)
+			}
+
+			if in.Description != test.expDescription {
+				t.Errorf("want description %q, got %q", test.expDescription, in.Description)
+			}
+		})
+	}
+}
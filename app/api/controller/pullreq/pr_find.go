@@ -55,6 +55,13 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to backfill pull request metadata: %w", err)
 	}
 
+	reactions, err := c.reactionStore.ListSummaries(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reactions: %w", err)
+	}
+
+	pr.Reactions = reactions[0]
+
 	return pr, nil
 }
 
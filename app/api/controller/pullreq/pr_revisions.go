@@ -0,0 +1,329 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// InterDiffInput holds the revisions of the pull request that are compared.
+type InterDiffInput struct {
+	// From is the SHA of the older revision.
+	From string
+	// To is the SHA of the newer revision. If not provided, the latest revision is used.
+	To string
+	// SinceLastReview, if set, uses the revision for which the caller submitted their latest review as From.
+	SinceLastReview bool
+
+	IncludePatch     bool
+	IgnoreWhitespace bool
+}
+
+// Revisions returns the recorded revisions of the pull request, from the oldest to the newest.
+// Every revision lists the reviewers whose latest review has been submitted for it.
+func (c *Controller) Revisions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) ([]*types.PullReqRevision, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	revisions, err := c.listRevisions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// RawInterDiff writes raw git diff between two revisions of the pull request to writer w.
+func (c *Controller) RawInterDiff(
+	ctx context.Context,
+	w io.Writer,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *InterDiffInput,
+	setSHAs func(fromSHA, toSHA string),
+	files ...gittypes.FileDiffRequest,
+) error {
+	params, err := c.interDiffParams(ctx, session, repoRef, pullreqNum, in)
+	if err != nil {
+		return err
+	}
+
+	if setSHAs != nil {
+		setSHAs(params.BaseRef, params.HeadRef)
+	}
+
+	return c.git.RawDiff(ctx, w, params, files...)
+}
+
+// InterDiff returns the diff between two revisions of the pull request.
+// The changes that came from the target branch, if the source branch was rebased in between, are excluded.
+func (c *Controller) InterDiff(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *InterDiffInput,
+	setSHAs func(fromSHA, toSHA string),
+	files ...gittypes.FileDiffRequest,
+) (types.Stream[*git.FileDiff], error) {
+	params, err := c.interDiffParams(ctx, session, repoRef, pullreqNum, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if setSHAs != nil {
+		setSHAs(params.BaseRef, params.HeadRef)
+	}
+
+	return git.NewStreamReader(c.git.Diff(ctx, params, files...)), nil
+}
+
+func (c *Controller) interDiffParams(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *InterDiffInput,
+) (*git.DiffParams, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	revisions, err := c.listRevisions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := findInterDiffRevisions(revisions, session.Principal.ID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// The merge bases are calculated against the target branch as it was before the merge.
+	targetRef := pr.TargetBranch
+	if pr.State == enum.PullReqStateMerged && pr.MergeTargetSHA != nil {
+		targetRef = *pr.MergeTargetSHA
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	fromMergeBase, err := c.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       from.SHA,
+		Ref2:       targetRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of the old revision: %w", err)
+	}
+
+	toMergeBase, err := c.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       to.SHA,
+		Ref2:       targetRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of the new revision: %w", err)
+	}
+
+	return &git.DiffParams{
+		ReadParams:       readParams,
+		BaseRef:          from.SHA,
+		HeadRef:          to.SHA,
+		IncludePatch:     in.IncludePatch,
+		IgnoreWhitespace: in.IgnoreWhitespace,
+		BaseMergeBaseSHA: fromMergeBase.MergeBaseSHA.String(),
+		HeadMergeBaseSHA: toMergeBase.MergeBaseSHA.String(),
+	}, nil
+}
+
+func findInterDiffRevisions(
+	revisions []*types.PullReqRevision,
+	principalID int64,
+	in *InterDiffInput,
+) (*types.PullReqRevision, *types.PullReqRevision, error) {
+	find := func(sha string) *types.PullReqRevision {
+		for i := len(revisions) - 1; i >= 0; i-- {
+			if revisions[i].SHA == sha {
+				return revisions[i]
+			}
+		}
+		return nil
+	}
+
+	var from *types.PullReqRevision
+
+	if in.SinceLastReview {
+		for _, rev := range revisions {
+			for _, review := range rev.Reviews {
+				if review.Reviewer.ID == principalID {
+					from = rev
+				}
+			}
+		}
+		if from == nil {
+			return nil, nil, usererror.BadRequest("You haven't reviewed any revision of the pull request.")
+		}
+	} else {
+		if in.From == "" {
+			return nil, nil, usererror.BadRequest("The revision to compare from must be provided.")
+		}
+		if from = find(in.From); from == nil {
+			return nil, nil, usererror.BadRequestf("Commit %s isn't a revision of the pull request.", in.From)
+		}
+	}
+
+	to := revisions[len(revisions)-1]
+	if in.To != "" {
+		if to = find(in.To); to == nil {
+			return nil, nil, usererror.BadRequestf("Commit %s isn't a revision of the pull request.", in.To)
+		}
+	}
+
+	if from.Number > to.Number {
+		return nil, nil, usererror.BadRequest("The revision to compare from must be older than the other revision.")
+	}
+
+	return from, to, nil
+}
+
+// listRevisions returns the revisions of the pull request. The revisions are assembled from the
+// branch update activities, and the reviews are attached to them using the pull request review records.
+func (c *Controller) listRevisions(ctx context.Context, pr *types.PullReq) ([]*types.PullReqRevision, error) {
+	activities, err := c.activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{
+		Types: []enum.PullReqActivityType{enum.PullReqActivityTypeBranchUpdate},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch update activities: %w", err)
+	}
+
+	revisions := make([]*types.PullReqRevision, 0, len(activities)+1)
+	revisionMap := make(map[string]*types.PullReqRevision, len(activities)+1)
+
+	addRevision := func(sha string, created int64, forced bool) {
+		if sha == "" {
+			return
+		}
+		if len(revisions) > 0 && revisions[len(revisions)-1].SHA == sha {
+			return
+		}
+
+		rev := &types.PullReqRevision{
+			Number:  len(revisions) + 1,
+			SHA:     sha,
+			Created: created,
+			Forced:  forced,
+			Reviews: []types.PullReqRevisionReview{},
+		}
+
+		revisions = append(revisions, rev)
+		revisionMap[sha] = rev
+	}
+
+	for _, act := range activities {
+		payload, err := act.GetPayload()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch update activity payload: %w", err)
+		}
+
+		update, ok := payload.(*types.PullRequestActivityPayloadBranchUpdate)
+		if !ok {
+			continue
+		}
+
+		if len(revisions) == 0 {
+			addRevision(update.Old, pr.Created, false)
+		}
+
+		addRevision(update.New, act.Created, update.Forced)
+	}
+
+	// The branch update activity might be missing (e.g. it failed to be written).
+	if len(revisions) == 0 || revisions[len(revisions)-1].SHA != pr.SourceSHA {
+		addRevision(pr.SourceSHA, pr.Updated, false)
+	}
+
+	reviews, err := c.reviewStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reviews: %w", err)
+	}
+
+	latestReviews := make(map[int64]*types.PullReqReview)
+	for _, review := range reviews {
+		latestReviews[review.CreatedBy] = review
+	}
+
+	reviewerIDs := make([]int64, 0, len(latestReviews))
+	for reviewerID := range latestReviews {
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	reviewers, err := c.principalInfoCache.Map(ctx, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reviewers: %w", err)
+	}
+
+	for _, review := range reviews {
+		if latestReviews[review.CreatedBy] != review {
+			continue
+		}
+
+		rev, ok := revisionMap[review.SHA]
+		if !ok {
+			continue
+		}
+
+		reviewer := reviewers[review.CreatedBy]
+		if reviewer == nil {
+			continue
+		}
+
+		rev.Reviews = append(rev.Reviews, types.PullReqRevisionReview{
+			ReviewID: review.ID,
+			Reviewed: review.Created,
+			Decision: review.Decision,
+			Reviewer: *reviewer,
+		})
+	}
+
+	return revisions, nil
+}
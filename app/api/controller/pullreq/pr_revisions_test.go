@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestFindInterDiffRevisions(t *testing.T) {
+	const reviewerID = 7
+
+	revisions := []*types.PullReqRevision{
+		{Number: 1, SHA: "aaa"},
+		{Number: 2, SHA: "bbb", Reviews: []types.PullReqRevisionReview{
+			{Reviewer: types.PrincipalInfo{ID: reviewerID}},
+		}},
+		{Number: 3, SHA: "ccc"},
+	}
+
+	tests := []struct {
+		name     string
+		in       InterDiffInput
+		wantFrom int
+		wantTo   int
+		wantErr  bool
+	}{
+		{
+			name:     "from revision to latest",
+			in:       InterDiffInput{From: "aaa"},
+			wantFrom: 1,
+			wantTo:   3,
+		},
+		{
+			name:     "between two revisions",
+			in:       InterDiffInput{From: "aaa", To: "bbb"},
+			wantFrom: 1,
+			wantTo:   2,
+		},
+		{
+			name:     "since last review",
+			in:       InterDiffInput{SinceLastReview: true},
+			wantFrom: 2,
+			wantTo:   3,
+		},
+		{
+			name:    "missing from",
+			in:      InterDiffInput{To: "bbb"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown revision",
+			in:      InterDiffInput{From: "ddd"},
+			wantErr: true,
+		},
+		{
+			name:    "wrong order",
+			in:      InterDiffInput{From: "ccc", To: "aaa"},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, to, err := findInterDiffRevisions(revisions, reviewerID, &test.in)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("findInterDiffRevisions() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if from.Number != test.wantFrom || to.Number != test.wantTo {
+				t.Errorf("findInterDiffRevisions() got = %d..%d, want %d..%d",
+					from.Number, to.Number, test.wantFrom, test.wantTo)
+			}
+		})
+	}
+
+	if _, _, err := findInterDiffRevisions(revisions, reviewerID+1, &InterDiffInput{SinceLastReview: true}); err == nil {
+		t.Error("expected an error for a principal without a review")
+	}
+}
@@ -111,6 +111,11 @@ func (c *Controller) State(ctx context.Context,
 	var mergeBaseSHA sha.SHA
 	var stateChange change
 
+	targetWriteParams, err := controller.CreateRPCSystemReferencesWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
 	//nolint:nestif // refactor if needed
 	if pr.State != enum.PullReqStateOpen && in.State == enum.PullReqStateOpen {
 		if sourceSHA, err = c.verifyBranchExistence(ctx, sourceRepo, pr.SourceBranch); err != nil {
@@ -126,9 +131,20 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		if sourceRepo.ID != targetRepo.ID {
+			err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+				WriteParams:   targetWriteParams,
+				SourceRepoUID: sourceRepo.GitUID,
+				ObjectSHAs:    []sha.SHA{sourceSHA},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch source commits from source repo: %w", err)
+			}
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA.String(),
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
@@ -142,11 +158,6 @@ func (c *Controller) State(ctx context.Context,
 		stateChange = changeClose
 	}
 
-	targetWriteParams, err := controller.CreateRPCSystemReferencesWriteParams(ctx, c.urlProvider, session, targetRepo)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
-	}
-
 	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
 		if pr == nil {
 			pr, err = c.pullreqStore.Find(ctx, id)
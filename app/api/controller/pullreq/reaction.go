@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReactionAdd adds a reaction of the current user on the pull request description.
+func (c *Controller) ReactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	reaction enum.PullReqReaction,
+) ([]types.PullReqReactionSummary, error) {
+	return c.reactionUpdate(ctx, session, repoRef, pullreqNum, 0, reaction, true)
+}
+
+// ReactionRemove removes a reaction of the current user from the pull request description.
+func (c *Controller) ReactionRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	reaction enum.PullReqReaction,
+) ([]types.PullReqReactionSummary, error) {
+	return c.reactionUpdate(ctx, session, repoRef, pullreqNum, 0, reaction, false)
+}
+
+// CommentReactionAdd adds a reaction of the current user on a pull request comment.
+func (c *Controller) CommentReactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	reaction enum.PullReqReaction,
+) ([]types.PullReqReactionSummary, error) {
+	if commentID <= 0 {
+		return nil, usererror.BadRequest("A valid comment ID must be provided.")
+	}
+
+	return c.reactionUpdate(ctx, session, repoRef, pullreqNum, commentID, reaction, true)
+}
+
+// CommentReactionRemove removes a reaction of the current user from a pull request comment.
+func (c *Controller) CommentReactionRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	reaction enum.PullReqReaction,
+) ([]types.PullReqReactionSummary, error) {
+	if commentID <= 0 {
+		return nil, usererror.BadRequest("A valid comment ID must be provided.")
+	}
+
+	return c.reactionUpdate(ctx, session, repoRef, pullreqNum, commentID, reaction, false)
+}
+
+// reactionUpdate adds or removes a reaction on a pull request comment,
+// or on the pull request description if the commentID is zero.
+// Reactions are deliberately not reported as pull request events, so they don't trigger any notifications.
+func (c *Controller) reactionUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	commentID int64,
+	reaction enum.PullReqReaction,
+	add bool,
+) ([]types.PullReqReactionSummary, error) {
+	if _, ok := reaction.Sanitize(); !ok {
+		return nil, usererror.BadRequestf("Invalid reaction %q.", reaction)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	var activityID *int64
+	if commentID != 0 {
+		comment, err := c.getCommentForPR(ctx, pr, commentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if comment.Pending {
+			return nil, usererror.BadRequest("Can't react to pending comments.")
+		}
+
+		activityID = &comment.ID
+	}
+
+	r := &types.PullReqReaction{
+		PullReqID:   pr.ID,
+		ActivityID:  activityID,
+		PrincipalID: session.Principal.ID,
+		Reaction:    reaction,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if add {
+		err = c.reactionStore.Create(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pull request reaction: %w", err)
+		}
+	} else {
+		err = c.reactionStore.Delete(ctx, r)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to delete pull request reaction: %w", err)
+		}
+	}
+
+	summaries, err := c.reactionStore.ListSummaries(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reactions: %w", err)
+	}
+
+	reactions := summaries[commentID]
+	if reactions == nil {
+		reactions = []types.PullReqReactionSummary{}
+	}
+
+	// The Reacted flag is specific to the current user, so it's cleared in the broadcast event.
+	broadcast := make([]types.PullReqReactionSummary, len(reactions))
+	for i, summary := range reactions {
+		summary.Reacted = false
+		broadcast[i] = summary
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqReactionsUpdated, &types.PullReqReactionsUpdate{
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		ActivityID:    activityID,
+		Reactions:     broadcast,
+	})
+
+	return reactions, nil
+}
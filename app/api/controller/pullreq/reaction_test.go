@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type reactionKey struct {
+	activityID  int64
+	principalID int64
+	reaction    enum.PullReqReaction
+}
+
+// reactionMapStore keeps the reactions unique per comment, principal and reaction, like the database does.
+type reactionMapStore struct {
+	appstore.PullReqReactionStore
+	reactions []reactionKey
+}
+
+func newReactionKey(reaction *types.PullReqReaction) reactionKey {
+	key := reactionKey{principalID: reaction.PrincipalID, reaction: reaction.Reaction}
+	if reaction.ActivityID != nil {
+		key.activityID = *reaction.ActivityID
+	}
+	return key
+}
+
+func (s *reactionMapStore) Create(_ context.Context, reaction *types.PullReqReaction) error {
+	key := newReactionKey(reaction)
+	for _, existing := range s.reactions {
+		if existing == key {
+			return nil
+		}
+	}
+	s.reactions = append(s.reactions, key)
+	return nil
+}
+
+func (s *reactionMapStore) Delete(_ context.Context, reaction *types.PullReqReaction) error {
+	key := newReactionKey(reaction)
+	for i, existing := range s.reactions {
+		if existing == key {
+			s.reactions = append(s.reactions[:i], s.reactions[i+1:]...)
+			return nil
+		}
+	}
+	return store.ErrResourceNotFound
+}
+
+func (s *reactionMapStore) ListSummaries(
+	_ context.Context,
+	_ int64,
+	principalID int64,
+) (map[int64][]types.PullReqReactionSummary, error) {
+	summaries := map[int64][]types.PullReqReactionSummary{}
+	for _, key := range s.reactions {
+		found := false
+		for i := range summaries[key.activityID] {
+			summary := &summaries[key.activityID][i]
+			if summary.Reaction != key.reaction {
+				continue
+			}
+			summary.Count++
+			summary.Reacted = summary.Reacted || key.principalID == principalID
+			found = true
+		}
+		if !found {
+			summaries[key.activityID] = append(summaries[key.activityID], types.PullReqReactionSummary{
+				Reaction: key.reaction,
+				Count:    1,
+				Reacted:  key.principalID == principalID,
+			})
+		}
+	}
+	return summaries, nil
+}
+
+func TestController_Reactions(t *testing.T) {
+	ctx := context.Background()
+	review := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoReview}
+
+	pr := newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable)
+	test := setupMergeTest(t, review, "", pr)
+
+	reactions := &reactionMapStore{}
+	test.c.reactionStore = reactions
+	test.c.activityStore = &pendingCommentsActivityStore{
+		comments: []*types.PullReqActivity{
+			{
+				ID:        10,
+				RepoID:    pr.TargetRepoID,
+				PullReqID: pr.ID,
+				Type:      enum.PullReqActivityTypeComment,
+				Kind:      enum.PullReqActivityKindComment,
+			},
+			{
+				ID:        11,
+				RepoID:    pr.TargetRepoID,
+				PullReqID: pr.ID,
+				Type:      enum.PullReqActivityTypeComment,
+				Kind:      enum.PullReqActivityKindComment,
+				Pending:   true,
+			},
+		},
+	}
+
+	other := &auth.Session{Principal: types.Principal{ID: 2, UID: "other", Type: enum.PrincipalTypeUser}}
+
+	assertSummaries := func(got []types.PullReqReactionSummary, want ...types.PullReqReactionSummary) {
+		t.Helper()
+		if want == nil {
+			want = []types.PullReqReactionSummary{}
+		}
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			t.Fatalf("reactions: want=%v got=%v", want, got)
+		}
+	}
+
+	// adding the same reaction twice must not count it twice.
+	for range 2 {
+		summaries, err := test.c.CommentReactionAdd(ctx, test.session, "repo", pr.Number, 10,
+			enum.PullReqReactionThumbsUp)
+		if err != nil {
+			t.Fatalf("failed to add reaction: %v", err)
+		}
+		assertSummaries(summaries,
+			types.PullReqReactionSummary{Reaction: enum.PullReqReactionThumbsUp, Count: 1, Reacted: true})
+	}
+
+	summaries, err := test.c.CommentReactionAdd(ctx, other, "repo", pr.Number, 10, enum.PullReqReactionThumbsUp)
+	if err != nil {
+		t.Fatalf("failed to add reaction: %v", err)
+	}
+	assertSummaries(summaries,
+		types.PullReqReactionSummary{Reaction: enum.PullReqReactionThumbsUp, Count: 2, Reacted: true})
+
+	// reactions on the pull request description are kept apart from the reactions on the comments.
+	summaries, err = test.c.ReactionAdd(ctx, test.session, "repo", pr.Number, enum.PullReqReactionHeart)
+	if err != nil {
+		t.Fatalf("failed to add reaction: %v", err)
+	}
+	assertSummaries(summaries,
+		types.PullReqReactionSummary{Reaction: enum.PullReqReactionHeart, Count: 1, Reacted: true})
+
+	// removing the reaction toggles it off, removing it again is a no-op.
+	for range 2 {
+		summaries, err = test.c.CommentReactionRemove(ctx, test.session, "repo", pr.Number, 10,
+			enum.PullReqReactionThumbsUp)
+		if err != nil {
+			t.Fatalf("failed to remove reaction: %v", err)
+		}
+		assertSummaries(summaries,
+			types.PullReqReactionSummary{Reaction: enum.PullReqReactionThumbsUp, Count: 1, Reacted: false})
+	}
+
+	summaries, err = test.c.ReactionRemove(ctx, test.session, "repo", pr.Number, enum.PullReqReactionHeart)
+	if err != nil {
+		t.Fatalf("failed to remove reaction: %v", err)
+	}
+	assertSummaries(summaries)
+
+	assertBadRequest := func(err error) {
+		t.Helper()
+		var uErr *usererror.Error
+		if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+			t.Fatalf("expected bad request, got: %v", err)
+		}
+	}
+
+	_, err = test.c.CommentReactionAdd(ctx, test.session, "repo", pr.Number, 10, "party_parrot")
+	assertBadRequest(err)
+
+	_, err = test.c.ReactionAdd(ctx, test.session, "repo", pr.Number, "")
+	assertBadRequest(err)
+
+	_, err = test.c.CommentReactionAdd(ctx, test.session, "repo", pr.Number, 11, enum.PullReqReactionThumbsUp)
+	assertBadRequest(err)
+
+	if want, got := 1, len(reactions.reactions); want != got {
+		t.Fatalf("stored reactions: want=%d got=%d", want, got)
+	}
+}
+
+func TestController_ReactionsRequireReviewPermission(t *testing.T) {
+	pr := newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable)
+	test := setupMergeTest(t, []enum.Permission{enum.PermissionRepoView}, "", pr)
+	test.c.reactionStore = &reactionMapStore{}
+
+	_, err := test.c.ReactionAdd(context.Background(), test.session, "repo", pr.Number, enum.PullReqReactionThumbsUp)
+	if !errors.Is(err, apiauth.ErrForbidden) {
+		t.Fatalf("expected forbidden, got: %v", err)
+	}
+}
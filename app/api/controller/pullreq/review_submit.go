@@ -96,6 +96,7 @@ func (c *Controller) ReviewSubmit(
 	commitSHA := commit.Commit.SHA
 
 	var review *types.PullReqReview
+	var comments []*types.PullReqActivity
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		now := time.Now().UnixMilli()
@@ -113,19 +114,35 @@ func (c *Controller) ReviewSubmit(
 		if err != nil {
 			return err
 		}
-		c.eventReporter.ReviewSubmitted(ctx, &events.ReviewSubmittedPayload{
-			Base:       eventBase(pr, &session.Principal),
-			Decision:   review.Decision,
-			ReviewerID: review.CreatedBy,
-		})
 
 		_, err = c.updateReviewer(ctx, session, pr, review, commitSHA.String())
+		if err != nil {
+			return err
+		}
+
+		comments, err = c.publishPendingComments(ctx, session, pr)
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	commentIDs := make([]int64, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+	}
+
+	c.eventReporter.ReviewSubmitted(ctx, &events.ReviewSubmittedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		Decision:   review.Decision,
+		ReviewerID: review.CreatedBy,
+		CommentIDs: commentIDs,
+	})
+
+	if len(comments) > 0 {
+		c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+	}
+
 	err = func() error {
 		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
 			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
@@ -161,6 +178,47 @@ func (c *Controller) ReviewSubmit(
 	return review, nil
 }
 
+// publishPendingComments publishes all pending comments of the reviewer and updates the pull request comment counters.
+// It returns the published comments, excluding the pending comments that got deleted.
+func (c *Controller) publishPendingComments(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+) ([]*types.PullReqActivity, error) {
+	published, err := c.activityStore.PublishPending(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish pending review comments: %w", err)
+	}
+
+	comments := make([]*types.PullReqActivity, 0, len(published))
+	for _, comment := range published {
+		if comment.Deleted == nil {
+			comments = append(comments, comment)
+		}
+	}
+
+	if len(comments) == 0 {
+		return comments, nil
+	}
+
+	prUpd, err := c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		for _, comment := range comments {
+			pr.CommentCount++
+			if comment.IsBlocking() {
+				pr.UnresolvedCount++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment pull request comment counters: %w", err)
+	}
+
+	*pr = *prUpd
+
+	return comments, nil
+}
+
 // updateReviewer updates pull request reviewer object.
 func (c *Controller) updateReviewer(
 	ctx context.Context,
@@ -0,0 +1,283 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// autoAssignReviewers picks the reviewers for the automatic reviewer assignments of the protection rules.
+// Members of an assignment's pool who are already reviewers count towards the number of required reviewers.
+// The excluded principals (like the pull request author) and the principals who are out of office are never picked.
+func (c *Controller) autoAssignReviewers(
+	ctx context.Context,
+	assignments []protection.DefReviewerAutoAssign,
+	existing map[int64]struct{},
+	excluded map[int64]struct{},
+	getCodeOwnerIDs func() ([]int64, error),
+) ([]int64, error) {
+	picked := make(map[int64]struct{})
+	var selected []int64
+
+	for _, assignment := range assignments {
+		pool, err := c.assignmentPool(ctx, assignment, getCodeOwnerIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		needed := assignment.Count
+		candidates := make([]int64, 0, len(pool))
+		for _, id := range pool {
+			_, isExisting := existing[id]
+			_, isPicked := picked[id]
+			if isExisting || isPicked {
+				needed--
+				continue
+			}
+
+			if _, isExcluded := excluded[id]; isExcluded {
+				continue
+			}
+
+			candidates = append(candidates, id)
+		}
+
+		if needed <= 0 || len(candidates) == 0 {
+			continue
+		}
+
+		outOfOffice, err := c.outOfOfficeStore.ListActive(ctx, candidates, time.Now().UnixMilli())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list out of office users: %w", err)
+		}
+
+		candidates = removeIDs(candidates, outOfOffice)
+
+		stats, err := c.reviewerStore.ListAssignmentStats(ctx, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reviewer assignment stats: %w", err)
+		}
+
+		for _, id := range selectReviewers(candidates, stats, assignment.Strategy, needed) {
+			picked[id] = struct{}{}
+			selected = append(selected, id)
+		}
+	}
+
+	return selected, nil
+}
+
+// assignmentPool returns the principals the automatic reviewer assignment picks the reviewers from.
+func (c *Controller) assignmentPool(
+	ctx context.Context,
+	assignment protection.DefReviewerAutoAssign,
+	getCodeOwnerIDs func() ([]int64, error),
+) ([]int64, error) {
+	if assignment.CodeOwners {
+		pool, err := getCodeOwnerIDs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get code owners: %w", err)
+		}
+		return pool, nil
+	}
+
+	pool, err := c.userGroupService.ListUserIDsByGroupIDs(ctx, []int64{assignment.UserGroupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users of user group %d: %w", assignment.UserGroupID, err)
+	}
+
+	return pool, nil
+}
+
+// rebalanceReviewers replaces a removed automatically assigned reviewer
+// with another one picked by the automatic reviewer assignments of the protection rules.
+func (c *Controller) rebalanceReviewers(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	removedID int64,
+) error {
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules: %w", err)
+	}
+
+	out, _, err := rules.CreatePullReqVerify(ctx, protection.CreatePullReqVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		IsRepoOwner:        isRepoOwner,
+		DefaultBranch:      repo.DefaultBranch,
+		TargetBranch:       pr.TargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	// Default reviewers listed by the rules were not picked automatically, so they aren't replaced.
+	if len(out.ReviewerAutoAssign) == 0 || slices.Contains(out.DefaultReviewerIDs, removedID) {
+		return nil
+	}
+
+	var codeOwnerIDs []int64
+	getCodeOwnerIDs := func() ([]int64, error) {
+		if codeOwnerIDs != nil {
+			return codeOwnerIDs, nil
+		}
+
+		codeOwners, err := c.getApplicableCodeOwners(ctx, repo, pr.TargetBranch, pr.MergeBaseSHA, pr.SourceSHA)
+		if err != nil {
+			return nil, err
+		}
+
+		codeOwnerIDs = maps.Keys(codeOwners)
+
+		return codeOwnerIDs, nil
+	}
+
+	// Only the assignments the removed reviewer could have been picked by are rebalanced.
+	var assignments []protection.DefReviewerAutoAssign
+	for _, assignment := range out.ReviewerAutoAssign {
+		pool, err := c.assignmentPool(ctx, assignment, getCodeOwnerIDs)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(pool, removedID) {
+			assignments = append(assignments, assignment)
+		}
+	}
+
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	existing := make(map[int64]struct{}, len(reviewers))
+	for _, reviewer := range reviewers {
+		existing[reviewer.PrincipalID] = struct{}{}
+	}
+
+	excluded := map[int64]struct{}{pr.CreatedBy: {}, removedID: {}}
+
+	ids, err := c.autoAssignReviewers(ctx, assignments, existing, excluded, getCodeOwnerIDs)
+	if err != nil {
+		return fmt.Errorf("failed to pick reviewers: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	principalInfos, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to find principal infos by ids: %w", err)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.createReviewers(ctx, session, principalInfos, repo, pr, enum.PullReqReviewerTypeDefault)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reviewers: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadReviewerAdd{
+		ReviewerType: enum.PullReqReviewerTypeDefault,
+		PrinciaplIDs: ids,
+	}
+
+	metadata := &types.PullReqActivityMetadata{
+		Mentions: &types.PullReqActivityMentionsMetadata{IDs: ids},
+	}
+
+	if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+		return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+	}
+
+	if _, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, metadata); err != nil {
+		return fmt.Errorf("failed to create pull request activity: %w", err)
+	}
+
+	for _, id := range ids {
+		c.reportReviewerAddition(ctx, session, pr, &types.PullReqReviewer{PrincipalID: id})
+	}
+
+	return nil
+}
+
+// selectReviewers picks up to count reviewers from the candidates using the provided strategy.
+// Round-robin picks the candidates who were automatically assigned the longest time ago (or never),
+// least-loaded picks the candidates with the fewest pending reviews and uses round-robin as the tiebreaker.
+func selectReviewers(
+	candidates []int64,
+	stats map[int64]types.ReviewerAssignmentStats,
+	strategy enum.ReviewerAssignmentStrategy,
+	count int,
+) []int64 {
+	sorted := make([]int64, len(candidates))
+	copy(sorted, candidates)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := stats[sorted[i]], stats[sorted[j]]
+		if strategy == enum.ReviewerAssignmentStrategyLeastLoaded && a.OpenReviews != b.OpenReviews {
+			return a.OpenReviews < b.OpenReviews
+		}
+		if a.LastAssigned != b.LastAssigned {
+			return a.LastAssigned < b.LastAssigned
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	if count < len(sorted) {
+		sorted = sorted[:count]
+	}
+
+	return sorted
+}
+
+func removeIDs(ids []int64, remove []int64) []int64 {
+	if len(remove) == 0 {
+		return ids
+	}
+
+	removeSet := make(map[int64]struct{}, len(remove))
+	for _, id := range remove {
+		removeSet[id] = struct{}{}
+	}
+
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := removeSet[id]; !ok {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
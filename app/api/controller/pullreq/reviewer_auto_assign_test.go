@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestSelectReviewers(t *testing.T) {
+	stats := map[int64]types.ReviewerAssignmentStats{
+		1: {LastAssigned: 300, OpenReviews: 0},
+		2: {LastAssigned: 100, OpenReviews: 5},
+		3: {LastAssigned: 200, OpenReviews: 1},
+		// 4 was never a reviewer
+		5: {LastAssigned: 50, OpenReviews: 0},
+	}
+
+	tests := []struct {
+		name       string
+		candidates []int64
+		strategy   enum.ReviewerAssignmentStrategy
+		count      int
+		want       []int64
+	}{
+		{
+			name:       "round-robin",
+			candidates: []int64{1, 2, 3, 4},
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			count:      2,
+			want:       []int64{4, 2},
+		},
+		{
+			name:       "least-loaded",
+			candidates: []int64{1, 2, 3, 4},
+			strategy:   enum.ReviewerAssignmentStrategyLeastLoaded,
+			count:      3,
+			want:       []int64{4, 1, 3},
+		},
+		{
+			name:       "least-loaded-tie-uses-round-robin",
+			candidates: []int64{1, 5},
+			strategy:   enum.ReviewerAssignmentStrategyLeastLoaded,
+			count:      1,
+			want:       []int64{5},
+		},
+		{
+			name:       "fewer-candidates-than-count",
+			candidates: []int64{3, 1},
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			count:      5,
+			want:       []int64{3, 1},
+		},
+		{
+			name:       "no-candidates",
+			candidates: []int64{},
+			strategy:   enum.ReviewerAssignmentStrategyRoundRobin,
+			count:      1,
+			want:       []int64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := selectReviewers(test.candidates, stats, test.strategy, test.count)
+			if !slices.Equal(test.want, got) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
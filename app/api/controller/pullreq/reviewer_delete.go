@@ -106,6 +106,13 @@ func (c *Controller) ReviewerDelete(
 		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after reviewer removal")
 	}
 
+	if reviewer.Type == enum.PullReqReviewerTypeDefault && pr.State == enum.PullReqStateOpen {
+		if err = c.rebalanceReviewers(ctx, session, repo, pr, reviewer.PrincipalID); err != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(err).Msg("failed to automatically assign a reviewer after reviewer removal")
+		}
+	}
+
 	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqReviewerAdded, pr)
 
 	return nil
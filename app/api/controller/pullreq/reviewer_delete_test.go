@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/services/usergroup"
+	appstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const ruleAutoAssign = `{"pullreq":{"reviewers":{` +
+	`"default_reviewer_ids":[20],` +
+	`"auto_assign":[{"user_group_id":1,"count":1,"strategy":"round_robin"}]}}}`
+
+// reviewerMapStore holds the reviewers of a single pull request by principal ID.
+type reviewerMapStore struct {
+	appstore.PullReqReviewerStore
+	reviewers map[int64]*types.PullReqReviewer
+}
+
+func (s *reviewerMapStore) Find(_ context.Context, _, principalID int64) (*types.PullReqReviewer, error) {
+	reviewer, ok := s.reviewers[principalID]
+	if !ok {
+		return nil, store.ErrResourceNotFound
+	}
+	return reviewer, nil
+}
+
+func (s *reviewerMapStore) Create(_ context.Context, reviewer *types.PullReqReviewer) error {
+	s.reviewers[reviewer.PrincipalID] = reviewer
+	return nil
+}
+
+func (s *reviewerMapStore) Delete(_ context.Context, _, principalID int64) error {
+	delete(s.reviewers, principalID)
+	return nil
+}
+
+func (s *reviewerMapStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	reviewers := make([]*types.PullReqReviewer, 0, len(s.reviewers))
+	for _, reviewer := range s.reviewers {
+		reviewers = append(reviewers, reviewer)
+	}
+	return reviewers, nil
+}
+
+func (s *reviewerMapStore) ListAssignmentStats(
+	context.Context,
+	[]int64,
+) (map[int64]types.ReviewerAssignmentStats, error) {
+	return map[int64]types.ReviewerAssignmentStats{}, nil
+}
+
+func (s *reviewerMapStore) ids() []int64 {
+	ids := make([]int64, 0, len(s.reviewers))
+	for id := range s.reviewers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+type userGroupMembers struct {
+	usergroup.SearchService
+	members map[int64][]int64
+}
+
+func (s userGroupMembers) ListUserIDsByGroupIDs(_ context.Context, groupIDs []int64) ([]int64, error) {
+	var ids []int64
+	for _, groupID := range groupIDs {
+		ids = append(ids, s.members[groupID]...)
+	}
+	return ids, nil
+}
+
+type noneOutOfOffice struct {
+	appstore.OutOfOfficeStore
+}
+
+func (noneOutOfOffice) ListActive(context.Context, []int64, int64) ([]int64, error) {
+	return nil, nil
+}
+
+type principalInfoMap struct {
+	appstore.PrincipalInfoCache
+}
+
+func (principalInfoMap) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	infos := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		infos[id] = &types.PrincipalInfo{ID: id, Type: enum.PrincipalTypeUser}
+	}
+	return infos, nil
+}
+
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+func TestController_ReviewerDeleteRebalance(t *testing.T) {
+	tests := []struct {
+		name      string
+		reviewers map[int64]enum.PullReqReviewerType
+		removeID  int64
+		want      []int64
+	}{
+		{
+			name: "auto-assigned-reviewer-replaced",
+			reviewers: map[int64]enum.PullReqReviewerType{
+				10: enum.PullReqReviewerTypeDefault,
+				20: enum.PullReqReviewerTypeDefault,
+			},
+			removeID: 10,
+			want:     []int64{11, 20},
+		},
+		{
+			name: "listed-default-reviewer-not-replaced",
+			reviewers: map[int64]enum.PullReqReviewerType{
+				20: enum.PullReqReviewerTypeDefault,
+			},
+			removeID: 20,
+			want:     []int64{},
+		},
+		{
+			name: "requested-reviewer-not-replaced",
+			reviewers: map[int64]enum.PullReqReviewerType{
+				10: enum.PullReqReviewerTypeDefault,
+				11: enum.PullReqReviewerTypeRequested,
+			},
+			removeID: 11,
+			want:     []int64{10},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := newOpenPullReq(1, testSourceSHA, enum.MergeCheckStatusMergeable)
+			push := []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+			mt := setupMergeTest(t, push, ruleAutoAssign, pr)
+
+			reviewers := &reviewerMapStore{reviewers: map[int64]*types.PullReqReviewer{}}
+			for id, reviewerType := range test.reviewers {
+				reviewers.reviewers[id] = &types.PullReqReviewer{
+					PullReqID:      pr.ID,
+					PrincipalID:    id,
+					Type:           reviewerType,
+					ReviewDecision: enum.PullReqReviewDecisionPending,
+				}
+			}
+
+			mt.c.reviewerStore = reviewers
+			mt.c.userGroupService = userGroupMembers{members: map[int64][]int64{1: {10, 11, 12}}}
+			mt.c.outOfOfficeStore = noneOutOfOffice{}
+			mt.c.principalInfoCache = principalInfoMap{}
+			mt.c.tx = noTx{}
+
+			err := mt.c.ReviewerDelete(context.Background(), mt.session, "repo", pr.Number, test.removeID)
+			if err != nil {
+				t.Fatalf("failed to delete reviewer: %v", err)
+			}
+
+			if got := reviewers.ids(); !slices.Equal(test.want, got) {
+				t.Errorf("reviewers: want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListTemplates returns the pull request templates of the target branch.
+// If the branch isn't provided, the default branch of the repository is used.
+func (c *Controller) ListTemplates(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	targetBranch string,
+) ([]*types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	if targetBranch == "" {
+		targetBranch = repo.DefaultBranch
+	}
+
+	templates, err := c.templateService.List(ctx, repo, targetBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// FindTemplate returns the pull request template with the provided name from the target branch.
+// If the branch isn't provided, the default branch of the repository is used.
+func (c *Controller) FindTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	targetBranch string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	if targetBranch == "" {
+		targetBranch = repo.DefaultBranch
+	}
+
+	template, err := c.templateService.Find(ctx, repo, targetBranch, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request template: %w", err)
+	}
+
+	return template, nil
+}
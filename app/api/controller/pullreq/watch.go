@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WatchFind returns if the current user is watching the pull request.
+// Watching the whole repository isn't taken into account.
+func (c *Controller) WatchFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.NotificationWatchStatus, error) {
+	repo, pr, err := c.getWatchedPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	watch, err := c.watchStore.Find(ctx, session.Principal.ID, repo.ID, &pr.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.NotificationWatchStatus{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request watch: %w", err)
+	}
+
+	return &types.NotificationWatchStatus{Watching: true, Since: watch.Created}, nil
+}
+
+// Watch starts watching the pull request.
+func (c *Controller) Watch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.NotificationWatchStatus, error) {
+	repo, pr, err := c.getWatchedPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.watchStore.Create(ctx, &types.NotificationWatch{
+		PrincipalID: session.Principal.ID,
+		RepoID:      repo.ID,
+		PullReqID:   &pr.ID,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request watch: %w", err)
+	}
+
+	return c.WatchFind(ctx, session, repoRef, pullreqNum)
+}
+
+// Unwatch stops watching the pull request.
+func (c *Controller) Unwatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.NotificationWatchStatus, error) {
+	repo, pr, err := c.getWatchedPullReq(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.watchStore.Delete(ctx, session.Principal.ID, repo.ID, &pr.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to delete pull request watch: %w", err)
+	}
+
+	return &types.NotificationWatchStatus{}, nil
+}
+
+func (c *Controller) getWatchedPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.RepositoryCore, *types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	return repo, pr, nil
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -67,6 +69,13 @@ func ProvideController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	autoMergeStore store.PullReqAutoMergeStore,
+	mergeQueueStore store.MergeQueueEntryStore,
+	templateService *pullreqtemplate.Service,
+	watchStore store.NotificationWatchStore,
+	reactionStore store.PullReqReactionStore,
+	outOfOfficeStore store.OutOfOfficeStore,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -99,5 +108,12 @@ func ProvideController(
 		labelSvc,
 		instrumentation,
 		userGroupService,
+		signatureVerifier,
+		autoMergeStore,
+		mergeQueueStore,
+		templateService,
+		watchStore,
+		reactionStore,
+		outOfOfficeStore,
 	)
 }
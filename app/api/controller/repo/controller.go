@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/lfs"
@@ -36,7 +37,9 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -59,6 +62,10 @@ type RepositoryOutput struct {
 	IsPublic  bool `json:"is_public" yaml:"is_public"`
 	Importing bool `json:"importing" yaml:"-"`
 	Archived  bool `json:"archived" yaml:"-"`
+	// Mirror contains the mirror configuration and synchronization status (only for mirrored repositories).
+	Mirror *types.RepoMirror `json:"mirror,omitempty" yaml:"-"`
+	// Upstream is the repository the repository was forked from (only for forks the user can access the upstream of).
+	Upstream *types.RepositoryCore `json:"upstream,omitempty" yaml:"-"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -112,6 +119,21 @@ type Controller struct {
 	rulesSvc           *rules.Service
 	sseStreamer        sse.Streamer
 	lfsCtrl            *lfs.Controller
+	signatureVerifier  *publickey.SignatureVerifier
+	mirrorStore        store.RepoMirrorStore
+	secretStore        store.SecretStore
+	mirrorSyncer       *repomirror.Syncer
+	mirrorConfig       mirrorConfig
+	pushMirrorStore    store.RepoPushMirrorStore
+	mirrorPusher       *repomirror.Pusher
+	watchStore         store.NotificationWatchStore
+}
+
+type mirrorConfig struct {
+	minInterval         time.Duration
+	defaultInterval     time.Duration
+	allowLoopback       bool
+	allowPrivateNetwork bool
 }
 
 func NewController(
@@ -151,6 +173,13 @@ func NewController(
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
 	lfsCtrl *lfs.Controller,
+	signatureVerifier *publickey.SignatureVerifier,
+	mirrorStore store.RepoMirrorStore,
+	secretStore store.SecretStore,
+	mirrorSyncer *repomirror.Syncer,
+	pushMirrorStore store.RepoPushMirrorStore,
+	mirrorPusher *repomirror.Pusher,
+	watchStore store.NotificationWatchStore,
 ) *Controller {
 	return &Controller{
 		defaultBranch:      config.Git.DefaultBranch,
@@ -189,6 +218,19 @@ func NewController(
 		rulesSvc:           rulesSvc,
 		sseStreamer:        sseStreamer,
 		lfsCtrl:            lfsCtrl,
+		signatureVerifier:  signatureVerifier,
+		mirrorStore:        mirrorStore,
+		secretStore:        secretStore,
+		mirrorSyncer:       mirrorSyncer,
+		mirrorConfig: mirrorConfig{
+			minInterval:         config.RepoMirror.MinInterval,
+			defaultInterval:     config.RepoMirror.DefaultInterval,
+			allowLoopback:       config.RepoMirror.AllowLoopback,
+			allowPrivateNetwork: config.RepoMirror.AllowPrivateNetwork,
+		},
+		pushMirrorStore: pushMirrorStore,
+		mirrorPusher:    mirrorPusher,
+		watchStore:      watchStore,
 	}
 }
 
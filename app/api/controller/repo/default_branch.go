@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
@@ -48,6 +49,10 @@ func (c *Controller) UpdateDefaultBranch(
 		return nil, err
 	}
 
+	if repo.State == enum.RepoStateMirror {
+		return nil, usererror.BadRequest("The default branch of a mirror follows the upstream repository.")
+	}
+
 	// the max time we give an update default branch to succeed
 	const timeout = 2 * time.Minute
 
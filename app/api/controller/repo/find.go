@@ -20,7 +20,10 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Find finds a repo.
@@ -44,5 +47,38 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, repoRef st
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
-	return GetRepoOutput(ctx, c.publicAccess, repo)
+	out, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.State == enum.RepoStateMirror {
+		out.Mirror, err = c.mirrorStore.Find(ctx, repo.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository mirror: %w", err)
+		}
+
+		c.backfillMirrorSecretSpacePath(ctx, out.Mirror)
+	}
+
+	if repo.ForkID != 0 {
+		out.Upstream = c.findUpstream(ctx, session, repo.ForkID)
+	}
+
+	return out, nil
+}
+
+// findUpstream returns the upstream repository of a fork, or nil if it's not accessible.
+func (c *Controller) findUpstream(ctx context.Context, session *auth.Session, upstreamID int64) *types.RepositoryCore {
+	upstream, err := c.repoFinder.FindByID(ctx, upstreamID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("upstream_id", upstreamID).Msg("failed to find upstream repository")
+		return nil
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, upstream, enum.PermissionRepoView); err != nil {
+		return nil
+	}
+
+	return upstream
 }
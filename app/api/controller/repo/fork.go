@@ -0,0 +1,564 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// errPublicForkOfPrivateRepo is returned in case a public fork of a private repository is requested,
+// as it would expose the code of the private repository.
+var errPublicForkOfPrivateRepo = usererror.BadRequest("A fork of a private repository can't be public.")
+
+// ForkInput is used for forking a repository.
+type ForkInput struct {
+	// ParentRef is the space in which the fork is created.
+	ParentRef string `json:"parent_ref"`
+	// Identifier is the identifier of the fork. If not provided, the identifier of the upstream repo is used.
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, session *auth.Session, upstream *types.Repository) error {
+	if err := ValidateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if in.Identifier == "" {
+		in.Identifier = upstream.Identifier
+	}
+
+	if err := c.identifierCheck(in.Identifier, session); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = upstream.Description
+	}
+
+	return check.Description(in.Description)
+}
+
+// Fork creates a fork of the repository in the provided space.
+// The fork shares the git objects of the upstream repository.
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*RepositoryOutput, error) {
+	upstreamCore, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := c.repoStore.Find(ctx, upstreamCore.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if err = c.sanitizeForkInput(in, session, upstream); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, parentSpace.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for parent space %q: %w",
+			parentSpace.Path,
+			err,
+		)
+	}
+	if in.IsPublic && !isPublicAccessSupported {
+		return nil, errPublicRepoCreationDisabled
+	}
+
+	upstreamIsPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, upstream.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check public access of upstream repository: %w", err)
+	}
+
+	if err = checkForkPublicAccess(upstreamIsPublic, in.IsPublic); err != nil {
+		return nil, err
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: upstream.DefaultBranch,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		ForkID:        upstream.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gitResp, err := c.forkGitRepository(ctx, session, upstream)
+	if err != nil {
+		return nil, fmt.Errorf("error forking repository on git: %w", err)
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		_, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitResp.UID,
+			Description:   in.Description,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			LastGITPush:   now,
+			ForkID:        upstream.ID,
+			DefaultBranch: upstream.DefaultBranch,
+			IsEmpty:       upstream.IsEmpty,
+		}
+
+		if err = c.repoStore.Create(ctx, repo); err != nil {
+			return err
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+			r.NumForks++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update number of forks of upstream repository: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		// best effort cleanup
+		if dErr := c.DeleteGitRepository(ctx, session, gitResp.UID); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete forked repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic)
+	if err != nil {
+		if dErr := c.publicAccess.Delete(ctx, enum.PublicResourceTypeRepo, repo.Path); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and public access cleanup: %w): %w", dErr, err)
+		}
+
+		// only cleanup repo itself if cleanup of public access succeeded (to avoid leaking public access)
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to set repo public access (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to set repo public access (successful cleanup): %w", err)
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	repoOutput := GetRepoOutputWithAccess(ctx, in.IsPublic, repo)
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: repoOutput.Repository,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for fork repository operation: %s", err)
+	}
+
+	err = c.instrumentation.Track(ctx, instrument.Event{
+		Type:      instrument.EventTypeRepositoryCreate,
+		Principal: session.Principal.ToPrincipalInfo(),
+		Path:      repo.Path,
+		Properties: map[instrument.Property]any{
+			instrument.PropertyRepositoryID:           repo.ID,
+			instrument.PropertyRepositoryName:         repo.Identifier,
+			instrument.PropertyRepositoryCreationType: instrument.CreationTypeFork,
+		},
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for fork repository operation: %s", err)
+	}
+
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		Base:     eventBase(repo.Core(), &session.Principal),
+		IsPublic: in.IsPublic,
+	})
+
+	if !repo.IsEmpty {
+		err = c.indexer.Index(ctx, repo)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+		}
+	}
+
+	return repoOutput, nil
+}
+
+func (c *Controller) forkGitRepository(
+	ctx context.Context,
+	session *auth.Session,
+	upstream *types.Repository,
+) (*git.ForkRepositoryOutput, error) {
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(ctx),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	resp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:           *identityFromPrincipal(session.Principal),
+		EnvVars:         envVars,
+		UpstreamRepoUID: upstream.GitUID,
+		DefaultBranch:   upstream.DefaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork repo: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListForks lists the forks of the repository. Forks the user has no access to are omitted.
+func (c *Controller) ListForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoFilter,
+) ([]*RepositoryOutput, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var forks []*types.Repository
+	var count int64
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		count, err = c.repoStore.CountForks(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count forks: %w", err)
+		}
+
+		forks, err = c.repoStore.ListForks(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	forksOut := make([]*RepositoryOutput, 0, len(forks))
+	for _, fork := range forks {
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, fork.Core(), enum.PermissionRepoView)
+		if apiauth.IsNoAccess(err) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to check access to fork %q: %w", fork.Path, err)
+		}
+
+		// backfill URLs
+		fork.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, fork.Path)
+		fork.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, fork.Path)
+
+		forkOut, err := GetRepoOutput(ctx, c.publicAccess, fork)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get fork %q output: %w", fork.Path, err)
+		}
+
+		forksOut = append(forksOut, forkOut)
+	}
+
+	return forksOut, count, nil
+}
+
+// ForkSyncInput is used for synchronizing a branch of a fork with the upstream repository.
+type ForkSyncInput struct {
+	// Branch is the branch of the fork that's fast-forwarded to the branch with the same name in the upstream repo.
+	// If not provided, the default branch of the fork is used.
+	Branch string `json:"branch"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+type ForkSyncOutput struct {
+	types.DryRunRulesOutput
+	Branch string  `json:"branch"`
+	OldSHA sha.SHA `json:"old_sha"`
+	NewSHA sha.SHA `json:"new_sha"`
+}
+
+// ForkSync fast-forwards a branch of a fork to the latest commit of the same branch in the upstream repository.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) ForkSync(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkSyncInput,
+) (*ForkSyncOutput, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if repo.ForkID == 0 {
+		return nil, nil, usererror.BadRequest("Repository is not a fork.")
+	}
+
+	upstream, err := c.repoFinder.FindByID(ctx, repo.ForkID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, upstream, enum.PermissionRepoView); err != nil {
+		return nil, nil, fmt.Errorf("access check on upstream repository failed: %w", err)
+	}
+
+	if in.Branch == "" {
+		in.Branch = repo.DefaultBranch
+	}
+
+	upstreamBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(upstream),
+		BranchName: in.Branch,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil, usererror.BadRequestf("Branch %q doesn't exist in the upstream repository.", in.Branch)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upstream branch: %w", err)
+	}
+
+	newSHA := upstreamBranch.Branch.SHA
+	oldSHA := sha.Nil
+	refAction := protection.RefActionCreate
+
+	forkBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: in.Branch,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to get fork branch: %w", err)
+	}
+	if err == nil {
+		oldSHA = forkBranch.Branch.SHA
+		refAction = protection.RefActionUpdate
+	}
+
+	out := &ForkSyncOutput{
+		Branch: in.Branch,
+		OldSHA: oldSHA,
+		NewSHA: newSHA,
+	}
+
+	if oldSHA.Equal(newSHA) {
+		return out, nil, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// the upstream objects are normally available through alternates, but the fork might have been dissociated.
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: upstream.GitUID,
+		ObjectSHAs:    []sha.SHA{newSHA},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch upstream commits: %w", err)
+	}
+
+	if refAction == protection.RefActionUpdate {
+		ancestorOut, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   oldSHA,
+			DescendantCommitSHA: newSHA,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check if the fork branch is behind upstream: %w", err)
+		}
+
+		if !ancestorOut.Ancestor {
+			return nil, nil, usererror.Conflict(fmt.Sprintf(
+				"Branch %q of the fork has diverged from the upstream repository and can't be fast-forwarded.",
+				in.Branch))
+		}
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{in.Branch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		out.DryRunRulesOutput = types.DryRunRulesOutput{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}
+		return out, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        in.Branch,
+		Type:        gitenum.RefTypeBranch,
+		NewValue:    newSHA,
+		OldValue:    oldSHA,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update fork branch: %w", err)
+	}
+
+	return out, nil, nil
+}
+
+// checkForkPublicAccess verifies that a fork doesn't expose the code of a private upstream repository.
+func checkForkPublicAccess(upstreamIsPublic bool, forkIsPublic bool) error {
+	if forkIsPublic && !upstreamIsPublic {
+		return errPublicForkOfPrivateRepo
+	}
+
+	return nil
+}
+
+// DissociateForks copies the objects borrowed from the repository into all of its forks and removes
+// the fork lineage. It must be called before the git repository is deleted, otherwise the forks get corrupted.
+func (c *Controller) DissociateForks(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+) error {
+	maxDeleted := int64(math.MaxInt64)
+	filters := []*types.RepoFilter{
+		{Page: 1, Size: math.MaxInt, Sort: enum.RepoAttrCreated, Order: enum.OrderAsc},
+		{Page: 1, Size: math.MaxInt, Sort: enum.RepoAttrCreated, Order: enum.OrderAsc, DeletedBeforeOrAt: &maxDeleted},
+	}
+
+	var forks []*types.Repository
+	for _, filter := range filters {
+		list, err := c.repoStore.ListForks(ctx, repoID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list forks: %w", err)
+		}
+
+		forks = append(forks, list...)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork.Core())
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+
+		err = c.git.DissociateRepository(ctx, &git.DissociateRepositoryParams{WriteParams: writeParams})
+		if err != nil {
+			return fmt.Errorf("failed to dissociate fork %d: %w", fork.ID, err)
+		}
+	}
+
+	if err := c.repoStore.DetachForks(ctx, repoID); err != nil {
+		return fmt.Errorf("failed to detach forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		fork.ForkID = 0
+		c.repoFinder.MarkChanged(ctx, fork.Core())
+	}
+
+	return nil
+}
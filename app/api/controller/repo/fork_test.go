@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckForkPublicAccess(t *testing.T) {
+	tests := []struct {
+		name             string
+		upstreamIsPublic bool
+		forkIsPublic     bool
+		wantErr          error
+	}{
+		{name: "private-fork-of-private-repo"},
+		{name: "private-fork-of-public-repo", upstreamIsPublic: true},
+		{name: "public-fork-of-public-repo", upstreamIsPublic: true, forkIsPublic: true},
+		{name: "public-fork-of-private-repo", forkIsPublic: true, wantErr: errPublicForkOfPrivateRepo},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkForkPublicAccess(test.upstreamIsPublic, test.forkIsPublic)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("want error %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
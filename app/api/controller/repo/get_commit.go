@@ -50,5 +50,8 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
-	return commit, nil
+	commits := []types.Commit{*commit}
+	c.signatureVerifier.FillCommitSignatureStatuses(ctx, git.CreateReadParams(repo), commits)
+
+	return &commits[0], nil
 }
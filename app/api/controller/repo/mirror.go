@@ -0,0 +1,370 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/repomirror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MirrorSetInput struct {
+	// URL is the clone URL of the upstream repository. Credentials mustn't be part of the URL.
+	URL      string `json:"url"`
+	Username string `json:"username"`
+
+	// PasswordSecretSpaceRef is the space of the secret holding the password. Defaults to the parent space.
+	PasswordSecretSpaceRef   string `json:"password_secret_space_ref"`
+	PasswordSecretIdentifier string `json:"password_secret_identifier"`
+
+	// Interval is the time between two synchronizations in seconds. Zero means the default interval.
+	Interval int64 `json:"interval"`
+}
+
+func (in *MirrorSetInput) sanitize(cfg mirrorConfig) error {
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+	in.PasswordSecretSpaceRef = strings.TrimSpace(in.PasswordSecretSpaceRef)
+	in.PasswordSecretIdentifier = strings.TrimSpace(in.PasswordSecretIdentifier)
+
+	if err := validateMirrorURL(in.URL, cfg); err != nil {
+		return err
+	}
+
+	if in.PasswordSecretIdentifier == "" && in.PasswordSecretSpaceRef != "" {
+		return usererror.BadRequest("Password secret identifier must be provided with the password secret space.")
+	}
+
+	if in.Interval == 0 {
+		in.Interval = int64(cfg.defaultInterval / time.Second)
+	}
+
+	if in.Interval < int64(cfg.minInterval/time.Second) {
+		return usererror.BadRequestf("Mirror interval must be at least %s.", cfg.minInterval)
+	}
+
+	return nil
+}
+
+func validateMirrorURL(rawURL string, cfg mirrorConfig) error {
+	mirrorURL, err := url.Parse(rawURL)
+	if err != nil || mirrorURL.Host == "" {
+		return usererror.BadRequest("Mirror URL is invalid.")
+	}
+
+	// basic validation of the host to give the user an early error,
+	// the resolved addresses are verified again whenever the mirror is synchronized.
+	host := mirrorURL.Hostname()
+	if host == "localhost" && !cfg.allowLoopback {
+		return usererror.BadRequest("Mirror URL mustn't point to localhost.")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if err = repomirror.CheckAddress(ip, cfg.allowLoopback, cfg.allowPrivateNetwork); err != nil {
+			return usererror.BadRequestf("Mirror URL is invalid: %s.", err)
+		}
+	}
+
+	if mirrorURL.Scheme != "http" && mirrorURL.Scheme != "https" {
+		return usererror.BadRequest("Mirror URL must use http or https.")
+	}
+
+	if mirrorURL.User != nil {
+		return usererror.BadRequest("Mirror URL mustn't contain credentials. Use a secret for the password instead.")
+	}
+
+	return nil
+}
+
+// MirrorFind returns the mirror configuration and the synchronization status of the repository.
+func (c *Controller) MirrorFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepoMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.mirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Repository is not a mirror.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	c.backfillMirrorSecretSpacePath(ctx, mirror)
+
+	return mirror, nil
+}
+
+// MirrorSet turns the repository into a read-only mirror of the upstream repository,
+// or updates the mirror configuration if the repository is already a mirror.
+// WARNING: All branches and tags of the repository are overwritten by the ones of the upstream repository.
+func (c *Controller) MirrorSet(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorSetInput,
+) (*types.RepoMirror, error) {
+	if err := in.sanitize(c.mirrorConfig); err != nil {
+		return nil, err
+	}
+
+	repoCore, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoCore.State != enum.RepoStateActive && repoCore.State != enum.RepoStateMirror {
+		return nil, usererror.BadRequestf("Repository in state %s can't be mirrored.", repoCore.State)
+	}
+
+	// mirroring overwrites all existing branches and tags of the repository, which is as destructive as deleting it.
+	if repoCore.State == enum.RepoStateActive {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repoCore, enum.PermissionRepoDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	var secretSpaceID int64
+	if in.PasswordSecretIdentifier != "" {
+		secretSpaceID, err = c.getMirrorSecretSpaceID(ctx, session, repoCore,
+			in.PasswordSecretSpaceRef, in.PasswordSecretIdentifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	var mirror *types.RepoMirror
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		mirror, err = c.mirrorStore.Find(ctx, repoCore.ID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find repository mirror: %w", err)
+		}
+
+		isNew := mirror == nil
+		if isNew {
+			mirror = &types.RepoMirror{
+				RepoID:    repoCore.ID,
+				CreatedBy: session.Principal.ID,
+				Created:   now,
+				SyncState: enum.RepoMirrorSyncStatePending,
+			}
+		}
+
+		mirror.Updated = now
+		mirror.URL = in.URL
+		mirror.Username = in.Username
+		mirror.PasswordSecretSpaceID = secretSpaceID
+		mirror.PasswordSecretIdentifier = in.PasswordSecretIdentifier
+		mirror.Interval = in.Interval
+		mirror.NextSync = now
+
+		if isNew {
+			err = c.mirrorStore.Create(ctx, mirror)
+		} else {
+			err = c.mirrorStore.Update(ctx, mirror)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to store repository mirror: %w", err)
+		}
+
+		if repoCore.State == enum.RepoStateMirror {
+			return nil
+		}
+
+		repo, err := c.repoStore.Find(ctx, repoCore.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+			if r.State != enum.RepoStateActive && r.State != enum.RepoStateMirror {
+				return usererror.BadRequestf("Repository in state %s can't be mirrored.", r.State)
+			}
+			r.State = enum.RepoStateMirror
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repository state: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.repoFinder.MarkChanged(ctx, repoCore)
+
+	if err = c.mirrorSyncer.Run(ctx, repoCore.ID); err != nil && !errors.Is(err, repomirror.ErrSyncInProgress) {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to start repository mirror synchronization")
+	}
+
+	c.backfillMirrorSecretSpacePath(ctx, mirror)
+
+	return mirror, nil
+}
+
+// MirrorDelete stops the mirroring of the repository and makes the repository writable again.
+func (c *Controller) MirrorDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repoCore, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.mirrorStore.Delete(ctx, repoCore.ID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return usererror.NotFound("Repository is not a mirror.")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete repository mirror: %w", err)
+		}
+
+		repo, err := c.repoStore.Find(ctx, repoCore.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+			r.State = enum.RepoStateActive
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repository state: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.repoFinder.MarkChanged(ctx, repoCore)
+
+	return nil
+}
+
+// MirrorSync starts the synchronization of the repository mirror immediately.
+func (c *Controller) MirrorSync(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepoMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.mirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Repository is not a mirror.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	err = c.mirrorSyncer.Run(ctx, repo.ID)
+	if errors.Is(err, repomirror.ErrSyncInProgress) {
+		return nil, usererror.Conflict("Repository mirror synchronization is already in progress.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start repository mirror synchronization: %w", err)
+	}
+
+	c.backfillMirrorSecretSpacePath(ctx, mirror)
+
+	return mirror, nil
+}
+
+// getMirrorSecretSpaceID returns the ID of the space of the password secret
+// after verifying that the secret exists and the user has access to it.
+func (c *Controller) getMirrorSecretSpaceID(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	spaceRef string,
+	identifier string,
+) (int64, error) {
+	if spaceRef == "" {
+		spaceRef = paths.Parent(repo.Path)
+	}
+
+	space, err := c.spaceFinder.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find space of the password secret: %w", err)
+	}
+
+	err = apiauth.CheckSecret(ctx, c.authorizer, session, space.Path, identifier, enum.PermissionSecretAccess)
+	if err != nil {
+		return 0, fmt.Errorf("access check failed: %w", err)
+	}
+
+	_, err = c.secretStore.FindByIdentifier(ctx, space.ID, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return 0, usererror.BadRequestf("Secret %q not found.", identifier)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find password secret: %w", err)
+	}
+
+	return space.ID, nil
+}
+
+func (c *Controller) backfillMirrorSecretSpacePath(ctx context.Context, mirror *types.RepoMirror) {
+	mirror.PasswordSecretSpacePath = c.findMirrorSecretSpacePath(ctx, mirror.PasswordSecretSpaceID)
+}
+
+func (c *Controller) findMirrorSecretSpacePath(ctx context.Context, spaceID int64) string {
+	if spaceID == 0 {
+		return ""
+	}
+
+	space, err := c.spaceFinder.FindByID(ctx, spaceID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find space of the mirror password secret")
+		return ""
+	}
+
+	return space.Path
+}
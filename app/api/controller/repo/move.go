@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -129,6 +130,14 @@ func (c *Controller) Move(ctx context.Context,
 		return nil, fmt.Errorf("failed to set repo public access for new path (cleanup successful): %w", err)
 	}
 
+	c.eventReporter.Renamed(ctx, &repoevents.RenamedPayload{
+		Base:          eventBase(renamedRepo.Core(), &session.Principal),
+		OldIdentifier: repo.Identifier,
+		NewIdentifier: renamedRepo.Identifier,
+		OldPath:       repo.Path,
+		NewPath:       renamedRepo.Path,
+	})
+
 	renamedRepo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, renamedRepo.Path)
 	renamedRepo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, renamedRepo.Path)
 
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -77,10 +78,18 @@ func (c *Controller) PurgeNoAuth(
 		}
 	}
 
+	if err := c.DissociateForks(ctx, session, repo.ID); err != nil {
+		return fmt.Errorf("failed to dissociate forks of the repo: %w", err)
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+
 	if err := c.DeleteGitRepository(ctx, session, repo.GitUID); err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
@@ -131,3 +140,25 @@ func (c *Controller) DeleteGitRepository(
 
 	return nil
 }
+
+// decrementNumForks decreases the number of forks of the upstream repository of a purged fork.
+func (c *Controller) decrementNumForks(ctx context.Context, upstreamID int64) {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find upstream repository of the purged fork")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of upstream repository")
+	}
+}
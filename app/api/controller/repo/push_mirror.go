@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type PushMirrorCreateInput struct {
+	// URL is the URL of the downstream repository. Credentials mustn't be part of the URL.
+	URL      string `json:"url"`
+	Username string `json:"username"`
+
+	// PasswordSecretSpaceRef is the space of the secret holding the password. Defaults to the parent space.
+	PasswordSecretSpaceRef   string `json:"password_secret_space_ref"`
+	PasswordSecretIdentifier string `json:"password_secret_identifier"`
+
+	// ProtectedOnly limits the mirroring to the branches and tags protected by a rule.
+	ProtectedOnly bool `json:"protected_only"`
+}
+
+func (in *PushMirrorCreateInput) sanitize(cfg mirrorConfig) error {
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+	in.PasswordSecretSpaceRef = strings.TrimSpace(in.PasswordSecretSpaceRef)
+	in.PasswordSecretIdentifier = strings.TrimSpace(in.PasswordSecretIdentifier)
+
+	if err := validateMirrorURL(in.URL, cfg); err != nil {
+		return err
+	}
+
+	if in.PasswordSecretIdentifier == "" && in.PasswordSecretSpaceRef != "" {
+		return usererror.BadRequest("Password secret identifier must be provided with the password secret space.")
+	}
+
+	return nil
+}
+
+// PushMirrorUpdateInput is used to update a push mirror. Fields that aren't provided remain unchanged.
+// If the password secret identifier is provided, the space of the secret defaults to the parent space.
+type PushMirrorUpdateInput struct {
+	URL                      *string `json:"url"`
+	Username                 *string `json:"username"`
+	PasswordSecretSpaceRef   *string `json:"password_secret_space_ref"`
+	PasswordSecretIdentifier *string `json:"password_secret_identifier"`
+	ProtectedOnly            *bool   `json:"protected_only"`
+}
+
+func (in *PushMirrorUpdateInput) sanitize(cfg mirrorConfig) error {
+	if in.URL != nil {
+		*in.URL = strings.TrimSpace(*in.URL)
+		if err := validateMirrorURL(*in.URL, cfg); err != nil {
+			return err
+		}
+	}
+
+	if in.Username != nil {
+		*in.Username = strings.TrimSpace(*in.Username)
+	}
+
+	if in.PasswordSecretSpaceRef != nil {
+		*in.PasswordSecretSpaceRef = strings.TrimSpace(*in.PasswordSecretSpaceRef)
+	}
+
+	if in.PasswordSecretIdentifier != nil {
+		*in.PasswordSecretIdentifier = strings.TrimSpace(*in.PasswordSecretIdentifier)
+	}
+
+	if in.PasswordSecretSpaceRef != nil && *in.PasswordSecretSpaceRef != "" &&
+		(in.PasswordSecretIdentifier == nil || *in.PasswordSecretIdentifier == "") {
+		return usererror.BadRequest("Password secret identifier must be provided with the password secret space.")
+	}
+
+	return nil
+}
+
+// PushMirrorList returns all push mirrors of the repository together with the status of their last push.
+func (c *Controller) PushMirrorList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.RepoPushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors, err := c.pushMirrorStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository push mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		mirror.PasswordSecretSpacePath = c.findMirrorSecretSpacePath(ctx, mirror.PasswordSecretSpaceID)
+	}
+
+	return mirrors, nil
+}
+
+// PushMirrorFind returns the push mirror of the repository together with the status of its last push.
+func (c *Controller) PushMirrorFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+) (*types.RepoPushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.getPushMirror(ctx, repo, pushMirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror.PasswordSecretSpacePath = c.findMirrorSecretSpacePath(ctx, mirror.PasswordSecretSpaceID)
+
+	return mirror, nil
+}
+
+// PushMirrorCreate adds a new push mirror to the repository and pushes the repository to it.
+// WARNING: The branches and tags of the downstream repository are overwritten by the ones of the repository.
+func (c *Controller) PushMirrorCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PushMirrorCreateInput,
+) (*types.RepoPushMirror, error) {
+	if err := in.sanitize(c.mirrorConfig); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	var secretSpaceID int64
+	if in.PasswordSecretIdentifier != "" {
+		secretSpaceID, err = c.getMirrorSecretSpaceID(ctx, session, repo,
+			in.PasswordSecretSpaceRef, in.PasswordSecretIdentifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	mirror := &types.RepoPushMirror{
+		RepoID:                   repo.ID,
+		CreatedBy:                session.Principal.ID,
+		Created:                  now,
+		Updated:                  now,
+		URL:                      in.URL,
+		Username:                 in.Username,
+		PasswordSecretSpaceID:    secretSpaceID,
+		PasswordSecretIdentifier: in.PasswordSecretIdentifier,
+		ProtectedOnly:            in.ProtectedOnly,
+		SyncState:                enum.RepoMirrorSyncStatePending,
+	}
+
+	if err = c.pushMirrorStore.Create(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to create repository push mirror: %w", err)
+	}
+
+	if err = c.mirrorPusher.Run(ctx, mirror.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to start push to repository push mirror")
+	}
+
+	mirror.PasswordSecretSpacePath = c.findMirrorSecretSpacePath(ctx, mirror.PasswordSecretSpaceID)
+
+	return mirror, nil
+}
+
+// PushMirrorUpdate updates the configuration of the push mirror.
+func (c *Controller) PushMirrorUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+	in *PushMirrorUpdateInput,
+) (*types.RepoPushMirror, error) {
+	if err := in.sanitize(c.mirrorConfig); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.getPushMirror(ctx, repo, pushMirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.URL != nil {
+		mirror.URL = *in.URL
+	}
+
+	if in.Username != nil {
+		mirror.Username = *in.Username
+	}
+
+	if in.PasswordSecretIdentifier != nil {
+		mirror.PasswordSecretSpaceID = 0
+		mirror.PasswordSecretIdentifier = *in.PasswordSecretIdentifier
+
+		if mirror.PasswordSecretIdentifier != "" {
+			var spaceRef string
+			if in.PasswordSecretSpaceRef != nil {
+				spaceRef = *in.PasswordSecretSpaceRef
+			}
+
+			mirror.PasswordSecretSpaceID, err = c.getMirrorSecretSpaceID(ctx, session, repo,
+				spaceRef, mirror.PasswordSecretIdentifier)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if in.ProtectedOnly != nil {
+		mirror.ProtectedOnly = *in.ProtectedOnly
+	}
+
+	mirror.Updated = time.Now().UnixMilli()
+
+	if err = c.pushMirrorStore.Update(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to update repository push mirror: %w", err)
+	}
+
+	mirror.PasswordSecretSpacePath = c.findMirrorSecretSpacePath(ctx, mirror.PasswordSecretSpaceID)
+
+	return mirror, nil
+}
+
+// PushMirrorDelete removes the push mirror from the repository.
+func (c *Controller) PushMirrorDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.getPushMirror(ctx, repo, pushMirrorID); err != nil {
+		return err
+	}
+
+	err = c.pushMirrorStore.Delete(ctx, pushMirrorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete repository push mirror: %w", err)
+	}
+
+	return nil
+}
+
+// PushMirrorPush starts a push of the repository to the push mirror immediately.
+func (c *Controller) PushMirrorPush(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+) (*types.RepoPushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := c.getPushMirror(ctx, repo, pushMirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.mirrorPusher.Run(ctx, mirror.ID); err != nil {
+		return nil, fmt.Errorf("failed to start push to repository push mirror: %w", err)
+	}
+
+	mirror.PasswordSecretSpacePath = c.findMirrorSecretSpacePath(ctx, mirror.PasswordSecretSpaceID)
+
+	return mirror, nil
+}
+
+func (c *Controller) getPushMirror(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pushMirrorID int64,
+) (*types.RepoPushMirror, error) {
+	mirror, err := c.pushMirrorStore.Find(ctx, pushMirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) || (err == nil && mirror.RepoID != repo.ID) {
+		return nil, usererror.NotFound("Push mirror not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository push mirror: %w", err)
+	}
+
+	return mirror, nil
+}
@@ -73,6 +73,22 @@ func (c *Controller) UpdatePublicAccess(ctx context.Context,
 		return GetRepoOutputWithAccess(ctx, isPublic, repo), nil
 	}
 
+	if in.IsPublic && repo.ForkID != 0 {
+		upstream, err := c.repoStore.Find(ctx, repo.ForkID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find upstream repository: %w", err)
+		}
+
+		upstreamIsPublic, err := c.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, upstream.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check public access of upstream repository: %w", err)
+		}
+
+		if err = checkForkPublicAccess(upstreamIsPublic, in.IsPublic); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = c.publicAccess.Set(ctx, enum.PublicResourceTypeRepo, repo.Path, in.IsPublic); err != nil {
 		return nil, fmt.Errorf("failed to update repo public access: %w", err)
 	}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WatchFind returns if the current user is watching the repository.
+func (c *Controller) WatchFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.NotificationWatchStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	watch, err := c.watchStore.Find(ctx, session.Principal.ID, repo.ID, nil)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return &types.NotificationWatchStatus{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository watch: %w", err)
+	}
+
+	return &types.NotificationWatchStatus{Watching: true, Since: watch.Created}, nil
+}
+
+// Watch starts watching the repository. The current user gets notified about all its pull requests.
+func (c *Controller) Watch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.NotificationWatchStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.watchStore.Create(ctx, &types.NotificationWatch{
+		PrincipalID: session.Principal.ID,
+		RepoID:      repo.ID,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository watch: %w", err)
+	}
+
+	return c.WatchFind(ctx, session, repoRef)
+}
+
+// Unwatch stops watching the repository.
+func (c *Controller) Unwatch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.NotificationWatchStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.watchStore.Delete(ctx, session.Principal.ID, repo.ID, nil)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to delete repository watch: %w", err)
+	}
+
+	return &types.NotificationWatchStatus{}, nil
+}
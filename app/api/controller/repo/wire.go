@@ -27,7 +27,9 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -86,6 +88,13 @@ func ProvideController(
 	rulesSvc *rules.Service,
 	sseStreamer sse.Streamer,
 	lfsCtrl *lfs.Controller,
+	signatureVerifier *publickey.SignatureVerifier,
+	mirrorStore store.RepoMirrorStore,
+	secretStore store.SecretStore,
+	mirrorSyncer *repomirror.Syncer,
+	pushMirrorStore store.RepoPushMirrorStore,
+	mirrorPusher *repomirror.Pusher,
+	watchStore store.NotificationWatchStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
@@ -94,7 +103,8 @@ func ProvideController(
 		principalInfoCache, protectionManager, rpcClient, spaceFinder, repoFinder, importer,
 		codeOwners, repoReporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
-		rulesSvc, sseStreamer, lfsCtrl,
+		rulesSvc, sseStreamer, lfsCtrl, signatureVerifier, mirrorStore, secretStore, mirrorSyncer,
+		pushMirrorStore, mirrorPusher, watchStore,
 	)
 }
 
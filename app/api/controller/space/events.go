@@ -33,7 +33,7 @@ func (c *Controller) Events(
 		return nil, nil, nil, fmt.Errorf("failed to acquire access to space: %w", err)
 	}
 
-	chEvents, chErr, sseCancel := c.sseStreamer.Stream(ctx, space.ID)
+	chEvents, chErr, sseCancel := c.sseStreamer.Stream(ctx, space.ID, session.Principal.ID)
 
 	return chEvents, chErr, sseCancel, nil
 }
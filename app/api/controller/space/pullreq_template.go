@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pullreqtemplate"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type PullReqTemplateUpdateInput struct {
+	// Content is the default pull request template of the space. Empty content removes the template.
+	Content string `json:"content"`
+}
+
+// PullReqTemplateFind returns the default pull request template of the space.
+// The template is inherited by the repositories in the space that don't have their own templates.
+func (c *Controller) PullReqTemplateFind(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.PullReqTemplate, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	template, err := c.templateSvc.SpaceFind(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space pull request template: %w", err)
+	}
+
+	if template == nil {
+		template = &types.PullReqTemplate{Name: pullreqtemplate.DefaultName}
+	}
+
+	template.Space = space.Path
+
+	return template, nil
+}
+
+// PullReqTemplateUpdate sets the default pull request template of the space.
+func (c *Controller) PullReqTemplateUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *PullReqTemplateUpdateInput,
+) (*types.PullReqTemplate, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err = c.templateSvc.SpaceSet(ctx, space.ID, in.Content); err != nil {
+		return nil, fmt.Errorf("failed to update space pull request template: %w", err)
+	}
+
+	return &types.PullReqTemplate{
+		Name:    pullreqtemplate.DefaultName,
+		Space:   space.Path,
+		Content: in.Content,
+	}, nil
+}
@@ -77,6 +77,13 @@ func (c *Controller) PurgeNoAuth(
 	// permanently purge all repositories in the space and its subspaces after successful space purge tnx.
 	// cleanup will handle failed repository deletions.
 	for _, repo := range toBeDeletedRepos {
+		if err := c.repoCtrl.DissociateForks(ctx, session, repo.ID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Msg("failed to dissociate forks of repository, skipping its deletion")
+			continue
+		}
+
 		err := c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).
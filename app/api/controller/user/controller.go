@@ -19,6 +19,8 @@ import (
 
 	"github.com/harness/gitness/app/auth/authz"
 	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -29,14 +31,20 @@ import (
 )
 
 type Controller struct {
-	tx                dbtx.Transactor
-	principalUIDCheck check.PrincipalUID
-	authorizer        authz.Authorizer
-	principalStore    store.PrincipalStore
-	tokenStore        store.TokenStore
-	membershipStore   store.MembershipStore
-	publicKeyStore    store.PublicKeyStore
-	eventReporter     *userevents.Reporter
+	tx                    dbtx.Transactor
+	principalUIDCheck     check.PrincipalUID
+	authorizer            authz.Authorizer
+	principalStore        store.PrincipalStore
+	tokenStore            store.TokenStore
+	membershipStore       store.MembershipStore
+	publicKeyStore        store.PublicKeyStore
+	eventReporter         *userevents.Reporter
+	digest                *notification.Digest
+	inbox                 *notification.Inbox
+	notificationPrefStore store.NotificationPreferenceStore
+	spaceFinder           refcache.SpaceFinder
+	repoFinder            refcache.RepoFinder
+	outOfOfficeStore      store.OutOfOfficeStore
 }
 
 func NewController(
@@ -48,16 +56,28 @@ func NewController(
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
 	eventReporter *userevents.Reporter,
+	digest *notification.Digest,
+	inbox *notification.Inbox,
+	notificationPrefStore store.NotificationPreferenceStore,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	outOfOfficeStore store.OutOfOfficeStore,
 ) *Controller {
 	return &Controller{
-		tx:                tx,
-		principalUIDCheck: principalUIDCheck,
-		authorizer:        authorizer,
-		principalStore:    principalStore,
-		tokenStore:        tokenStore,
-		membershipStore:   membershipStore,
-		publicKeyStore:    publicKeyStore,
-		eventReporter:     eventReporter,
+		tx:                    tx,
+		principalUIDCheck:     principalUIDCheck,
+		authorizer:            authorizer,
+		principalStore:        principalStore,
+		tokenStore:            tokenStore,
+		membershipStore:       membershipStore,
+		publicKeyStore:        publicKeyStore,
+		eventReporter:         eventReporter,
+		digest:                digest,
+		inbox:                 inbox,
+		notificationPrefStore: notificationPrefStore,
+		spaceFinder:           spaceFinder,
+		repoFinder:            repoFinder,
+		outOfOfficeStore:      outOfOfficeStore,
 	}
 }
 
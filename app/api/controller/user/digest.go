@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxDigestIdleDays = 365
+
+// UpdateDigestInput holds the changes of the pull request digest configuration.
+type UpdateDigestInput struct {
+	Enabled  *bool   `json:"enabled"`
+	Hour     *int    `json:"hour"`
+	Timezone *string `json:"timezone"`
+	IdleDays *int    `json:"idle_days"`
+}
+
+func (in *UpdateDigestInput) Sanitize() error {
+	if in.Hour != nil && (*in.Hour < 0 || *in.Hour > 23) {
+		return usererror.BadRequest("Digest hour must be between 0 and 23.")
+	}
+
+	if in.Timezone != nil {
+		*in.Timezone = strings.TrimSpace(*in.Timezone)
+		if *in.Timezone == "" {
+			return usererror.BadRequest("Digest timezone can't be empty.")
+		}
+
+		if _, err := time.LoadLocation(*in.Timezone); err != nil {
+			return usererror.BadRequestf("Unknown digest timezone %q.", *in.Timezone)
+		}
+	}
+
+	if in.IdleDays != nil && (*in.IdleDays < 1 || *in.IdleDays > maxDigestIdleDays) {
+		return usererror.BadRequestf("Digest idle days must be between 1 and %d.", maxDigestIdleDays)
+	}
+
+	return nil
+}
+
+// FindDigest returns the pull request digest configuration of the user.
+func (c *Controller) FindDigest(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.NotificationDigest, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	digest, err := c.digest.Find(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find digest configuration: %w", err)
+	}
+
+	return digest, nil
+}
+
+// UpdateDigest updates the pull request digest configuration of the user.
+func (c *Controller) UpdateDigest(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *UpdateDigestInput,
+) (*types.NotificationDigest, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	digest, err := c.digest.Find(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find digest configuration: %w", err)
+	}
+
+	if in.Enabled != nil {
+		digest.Enabled = *in.Enabled
+	}
+	if in.Hour != nil {
+		digest.Hour = *in.Hour
+	}
+	if in.Timezone != nil {
+		digest.Timezone = *in.Timezone
+	}
+	if in.IdleDays != nil {
+		digest.IdleDays = *in.IdleDays
+	}
+
+	if err = c.digest.Save(ctx, digest); err != nil {
+		return nil, fmt.Errorf("failed to update digest configuration: %w", err)
+	}
+
+	return digest, nil
+}
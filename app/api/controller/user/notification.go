@@ -0,0 +1,290 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpsertNotificationPreferenceInput holds a notification preference of the user.
+type UpsertNotificationPreferenceInput struct {
+	Scope enum.NotificationScope `json:"scope"`
+	// ScopeRef is the path or the ID of the space or of the repository. It's ignored for the watch scope.
+	ScopeRef string                   `json:"scope_ref"`
+	Event    enum.NotificationEvent   `json:"event"`
+	Channel  enum.NotificationChannel `json:"channel"`
+	Enabled  bool                     `json:"enabled"`
+}
+
+func (in *UpsertNotificationPreferenceInput) Sanitize() error {
+	var ok bool
+
+	if in.Scope, ok = in.Scope.Sanitize(); !ok {
+		return usererror.BadRequestf("Invalid notification scope %q.", in.Scope)
+	}
+
+	if in.Event, ok = in.Event.Sanitize(); !ok {
+		return usererror.BadRequestf("Invalid notification event %q.", in.Event)
+	}
+
+	if in.Channel, ok = in.Channel.Sanitize(); !ok {
+		return usererror.BadRequestf("Invalid notification channel %q.", in.Channel)
+	}
+
+	in.ScopeRef = strings.TrimSpace(in.ScopeRef)
+	if in.Scope == enum.NotificationScopeWatch {
+		in.ScopeRef = ""
+	} else if in.ScopeRef == "" {
+		return usererror.BadRequest("Notification scope reference is required.")
+	}
+
+	return nil
+}
+
+// UpdateNotificationInput holds the changes of an in-app notification.
+type UpdateNotificationInput struct {
+	Read bool `json:"read"`
+}
+
+// ListNotificationPreferences returns the notification preferences of the user.
+func (c *Controller) ListNotificationPreferences(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.NotificationPreference, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserView)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := c.notificationPrefStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	for _, pref := range prefs {
+		c.setNotificationScopePath(ctx, pref)
+	}
+
+	return prefs, nil
+}
+
+// UpsertNotificationPreference creates or updates a notification preference of the user.
+func (c *Controller) UpsertNotificationPreference(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *UpsertNotificationPreferenceInput,
+) (*types.NotificationPreference, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	pref := &types.NotificationPreference{
+		PrincipalID: user.ID,
+		Created:     now,
+		Updated:     now,
+		Scope:       in.Scope,
+		Event:       in.Event,
+		Channel:     in.Channel,
+		Enabled:     in.Enabled,
+	}
+
+	switch in.Scope {
+	case enum.NotificationScopeSpace:
+		space, err := c.spaceFinder.FindByRef(ctx, in.ScopeRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return nil, err
+		}
+
+		pref.ScopeID = space.ID
+		pref.ScopePath = space.Path
+	case enum.NotificationScopeRepo:
+		repo, err := c.repoFinder.FindByRef(ctx, in.ScopeRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return nil, err
+		}
+
+		pref.ScopeID = repo.ID
+		pref.ScopePath = repo.Path
+	case enum.NotificationScopeWatch:
+	}
+
+	if err = c.notificationPrefStore.Upsert(ctx, pref); err != nil {
+		return nil, fmt.Errorf("failed to upsert notification preference: %w", err)
+	}
+
+	return pref, nil
+}
+
+// DeleteNotificationPreference deletes a notification preference of the user.
+func (c *Controller) DeleteNotificationPreference(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+) error {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.notificationPrefStore.Delete(ctx, user.ID, id); err != nil {
+		return fmt.Errorf("failed to delete notification preference: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotifications returns the in-app notifications of the user.
+func (c *Controller) ListNotifications(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, int64, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notifications, count, err := c.inbox.List(ctx, user.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, count, nil
+}
+
+// NotificationUnreadCount returns the number of unread in-app notifications of the user.
+func (c *Controller) NotificationUnreadCount(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.NotificationUnreadCount, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserView)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := c.inbox.UnreadCount(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &types.NotificationUnreadCount{UnreadCount: count}, nil
+}
+
+// UpdateNotification marks an in-app notification of the user as read or unread.
+func (c *Controller) UpdateNotification(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+	in *UpdateNotificationInput,
+) (*types.Notification, error) {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	notification, err := c.inbox.MarkRead(ctx, user.ID, id, in.Read)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+// MarkAllNotificationsRead marks all in-app notifications of the user as read.
+func (c *Controller) MarkAllNotificationsRead(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := c.findUserCheckAccess(ctx, session, userUID, enum.PermissionUserEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.inbox.MarkAllRead(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) findUserCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	permission enum.Permission,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, permission); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (c *Controller) setNotificationScopePath(ctx context.Context, pref *types.NotificationPreference) {
+	switch pref.Scope {
+	case enum.NotificationScopeSpace:
+		space, err := c.spaceFinder.FindByID(ctx, pref.ScopeID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to find space %d of notification preference", pref.ScopeID)
+			return
+		}
+		pref.ScopePath = space.Path
+	case enum.NotificationScopeRepo:
+		repo, err := c.repoFinder.FindByID(ctx, pref.ScopeID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to find repository %d of notification preference", pref.ScopeID)
+			return
+		}
+		pref.ScopePath = repo.Path
+	case enum.NotificationScopeWatch:
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxOutOfOfficeMessageLength = 1024
+
+// SetOutOfOfficeInput holds the absence of the user.
+type SetOutOfOfficeInput struct {
+	// From is the start of the absence, the current time is used if not provided.
+	From int64 `json:"from"`
+	// Until is the end of the absence, if not provided the absence lasts until it's cleared.
+	Until   int64  `json:"until"`
+	Message string `json:"message"`
+}
+
+func (in *SetOutOfOfficeInput) Sanitize() error {
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.From < 0 || in.Until < 0 {
+		return usererror.BadRequest("Out of office time can't be negative.")
+	}
+
+	if in.From == 0 {
+		in.From = time.Now().UnixMilli()
+	}
+
+	if in.Until != 0 && in.Until <= in.From {
+		return usererror.BadRequest("Out of office end time must be after the start time.")
+	}
+
+	if utf8.RuneCountInString(in.Message) > maxOutOfOfficeMessageLength {
+		return usererror.BadRequestf("Out of office message is too long (maximum is %d characters).",
+			maxOutOfOfficeMessageLength)
+	}
+
+	return nil
+}
+
+// FindOutOfOffice returns the absence of the user.
+func (c *Controller) FindOutOfOffice(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.OutOfOffice, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	ooo, err := c.outOfOfficeStore.Find(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find out of office: %w", err)
+	}
+
+	return ooo, nil
+}
+
+// SetOutOfOffice creates or replaces the absence of the user.
+func (c *Controller) SetOutOfOffice(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *SetOutOfOfficeInput,
+) (*types.OutOfOffice, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	ooo := &types.OutOfOffice{
+		PrincipalID: user.ID,
+		Created:     now,
+		Updated:     now,
+		From:        in.From,
+		Until:       in.Until,
+		Message:     in.Message,
+	}
+
+	if err = c.outOfOfficeStore.Upsert(ctx, ooo); err != nil {
+		return nil, fmt.Errorf("failed to save out of office: %w", err)
+	}
+
+	return ooo, nil
+}
+
+// DeleteOutOfOffice clears the absence of the user.
+func (c *Controller) DeleteOutOfOffice(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	err = c.outOfOfficeStore.Delete(ctx, user.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to delete out of office: %w", err)
+	}
+
+	return nil
+}
@@ -54,6 +54,10 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
+	if publickey.IsGPG(in.Content) {
+		return c.createGPGPublicKey(ctx, user.ID, in)
+	}
+
 	key, comment, err := publickey.ParseString(in.Content)
 	if err != nil {
 		return nil, errors.InvalidArgument("could not parse public key")
@@ -80,7 +84,9 @@ func (c *Controller) CreatePublicKey(
 		}
 
 		for _, existingKey := range existingKeys {
-			if key.Matches(existingKey.Content) {
+			// the same key can be registered by its owner once for each usage.
+			if key.Matches(existingKey.Content) &&
+				(existingKey.PrincipalID != k.PrincipalID || existingKey.Usage == k.Usage) {
 				return errors.InvalidArgument("Key is already in use")
 			}
 		}
@@ -99,6 +105,57 @@ func (c *Controller) CreatePublicKey(
 	return k, nil
 }
 
+// createGPGPublicKey stores a GPG public key. GPG keys can only be used for verification of commit signatures.
+func (c *Controller) createGPGPublicKey(
+	ctx context.Context,
+	principalID int64,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	if in.Usage != enum.PublicKeyUsageSign {
+		return nil, errors.InvalidArgument("GPG keys can only be used for signing")
+	}
+
+	key, err := publickey.ParseGPG(in.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &types.PublicKey{
+		PrincipalID: principalID,
+		Created:     time.Now().UnixMilli(),
+		Verified:    nil, // the key is created as unverified
+		Identifier:  in.Identifier,
+		Usage:       in.Usage,
+		Fingerprint: key.Fingerprint(),
+		Content:     in.Content,
+		Comment:     key.Comment(),
+		Type:        publickey.TypeGPG,
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		existingKeys, err := c.publicKeyStore.ListByFingerprint(ctx, k.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("failed to read keys by fingerprint: %w", err)
+		}
+
+		if len(existingKeys) > 0 {
+			return errors.InvalidArgument("Key is already in use")
+		}
+
+		err = c.publicKeyStore.Create(ctx, k)
+		if err != nil {
+			return fmt.Errorf("failed to insert public key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
 func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
 	if err := check.Identifier(in.Identifier); err != nil {
 		return err
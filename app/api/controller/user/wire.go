@@ -17,6 +17,8 @@ package user
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	userevents "github.com/harness/gitness/app/events/user"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
@@ -38,6 +40,12 @@ func ProvideController(
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
 	eventReporter *userevents.Reporter,
+	digest *notification.Digest,
+	inbox *notification.Inbox,
+	notificationPrefStore store.NotificationPreferenceStore,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	outOfOfficeStore store.OutOfOfficeStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -47,5 +55,12 @@ func ProvideController(
 		tokenStore,
 		membershipStore,
 		publicKeyStore,
-		eventReporter)
+		eventReporter,
+		digest,
+		inbox,
+		notificationPrefStore,
+		spaceFinder,
+		repoFinder,
+		outOfOfficeStore,
+	)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
@@ -37,6 +38,7 @@ type Controller struct {
 	webhookService *webhook.Service
 	encrypter      encrypt.Encrypter
 	preprocessor   Preprocessor
+	secretStore    store.SecretStore
 }
 
 func NewController(
@@ -46,6 +48,7 @@ func NewController(
 	webhookService *webhook.Service,
 	encrypter encrypt.Encrypter,
 	preprocessor Preprocessor,
+	secretStore store.SecretStore,
 ) *Controller {
 	return &Controller{
 		authorizer:     authorizer,
@@ -54,6 +57,7 @@ func NewController(
 		webhookService: webhookService,
 		encrypter:      encrypter,
 		preprocessor:   preprocessor,
+		secretStore:    secretStore,
 	}
 }
 
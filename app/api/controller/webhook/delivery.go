@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// resolveDeliverySecret verifies that the auth secret of the delivery options exists
+// and that the user has access to it, and sets the space of the secret.
+// The space of the secret defaults to the provided space.
+func (c *Controller) resolveDeliverySecret(
+	ctx context.Context,
+	session *auth.Session,
+	defaultSpacePath string,
+	delivery *types.WebhookDelivery,
+) error {
+	if delivery == nil {
+		return nil
+	}
+
+	spaceRef := strings.TrimSpace(delivery.AuthSecretSpaceRef)
+	delivery.AuthSecretSpaceRef = ""
+	delivery.AuthSecretSpaceID = 0
+	delivery.AuthSecretIdentifier = strings.TrimSpace(delivery.AuthSecretIdentifier)
+
+	if delivery.AuthSecretIdentifier == "" {
+		if spaceRef != "" {
+			return errors.InvalidArgument("Auth secret identifier must be provided with the auth secret space.")
+		}
+		return nil
+	}
+
+	if spaceRef == "" {
+		spaceRef = defaultSpacePath
+	}
+
+	space, err := c.spaceFinder.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return fmt.Errorf("failed to find space of the auth secret: %w", err)
+	}
+
+	err = apiauth.CheckSecret(ctx, c.authorizer, session, space.Path,
+		delivery.AuthSecretIdentifier, enum.PermissionSecretAccess)
+	if err != nil {
+		return fmt.Errorf("access check failed: %w", err)
+	}
+
+	_, err = c.secretStore.FindByIdentifier(ctx, space.ID, delivery.AuthSecretIdentifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return errors.InvalidArgument("Secret %q not found.", delivery.AuthSecretIdentifier)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find auth secret: %w", err)
+	}
+
+	delivery.AuthSecretSpaceID = space.ID
+
+	return nil
+}
+
+// resolveUpdateDeliverySecret resolves the auth secret of the delivery options of the update input.
+// If the URL of the webhook changes without new delivery options, the access to the existing auth secret
+// is verified again, as the secret would otherwise be sent to a URL chosen by a user without access to it.
+func (c *Controller) resolveUpdateDeliverySecret(
+	ctx context.Context,
+	session *auth.Session,
+	defaultSpacePath string,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	in *types.WebhookUpdateInput,
+) error {
+	if in.Delivery != nil || in.URL == nil {
+		return c.resolveDeliverySecret(ctx, session, defaultSpacePath, in.Delivery)
+	}
+
+	hook, err := c.webhookService.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return fmt.Errorf("failed to verify webhook ownership: %w", err)
+	}
+
+	if hook.URL == *in.URL || hook.Delivery.AuthSecretIdentifier == "" {
+		return nil
+	}
+
+	secretSpace, err := c.spaceFinder.FindByID(ctx, hook.Delivery.AuthSecretSpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find space of the auth secret: %w", err)
+	}
+
+	delivery := hook.Delivery
+	delivery.AuthSecretSpaceRef = secretSpace.Path
+
+	return c.resolveDeliverySecret(ctx, session, defaultSpacePath, &delivery)
+}
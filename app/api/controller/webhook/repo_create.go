@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -36,6 +37,10 @@ func (c *Controller) CreateRepo(
 		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
 	}
 
+	if err = c.resolveDeliverySecret(ctx, session, paths.Parent(repo.Path), &in.Delivery); err != nil {
+		return nil, err
+	}
+
 	typ, err := c.preprocessor.PreprocessCreateInput(session.Principal.Type, in, signatureData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to preprocess create input: %w", err)
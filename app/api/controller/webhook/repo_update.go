@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -37,6 +38,11 @@ func (c *Controller) UpdateRepo(
 		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
 	}
 
+	if err = c.resolveUpdateDeliverySecret(ctx, session, paths.Parent(repo.Path),
+		repo.ID, enum.WebhookParentRepo, webhookIdentifier, in); err != nil {
+		return nil, err
+	}
+
 	typ, err := c.preprocessor.PreprocessUpdateInput(session.Principal.Type, in, signatureData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to preprocess update input: %w", err)
@@ -36,6 +36,10 @@ func (c *Controller) CreateSpace(
 		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
 	}
 
+	if err = c.resolveDeliverySecret(ctx, session, space.Path, &in.Delivery); err != nil {
+		return nil, err
+	}
+
 	internal, err := c.preprocessor.PreprocessCreateInput(session.Principal.Type, in, signatureData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to preprocess create input: %w", err)
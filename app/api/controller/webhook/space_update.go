@@ -37,6 +37,11 @@ func (c *Controller) UpdateSpace(
 		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
 	}
 
+	if err = c.resolveUpdateDeliverySecret(ctx, session, space.Path,
+		space.ID, enum.WebhookParentSpace, webhookIdentifier, in); err != nil {
+		return nil, err
+	}
+
 	typ, err := c.preprocessor.PreprocessUpdateInput(session.Principal.Type, in, signatureData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to preprocess update input: %w", err)
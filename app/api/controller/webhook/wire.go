@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
 
 	"github.com/google/wire"
@@ -32,9 +33,10 @@ func ProvideController(authorizer authz.Authorizer,
 	spaceFinder refcache.SpaceFinder, repoFinder refcache.RepoFinder,
 	webhookService *webhook.Service, encrypter encrypt.Encrypter,
 	preprocessor Preprocessor,
+	secretStore store.SecretStore,
 ) *Controller {
 	return NewController(
-		authorizer, spaceFinder, repoFinder, webhookService, encrypter, preprocessor)
+		authorizer, spaceFinder, repoFinder, webhookService, encrypter, preprocessor, secretStore)
 }
 
 func ProvidePreprocessor() Preprocessor {
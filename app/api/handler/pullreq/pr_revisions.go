@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleRevisions returns a http.HandlerFunc that returns the revisions of a pull request.
+func HandleRevisions(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		revisions, err := pullreqCtrl.Revisions(ctx, session, repoRef, pullreqNumber)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, revisions)
+	}
+}
+
+// HandleInterDiff returns a http.HandlerFunc that returns diff between two revisions of a pull request.
+func HandleInterDiff(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := &pullreq.InterDiffInput{
+			From: request.QueryParamOrDefault(r, request.QueryParamRevisionFrom, ""),
+			To:   request.QueryParamOrDefault(r, request.QueryParamRevisionTo, ""),
+		}
+
+		in.SinceLastReview, err = request.QueryParamAsBoolOrDefault(r, request.QueryParamSinceLastReview, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in.IncludePatch, err = request.QueryParamAsBoolOrDefault(r, "include_patch", false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in.IgnoreWhitespace, err = request.QueryParamAsBoolOrDefault(r, request.QueryParamIgnoreWhitespace, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		setSHAs := func(fromSHA, toSHA string) {
+			w.Header().Set("X-From-Sha", fromSHA)
+			w.Header().Set("X-To-Sha", toSHA)
+		}
+		files := request.GetFileDiffFromQuery(r)
+
+		if strings.HasPrefix(r.Header.Get("Accept"), "text/plain") {
+			err := pullreqCtrl.RawInterDiff(ctx, w, session, repoRef, pullreqNumber, in, setSHAs, files...)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusOK)
+			}
+			return
+		}
+
+		stream, err := pullreqCtrl.InterDiff(ctx, session, repoRef, pullreqNumber, in, setSHAs, files...)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSONArrayDynamic(ctx, w, stream)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterQueryAuditLog = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the audit logs are filtered (matched against the resource identifier)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterAuditLogAction = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The actions of the audit logs to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterAuditLogResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The resource types of the audit logs to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterAuditLogPrincipalID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only audit logs of actions performed by these principals."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+	},
+}
+
+func auditLogOperations(reflector *openapi3.Reflector) {
+	opSpaceAuditLogList := openapi3.Operation{}
+	opSpaceAuditLogList.WithTags("space")
+	opSpaceAuditLogList.WithMapOfAnything(map[string]interface{}{"operationId": "spaceAuditLogList"})
+	opSpaceAuditLogList.WithParameters(
+		queryParameterQueryAuditLog, queryParameterAuditLogAction, queryParameterAuditLogResourceType,
+		queryParameterAuditLogPrincipalID, queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opSpaceAuditLogList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSpaceAuditLogList, []types.AuditLog{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSpaceAuditLogList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSpaceAuditLogList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSpaceAuditLogList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSpaceAuditLogList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-logs", opSpaceAuditLogList)
+
+	opAdminAuditLogList := openapi3.Operation{}
+	opAdminAuditLogList.WithTags("admin")
+	opAdminAuditLogList.WithMapOfAnything(map[string]interface{}{"operationId": "adminAuditLogList"})
+	opAdminAuditLogList.WithParameters(
+		queryParameterQueryAuditLog, queryParameterAuditLogAction, queryParameterAuditLogResourceType,
+		queryParameterAuditLogPrincipalID, queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opAdminAuditLogList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAdminAuditLogList, []types.AuditLog{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAdminAuditLogList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAdminAuditLogList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAdminAuditLogList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-logs", opAdminAuditLogList)
+}
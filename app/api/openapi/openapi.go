@@ -73,6 +73,7 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	uploadOperations(&reflector)
 	gitspaceOperations(&reflector)
 	infraProviderOperations(&reflector)
+	auditLogOperations(&reflector)
 
 	//
 	// define security scheme
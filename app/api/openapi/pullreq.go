@@ -72,6 +72,32 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type autoMergeArmPullReqRequest struct {
+	pullReqRequest
+	pullreq.AutoMergeInput
+}
+
+type mergeQueueListRequest struct {
+	repoRequest
+	Branch string `query:"branch"`
+}
+
+type listPullReqTemplatesRequest struct {
+	repoRequest
+	TargetBranch string `query:"target_branch"`
+}
+
+type findPullReqTemplateRequest struct {
+	repoRequest
+	Name         string `path:"pullreq_template_name"`
+	TargetBranch string `query:"target_branch"`
+}
+
+type mergeQueueEnqueuePullReqRequest struct {
+	pullReqRequest
+	pullreq.MergeQueueEnqueueInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -101,6 +127,16 @@ type commentStatusPullReqRequest struct {
 	pullreq.CommentStatusInput
 }
 
+type reactionPullReqRequest struct {
+	pullReqRequest
+	Reaction enum.PullReqReaction `path:"pullreq_reaction"`
+}
+
+type commentReactionPullReqRequest struct {
+	pullReqCommentRequest
+	Reaction enum.PullReqReaction `path:"pullreq_reaction"`
+}
+
 type reviewerListPullReqRequest struct {
 	pullReqRequest
 }
@@ -146,6 +182,16 @@ type postRawPRDiffRequest struct {
 	IgnoreWhitespace bool `query:"ignore_whitespace" required:"false" default:"false"`
 }
 
+type getPullReqInterDiffRequest struct {
+	pullReqRequest
+	From             string   `query:"from" description:"SHA of the older revision"`
+	To               string   `query:"to" description:"SHA of the newer revision, the latest revision by default"`
+	SinceLastReview  bool     `query:"since_last_review" required:"false" default:"false"`
+	Path             []string `query:"path" description:"provide path for diff operation"`
+	IncludePatch     bool     `query:"include_patch" required:"false" default:"false"`
+	IgnoreWhitespace bool     `query:"ignore_whitespace" required:"false" default:"false"`
+}
+
 type getPullReqChecksRequest struct {
 	pullReqRequest
 }
@@ -689,6 +735,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews", reviewSubmit)
 
+	reviewDiscard := openapi3.Operation{}
+	reviewDiscard.WithTags("pullreq")
+	reviewDiscard.WithMapOfAnything(map[string]interface{}{"operationId": "reviewDiscardPullReq"})
+	_ = reflector.SetRequest(&reviewDiscard, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&reviewDiscard, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&reviewDiscard, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviews/pending", reviewDiscard)
+
 	mergePullReqOp := openapi3.Operation{}
 	mergePullReqOp.WithTags("pullreq")
 	mergePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergePullReqOp"})
@@ -704,6 +762,119 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	autoMergeFindPullReqOp := openapi3.Operation{}
+	autoMergeFindPullReqOp.WithTags("pullreq")
+	autoMergeFindPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeFindPullReq"})
+	_ = reflector.SetRequest(&autoMergeFindPullReqOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&autoMergeFindPullReqOp, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeFindPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeFindPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeFindPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeFindPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/automerge", autoMergeFindPullReqOp)
+
+	autoMergeArmPullReqOp := openapi3.Operation{}
+	autoMergeArmPullReqOp.WithTags("pullreq")
+	autoMergeArmPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeArmPullReq"})
+	_ = reflector.SetRequest(&autoMergeArmPullReqOp, new(autoMergeArmPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&autoMergeArmPullReqOp, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&autoMergeArmPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeArmPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeArmPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeArmPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/automerge", autoMergeArmPullReqOp)
+
+	autoMergeDisarmPullReqOp := openapi3.Operation{}
+	autoMergeDisarmPullReqOp.WithTags("pullreq")
+	autoMergeDisarmPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeDisarmPullReq"})
+	_ = reflector.SetRequest(&autoMergeDisarmPullReqOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&autoMergeDisarmPullReqOp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&autoMergeDisarmPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&autoMergeDisarmPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&autoMergeDisarmPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&autoMergeDisarmPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/automerge", autoMergeDisarmPullReqOp)
+
+	mergeQueueListOp := openapi3.Operation{}
+	mergeQueueListOp.WithTags("pullreq")
+	mergeQueueListOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueList"})
+	_ = reflector.SetRequest(&mergeQueueListOp, new(mergeQueueListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new([]types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueListOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/merge-queue", mergeQueueListOp)
+
+	listPullReqTemplatesOp := openapi3.Operation{}
+	listPullReqTemplatesOp.WithTags("pullreq")
+	listPullReqTemplatesOp.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqTemplates"})
+	_ = reflector.SetRequest(&listPullReqTemplatesOp, new(listPullReqTemplatesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listPullReqTemplatesOp, new([]types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listPullReqTemplatesOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listPullReqTemplatesOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listPullReqTemplatesOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listPullReqTemplatesOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&listPullReqTemplatesOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/templates", listPullReqTemplatesOp)
+
+	findPullReqTemplateOp := openapi3.Operation{}
+	findPullReqTemplateOp.WithTags("pullreq")
+	findPullReqTemplateOp.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqTemplate"})
+	_ = reflector.SetRequest(&findPullReqTemplateOp, new(findPullReqTemplateRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&findPullReqTemplateOp, new(types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&findPullReqTemplateOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&findPullReqTemplateOp, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&findPullReqTemplateOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&findPullReqTemplateOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&findPullReqTemplateOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/templates/{pullreq_template_name}", findPullReqTemplateOp)
+
+	mergeQueueFindPullReqOp := openapi3.Operation{}
+	mergeQueueFindPullReqOp.WithTags("pullreq")
+	mergeQueueFindPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueFindPullReq"})
+	_ = reflector.SetRequest(&mergeQueueFindPullReqOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeQueueFindPullReqOp, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueFindPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueFindPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueFindPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueFindPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueFindPullReqOp)
+
+	mergeQueueEnqueuePullReqOp := openapi3.Operation{}
+	mergeQueueEnqueuePullReqOp.WithTags("pullreq")
+	mergeQueueEnqueuePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueEnqueuePullReq"})
+	_ = reflector.SetRequest(&mergeQueueEnqueuePullReqOp, new(mergeQueueEnqueuePullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(types.MergeQueueEntry), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&mergeQueueEnqueuePullReqOp, new(types.MergeViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueEnqueuePullReqOp)
+
+	mergeQueueDequeuePullReqOp := openapi3.Operation{}
+	mergeQueueDequeuePullReqOp.WithTags("pullreq")
+	mergeQueueDequeuePullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueDequeuePullReq"})
+	_ = reflector.SetRequest(&mergeQueueDequeuePullReqOp, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeuePullReqOp, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeuePullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeuePullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeuePullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeQueueDequeuePullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", mergeQueueDequeuePullReqOp)
+
 	revertPullReqOp := openapi3.Operation{}
 	revertPullReqOp.WithTags("pullreq")
 	revertPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReqOp"})
@@ -720,6 +891,121 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", revertPullReqOp)
 
+	backportPullReqOp := openapi3.Operation{}
+	backportPullReqOp.WithTags("pullreq")
+	backportPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "backportPullReqOp"})
+	_ = reflector.SetRequest(&backportPullReqOp, &struct {
+		pullReqRequest
+		pullreq.BackportInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&backportPullReqOp, new(pullreq.BackportOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&backportPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&backportPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&backportPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&backportPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/backport", backportPullReqOp)
+
+	stackPullReqOp := openapi3.Operation{}
+	stackPullReqOp.WithTags("pullreq")
+	stackPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "stackPullReq"})
+	_ = reflector.SetRequest(&stackPullReqOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&stackPullReqOp, new(types.PullReqStack), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stackPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stackPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stackPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stackPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/stack", stackPullReqOp)
+
+	opPullReqWatchFind := openapi3.Operation{}
+	opPullReqWatchFind.WithTags("pullreq")
+	opPullReqWatchFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPullReqWatch"})
+	_ = reflector.SetRequest(&opPullReqWatchFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(types.NotificationWatchStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opPullReqWatchFind)
+
+	opPullReqWatch := openapi3.Operation{}
+	opPullReqWatch.WithTags("pullreq")
+	opPullReqWatch.WithMapOfAnything(map[string]interface{}{"operationId": "watchPullReq"})
+	_ = reflector.SetRequest(&opPullReqWatch, new(pullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opPullReqWatch, new(types.NotificationWatchStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opPullReqWatch)
+
+	opPullReqUnwatch := openapi3.Operation{}
+	opPullReqUnwatch.WithTags("pullreq")
+	opPullReqUnwatch.WithMapOfAnything(map[string]interface{}{"operationId": "unwatchPullReq"})
+	_ = reflector.SetRequest(&opPullReqUnwatch, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPullReqUnwatch, new(types.NotificationWatchStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqUnwatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqUnwatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqUnwatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqUnwatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opPullReqUnwatch)
+
+	opPullReqReactionAdd := openapi3.Operation{}
+	opPullReqReactionAdd.WithTags("pullreq")
+	opPullReqReactionAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addPullReqReaction"})
+	_ = reflector.SetRequest(&opPullReqReactionAdd, new(reactionPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opPullReqReactionAdd, []types.PullReqReactionSummary{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqReactionAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPullReqReactionAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqReactionAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqReactionAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqReactionAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions/{pullreq_reaction}", opPullReqReactionAdd)
+
+	opPullReqReactionRemove := openapi3.Operation{}
+	opPullReqReactionRemove.WithTags("pullreq")
+	opPullReqReactionRemove.WithMapOfAnything(map[string]interface{}{"operationId": "removePullReqReaction"})
+	_ = reflector.SetRequest(&opPullReqReactionRemove, new(reactionPullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPullReqReactionRemove, []types.PullReqReactionSummary{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqReactionRemove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPullReqReactionRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqReactionRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqReactionRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqReactionRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions/{pullreq_reaction}", opPullReqReactionRemove)
+
+	opCommentReactionAdd := openapi3.Operation{}
+	opCommentReactionAdd.WithTags("pullreq")
+	opCommentReactionAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addPullReqCommentReaction"})
+	_ = reflector.SetRequest(&opCommentReactionAdd, new(commentReactionPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opCommentReactionAdd, []types.PullReqReactionSummary{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommentReactionAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommentReactionAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentReactionAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentReactionAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentReactionAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions/{pullreq_reaction}", opCommentReactionAdd)
+
+	opCommentReactionRemove := openapi3.Operation{}
+	opCommentReactionRemove.WithTags("pullreq")
+	opCommentReactionRemove.WithMapOfAnything(map[string]interface{}{"operationId": "removePullReqCommentReaction"})
+	_ = reflector.SetRequest(&opCommentReactionRemove, new(commentReactionPullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCommentReactionRemove, []types.PullReqReactionSummary{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommentReactionRemove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommentReactionRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommentReactionRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommentReactionRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommentReactionRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions/{pullreq_reaction}", opCommentReactionRemove)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -862,6 +1148,32 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opPostDiff, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq/{pullreq_number}/diff", opPostDiff))
 
+	opRevisions := openapi3.Operation{}
+	opRevisions.WithTags("pullreq")
+	opRevisions.WithMapOfAnything(map[string]interface{}{"operationId": "revisionsPullReq"})
+	panicOnErr(reflector.SetRequest(&opRevisions, new(pullReqRequest), http.MethodGet))
+	panicOnErr(reflector.SetJSONResponse(&opRevisions, new([]types.PullReqRevision), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opRevisions, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opRevisions, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opRevisions, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opRevisions, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/revisions", opRevisions))
+
+	opInterDiff := openapi3.Operation{}
+	opInterDiff.WithTags("pullreq")
+	opInterDiff.WithMapOfAnything(map[string]interface{}{"operationId": "interDiffPullReq"})
+	panicOnErr(reflector.SetRequest(&opInterDiff, new(getPullReqInterDiffRequest), http.MethodGet))
+	panicOnErr(reflector.SetStringResponse(&opInterDiff, http.StatusOK, "text/plain"))
+	panicOnErr(reflector.SetJSONResponse(&opInterDiff, new([]git.FileDiff), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opInterDiff, new(usererror.Error), http.StatusBadRequest))
+	panicOnErr(reflector.SetJSONResponse(&opInterDiff, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opInterDiff, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opInterDiff, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opInterDiff, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/interdiff", opInterDiff))
+
 	opChecks := openapi3.Operation{}
 	opChecks.WithTags("pullreq")
 	opChecks.WithMapOfAnything(map[string]interface{}{"operationId": "checksPullReq"})
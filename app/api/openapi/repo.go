@@ -50,6 +50,36 @@ type updateRepoRequest struct {
 	repo.UpdateInput
 }
 
+type setRepoMirrorRequest struct {
+	repoRequest
+	repo.MirrorSetInput
+}
+
+type pushMirrorRequest struct {
+	repoRequest
+	ID int64 `path:"push_mirror_id"`
+}
+
+type createPushMirrorRequest struct {
+	repoRequest
+	repo.PushMirrorCreateInput
+}
+
+type updatePushMirrorRequest struct {
+	pushMirrorRequest
+	repo.PushMirrorUpdateInput
+}
+
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
+type syncForkRequest struct {
+	repoRequest
+	repo.ForkSyncInput
+}
+
 type updateDefaultBranchRequest struct {
 	repoRequest
 	repo.UpdateDefaultBranchInput
@@ -963,6 +993,196 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&importProgressRepository, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/import-progress", importProgressRepository)
 
+	opFindMirror := openapi3.Operation{}
+	opFindMirror.WithTags("repository")
+	opFindMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoMirror"})
+	_ = reflector.SetRequest(&opFindMirror, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(types.RepoMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opFindMirror)
+
+	opSetMirror := openapi3.Operation{}
+	opSetMirror.WithTags("repository")
+	opSetMirror.WithMapOfAnything(map[string]interface{}{"operationId": "setRepoMirror"})
+	_ = reflector.SetRequest(&opSetMirror, new(setRepoMirrorRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetMirror, new(types.RepoMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSetMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSetMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/mirror", opSetMirror)
+
+	opDeleteMirror := openapi3.Operation{}
+	opDeleteMirror.WithTags("repository")
+	opDeleteMirror.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoMirror"})
+	_ = reflector.SetRequest(&opDeleteMirror, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteMirror, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/mirror", opDeleteMirror)
+
+	opSyncMirror := openapi3.Operation{}
+	opSyncMirror.WithTags("repository")
+	opSyncMirror.WithMapOfAnything(map[string]interface{}{"operationId": "syncRepoMirror"})
+	_ = reflector.SetRequest(&opSyncMirror, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncMirror, new(types.RepoMirror), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opSyncMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncMirror, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opSyncMirror)
+
+	opListPushMirrors := openapi3.Operation{}
+	opListPushMirrors.WithTags("repository")
+	opListPushMirrors.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoPushMirrors"})
+	_ = reflector.SetRequest(&opListPushMirrors, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, []types.RepoPushMirror{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPushMirrors, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors", opListPushMirrors)
+
+	opCreatePushMirror := openapi3.Operation{}
+	opCreatePushMirror.WithTags("repository")
+	opCreatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoPushMirror"})
+	_ = reflector.SetRequest(&opCreatePushMirror, new(createPushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(types.RepoPushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors", opCreatePushMirror)
+
+	opFindPushMirror := openapi3.Operation{}
+	opFindPushMirror.WithTags("repository")
+	opFindPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoPushMirror"})
+	_ = reflector.SetRequest(&opFindPushMirror, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(types.RepoPushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_id}", opFindPushMirror)
+
+	opUpdatePushMirror := openapi3.Operation{}
+	opUpdatePushMirror.WithTags("repository")
+	opUpdatePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoPushMirror"})
+	_ = reflector.SetRequest(&opUpdatePushMirror, new(updatePushMirrorRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(types.RepoPushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdatePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_id}", opUpdatePushMirror)
+
+	opDeletePushMirror := openapi3.Operation{}
+	opDeletePushMirror.WithTags("repository")
+	opDeletePushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoPushMirror"})
+	_ = reflector.SetRequest(&opDeletePushMirror, new(pushMirrorRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeletePushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_id}", opDeletePushMirror)
+
+	opPushPushMirror := openapi3.Operation{}
+	opPushPushMirror.WithTags("repository")
+	opPushPushMirror.WithMapOfAnything(map[string]interface{}{"operationId": "pushRepoPushMirror"})
+	_ = reflector.SetRequest(&opPushPushMirror, new(pushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(types.RepoPushMirror), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushPushMirror, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/push-mirrors/{push_mirror_id}/push", opPushPushMirror)
+
+	opRepoWatchFind := openapi3.Operation{}
+	opRepoWatchFind.WithTags("repository")
+	opRepoWatchFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoWatch"})
+	_ = reflector.SetRequest(&opRepoWatchFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(types.NotificationWatchStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/watch", opRepoWatchFind)
+
+	opRepoWatch := openapi3.Operation{}
+	opRepoWatch.WithTags("repository")
+	opRepoWatch.WithMapOfAnything(map[string]interface{}{"operationId": "watchRepo"})
+	_ = reflector.SetRequest(&opRepoWatch, new(repoRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opRepoWatch, new(types.NotificationWatchStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoWatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/watch", opRepoWatch)
+
+	opRepoUnwatch := openapi3.Operation{}
+	opRepoUnwatch.WithTags("repository")
+	opRepoUnwatch.WithMapOfAnything(map[string]interface{}{"operationId": "unwatchRepo"})
+	_ = reflector.SetRequest(&opRepoUnwatch, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoUnwatch, new(types.NotificationWatchStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoUnwatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoUnwatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoUnwatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoUnwatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/watch", opRepoUnwatch)
+
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepo"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
+	opSyncFork := openapi3.Operation{}
+	opSyncFork.WithTags("repository")
+	opSyncFork.WithMapOfAnything(map[string]interface{}{"operationId": "syncRepoFork"})
+	_ = reflector.SetRequest(&opSyncFork, new(syncForkRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(repo.ForkSyncOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opSyncFork, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork/sync", opSyncFork)
+
+	opListForks := openapi3.Operation{}
+	opListForks.WithTags("repository")
+	opListForks.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoForks"})
+	opListForks.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListForks, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListForks, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListForks, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/forks", opListForks)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("repository")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listCommits"})
@@ -60,6 +60,11 @@ type restoreSpaceRequest struct {
 	space.RestoreInput
 }
 
+type updateSpacePullReqTemplateRequest struct {
+	spaceRequest
+	space.PullReqTemplateUpdateInput
+}
+
 type importRepositoriesRequest struct {
 	spaceRequest
 	space.ImportRepositoriesInput
@@ -699,6 +704,29 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&countPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/pullreq/count", countPullReq)
 
+	findPullReqTemplate := openapi3.Operation{}
+	findPullReqTemplate.WithTags("space")
+	findPullReqTemplate.WithMapOfAnything(map[string]interface{}{"operationId": "findSpacePullReqTemplate"})
+	_ = reflector.SetRequest(&findPullReqTemplate, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&findPullReqTemplate, new(types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&findPullReqTemplate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&findPullReqTemplate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&findPullReqTemplate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&findPullReqTemplate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/pullreq/template", findPullReqTemplate)
+
+	updatePullReqTemplate := openapi3.Operation{}
+	updatePullReqTemplate.WithTags("space")
+	updatePullReqTemplate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpacePullReqTemplate"})
+	_ = reflector.SetRequest(&updatePullReqTemplate, new(updateSpacePullReqTemplateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&updatePullReqTemplate, new(types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&updatePullReqTemplate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&updatePullReqTemplate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&updatePullReqTemplate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&updatePullReqTemplate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&updatePullReqTemplate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/pullreq/template", updatePullReqTemplate)
+
 	listPullReq := openapi3.Operation{}
 	listPullReq.WithTags("space")
 	listPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "listSpacePullReq"})
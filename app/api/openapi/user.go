@@ -45,6 +45,20 @@ var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterNotificationRead = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRead,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The read state by which the notifications are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeBoolean),
+			},
+		},
+	},
+}
+
 var queryParameterSortMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -122,6 +136,118 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
 
+	opFindDigest := openapi3.Operation{}
+	opFindDigest.WithTags("user")
+	opFindDigest.WithMapOfAnything(map[string]interface{}{"operationId": "getUserDigest"})
+	_ = reflector.SetRequest(&opFindDigest, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindDigest, new(types.NotificationDigest), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindDigest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/digest", opFindDigest)
+
+	opUpdateDigest := openapi3.Operation{}
+	opUpdateDigest.WithTags("user")
+	opUpdateDigest.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserDigest"})
+	_ = reflector.SetRequest(&opUpdateDigest, new(user.UpdateDigestInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateDigest, new(types.NotificationDigest), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateDigest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateDigest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/digest", opUpdateDigest)
+
+	opFindOutOfOffice := openapi3.Operation{}
+	opFindOutOfOffice.WithTags("user")
+	opFindOutOfOffice.WithMapOfAnything(map[string]interface{}{"operationId": "getUserOutOfOffice"})
+	_ = reflector.SetRequest(&opFindOutOfOffice, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindOutOfOffice, new(types.OutOfOffice), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindOutOfOffice, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindOutOfOffice, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/out-of-office", opFindOutOfOffice)
+
+	opSetOutOfOffice := openapi3.Operation{}
+	opSetOutOfOffice.WithTags("user")
+	opSetOutOfOffice.WithMapOfAnything(map[string]interface{}{"operationId": "setUserOutOfOffice"})
+	_ = reflector.SetRequest(&opSetOutOfOffice, new(user.SetOutOfOfficeInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetOutOfOffice, new(types.OutOfOffice), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetOutOfOffice, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetOutOfOffice, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/out-of-office", opSetOutOfOffice)
+
+	opDeleteOutOfOffice := openapi3.Operation{}
+	opDeleteOutOfOffice.WithTags("user")
+	opDeleteOutOfOffice.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserOutOfOffice"})
+	_ = reflector.SetRequest(&opDeleteOutOfOffice, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteOutOfOffice, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteOutOfOffice, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/out-of-office", opDeleteOutOfOffice)
+
+	opListNotificationPrefs := openapi3.Operation{}
+	opListNotificationPrefs.WithTags("user")
+	opListNotificationPrefs.WithMapOfAnything(map[string]interface{}{"operationId": "listNotificationPreferences"})
+	_ = reflector.SetRequest(&opListNotificationPrefs, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotificationPrefs, new([]types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotificationPrefs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-preferences", opListNotificationPrefs)
+
+	opUpsertNotificationPref := openapi3.Operation{}
+	opUpsertNotificationPref.WithTags("user")
+	opUpsertNotificationPref.WithMapOfAnything(map[string]interface{}{"operationId": "upsertNotificationPreference"})
+	_ = reflector.SetRequest(&opUpsertNotificationPref, new(user.UpsertNotificationPreferenceInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpsertNotificationPref, new(types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpsertNotificationPref, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpsertNotificationPref, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpsertNotificationPref, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/notification-preferences", opUpsertNotificationPref)
+
+	opDeleteNotificationPref := openapi3.Operation{}
+	opDeleteNotificationPref.WithTags("user")
+	opDeleteNotificationPref.WithMapOfAnything(map[string]interface{}{"operationId": "deleteNotificationPreference"})
+	_ = reflector.SetRequest(&opDeleteNotificationPref, struct {
+		ID int64 `path:"notification_preference_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteNotificationPref, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/user/notification-preferences/{notification_preference_id}", opDeleteNotificationPref)
+
+	opListNotifications := openapi3.Operation{}
+	opListNotifications.WithTags("user")
+	opListNotifications.WithMapOfAnything(map[string]interface{}{"operationId": "listNotifications"})
+	opListNotifications.WithParameters(QueryParameterPage, QueryParameterLimit, queryParameterNotificationRead)
+	_ = reflector.SetRequest(&opListNotifications, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotifications, new([]types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opListNotifications)
+
+	opNotificationUnreadCount := openapi3.Operation{}
+	opNotificationUnreadCount.WithTags("user")
+	opNotificationUnreadCount.WithMapOfAnything(map[string]interface{}{"operationId": "notificationUnreadCount"})
+	_ = reflector.SetRequest(&opNotificationUnreadCount, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opNotificationUnreadCount, new(types.NotificationUnreadCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationUnreadCount, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications/unread-count", opNotificationUnreadCount)
+
+	opMarkAllNotificationsRead := openapi3.Operation{}
+	opMarkAllNotificationsRead.WithTags("user")
+	opMarkAllNotificationsRead.WithMapOfAnything(map[string]interface{}{"operationId": "markAllNotificationsRead"})
+	_ = reflector.SetRequest(&opMarkAllNotificationsRead, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkAllNotificationsRead, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMarkAllNotificationsRead, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/mark-all-read", opMarkAllNotificationsRead)
+
+	opUpdateNotification := openapi3.Operation{}
+	opUpdateNotification.WithTags("user")
+	opUpdateNotification.WithMapOfAnything(map[string]interface{}{"operationId": "updateNotification"})
+	_ = reflector.SetRequest(&opUpdateNotification, struct {
+		user.UpdateNotificationInput
+		ID int64 `path:"notification_id"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateNotification, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notifications/{notification_id}", opUpdateNotification)
+
 	opKeyCreate := openapi3.Operation{}
 	opKeyCreate.WithTags("user")
 	opKeyCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
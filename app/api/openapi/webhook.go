@@ -112,6 +112,26 @@ type getRepoWebhookExecutionRequest struct {
 	repoWebhookExecutionRequest
 }
 
+type testSpaceWebhookRequest struct {
+	spaceWebhookRequest
+	types.WebhookTestInput
+}
+
+type testRepoWebhookRequest struct {
+	repoWebhookRequest
+	types.WebhookTestInput
+}
+
+type redeliverSpaceWebhookExecutionsRequest struct {
+	spaceWebhookRequest
+	types.WebhookRedeliverInput
+}
+
+type redeliverRepoWebhookExecutionsRequest struct {
+	repoWebhookRequest
+	types.WebhookRedeliverInput
+}
+
 var queryParameterSortWebhook = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -259,6 +279,38 @@ func webhookOperations(reflector *openapi3.Reflector) {
 		retriggerSpaceWebhookExecution,
 	)
 
+	redeliverSpaceWebhookExecutions := openapi3.Operation{}
+	redeliverSpaceWebhookExecutions.WithTags("webhook")
+	redeliverSpaceWebhookExecutions.WithMapOfAnything(
+		map[string]interface{}{"operationId": "redeliverSpaceWebhookExecutions"},
+	)
+	_ = reflector.SetRequest(&redeliverSpaceWebhookExecutions,
+		new(redeliverSpaceWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions,
+		new(types.WebhookRedeliverOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/redeliver",
+		redeliverSpaceWebhookExecutions,
+	)
+
+	testSpaceWebhook := openapi3.Operation{}
+	testSpaceWebhook.WithTags("webhook")
+	testSpaceWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testSpaceWebhook"})
+	_ = reflector.SetRequest(&testSpaceWebhook, new(testSpaceWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testSpaceWebhook, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/test", testSpaceWebhook)
+
 	// repo
 
 	createRepoWebhook := openapi3.Operation{}
@@ -358,4 +410,35 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}/retrigger",
 		retriggerRepoWebhookExecution)
+
+	redeliverRepoWebhookExecutions := openapi3.Operation{}
+	redeliverRepoWebhookExecutions.WithTags("webhook")
+	redeliverRepoWebhookExecutions.WithMapOfAnything(
+		map[string]interface{}{"operationId": "redeliverRepoWebhookExecutions"},
+	)
+	_ = reflector.SetRequest(&redeliverRepoWebhookExecutions,
+		new(redeliverRepoWebhookExecutionsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions,
+		new(types.WebhookRedeliverOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/redeliver",
+		redeliverRepoWebhookExecutions)
+
+	testRepoWebhook := openapi3.Operation{}
+	testRepoWebhook.WithTags("webhook")
+	testRepoWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "testRepoWebhook"})
+	_ = reflector.SetRequest(&testRepoWebhook, new(testRepoWebhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testRepoWebhook, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/test", testRepoWebhook)
 }
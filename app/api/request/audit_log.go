@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	QueryParamAction       = "action"
+	QueryParamResourceType = "resource_type"
+	QueryParamPrincipalID  = "principal_id"
+)
+
+// ParseAuditLogFilter extracts the audit log query parameters from the url.
+func ParseAuditLogFilter(r *http.Request) (*types.AuditLogFilter, error) {
+	principalIDs, err := QueryParamListAsPositiveInt64(r, QueryParamPrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing principal ID filter: %w", err)
+	}
+
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing created filter: %w", err)
+	}
+
+	actions, _ := QueryParamList(r, QueryParamAction)
+	resourceTypes, _ := QueryParamList(r, QueryParamResourceType)
+
+	return &types.AuditLogFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		CreatedFilter:   createdFilter,
+		Actions:         actions,
+		ResourceTypes:   resourceTypes,
+		PrincipalIDs:    principalIDs,
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamNotificationID           = "notification_id"
+	PathParamNotificationPreferenceID = "notification_preference_id"
+
+	QueryParamRead = "read"
+)
+
+// GetNotificationIDFromPath extracts the in-app notification ID from the url.
+func GetNotificationIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamNotificationID)
+}
+
+// GetNotificationPreferenceIDFromPath extracts the notification preference ID from the url.
+func GetNotificationPreferenceIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamNotificationPreferenceID)
+}
+
+// ParseNotificationFilter extracts the in-app notification query parameters from the url.
+func ParseNotificationFilter(r *http.Request) (*types.NotificationFilter, error) {
+	filter := &types.NotificationFilter{
+		Pagination: ParsePaginationFromRequest(r),
+	}
+
+	if rawValue, ok := QueryParam(r, QueryParamRead); ok && rawValue != "" {
+		read, err := strconv.ParseBool(rawValue)
+		if err != nil {
+			return nil, usererror.BadRequestf("Parameter '%s' must be a boolean.", QueryParamRead)
+		}
+		filter.Read = &read
+	}
+
+	return filter, nil
+}
@@ -30,6 +30,8 @@ const (
 	PathParamUserGroupID      = "user_group_id"
 	PathParamSourceBranch     = "source_branch"
 	PathParamTargetBranch     = "target_branch"
+	PathParamTemplateName     = "pullreq_template_name"
+	PathParamReaction         = "pullreq_reaction"
 
 	QueryParamCommenterID        = "commenter_id"
 	QueryParamReviewerID         = "reviewer_id"
@@ -39,6 +41,9 @@ const (
 	QueryParamSourceRepoRef      = "source_repo_ref"
 	QueryParamSourceBranch       = "source_branch"
 	QueryParamTargetBranch       = "target_branch"
+	QueryParamRevisionFrom       = "from"
+	QueryParamRevisionTo         = "to"
+	QueryParamSinceLastReview    = "since_last_review"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -64,6 +69,23 @@ func GetPullReqTargetBranchFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTargetBranch)
 }
 
+func GetPullReqTemplateNameFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamTemplateName)
+}
+
+func GetPullReqReactionFromPath(r *http.Request) (enum.PullReqReaction, error) {
+	reaction, err := PathParamOrError(r, PathParamReaction)
+	if err != nil {
+		return "", err
+	}
+
+	return enum.PullReqReaction(reaction), nil
+}
+
+func GetPullReqTargetBranchFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamTargetBranch, "")
+}
+
 func GetSourceRepoRefFromQueryOrDefault(r *http.Request, deflt string) string {
 	return QueryParamOrDefault(r, QueryParamSourceRepoRef, deflt)
 }
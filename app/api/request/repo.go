@@ -22,14 +22,19 @@ import (
 )
 
 const (
-	PathParamRepoRef = "repo_ref"
-	QueryParamRepoID = "repo_id"
+	PathParamRepoRef      = "repo_ref"
+	QueryParamRepoID      = "repo_id"
+	PathParamPushMirrorID = "push_mirror_id"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamRepoRef)
 }
 
+func GetPushMirrorIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPushMirrorID)
+}
+
 // ParseSortRepo extracts the repo sort parameter from the url.
 func ParseSortRepo(r *http.Request) enum.RepoAttr {
 	return enum.ParseRepoAttr(
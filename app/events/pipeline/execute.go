@@ -30,6 +30,7 @@ type ExecutedPayload struct {
 	RepoID       int64         `json:"repo_id"`
 	ExecutionNum int64         `json:"execution_number"`
 	Status       enum.CIStatus `json:"status"`
+	CommitSHA    string        `json:"commit_sha,omitempty"`
 }
 
 func (r *Reporter) Executed(ctx context.Context, payload *ExecutedPayload) {
@@ -46,3 +47,51 @@ func (r *Reader) RegisterExecuted(fn events.HandlerFunc[*ExecutedPayload],
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, ExecutedEvent, fn, opts...)
 }
+
+const StartedEvent events.EventType = "started"
+
+type StartedPayload struct {
+	PipelineID   int64  `json:"pipeline_id"`
+	RepoID       int64  `json:"repo_id"`
+	ExecutionNum int64  `json:"execution_number"`
+	CommitSHA    string `json:"commit_sha,omitempty"`
+}
+
+func (r *Reporter) Started(ctx context.Context, payload *StartedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, StartedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pipeline execution started event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pipeline execution started event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterStarted(fn events.HandlerFunc[*StartedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, StartedEvent, fn, opts...)
+}
+
+const CanceledEvent events.EventType = "canceled"
+
+type CanceledPayload struct {
+	PipelineID   int64  `json:"pipeline_id"`
+	RepoID       int64  `json:"repo_id"`
+	ExecutionNum int64  `json:"execution_number"`
+	CommitSHA    string `json:"commit_sha,omitempty"`
+}
+
+func (r *Reporter) Canceled(ctx context.Context, payload *CanceledPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, CanceledEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pipeline execution canceled event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pipeline execution canceled event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterCanceled(fn events.HandlerFunc[*CanceledPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, CanceledEvent, fn, opts...)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeCheckCompletedEvent events.EventType = "merge-check-completed"
+
+type MergeCheckCompletedPayload struct {
+	Base
+	SourceSHA string `json:"source_sha"`
+	Mergeable bool   `json:"mergeable"`
+}
+
+func (r *Reporter) MergeCheckCompleted(ctx context.Context, payload *MergeCheckCompletedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeCheckCompletedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge check completed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge check completed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeCheckCompleted(
+	fn events.HandlerFunc[*MergeCheckCompletedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeCheckCompletedEvent, fn, opts...)
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueChecksRequestedEvent events.EventType = "merge-queue-checks-requested"
+
+// MergeQueueChecksRequestedPayload is sent when a speculative merge commit of a merge queue entry
+// has been created and the checks should run on it.
+type MergeQueueChecksRequestedPayload struct {
+	Base
+	Ref       string `json:"ref"`
+	SourceSHA string `json:"source_sha"`
+	BaseSHA   string `json:"base_sha"`
+	MergeSHA  string `json:"merge_sha"`
+}
+
+func (r *Reporter) MergeQueueChecksRequested(ctx context.Context, payload *MergeQueueChecksRequestedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueChecksRequestedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue checks requested event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue checks requested event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueChecksRequested(
+	fn events.HandlerFunc[*MergeQueueChecksRequestedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueChecksRequestedEvent, fn, opts...)
+}
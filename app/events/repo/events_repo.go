@@ -190,10 +190,42 @@ func (r *Reader) RegisterDefaultBranchUpdated(
 	return events.ReaderRegisterEvent(r.innerReader, DefaultBranchUpdatedEvent, fn, opts...)
 }
 
+const RenamedEvent events.EventType = "renamed"
+
+type RenamedPayload struct {
+	Base
+	OldIdentifier string `json:"old_identifier"`
+	NewIdentifier string `json:"new_identifier"`
+	OldPath       string `json:"old_path"`
+	NewPath       string `json:"new_path"`
+}
+
+func (r *Reporter) Renamed(ctx context.Context, payload *RenamedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RenamedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo renamed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo renamed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRenamed(
+	fn events.HandlerFunc[*RenamedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, RenamedEvent, fn, opts...)
+}
+
 const PushedEvent events.EventType = "pushed"
 
 type PushedPayload struct {
 	Base
+	InternalRefsOnly bool `json:"internal_refs_only"`
 }
 
 func (r *Reporter) Pushed(ctx context.Context, payload *PushedPayload) {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
 
 	"github.com/rs/zerolog/log"
 )
@@ -48,3 +49,60 @@ func (r *Reader) RegisterCreated(
 ) error {
 	return events.ReaderRegisterEvent(r.innerReader, CreatedEvent, fn, opts...)
 }
+
+const UpdatedEvent events.EventType = "updated"
+
+type UpdatedPayload struct {
+	Base
+}
+
+func (r *Reporter) Updated(ctx context.Context, payload *UpdatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, UpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send rule updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported rule updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterUpdated(
+	fn events.HandlerFunc[*UpdatedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, UpdatedEvent, fn, opts...)
+}
+
+const DeletedEvent events.EventType = "deleted"
+
+// DeletedPayload carries the details of the deleted rule, because the rule can't be fetched anymore.
+type DeletedPayload struct {
+	Base
+	Identifier string         `json:"identifier"`
+	Type       types.RuleType `json:"type"`
+}
+
+func (r *Reporter) Deleted(ctx context.Context, payload *DeletedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, DeletedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send rule deleted event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported rule deleted event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterDeleted(
+	fn events.HandlerFunc[*DeletedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, DeletedEvent, fn, opts...)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -35,6 +36,7 @@ type service struct {
 	scheduler      scheduler.Scheduler
 	stageStore     store.StageStore
 	stepStore      store.StepStore
+	reporter       *events.Reporter
 }
 
 // Canceler cancels a build.
@@ -52,6 +54,7 @@ func New(
 	scheduler scheduler.Scheduler,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
+	reporter *events.Reporter,
 ) Canceler {
 	return &service{
 		executionStore: executionStore,
@@ -60,6 +63,7 @@ func New(
 		scheduler:      scheduler,
 		stageStore:     stageStore,
 		stepStore:      stepStore,
+		reporter:       reporter,
 	}
 }
 
@@ -148,5 +152,12 @@ func (s *service) Cancel(ctx context.Context, repo *types.RepositoryCore, execut
 
 	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeExecutionCanceled, execution)
 
+	s.reporter.Canceled(ctx, &events.CanceledPayload{
+		PipelineID:   execution.PipelineID,
+		RepoID:       execution.RepoID,
+		ExecutionNum: execution.Number,
+		CommitSHA:    execution.After,
+	})
+
 	return nil
 }
@@ -469,6 +469,7 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 		Steps:       m.Steps,
 		Stages:      m.Stages,
 		Users:       m.Users,
+		Reporter:    m.reporter,
 	}
 
 	return s.do(noContext, stage)
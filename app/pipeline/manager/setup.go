@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -38,6 +39,7 @@ type setup struct {
 	Steps       store.StepStore
 	Stages      store.StageStore
 	Users       store.PrincipalStore
+	Reporter    events.Reporter
 }
 
 func (s *setup) do(ctx context.Context, stage *types.Stage) error {
@@ -87,7 +89,7 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		}
 	}
 
-	_, err = s.updateExecution(noContext, execution)
+	started, err := s.updateExecution(noContext, execution)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot update the execution")
 		return err
@@ -111,6 +113,11 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 
 	s.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionRunning, execution)
 
+	// only the first stage of an execution moves it to running, so the event is reported once.
+	if started {
+		s.reportExecutionStarted(ctx, execution)
+	}
+
 	return nil
 }
 
@@ -132,3 +139,12 @@ func (s *setup) updateExecution(ctx context.Context, execution *types.Execution)
 	}
 	return true, nil
 }
+
+func (s *setup) reportExecutionStarted(ctx context.Context, execution *types.Execution) {
+	s.Reporter.Started(ctx, &events.StartedPayload{
+		PipelineID:   execution.PipelineID,
+		RepoID:       execution.RepoID,
+		ExecutionNum: execution.Number,
+		CommitSHA:    execution.After,
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
@@ -45,6 +46,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
+	handlerauditlog "github.com/harness/gitness/app/api/handler/auditlog"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
@@ -132,6 +134,7 @@ func NewAPIHandler(
 	migrateCtrl *migrate.Controller,
 	gitspaceCtrl *gitspace.Controller,
 	usageSender usage.Sender,
+	auditLogCtrl *auditlog.Controller,
 ) http.Handler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -164,7 +167,7 @@ func NewAPIHandler(
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, usageSender)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, usageSender, auditLogCtrl)
 		})
 	})
 
@@ -215,9 +218,10 @@ func setupRoutesV1WithAuth(r chi.Router,
 	infraProviderCtrl *infraprovider.Controller,
 	migrateCtrl *migrate.Controller,
 	usageSender usage.Sender,
+	auditLogCtrl *auditlog.Controller,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl, auditLogCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
 		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
 	setupConnectors(r, connectorCtrl)
@@ -227,7 +231,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, auditLogCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -244,6 +248,7 @@ func setupSpaces(
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
+	auditLogCtrl *auditlog.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -278,6 +283,9 @@ func setupSpaces(
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
 			r.Get("/pullreq/count", handlerspace.HandleCountPullReqs(spaceCtrl))
+			r.Get("/pullreq/template", handlerspace.HandlePullReqTemplateFind(spaceCtrl))
+			r.Put("/pullreq/template", handlerspace.HandlePullReqTemplateUpdate(spaceCtrl))
+			r.Get("/audit-logs", handlerauditlog.HandleListSpace(auditLogCtrl))
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -332,9 +340,11 @@ func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 			r.Get("/", handlerwebhook.HandleFindSpace(webhookCtrl))
 			r.Patch("/", handlerwebhook.HandleUpdateSpace(webhookCtrl))
 			r.Delete("/", handlerwebhook.HandleDeleteSpace(webhookCtrl))
+			r.Post("/test", handlerwebhook.HandleTestDeliverySpace(webhookCtrl))
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsSpace(webhookCtrl))
+				r.Post("/redeliver", handlerwebhook.HandleRedeliverExecutionsSpace(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionSpace(webhookCtrl))
@@ -398,6 +408,36 @@ func setupRepos(r chi.Router,
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleWatchFind(repoCtrl))
+				r.Put("/", handlerrepo.HandleWatch(repoCtrl))
+				r.Delete("/", handlerrepo.HandleUnwatch(repoCtrl))
+			})
+
+			r.Route("/mirror", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMirrorFind(repoCtrl))
+				r.Put("/", handlerrepo.HandleMirrorSet(repoCtrl))
+				r.Delete("/", handlerrepo.HandleMirrorDelete(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleMirrorSync(repoCtrl))
+			})
+
+			r.Route("/push-mirrors", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandlePushMirrorList(repoCtrl))
+				r.Post("/", handlerrepo.HandlePushMirrorCreate(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorID), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandlePushMirrorFind(repoCtrl))
+					r.Patch("/", handlerrepo.HandlePushMirrorUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandlePushMirrorDelete(repoCtrl))
+					r.Post("/push", handlerrepo.HandlePushMirrorPush(repoCtrl))
+				})
+			})
+
+			r.Route("/fork", func(r chi.Router) {
+				r.Post("/", handlerrepo.HandleFork(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleForkSync(repoCtrl))
+			})
+			r.Get("/forks", handlerrepo.HandleListForks(repoCtrl))
+
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
 			// content operations
@@ -651,6 +691,11 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
+		r.Route("/templates", func(r chi.Router) {
+			r.Get("/", handlerpullreq.HandleListTemplates(pullreqCtrl))
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamTemplateName), handlerpullreq.HandleFindTemplate(pullreqCtrl))
+		})
 		r.Get(
 			fmt.Sprintf("/{%s}...{%s}", request.PathParamTargetBranch, request.PathParamSourceBranch),
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
@@ -668,8 +713,16 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
 					r.Put("/status", handlerpullreq.HandleCommentStatus(pullreqCtrl))
+					r.Route(fmt.Sprintf("/reactions/{%s}", request.PathParamReaction), func(r chi.Router) {
+						r.Put("/", handlerpullreq.HandleCommentReactionAdd(pullreqCtrl))
+						r.Delete("/", handlerpullreq.HandleCommentReactionRemove(pullreqCtrl))
+					})
 				})
 			})
+			r.Route(fmt.Sprintf("/reactions/{%s}", request.PathParamReaction), func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleReactionAdd(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleReactionRemove(pullreqCtrl))
+			})
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
@@ -688,9 +741,27 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			})
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
+				r.Delete("/pending", handlerpullreq.HandleReviewDiscard(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Route("/automerge", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleAutoMergeArm(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisarm(pullreqCtrl))
+			})
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleMergeQueueFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleMergeQueueEnqueue(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueDequeue(pullreqCtrl))
+			})
 			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Post("/backport", handlerpullreq.HandleBackport(pullreqCtrl))
+			r.Get("/stack", handlerpullreq.HandleStack(pullreqCtrl))
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleWatchFind(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleWatch(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleUnwatch(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 			r.Route("/branch", func(r chi.Router) {
@@ -708,6 +779,8 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/codeowners", handlerpullreq.HandleCodeOwner(pullreqCtrl))
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.Get("/revisions", handlerpullreq.HandleRevisions(pullreqCtrl))
+			r.Get("/interdiff", handlerpullreq.HandleInterDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
 
 			setupPullReqLabels(r, pullreqCtrl)
@@ -734,9 +807,11 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 			r.Get("/", handlerwebhook.HandleFindRepo(webhookCtrl))
 			r.Patch("/", handlerwebhook.HandleUpdateRepo(webhookCtrl))
 			r.Delete("/", handlerwebhook.HandleDeleteRepo(webhookCtrl))
+			r.Post("/test", handlerwebhook.HandleTestDeliveryRepo(webhookCtrl))
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsRepo(webhookCtrl))
+				r.Post("/redeliver", handlerwebhook.HandleRedeliverExecutionsRepo(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionRepo(webhookCtrl))
@@ -777,6 +852,29 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Get("/digest", handleruser.HandleFindDigest(userCtrl))
+		r.Patch("/digest", handleruser.HandleUpdateDigest(userCtrl))
+
+		r.Route("/out-of-office", func(r chi.Router) {
+			r.Get("/", handleruser.HandleFindOutOfOffice(userCtrl))
+			r.Put("/", handleruser.HandleSetOutOfOffice(userCtrl))
+			r.Delete("/", handleruser.HandleDeleteOutOfOffice(userCtrl))
+		})
+
+		r.Route("/notification-preferences", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListNotificationPreferences(userCtrl))
+			r.Put("/", handleruser.HandleUpsertNotificationPreference(userCtrl))
+			r.Delete(fmt.Sprintf("/{%s}", request.PathParamNotificationPreferenceID),
+				handleruser.HandleDeleteNotificationPreference(userCtrl))
+		})
+
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListNotifications(userCtrl))
+			r.Get("/unread-count", handleruser.HandleNotificationUnreadCount(userCtrl))
+			r.Post("/mark-all-read", handleruser.HandleMarkAllNotificationsRead(userCtrl))
+			r.Patch(fmt.Sprintf("/{%s}", request.PathParamNotificationID),
+				handleruser.HandleUpdateNotification(userCtrl))
+		})
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -885,9 +983,10 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, auditLogCtrl *auditlog.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Get("/audit-logs", handlerauditlog.HandleList(auditLogCtrl))
 		r.Route("/users", func(r chi.Router) {
 			r.Get("/", users.HandleList(userCtrl))
 			r.Post("/", users.HandleCreate(userCtrl))
@@ -18,6 +18,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
@@ -112,6 +113,7 @@ func ProvideRouter(
 	registryRouter router.AppRouter,
 	usageSender usage.Sender,
 	lfsCtrl *lfs.Controller,
+	auditLogCtrl *auditlog.Controller,
 ) *Router {
 	routers := make([]Interface, 4)
 
@@ -132,7 +134,7 @@ func ProvideRouter(
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
 		githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		infraProviderCtrl, migrateCtrl, gitspaceCtrl, usageSender)
+		infraProviderCtrl, migrateCtrl, gitspaceCtrl, usageSender, auditLogCtrl)
 	routers[2] = NewAPIRouter(apiHandler)
 
 	sec := NewSecure(config)
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+var _ audit.Service = (*Service)(nil)
+
+// Service is an audit.Service that persists all audit events in the database.
+type Service struct {
+	auditLogStore store.AuditLogStore
+}
+
+func NewService(auditLogStore store.AuditLogStore) *Service {
+	return &Service{
+		auditLogStore: auditLogStore,
+	}
+}
+
+// Log stores the audit event in the database.
+// Request related information that isn't provided via options is taken from the context.
+func (s *Service) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	spacePath string,
+	options ...audit.Option,
+) error {
+	event := audit.Event{
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      audit.GetRealIP(ctx),
+		RequestMethod: audit.GetRequestMethod(ctx),
+	}
+
+	for _, opt := range options {
+		opt.Apply(&event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal old object: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal new object: %w", err)
+	}
+
+	auditLog := &types.AuditLog{
+		Created:            event.Timestamp,
+		Action:             string(event.Action),
+		Principal:          *event.User.ToPrincipalInfo(),
+		SpacePath:          event.SpacePath,
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		ResourceData:       event.Resource.Data,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		RequestID:          audit.GetRequestID(ctx),
+		RequestPath:        audit.GetPath(ctx),
+		Data:               event.Data,
+		OldObject:          oldObject,
+		NewObject:          newObject,
+	}
+
+	err = s.auditLogStore.Create(ctx, auditLog)
+	if err != nil {
+		return fmt.Errorf("failed to store audit log: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the audit logs matching the filter together with the total count.
+func (s *Service) List(
+	ctx context.Context,
+	filter *types.AuditLogFilter,
+) ([]*types.AuditLog, int64, error) {
+	count, err := s.auditLogStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	list, err := s.auditLogStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return list, count, nil
+}
+
+func marshalObject(obj any) (json.RawMessage, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(obj)
+}
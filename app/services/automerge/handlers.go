@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// mergeOnReviewSubmitted attempts the auto-merge because the review might be the missing approval.
+func (s *Service) mergeOnReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	return s.pullreqCtrl.AutoMergeAttempt(ctx, event.Payload.TargetRepoID, event.Payload.Number)
+}
+
+// mergeOnMergeCheckCompleted attempts the auto-merge because the pull request might have become mergeable.
+func (s *Service) mergeOnMergeCheckCompleted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeCheckCompletedPayload],
+) error {
+	if !event.Payload.Mergeable {
+		return nil
+	}
+
+	return s.pullreqCtrl.AutoMergeAttempt(ctx, event.Payload.TargetRepoID, event.Payload.Number)
+}
+
+// cancelOnBranchUpdated disarms the auto-merge if it's configured to be canceled on new commits.
+func (s *Service) cancelOnBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.pullreqCtrl.AutoMergeCancelOnPush(ctx,
+		event.Payload.TargetRepoID, event.Payload.Number, event.Payload.PrincipalID)
+}
+
+// cancelOnClosed disarms the auto-merge of closed pull requests.
+func (s *Service) cancelOnClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.pullreqCtrl.AutoMergeCancelOnClose(ctx,
+		event.Payload.TargetRepoID, event.Payload.Number, event.Payload.PrincipalID)
+}
+
+// mergeOnCheckReported attempts the auto-merge of all pull requests with the commit as the latest commit
+// because the check result might have been required.
+func (s *Service) mergeOnCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if event.Payload.Status == enum.CheckStatusPending || event.Payload.Status == enum.CheckStatusRunning {
+		return nil
+	}
+
+	return s.mergeBySourceSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+// mergeOnPipelineExecuted attempts the auto-merge of all pull requests with the commit as the latest commit
+// because the pipeline check result might have been required.
+func (s *Service) mergeOnPipelineExecuted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload],
+) error {
+	if event.Payload.CommitSHA == "" {
+		return nil
+	}
+
+	return s.mergeBySourceSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+func (s *Service) mergeBySourceSHA(ctx context.Context, repoID int64, sourceSHA string) error {
+	numbers, err := s.autoMergeStore.ListPullReqNumbersBySourceSHA(ctx, repoID, sourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto-merge: %w", err)
+	}
+
+	for _, number := range numbers {
+		if err = s.pullreqCtrl.AutoMergeAttempt(ctx, repoID, number); err != nil {
+			return fmt.Errorf("failed to attempt auto-merge of pull request #%d: %w", number, err)
+		}
+	}
+
+	return nil
+}
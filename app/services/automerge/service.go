@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automerge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	checkevents "github.com/harness/gitness/app/events/check"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+const groupAutoMerge = "gitness:pullreq:automerge"
+
+// Service merges pull requests with armed auto-merge when an event indicates
+// that the pull request might have become mergeable.
+type Service struct {
+	pullreqCtrl    *pullreq.Controller
+	autoMergeStore store.PullReqAutoMergeStore
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	pullreqCtrl *pullreq.Controller,
+	autoMergeStore store.PullReqAutoMergeStore,
+) (*Service, error) {
+	service := &Service{
+		pullreqCtrl:    pullreqCtrl,
+		autoMergeStore: autoMergeStore,
+	}
+
+	const idleTimeout = 3 * time.Minute // the merge can take a while
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupAutoMerge, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReviewSubmitted(service.mergeOnReviewSubmitted)
+			_ = r.RegisterMergeCheckCompleted(service.mergeOnMergeCheckCompleted)
+			_ = r.RegisterBranchUpdated(service.cancelOnBranchUpdated)
+			_ = r.RegisterClosed(service.cancelOnClosed)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader: %w", err)
+	}
+
+	_, err = checkEvReaderFactory.Launch(ctx, groupAutoMerge, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.mergeOnCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader: %w", err)
+	}
+
+	_, err = pipelineEvReaderFactory.Launch(ctx, groupAutoMerge, config.InstanceID,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterExecuted(service.mergeOnPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader: %w", err)
+	}
+
+	return service, nil
+}
@@ -28,6 +28,7 @@ import (
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	AuditLogsRetentionTime           time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +42,10 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.AuditLogsRetentionTime <= 0 {
+		return errors.New("config.AuditLogsRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +58,7 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	auditLogStore         store.AuditLogStore
 }
 
 func NewService(
@@ -63,6 +69,7 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	auditLogStore store.AuditLogStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +84,7 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		auditLogStore:         auditLogStore,
 	}, nil
 }
 
@@ -126,6 +134,17 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeAuditLogs,
+		jobTypeAuditLogs,
+		jobCronAuditLogs,
+		jobMaxDurationAuditLogs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule audit logs cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +179,15 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeAuditLogs,
+		newAuditLogsCleanupJob(
+			s.config.AuditLogsRetentionTime,
+			s.auditLogStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for audit logs cleanup: %w", err)
+	}
 	return nil
 }
@@ -19,7 +19,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/app/services/usergroup"
@@ -59,6 +61,11 @@ var (
 			"['*', '?', '[', ']', '{', '}', '-', '!', '^']",
 	)
 	ErrFileParseTrailingBackslashInPattern = errors.New("a pattern can't end with a trailing '\\'")
+	ErrFileParseInvalidSectionHeader       = errors.New(
+		"a section header must be in the format '[Section Name][N] owners...' " +
+			"where the approval count N and the default owners are optional, " +
+			"and it can be prefixed with '^' to mark the section as optional",
+	)
 )
 
 // TooLargeError represents an error if codeowners file is too large.
@@ -125,10 +132,25 @@ type CodeOwners struct {
 	Entries []Entry
 }
 
+// Section is a group of code owners entries with its own approval requirements.
+// A section starts with a header line in the format `[Section Name][N] owners...`,
+// entries before the first section header belong to the default section.
+type Section struct {
+	// Name is the name of the section. Headers with the same name (case-insensitive) declare the same section.
+	Name string
+	// Optional sections (header prefixed with '^') don't require any approvals.
+	Optional bool
+	// RequiredApprovals is the number of approvals required for every matching entry of the section.
+	RequiredApprovals int
+}
+
 type Entry struct {
 	// LineNumber is the line number of the code owners entry.
 	LineNumber int64
 
+	// Section is the section the entry belongs to, nil for the entries of the default section.
+	Section *Section
+
 	// Pattern is a glob star pattern used to match the entry against a given file path.
 	Pattern string
 	// Owners is the list of owners for the given pattern.
@@ -142,8 +164,17 @@ func (e *Entry) IsOwnershipReset() bool {
 }
 
 type Evaluation struct {
+	EvaluationSections []EvaluationSection
+	FileSha            string
+}
+
+// EvaluationSection holds the evaluation of the matching entries of a code owners section.
+type EvaluationSection struct {
+	// Name is the name of the section, empty for the default section.
+	Name              string
+	Optional          bool
+	RequiredApprovals int
 	EvaluationEntries []EvaluationEntry
-	FileSha           string
 }
 
 type EvaluationEntry struct {
@@ -206,9 +237,17 @@ func (s *Service) get(
 	}, nil
 }
 
+//nolint:gocognit,gocyclo,cyclop // refactor if needed
 func (s *Service) parseCodeOwner(codeOwnersContent string) ([]Entry, error) {
 	var lineNumber int64
 	var codeOwners []Entry
+
+	var section *Section
+	var sectionDefaultOwners []string
+	sections := map[string]*Section{}
+
+	isSeparator := func(r rune) bool { return r == ' ' || r == '\t' }
+
 	scanner := bufio.NewScanner(strings.NewReader(codeOwnersContent))
 	for scanner.Scan() {
 		lineNumber++
@@ -219,7 +258,30 @@ func (s *Service) parseCodeOwner(codeOwnersContent string) ([]Entry, error) {
 			continue
 		}
 
-		isSeparator := func(r rune) bool { return r == ' ' || r == '\t' }
+		// unescaped '[' at the beginning of a line starts a section header,
+		// unless it's the character class of a pattern, e.g. `[Dd]ocs/ owner`.
+		if isSectionHeader(line) {
+			header, err := parseSectionHeader(line)
+			if err != nil {
+				return nil, &FileParseError{
+					LineNumber: lineNumber,
+					Line:       originalLine,
+					Err:        err,
+				}
+			}
+
+			key := strings.ToLower(header.Name)
+			if existing, ok := sections[key]; ok {
+				section = existing
+			} else {
+				section = &header.Section
+				sections[key] = section
+			}
+
+			sectionDefaultOwners = header.DefaultOwners
+
+			continue
+		}
 		lineAsRunes := []rune(line)
 		pattern := strings.Builder{}
 
@@ -267,11 +329,17 @@ func (s *Service) parseCodeOwner(codeOwnersContent string) ([]Entry, error) {
 			lineAsRunes = lineAsRunes[:i]
 		}
 
+		// could be empty list in case of removing ownership
+		owners := strings.FieldsFunc(string(lineAsRunes), isSeparator)
+		if len(owners) == 0 && len(sectionDefaultOwners) > 0 {
+			owners = slices.Clone(sectionDefaultOwners)
+		}
+
 		codeOwners = append(codeOwners, Entry{
 			LineNumber: lineNumber,
+			Section:    section,
 			Pattern:    pattern.String(),
-			// could be empty list in case of removing ownership
-			Owners: strings.FieldsFunc(string(lineAsRunes), isSeparator),
+			Owners:     owners,
 		})
 	}
 	if err := scanner.Err(); err != nil {
@@ -281,6 +349,65 @@ func (s *Service) parseCodeOwner(codeOwnersContent string) ([]Entry, error) {
 	return codeOwners, nil
 }
 
+type sectionHeader struct {
+	Section
+	DefaultOwners []string
+}
+
+// sectionHeaderRegex matches section header lines in the format `^[Section Name][N] owners... # comment`,
+// where the optional marker '^', the approval count N, the default owners and the comment are optional.
+var sectionHeaderRegex = regexp.MustCompile(`^(\^)?\[([^\]]+)\](?:\[([0-9]+)\])?(?:[ \t]+([^#]*))?(?:#.*)?$`)
+
+// isSectionHeader returns true if the line is meant to be a section header and not a pattern starting
+// with a character class, i.e. the leading bracket isn't closed or is followed by a separator, a comment,
+// the approval count or nothing at all.
+func isSectionHeader(line string) bool {
+	line = strings.TrimPrefix(line, "^")
+	if !strings.HasPrefix(line, "[") {
+		return false
+	}
+
+	_, rest, ok := strings.Cut(line, "]")
+	if !ok || rest == "" {
+		return true
+	}
+
+	return strings.ContainsRune(" \t#[", rune(rest[0]))
+}
+
+// parseSectionHeader parses a section header line in the format `^[Section Name][N] owners...`.
+func parseSectionHeader(line string) (sectionHeader, error) {
+	matches := sectionHeaderRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return sectionHeader{}, ErrFileParseInvalidSectionHeader
+	}
+
+	name := strings.TrimSpace(matches[2])
+	if name == "" {
+		return sectionHeader{}, ErrFileParseInvalidSectionHeader
+	}
+
+	header := sectionHeader{
+		Section: Section{
+			Name:              name,
+			Optional:          matches[1] != "",
+			RequiredApprovals: 1,
+		},
+		DefaultOwners: strings.Fields(matches[4]),
+	}
+
+	if matches[3] != "" {
+		n, err := strconv.Atoi(matches[3])
+		if err != nil || n < 1 {
+			return sectionHeader{}, ErrFileParseInvalidSectionHeader
+		}
+
+		header.RequiredApprovals = n
+	}
+
+	return header, nil
+}
+
 func (s *Service) getCodeOwnerFile(
 	ctx context.Context,
 	repo *types.RepositoryCore,
@@ -379,14 +506,19 @@ func (s *Service) GetApplicableCodeOwners(
 
 	entryIDs := map[int]struct{}{}
 	for _, file := range diffFileStats.Files {
-		// last rule that matches wins (hence simply go in reverse order)
+		// last rule that matches wins (hence simply go in reverse order), sections are matched independently.
+		matchedSections := map[*Section]struct{}{}
 		for i := len(codeOwners.Entries) - 1; i >= 0; i-- {
+			if _, ok := matchedSections[codeOwners.Entries[i].Section]; ok {
+				continue
+			}
+
 			pattern := codeOwners.Entries[i].Pattern
 			if ok, err := match(pattern, file); err != nil {
 				return nil, fmt.Errorf("failed to match pattern %q for file %q: %w", pattern, file, err)
 			} else if ok {
 				entryIDs[i] = struct{}{}
-				break
+				matchedSections[codeOwners.Entries[i].Section] = struct{}{}
 			}
 		}
 	}
@@ -428,7 +560,8 @@ func (s *Service) Evaluate(
 		return &Evaluation{}, nil
 	}
 
-	evaluationEntries := make([]EvaluationEntry, 0, len(owners.Entries))
+	evaluationSections := make([]EvaluationSection, 0)
+	sectionIdx := map[*Section]int{}
 
 	for _, entry := range owners.Entries {
 		ownerEvaluations := make([]OwnerEvaluation, 0, len(owners.Entries))
@@ -462,22 +595,44 @@ func (s *Service) Evaluate(
 
 			ownerEvaluations = append(ownerEvaluations, *userCodeOwner)
 		}
-		if len(ownerEvaluations) != 0 || len(userGroupOwnerEvaluations) != 0 {
-			evaluationEntries = append(evaluationEntries, EvaluationEntry{
+		if len(ownerEvaluations) == 0 && len(userGroupOwnerEvaluations) == 0 {
+			continue
+		}
+
+		idx, ok := sectionIdx[entry.Section]
+		if !ok {
+			idx = len(evaluationSections)
+			sectionIdx[entry.Section] = idx
+			evaluationSections = append(evaluationSections, newEvaluationSection(entry.Section))
+		}
+
+		evaluationSections[idx].EvaluationEntries = append(evaluationSections[idx].EvaluationEntries,
+			EvaluationEntry{
 				LineNumber:                entry.LineNumber,
 				Pattern:                   entry.Pattern,
 				OwnerEvaluations:          ownerEvaluations,
 				UserGroupOwnerEvaluations: userGroupOwnerEvaluations,
 			})
-		}
 	}
 
 	return &Evaluation{
-		EvaluationEntries: evaluationEntries,
-		FileSha:           owners.FileSHA,
+		EvaluationSections: evaluationSections,
+		FileSha:            owners.FileSHA,
 	}, nil
 }
 
+func newEvaluationSection(section *Section) EvaluationSection {
+	if section == nil {
+		return EvaluationSection{RequiredApprovals: 1}
+	}
+
+	return EvaluationSection{
+		Name:              section.Name,
+		Optional:          section.Optional,
+		RequiredApprovals: section.RequiredApprovals,
+	}
+}
+
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
 	owner string,
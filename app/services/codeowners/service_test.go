@@ -15,7 +15,9 @@
 package codeowners
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/harness/gitness/app/store"
@@ -268,6 +270,70 @@ g   user1@harness.io	#  user2@harness.io
 	}
 }
 
+func TestService_ParseCodeOwnerSections(t *testing.T) {
+	content := `
+*.md user1@harness.io
+[Backend][2] user2@harness.io user3@harness.io
+/app/
+/app/docs/ user4@harness.io
+^[Docs] user4@harness.io # optional section
+docs/
+[backend]
+/cmd/ user2@harness.io
+`
+	backend := &Section{Name: "Backend", RequiredApprovals: 2}
+	docs := &Section{Name: "Docs", Optional: true, RequiredApprovals: 1}
+
+	want := []Entry{
+		{LineNumber: 2, Pattern: "*.md", Owners: []string{"user1@harness.io"}},
+		{LineNumber: 4, Section: backend, Pattern: "/app/", Owners: []string{"user2@harness.io", "user3@harness.io"}},
+		{LineNumber: 5, Section: backend, Pattern: "/app/docs/", Owners: []string{"user4@harness.io"}},
+		{LineNumber: 7, Section: docs, Pattern: "docs/", Owners: []string{"user4@harness.io"}},
+		{LineNumber: 9, Section: backend, Pattern: "/cmd/", Owners: []string{"user2@harness.io"}},
+	}
+
+	s := &Service{}
+
+	got, err := s.parseCodeOwner(content)
+	if err != nil {
+		t.Fatalf("ParseCodeOwner() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseCodeOwner() got = %v, want %v", got, want)
+	}
+	if got[1].Section != got[4].Section {
+		t.Errorf("ParseCodeOwner() expected headers with the same name to declare the same section")
+	}
+
+	for _, invalid := range []string{"[]", "[Backend", "^[Backend", "[Backend][0]", "[Backend][x]", "[Sec][x] @team"} {
+		if _, err := s.parseCodeOwner(invalid); !errors.Is(err, ErrFileParseInvalidSectionHeader) {
+			t.Errorf("ParseCodeOwner(%q) expected invalid section header error, got %v", invalid, err)
+		}
+	}
+
+	// lines starting with a character class are patterns
+	for _, line := range []string{"[Dd]ocs/ @team", "[Backend]x", "[a-z]*.go @team"} {
+		got, err := s.parseCodeOwner(line)
+		if err != nil {
+			t.Errorf("ParseCodeOwner(%q) error = %v", line, err)
+			continue
+		}
+		if len(got) != 1 || got[0].Section != nil || got[0].Pattern != strings.Fields(line)[0] {
+			t.Errorf("ParseCodeOwner(%q) expected a single pattern, got %v", line, got)
+		}
+	}
+
+	got, err = s.parseCodeOwner("[Dd]ocs/ @team")
+	if err != nil {
+		t.Fatalf("ParseCodeOwner() error = %v", err)
+	}
+	for _, path := range []string{"docs/readme.md", "Docs/readme.md"} {
+		if ok, err := match(got[0].Pattern, path); err != nil || !ok {
+			t.Errorf("ParseCodeOwner() expected pattern %q to match %q", got[0].Pattern, path)
+		}
+	}
+}
+
 func Test_match(t *testing.T) {
 	type args struct {
 		pattern            string
@@ -0,0 +1,335 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"path"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+)
+
+const trigramLength = 3
+
+// localIndex is the on-disk representation of the keyword search index of a single repository.
+// It holds the content of all indexed files of the default branch and a trigram posting list
+// that is used to reduce the number of files that have to be scanned for a query.
+type localIndex struct {
+	RepoID    int64
+	Branch    string
+	CommitSHA string
+	Files     []indexedFile
+
+	// Trigrams maps every (lower-cased) trigram to the sorted list of indexes of files containing it.
+	Trigrams map[string][]int
+
+	// contentSize is the total size of the content of all indexed files. It's calculated lazily after loading.
+	contentSize int
+}
+
+type indexedFile struct {
+	Path    string
+	Content string
+}
+
+func newLocalIndex(repoID int64, branch string, commitSHA string) *localIndex {
+	return &localIndex{
+		RepoID:    repoID,
+		Branch:    branch,
+		CommitSHA: commitSHA,
+		Trigrams:  map[string][]int{},
+	}
+}
+
+// add adds the file to the index and updates the trigram posting lists.
+// It returns false without adding the file if the index would exceed the max index size.
+func (idx *localIndex) add(filePath string, content string) bool {
+	if idx.size()+len(content) > maxIndexSize {
+		return false
+	}
+
+	idx.contentSize += len(content)
+
+	fileIdx := len(idx.Files)
+	idx.Files = append(idx.Files, indexedFile{
+		Path:    filePath,
+		Content: content,
+	})
+
+	for trigram := range extractTrigrams(strings.ToLower(content)) {
+		idx.Trigrams[trigram] = append(idx.Trigrams[trigram], fileIdx)
+	}
+
+	return true
+}
+
+// size returns the total size of the content of all indexed files.
+func (idx *localIndex) size() int {
+	if idx.contentSize == 0 {
+		for _, file := range idx.Files {
+			idx.contentSize += len(file.Content)
+		}
+	}
+
+	return idx.contentSize
+}
+
+// candidates returns the indexes of all files that contain all provided trigrams.
+// If no trigrams are provided, all files are considered candidates.
+func (idx *localIndex) candidates(trigrams map[string]struct{}) []int {
+	if len(trigrams) == 0 {
+		all := make([]int, len(idx.Files))
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	}
+
+	var result []int
+	first := true
+	for trigram := range trigrams {
+		postings, ok := idx.Trigrams[trigram]
+		if !ok {
+			return nil
+		}
+
+		if first {
+			result = append(result, postings...)
+			first = false
+			continue
+		}
+
+		result = intersectSorted(result, postings)
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// search scans all candidate files of the index using the provided query
+// and appends the matches to the result until the limit of matched lines is reached.
+// It returns false in case the limit was reached.
+func (idx *localIndex) search(q *query, result *types.SearchResult, limit int) bool {
+	for _, fileIdx := range idx.candidates(q.trigrams) {
+		file := idx.Files[fileIdx]
+
+		matches := matchLines(q.re, file.Content, limit-result.Stats.TotalMatches)
+		if len(matches) == 0 {
+			continue
+		}
+
+		result.FileMatches = append(result.FileMatches, types.FileMatch{
+			FileName:   file.Path,
+			RepoID:     idx.RepoID,
+			RepoBranch: idx.Branch,
+			Language:   languageFromPath(file.Path),
+			Matches:    matches,
+		})
+		result.Stats.TotalFiles++
+		result.Stats.TotalMatches += len(matches)
+
+		if result.Stats.TotalMatches >= limit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// query is a compiled keyword search query.
+type query struct {
+	re       *regexp.Regexp
+	trigrams map[string]struct{}
+}
+
+// compileQuery compiles the user provided query into a regular expression
+// and extracts the trigrams any matching file is required to contain.
+func compileQuery(q string, enableRegex bool, caseSensitive bool) (*query, error) {
+	pattern := q
+	if !enableRegex {
+		pattern = regexp.QuoteMeta(q)
+	}
+	if !caseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	trigrams := map[string]struct{}{}
+	for _, literal := range requiredLiterals(pattern) {
+		for trigram := range extractTrigrams(strings.ToLower(literal)) {
+			trigrams[trigram] = struct{}{}
+		}
+	}
+
+	return &query{
+		re:       re,
+		trigrams: trigrams,
+	}, nil
+}
+
+// requiredLiterals returns literals that have to be part of any string matching the regular expression.
+// The analysis is intentionally conservative and only considers literals of a top level concatenation.
+func requiredLiterals(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	re = re.Simplify()
+
+	switch re.Op { //nolint:exhaustive // all other operations don't guarantee any literal.
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				literals = append(literals, string(sub.Rune))
+			}
+		}
+		return literals
+	default:
+		return nil
+	}
+}
+
+// matchLines returns up to limit lines of the content that match the regular expression.
+func matchLines(re *regexp.Regexp, content string, limit int) []types.Match {
+	if limit <= 0 {
+		return nil
+	}
+
+	lines := strings.Split(content, "\n")
+
+	var matches []types.Match
+	for i, line := range lines {
+		locs := re.FindAllStringIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		fragments := make([]types.Fragment, 0, len(locs))
+		for _, loc := range locs {
+			// skip empty matches, they don't carry any information for the user.
+			if loc[0] == loc[1] {
+				continue
+			}
+			fragments = append(fragments, types.Fragment{
+				Pre:   line[:loc[0]],
+				Match: line[loc[0]:loc[1]],
+				Post:  line[loc[1]:],
+			})
+		}
+		if len(fragments) == 0 {
+			continue
+		}
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+		}
+		if i > 0 {
+			match.Before = lines[i-1]
+		}
+		if i < len(lines)-1 {
+			match.After = lines[i+1]
+		}
+
+		matches = append(matches, match)
+		if len(matches) >= limit {
+			break
+		}
+	}
+
+	return matches
+}
+
+func extractTrigrams(s string) map[string]struct{} {
+	trigrams := map[string]struct{}{}
+	for i := 0; i+trigramLength <= len(s); i++ {
+		trigrams[s[i:i+trigramLength]] = struct{}{}
+	}
+	return trigrams
+}
+
+func intersectSorted(a, b []int) []int {
+	result := a[:0]
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+var extensionLanguages = map[string]string{
+	".c":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".css":   "CSS",
+	".go":    "Go",
+	".h":     "C",
+	".hpp":   "C++",
+	".html":  "HTML",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".json":  "JSON",
+	".jsx":   "JavaScript",
+	".kt":    "Kotlin",
+	".md":    "Markdown",
+	".php":   "PHP",
+	".py":    "Python",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".scss":  "SCSS",
+	".sh":    "Shell",
+	".sql":   "SQL",
+	".swift": "Swift",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".xml":   "XML",
+	".yaml":  "YAML",
+	".yml":   "YAML",
+}
+
+func languageFromPath(filePath string) string {
+	return extensionLanguages[strings.ToLower(path.Ext(filePath))]
+}
+
+// sortedRepoIDs returns a sorted copy of the provided repo IDs to guarantee a stable result order.
+func sortedRepoIDs(repoIDs []int64) []int64 {
+	sorted := make([]int64, len(repoIDs))
+	copy(sorted, repoIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"container/list"
+)
+
+// localIndexCache is a least recently used cache of loaded indexes.
+// It's limited by the total size of the content of the cached indexes. It's not safe for concurrent use.
+type localIndexCache struct {
+	maxSize int
+	size    int
+	lru     *list.List
+	entries map[int64]*list.Element
+}
+
+func newLocalIndexCache(maxSize int) *localIndexCache {
+	return &localIndexCache{
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: map[int64]*list.Element{},
+	}
+}
+
+// get returns the cached index of the repository and marks it as the most recently used one.
+func (c *localIndexCache) get(repoID int64) (*localIndex, bool) {
+	elem, ok := c.entries[repoID]
+	if !ok {
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+
+	return elem.Value.(*localIndex), true //nolint:errcheck // the cache only holds indexes.
+}
+
+// put adds the index to the cache, replacing the existing index of the same repository,
+// and evicts the least recently used indexes in case the cache exceeds its max size.
+// Indexes larger than the max size of the cache are not cached.
+func (c *localIndexCache) put(idx *localIndex) {
+	c.remove(idx.RepoID)
+
+	size := idx.size()
+	if size > c.maxSize {
+		return
+	}
+
+	c.entries[idx.RepoID] = c.lru.PushFront(idx)
+	c.size += size
+
+	for c.size > c.maxSize {
+		c.remove(c.lru.Back().Value.(*localIndex).RepoID) //nolint:errcheck // the cache only holds indexes.
+	}
+}
+
+// remove removes the index of the repository from the cache.
+func (c *localIndexCache) remove(repoID int64) {
+	elem, ok := c.entries[repoID]
+	if !ok {
+		return
+	}
+
+	c.lru.Remove(elem)
+	delete(c.entries, repoID)
+	c.size -= elem.Value.(*localIndex).size() //nolint:errcheck // the cache only holds indexes.
+}
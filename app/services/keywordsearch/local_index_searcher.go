@@ -15,28 +15,328 @@
 package keywordsearch
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
 
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// errIndexFull is returned while indexing a repository that exceeds the max index size.
+var errIndexFull = errors.New("index is full")
+
+const (
+	// maxIndexedFileSize is the maximum size of a file that is added to the index.
+	maxIndexedFileSize = 1 << 20 // 1 MiB
+
+	// maxIndexSize is the maximum total size of the files of a single repository that are added to the index.
+	// Files of larger repositories are not searchable.
+	maxIndexSize = 256 << 20 // 256 MiB
+
+	// maxCacheSize is the maximum total size of the indexes kept in memory.
+	maxCacheSize = 1 << 30 // 1 GiB
+
+	// defaultMaxResultCount is used in case the caller doesn't limit the number of results.
+	defaultMaxResultCount = 100
+
+	indexFileExtension = ".idx"
 )
 
+// LocalIndexSearcher maintains an on-disk trigram index per repository
+// which contains the content of the default branch of the repository.
 type LocalIndexSearcher struct {
+	indexDir string
+	git      git.Interface
+
+	// mx protects the cache, repoLocks is used to serialize indexing of a single repository.
+	// NOTE: The locks are never removed, as a lock removed while another goroutine waits for it
+	// would allow a third goroutine to create a new lock for the same repository.
+	mx        sync.Mutex
+	cache     *localIndexCache
+	repoLocks sync.Map
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(indexDir string, git git.Interface) *LocalIndexSearcher {
+	return &LocalIndexSearcher{
+		indexDir: indexDir,
+		git:      git,
+		cache:    newLocalIndexCache(maxCacheSize),
+	}
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	caseSensitive bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	q, err := compileQuery(query, enableRegex, caseSensitive)
+	if err != nil {
+		return types.SearchResult{}, usererror.BadRequestf("Invalid search query: %s", err)
+	}
+
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range sortedRepoIDs(repoIDs) {
+		if err := ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		idx, err := s.load(repoID)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to load index of repo %d: %w", repoID, err)
+		}
+		if idx == nil {
+			// repository isn't indexed (yet)
+			continue
+		}
+
+		if !idx.search(q, &result, maxResultCount) {
+			break
+		}
+	}
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
+// Index (re)builds the index of the default branch of the repository.
+// The index is only rebuilt in case the default branch points to a different commit than the existing index.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	lock := s.repoLock(repo.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	readParams := git.CreateReadParams(repo)
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the default branch doesn't exist (e.g. empty repository) - there's nothing to search in.
+		return s.remove(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch %q: %w", repo.DefaultBranch, err)
+	}
+
+	commitSHA := branchOut.Branch.SHA.String()
+
+	existing, err := s.load(repo.ID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to load existing index of repo %d, rebuilding it", repo.ID)
+	}
+	if existing != nil && existing.Branch == repo.DefaultBranch && existing.CommitSHA == commitSHA {
+		return nil
+	}
+
+	idx := newLocalIndex(repo.ID, repo.DefaultBranch, commitSHA)
+	err = s.indexTree(ctx, readParams, commitSHA, "", idx)
+	if err != nil && !errors.Is(err, errIndexFull) {
+		return fmt.Errorf("failed to index tree of commit %s: %w", commitSHA, err)
+	}
+
+	err = s.store(idx)
+	if err != nil {
+		return fmt.Errorf("failed to store index: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().Msgf("indexed %d files of repo %d on commit %s", len(idx.Files), repo.ID, commitSHA)
+
 	return nil
 }
+
+// Delete removes the index of the repository.
+func (s *LocalIndexSearcher) Delete(_ context.Context, repoID int64) error {
+	lock := s.repoLock(repoID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return s.remove(repoID)
+}
+
+// repoLock returns the lock used to serialize the changes of the index of the repository.
+func (s *LocalIndexSearcher) repoLock(repoID int64) *sync.Mutex {
+	lock, _ := s.repoLocks.LoadOrStore(repoID, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// indexTree recursively walks the git tree and adds all text files to the index.
+func (s *LocalIndexSearcher) indexTree(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHA string,
+	dirPath string,
+	idx *localIndex,
+) error {
+	out, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+		Path:       dirPath,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tree nodes of %q: %w", dirPath, err)
+	}
+
+	for _, node := range out.Nodes {
+		switch {
+		case node.Type == git.TreeNodeTypeTree:
+			err = s.indexTree(ctx, readParams, commitSHA, node.Path, idx)
+			if err != nil {
+				return err
+			}
+		case node.Type == git.TreeNodeTypeBlob && node.Mode != git.TreeNodeModeSymlink:
+			content, ok, err := s.readTextBlob(ctx, readParams, node.SHA)
+			if err != nil {
+				return fmt.Errorf("failed to read file %q: %w", node.Path, err)
+			}
+			if ok && !idx.add(node.Path, content) {
+				log.Ctx(ctx).Warn().Msgf("repo %d exceeds the max index size, file %q and all following aren't indexed",
+					idx.RepoID, node.Path)
+				return errIndexFull
+			}
+		}
+	}
+
+	return nil
+}
+
+// readTextBlob returns the content of the blob in case it's a text file not exceeding the max indexed file size.
+func (s *LocalIndexSearcher) readTextBlob(
+	ctx context.Context,
+	readParams git.ReadParams,
+	blobSHA string,
+) (string, bool, error) {
+	out, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  maxIndexedFileSize,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	defer out.Content.Close()
+
+	if out.Size > maxIndexedFileSize {
+		return "", false, nil
+	}
+
+	content, err := io.ReadAll(out.Content)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	// skip binary files
+	if bytes.IndexByte(content, 0) >= 0 {
+		return "", false, nil
+	}
+
+	return string(content), true, nil
+}
+
+// load returns the index of the repository, or nil if the repository isn't indexed.
+func (s *LocalIndexSearcher) load(repoID int64) (*localIndex, error) {
+	s.mx.Lock()
+	idx, ok := s.cache.get(repoID)
+	s.mx.Unlock()
+	if ok {
+		return idx, nil
+	}
+
+	f, err := os.Open(s.indexPath(repoID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	idx = &localIndex{}
+	err = gob.NewDecoder(f).Decode(idx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	s.mx.Lock()
+	s.cache.put(idx)
+	s.mx.Unlock()
+
+	return idx, nil
+}
+
+// store writes the index to disk and replaces the cached index of the repository.
+func (s *LocalIndexSearcher) store(idx *localIndex) error {
+	err := os.MkdirAll(s.indexDir, 0o700)
+	if err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	// write to a temporary file first to never expose partially written indexes.
+	f, err := os.CreateTemp(s.indexDir, strconv.FormatInt(idx.RepoID, 10)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	err = gob.NewEncoder(f).Encode(idx)
+	if err != nil {
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	err = os.Rename(f.Name(), s.indexPath(idx.RepoID))
+	if err != nil {
+		return fmt.Errorf("failed to move index file: %w", err)
+	}
+
+	s.mx.Lock()
+	s.cache.put(idx)
+	s.mx.Unlock()
+
+	return nil
+}
+
+// remove deletes the index of the repository.
+func (s *LocalIndexSearcher) remove(repoID int64) error {
+	s.mx.Lock()
+	s.cache.remove(repoID)
+	s.mx.Unlock()
+
+	err := os.Remove(s.indexPath(repoID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove index file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalIndexSearcher) indexPath(repoID int64) string {
+	return filepath.Join(s.indexDir, strconv.FormatInt(repoID, 10)+indexFileExtension)
+}
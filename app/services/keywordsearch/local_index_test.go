@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestLocalIndex_Search(t *testing.T) {
+	idx := newLocalIndex(1, "main", "abc")
+	idx.add("main.go", "package main\n\nfunc main() {\n\tprintln(\"Hello World\")\n}\n")
+	idx.add("README.md", "# Hello\nhello world from the readme\n")
+	idx.add("empty.txt", "")
+
+	tests := []struct {
+		name          string
+		query         string
+		regex         bool
+		caseSensitive bool
+		limit         int
+		wantFiles     []string
+		wantMatches   int
+	}{
+		{
+			name:        "case-insensitive",
+			query:       "hello world",
+			limit:       10,
+			wantFiles:   []string{"main.go", "README.md"},
+			wantMatches: 2,
+		},
+		{
+			name:          "case-sensitive",
+			query:         "Hello World",
+			caseSensitive: true,
+			limit:         10,
+			wantFiles:     []string{"main.go"},
+			wantMatches:   1,
+		},
+		{
+			name:        "short-query",
+			query:       "he",
+			limit:       10,
+			wantFiles:   []string{"main.go", "README.md"},
+			wantMatches: 3,
+		},
+		{
+			name:        "regex",
+			query:       "func [a-z]+\\(",
+			regex:       true,
+			limit:       10,
+			wantFiles:   []string{"main.go"},
+			wantMatches: 1,
+		},
+		{
+			name:        "literal-is-escaped",
+			query:       "main()",
+			limit:       10,
+			wantFiles:   []string{"main.go"},
+			wantMatches: 1,
+		},
+		{
+			name:        "no-match",
+			query:       "goodbye",
+			limit:       10,
+			wantFiles:   nil,
+			wantMatches: 0,
+		},
+		{
+			name:        "limit",
+			query:       "hello",
+			limit:       1,
+			wantFiles:   []string{"main.go"},
+			wantMatches: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := compileQuery(test.query, test.regex, test.caseSensitive)
+			if err != nil {
+				t.Fatalf("failed to compile query: %s", err)
+			}
+
+			result := types.SearchResult{}
+			idx.search(q, &result, test.limit)
+
+			if len(result.FileMatches) != len(test.wantFiles) {
+				t.Fatalf("want %d files, got %d: %+v", len(test.wantFiles), len(result.FileMatches), result.FileMatches)
+			}
+			for i, fileMatch := range result.FileMatches {
+				if fileMatch.FileName != test.wantFiles[i] {
+					t.Errorf("want file %q, got %q", test.wantFiles[i], fileMatch.FileName)
+				}
+				if fileMatch.RepoID != 1 || fileMatch.RepoBranch != "main" {
+					t.Errorf("unexpected repo info: %d %q", fileMatch.RepoID, fileMatch.RepoBranch)
+				}
+			}
+			if result.Stats.TotalMatches != test.wantMatches {
+				t.Errorf("want %d matches, got %d", test.wantMatches, result.Stats.TotalMatches)
+			}
+		})
+	}
+}
+
+func TestMatchLines(t *testing.T) {
+	q, err := compileQuery("world", false, false)
+	if err != nil {
+		t.Fatalf("failed to compile query: %s", err)
+	}
+
+	matches := matchLines(q.re, "first\nhello world, World\nlast", 10)
+	if len(matches) != 1 {
+		t.Fatalf("want 1 match, got %d", len(matches))
+	}
+
+	m := matches[0]
+	if m.LineNum != 2 || m.Before != "first" || m.After != "last" {
+		t.Errorf("unexpected match: %+v", m)
+	}
+	if len(m.Fragments) != 2 {
+		t.Fatalf("want 2 fragments, got %d", len(m.Fragments))
+	}
+	if f := m.Fragments[1]; f.Pre != "hello world, " || f.Match != "World" || f.Post != "" {
+		t.Errorf("unexpected fragment: %+v", f)
+	}
+}
+
+func TestLocalIndexCache(t *testing.T) {
+	newIndex := func(repoID int64, content string) *localIndex {
+		idx := newLocalIndex(repoID, "main", "abc")
+		idx.add("file.txt", content)
+		return idx
+	}
+
+	c := newLocalIndexCache(10)
+	c.put(newIndex(1, "1234"))
+	c.put(newIndex(2, "1234"))
+
+	// mark 1 as the most recently used index, so 2 gets evicted
+	if _, ok := c.get(1); !ok {
+		t.Fatal("want index 1 to be cached")
+	}
+	c.put(newIndex(3, "1234"))
+
+	if _, ok := c.get(2); ok {
+		t.Error("want index 2 to be evicted")
+	}
+	if _, ok := c.get(1); !ok {
+		t.Error("want index 1 to be cached")
+	}
+	if _, ok := c.get(3); !ok {
+		t.Error("want index 3 to be cached")
+	}
+	if c.size != 8 {
+		t.Errorf("want cache size 8, got %d", c.size)
+	}
+
+	// indexes larger than the cache aren't cached
+	c.put(newIndex(4, "12345678901"))
+	if _, ok := c.get(4); ok {
+		t.Error("want index 4 not to be cached")
+	}
+
+	c.remove(1)
+	if _, ok := c.get(1); ok {
+		t.Error("want index 1 to be removed")
+	}
+	if c.size != 4 {
+		t.Errorf("want cache size 4, got %d", c.size)
+	}
+}
+
+func TestLocalIndex_AddMaxSize(t *testing.T) {
+	idx := newLocalIndex(1, "main", "abc")
+
+	if !idx.add("small.txt", "hello") {
+		t.Fatal("want small file to be added")
+	}
+
+	// pretend the index is almost full
+	idx.contentSize = maxIndexSize - 1
+	if idx.add("large.txt", "ab") {
+		t.Error("want file exceeding the max index size not to be added")
+	}
+	if len(idx.Files) != 1 {
+		t.Errorf("want 1 indexed file, got %d", len(idx.Files))
+	}
+}
+
+func TestLocalIndexSearcher_Delete(t *testing.T) {
+	s := NewLocalIndexSearcher(t.TempDir(), nil)
+
+	idx := newLocalIndex(1, "main", "abc")
+	idx.add("file.txt", "hello")
+	if err := s.store(idx); err != nil {
+		t.Fatalf("failed to store index: %s", err)
+	}
+
+	if err := s.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("failed to delete index: %s", err)
+	}
+
+	loaded, err := s.load(1)
+	if err != nil {
+		t.Fatalf("failed to load index: %s", err)
+	}
+	if loaded != nil {
+		t.Error("want index to be deleted")
+	}
+}
+
+func TestLocalIndexSearcher_DeleteKeepsRepoLock(t *testing.T) {
+	s := NewLocalIndexSearcher(t.TempDir(), nil)
+
+	lock := s.repoLock(1)
+
+	if err := s.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("failed to delete index: %s", err)
+	}
+
+	// a concurrent indexing waiting for the lock must still exclude any later indexing of the repo.
+	if s.repoLock(1) != lock {
+		t.Error("want the lock of the repo to be kept after the index is deleted")
+	}
+}
@@ -51,6 +51,11 @@ func (c *Config) Prepare() error {
 	return nil
 }
 
+// IndexConfig is the configuration of the local keyword search index.
+type IndexConfig struct {
+	Dir string
+}
+
 // Service is responsible for indexing of repository for keyword search.
 type Service struct {
 	config    Config
@@ -106,6 +111,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterDeleted(service.handleRepoDeleted)
 			return nil
 		})
 	if err != nil {
@@ -40,3 +40,38 @@ func (l Locker) LockDefaultBranch(
 
 	return unlockFn, nil
 }
+
+func (l Locker) LockRepoMirror(
+	ctx context.Context,
+	repoID int64,
+	expiry time.Duration,
+) (func(), error) {
+	key := strconv.FormatInt(repoID, 10) + "/mirror"
+
+	log.Ctx(ctx).Debug().Msg("attempting to lock to synchronize the repo mirror")
+
+	unlockFn, err := l.lock(ctx, namespaceRepo, key, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock repo to synchronize the mirror: %w", err)
+	}
+
+	return unlockFn, nil
+}
+
+func (l Locker) LockRepoPushMirror(
+	ctx context.Context,
+	repoID int64,
+	pushMirrorID int64,
+	expiry time.Duration,
+) (func(), error) {
+	key := strconv.FormatInt(repoID, 10) + "/push-mirror/" + strconv.FormatInt(pushMirrorID, 10)
+
+	log.Ctx(ctx).Debug().Msg("attempting to lock to push to the repo push mirror")
+
+	unlockFn, err := l.lock(ctx, namespaceRepo, key, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock repo to push to the push mirror: %w", err)
+	}
+
+	return unlockFn, nil
+}
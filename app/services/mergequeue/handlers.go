@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git/api"
+)
+
+// processOnBranchUpdated processes the merge queue of the updated branch
+// because its speculative merge commits are no longer based on the branch head.
+func (s *Service) processOnBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch := strings.TrimPrefix(event.Payload.Ref, api.BranchPrefix)
+
+	entries, err := s.mergeQueueStore.ListByBranch(ctx, event.Payload.RepoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return s.pullreqCtrl.MergeQueueProcess(ctx, event.Payload.RepoID, branch)
+}
+
+// ejectOnPullReqBranchUpdated ejects the pull request from the merge queue because its source branch has changed.
+func (s *Service) ejectOnPullReqBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.pullreqCtrl.MergeQueueEjectOnBranchUpdate(ctx,
+		event.Payload.TargetRepoID, event.Payload.Number, event.Payload.PrincipalID)
+}
+
+// dequeueOnClosed removes the closed pull request from the merge queue.
+func (s *Service) dequeueOnClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.pullreqCtrl.MergeQueueDequeueOnClose(ctx,
+		event.Payload.TargetRepoID, event.Payload.Number, event.Payload.PrincipalID)
+}
+
+// processOnCheckReported processes the merge queues with the commit as a speculative merge commit
+// because the check result might have been required for the merge.
+func (s *Service) processOnCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	return s.pullreqCtrl.MergeQueueProcessByMergeSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+// processOnPipelineExecuted processes the merge queues with the commit as a speculative merge commit
+// because the pipeline check result might have been required for the merge.
+func (s *Service) processOnPipelineExecuted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload],
+) error {
+	if event.Payload.CommitSHA == "" {
+		return nil
+	}
+
+	return s.pullreqCtrl.MergeQueueProcessByMergeSHA(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// canViewRepo returns true if the principal is allowed to view the repository.
+// Notifications must only be sent to principals that have access to the repository.
+func canViewRepo(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	principal *types.Principal,
+	repo *types.RepositoryCore,
+) (bool, error) {
+	session := &auth.Session{Principal: *principal, Metadata: &auth.EmptyMetadata{}}
+
+	err := apiauth.CheckRepo(ctx, authorizer, session, repo, enum.PermissionRepoView)
+	if apiauth.IsNoAccess(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check access of principal %d to repository: %w", principal.ID, err)
+	}
+
+	return true, nil
+}
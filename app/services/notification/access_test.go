@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// repoViewAuthorizer allows the repository view permission to the listed principals only.
+type repoViewAuthorizer struct {
+	authz.Authorizer
+	allowed map[int64]bool
+	err     error
+}
+
+func (a repoViewAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if a.err != nil {
+		return false, a.err
+	}
+	if scope.SpacePath != "space" || resource.Type != enum.ResourceTypeRepo || resource.Identifier != "repo" ||
+		permission != enum.PermissionRepoView {
+		return false, nil
+	}
+	return a.allowed[session.Principal.ID], nil
+}
+
+func TestCanViewRepo(t *testing.T) {
+	repo := &types.RepositoryCore{ID: 1, Path: "space/repo"}
+	authorizer := repoViewAuthorizer{allowed: map[int64]bool{1: true}}
+
+	canView, err := canViewRepo(context.Background(), authorizer, &types.Principal{ID: 1}, repo)
+	if err != nil || !canView {
+		t.Errorf("principal with access must be able to view the repo: canView=%t err=%v", canView, err)
+	}
+
+	canView, err = canViewRepo(context.Background(), authorizer, &types.Principal{ID: 2}, repo)
+	if err != nil || canView {
+		t.Errorf("principal without access must not be able to view the repo: canView=%t err=%v", canView, err)
+	}
+
+	authorizer.err = errors.New("authorizer failed")
+	if _, err = canViewRepo(context.Background(), authorizer, &types.Principal{ID: 1}, repo); err == nil {
+		t.Error("expected an error")
+	}
+}
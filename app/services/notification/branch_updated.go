@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -43,11 +44,23 @@ func (s *Service) notifyPullReqBranchUpdated(
 		)
 	}
 
-	if len(reviewers) == 0 {
+	recipients, err := s.deliver(ctx, delivery{
+		event:        enum.NotificationEventBranchUpdated,
+		base:         payload.Base,
+		actorID:      event.Payload.PrincipalID,
+		text:         event.Payload.NewSHA,
+		recipients:   reviewers,
+		withWatchers: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+
+	if len(recipients) == 0 {
 		return nil
 	}
 
-	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
+	err = s.notificationClient.SendPullReqBranchUpdated(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
@@ -58,4 +58,19 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendPullReqCreated(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *PullReqCreatedPayload,
+	) error
+	SendDigest(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *DigestPayload,
+	) error
+	SendWebhookDisabled(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *WebhookDisabledPayload,
+	) error
 }
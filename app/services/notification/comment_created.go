@@ -48,6 +48,20 @@ func (s *Service) notifyCommentCreated(
 		)
 	}
 
+	notified := map[int64]bool{}
+
+	mentions, err = s.deliver(ctx, delivery{
+		event:      gitnessenum.NotificationEventCommentMentioned,
+		base:       payload.Base,
+		actorID:    payload.Commenter.ID,
+		text:       payload.Text,
+		recipients: mentions,
+		notified:   notified,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+
 	if len(mentions) > 0 {
 		err = s.notificationClient.SendCommentMentions(ctx, mentions, payload)
 		if err != nil {
@@ -60,6 +74,35 @@ func (s *Service) notifyCommentCreated(
 		}
 	}
 
+	recipients := participants
+	if author != nil {
+		recipients = append(recipients, author)
+	}
+
+	// the watchers are notified with the same email as the thread participants.
+	recipients, err = s.deliver(ctx, delivery{
+		event:        gitnessenum.NotificationEventCommentCreated,
+		base:         payload.Base,
+		actorID:      payload.Commenter.ID,
+		text:         payload.Text,
+		recipients:   recipients,
+		withWatchers: true,
+		notified:     notified,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+
+	participants = make([]*types.PrincipalInfo, 0, len(recipients))
+	var authorRecipients []*types.PrincipalInfo
+	for _, recipient := range recipients {
+		if author != nil && recipient.ID == author.ID {
+			authorRecipients = append(authorRecipients, recipient)
+			continue
+		}
+		participants = append(participants, recipient)
+	}
+
 	if len(participants) > 0 {
 		err = s.notificationClient.SendCommentParticipants(ctx, participants, payload)
 		if err != nil {
@@ -72,12 +115,8 @@ func (s *Service) notifyCommentCreated(
 		}
 	}
 
-	if author != nil {
-		err = s.notificationClient.SendCommentPRAuthor(
-			ctx,
-			[]*types.PrincipalInfo{author},
-			payload,
-		)
+	if len(authorRecipients) > 0 {
+		err = s.notificationClient.SendCommentPRAuthor(ctx, authorRecipients, payload)
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to author for event %s for pullReqID %d: %w",
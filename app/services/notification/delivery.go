@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// delivery describes a single pull request event that is delivered to a group of recipients.
+type delivery struct {
+	event enum.NotificationEvent
+	base  *BasePullReqPayload
+	// actorID is the ID of the principal who triggered the event. The actor doesn't get in-app notifications.
+	actorID int64
+	// text holds event specific details stored with the in-app notifications.
+	text       string
+	recipients []*types.PrincipalInfo
+	// withWatchers adds the principals watching the repository or the pull request to the recipients.
+	withWatchers bool
+	// notified holds IDs of principals already notified about the same event. They are not added as watchers.
+	notified map[int64]bool
+}
+
+// deliver filters the recipients of the event by their notification preferences, stores the in-app notifications
+// and returns the recipients of the email notification.
+func (s *Service) deliver(ctx context.Context, d delivery) ([]*types.PrincipalInfo, error) {
+	recipients := make([]*types.PrincipalInfo, 0, len(d.recipients))
+	seen := make(map[int64]bool, len(d.recipients))
+	for _, recipient := range d.recipients {
+		if seen[recipient.ID] {
+			continue
+		}
+		seen[recipient.ID] = true
+		recipients = append(recipients, recipient)
+	}
+
+	watchers := make(map[int64]bool)
+	if d.withWatchers {
+		watcherIDs, err := s.watchStore.ListPrincipalIDs(ctx, d.base.Repo.ID, d.base.PullReq.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list watchers: %w", err)
+		}
+
+		for _, id := range watcherIDs {
+			if seen[id] || d.notified[id] || id == d.actorID {
+				continue
+			}
+
+			principal, err := s.principalStore.Find(ctx, id)
+			if errors.Is(err, gitness_store.ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to find watcher %d: %w", id, err)
+			}
+
+			// watchers might have lost access to the repository after they started watching.
+			canView, err := canViewRepo(ctx, s.authorizer, principal, d.base.Repo.Core())
+			if err != nil {
+				return nil, err
+			}
+			if !canView {
+				continue
+			}
+
+			watchers[id] = true
+			recipients = append(recipients, principal.ToPrincipalInfo())
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+
+	resolver, err := s.newPreferenceResolver(ctx, d.base.Repo, d.event, recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	emailRecipients := make([]*types.PrincipalInfo, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		if d.notified != nil {
+			d.notified[recipient.ID] = true
+		}
+
+		isWatcher := watchers[recipient.ID]
+
+		if resolver.enabled(recipient.ID, enum.NotificationChannelEmail, isWatcher) {
+			emailRecipients = append(emailRecipients, recipient)
+		}
+
+		if recipient.ID == d.actorID || !resolver.enabled(recipient.ID, enum.NotificationChannelInApp, isWatcher) {
+			continue
+		}
+
+		err = s.inbox.Add(ctx, &types.Notification{
+			PrincipalID:   recipient.ID,
+			Created:       now,
+			Updated:       now,
+			Event:         d.event,
+			ActorID:       d.actorID,
+			RepoID:        d.base.Repo.ID,
+			PullReqID:     d.base.PullReq.ID,
+			PullReqNumber: d.base.PullReq.Number,
+			Title:         d.base.PullReq.Title,
+			Text:          d.text,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add in-app notification for principal %d: %w", recipient.ID, err)
+		}
+	}
+
+	return emailRecipients, nil
+}
+
+func (s *Service) newPreferenceResolver(
+	ctx context.Context,
+	repo *types.Repository,
+	event enum.NotificationEvent,
+	recipients []*types.PrincipalInfo,
+) (*preferenceResolver, error) {
+	principalIDs := make([]int64, len(recipients))
+	for i, recipient := range recipients {
+		principalIDs[i] = recipient.ID
+	}
+
+	prefs, err := s.preferenceStore.ListByEvent(ctx, principalIDs, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	var spaceIDs []int64
+	for spaceID := repo.ParentID; spaceID != 0; {
+		spaceIDs = append(spaceIDs, spaceID)
+
+		space, err := s.spaceFinder.FindByID(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return newPreferenceResolver(prefs, repo.ID, spaceIDs), nil
+}
+
+// preferenceResolver decides if a principal receives a notification event on a channel.
+// Without any stored preference all notifications are enabled.
+type preferenceResolver struct {
+	prefs    map[int64][]*types.NotificationPreference
+	repoID   int64
+	spaceIDs []int64
+}
+
+// newPreferenceResolver returns a preferenceResolver for the repository.
+// The spaceIDs must be ordered from the parent space of the repository up to the root space.
+func newPreferenceResolver(
+	prefs []*types.NotificationPreference,
+	repoID int64,
+	spaceIDs []int64,
+) *preferenceResolver {
+	byPrincipal := make(map[int64][]*types.NotificationPreference)
+	for _, pref := range prefs {
+		byPrincipal[pref.PrincipalID] = append(byPrincipal[pref.PrincipalID], pref)
+	}
+
+	return &preferenceResolver{
+		prefs:    byPrincipal,
+		repoID:   repoID,
+		spaceIDs: spaceIDs,
+	}
+}
+
+// enabled returns if the principal should be notified on the channel. A repository preference overrides
+// the preferences of the spaces and the preference of the closest space wins. Principals notified only because
+// they are watching the repository or the pull request must also have the watch preference enabled.
+func (r *preferenceResolver) enabled(principalID int64, channel enum.NotificationChannel, isWatcher bool) bool {
+	var repoPref, watchPref *bool
+	spacePrefs := make(map[int64]bool)
+
+	for _, pref := range r.prefs[principalID] {
+		if pref.Channel != channel {
+			continue
+		}
+
+		switch pref.Scope {
+		case enum.NotificationScopeRepo:
+			if pref.ScopeID == r.repoID {
+				repoPref = &pref.Enabled
+			}
+		case enum.NotificationScopeSpace:
+			spacePrefs[pref.ScopeID] = pref.Enabled
+		case enum.NotificationScopeWatch:
+			watchPref = &pref.Enabled
+		}
+	}
+
+	if isWatcher && watchPref != nil && !*watchPref {
+		return false
+	}
+
+	if repoPref != nil {
+		return *repoPref
+	}
+
+	for _, spaceID := range r.spaceIDs {
+		if enabled, ok := spacePrefs[spaceID]; ok {
+			return enabled
+		}
+	}
+
+	return true
+}
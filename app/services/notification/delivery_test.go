@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPreferenceResolver(t *testing.T) {
+	const (
+		repoID        = 10
+		parentSpaceID = 2
+		rootSpaceID   = 1
+		otherRepoID   = 11
+	)
+
+	pref := func(
+		scope enum.NotificationScope,
+		scopeID int64,
+		channel enum.NotificationChannel,
+		enabled bool,
+	) *types.NotificationPreference {
+		return &types.NotificationPreference{
+			PrincipalID: 1,
+			Scope:       scope,
+			ScopeID:     scopeID,
+			Event:       enum.NotificationEventCommentCreated,
+			Channel:     channel,
+			Enabled:     enabled,
+		}
+	}
+
+	email := enum.NotificationChannelEmail
+	inApp := enum.NotificationChannelInApp
+
+	tests := []struct {
+		name       string
+		prefs      []*types.NotificationPreference
+		channel    enum.NotificationChannel
+		isWatcher  bool
+		expEnabled bool
+	}{
+		{
+			name:       "no-preferences",
+			channel:    email,
+			expEnabled: true,
+		},
+		{
+			name:       "root-space-disabled",
+			prefs:      []*types.NotificationPreference{pref(enum.NotificationScopeSpace, rootSpaceID, email, false)},
+			channel:    email,
+			expEnabled: false,
+		},
+		{
+			name: "closest-space-wins",
+			prefs: []*types.NotificationPreference{
+				pref(enum.NotificationScopeSpace, rootSpaceID, email, false),
+				pref(enum.NotificationScopeSpace, parentSpaceID, email, true),
+			},
+			channel:    email,
+			expEnabled: true,
+		},
+		{
+			name: "repo-overrides-space",
+			prefs: []*types.NotificationPreference{
+				pref(enum.NotificationScopeSpace, parentSpaceID, email, true),
+				pref(enum.NotificationScopeRepo, repoID, email, false),
+			},
+			channel:    email,
+			expEnabled: false,
+		},
+		{
+			name:       "other-repo-ignored",
+			prefs:      []*types.NotificationPreference{pref(enum.NotificationScopeRepo, otherRepoID, email, false)},
+			channel:    email,
+			expEnabled: true,
+		},
+		{
+			name:       "other-channel-ignored",
+			prefs:      []*types.NotificationPreference{pref(enum.NotificationScopeRepo, repoID, inApp, false)},
+			channel:    email,
+			expEnabled: true,
+		},
+		{
+			name:       "watch-disabled-for-watcher",
+			prefs:      []*types.NotificationPreference{pref(enum.NotificationScopeWatch, 0, email, false)},
+			channel:    email,
+			isWatcher:  true,
+			expEnabled: false,
+		},
+		{
+			name:       "watch-disabled-for-participant",
+			prefs:      []*types.NotificationPreference{pref(enum.NotificationScopeWatch, 0, email, false)},
+			channel:    email,
+			expEnabled: true,
+		},
+		{
+			name: "watch-enabled-repo-disabled",
+			prefs: []*types.NotificationPreference{
+				pref(enum.NotificationScopeWatch, 0, email, true),
+				pref(enum.NotificationScopeRepo, repoID, email, false),
+			},
+			channel:    email,
+			isWatcher:  true,
+			expEnabled: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newPreferenceResolver(test.prefs, repoID, []int64{parentSpaceID, rootSpaceID})
+			if got := r.enabled(1, test.channel, test.isWatcher); got != test.expEnabled {
+				t.Errorf("want=%t got=%t", test.expEnabled, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	// the time zone database is embedded because the digest is sent in the time zones of the users.
+	_ "time/tzdata"
+
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	digestJobType = "pullreq-digest"
+
+	// digestUserPageSize is the number of users processed in one batch.
+	digestUserPageSize = 100
+	// digestMaxPullReqs is the maximum number of pull requests listed in a single section of a digest.
+	digestMaxPullReqs = 50
+	// digestMinInterval is the minimum time between two digests sent to the same user.
+	// It prevents sending the digest twice if the job runs more than once in the configured hour.
+	digestMinInterval = 23 * time.Hour
+)
+
+// DigestPullReq is a pull request listed in a digest.
+type DigestPullReq struct {
+	PullReq    *types.PullReq
+	RepoPath   string
+	PullReqURL string
+}
+
+// DigestPayload is the content of a pull request digest.
+type DigestPayload struct {
+	Recipient *types.PrincipalInfo
+	// AwaitingReview are the pull requests in which the recipient is a reviewer who hasn't reviewed yet.
+	AwaitingReview []DigestPullReq
+	// ChangesRequested are the pull requests of the recipient in which a reviewer requested changes.
+	ChangesRequested []DigestPullReq
+	// FailingChecks are the pull requests of the recipient with failing status checks.
+	FailingChecks []DigestPullReq
+	// Idle are the pull requests of the recipient, or reviewed by the recipient,
+	// that haven't been updated in the last IdleDays days.
+	Idle     []DigestPullReq
+	IdleDays int
+}
+
+// IsEmpty returns true if there is nothing to report in the digest.
+func (p *DigestPayload) IsEmpty() bool {
+	return len(p.AwaitingReview) == 0 && len(p.ChangesRequested) == 0 &&
+		len(p.FailingChecks) == 0 && len(p.Idle) == 0
+}
+
+// DigestConfig holds the system-wide digest configuration and the defaults used for users without one.
+type DigestConfig struct {
+	Enabled         bool
+	CRON            string
+	MaxDuration     time.Duration
+	DefaultHour     int
+	DefaultTimezone string
+	DefaultIdleDays int
+}
+
+// Digest is a recurring job that sends to every user an email with the pull requests that need attention.
+type Digest struct {
+	config             DigestConfig
+	scheduler          *job.Scheduler
+	notificationClient Client
+	principalStore     store.PrincipalStore
+	digestStore        store.NotificationDigestStore
+	pullReqStore       store.PullReqStore
+	reviewerStore      store.PullReqReviewerStore
+	checkStore         store.CheckStore
+	repoStore          store.RepoStore
+	authorizer         authz.Authorizer
+	urlProvider        url.Provider
+}
+
+func (d *Digest) Register(ctx context.Context) error {
+	if !d.config.Enabled {
+		return nil
+	}
+
+	err := d.scheduler.AddRecurring(ctx, digestJobType, digestJobType, d.config.CRON, d.config.MaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pull request digest: %w", err)
+	}
+
+	return nil
+}
+
+// Find returns the digest configuration of the principal, or the default configuration if there is none.
+func (d *Digest) Find(ctx context.Context, principalID int64) (*types.NotificationDigest, error) {
+	digest, err := d.digestStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return d.defaultDigest(principalID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification digest: %w", err)
+	}
+
+	return digest, nil
+}
+
+// Save stores the digest configuration of the principal.
+func (d *Digest) Save(ctx context.Context, digest *types.NotificationDigest) error {
+	now := time.Now().UnixMilli()
+	if digest.Created == 0 {
+		digest.Created = now
+	}
+	digest.Updated = now
+
+	if err := d.digestStore.Upsert(ctx, digest); err != nil {
+		return fmt.Errorf("failed to save notification digest: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Digest) defaultDigest(principalID int64) *types.NotificationDigest {
+	return &types.NotificationDigest{
+		PrincipalID: principalID,
+		Enabled:     true,
+		Hour:        d.config.DefaultHour,
+		Timezone:    d.config.DefaultTimezone,
+		IdleDays:    d.config.DefaultIdleDays,
+	}
+}
+
+func (d *Digest) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !d.config.Enabled {
+		return "", nil
+	}
+
+	now := time.Now()
+
+	for page := 1; ; page++ {
+		users, err := d.principalStore.ListUsers(ctx, &types.UserFilter{
+			Page:  page,
+			Size:  digestUserPageSize,
+			Sort:  enum.UserAttrCreated,
+			Order: enum.OrderAsc,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list users: %w", err)
+		}
+
+		for _, user := range users {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+
+			if user.Blocked || user.Email == "" {
+				continue
+			}
+
+			if err := d.process(ctx, user, now); err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("principal_id", user.ID).
+					Msg("failed to send pull request digest")
+			}
+		}
+
+		if len(users) < digestUserPageSize {
+			break
+		}
+	}
+
+	return "", nil
+}
+
+func (d *Digest) process(ctx context.Context, user *types.User, now time.Time) error {
+	digest, err := d.Find(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if !isDigestDue(digest, now) {
+		return nil
+	}
+
+	payload, err := d.generate(ctx, user, digest, now)
+	if err != nil {
+		return fmt.Errorf("failed to generate digest: %w", err)
+	}
+
+	if !payload.IsEmpty() {
+		err = d.notificationClient.SendDigest(ctx, []*types.PrincipalInfo{payload.Recipient}, payload)
+		if err != nil {
+			return fmt.Errorf("failed to send digest: %w", err)
+		}
+	}
+
+	if digest.Created == 0 {
+		digest.LastSent = now.UnixMilli()
+		return d.Save(ctx, digest)
+	}
+
+	if err = d.digestStore.UpdateLastSent(ctx, user.ID, now.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to update time of the last digest: %w", err)
+	}
+
+	return nil
+}
+
+// isDigestDue returns true if it's time to send the digest to the user.
+func isDigestDue(digest *types.NotificationDigest, now time.Time) bool {
+	if !digest.Enabled {
+		return false
+	}
+
+	location, err := time.LoadLocation(digest.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	if now.In(location).Hour() != digest.Hour {
+		return false
+	}
+
+	return now.Sub(time.UnixMilli(digest.LastSent)) >= digestMinInterval
+}
+
+func (d *Digest) generate(
+	ctx context.Context,
+	user *types.User,
+	digest *types.NotificationDigest,
+	now time.Time,
+) (*DigestPayload, error) {
+	payload := &DigestPayload{
+		Recipient: user.ToPrincipalInfo(),
+		IdleDays:  digest.IdleDays,
+	}
+
+	idleBefore := now.AddDate(0, 0, -digest.IdleDays).UnixMilli()
+	principal := user.ToPrincipal()
+	// repos holds the repositories of the listed pull requests, nil for the ones that are excluded from the digest.
+	repos := map[int64]*types.Repository{}
+	idle := map[int64]struct{}{}
+
+	awaitingReview, err := d.listOpen(ctx, &types.PullReqFilter{
+		ReviewerID:      user.ID,
+		ReviewDecisions: []enum.PullReqReviewDecision{enum.PullReqReviewDecisionPending},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests awaiting review: %w", err)
+	}
+
+	for _, pr := range awaitingReview {
+		entry, ok, err := d.digestPullReq(ctx, principal, repos, pr)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		payload.AwaitingReview = append(payload.AwaitingReview, entry)
+
+		if pr.Updated < idleBefore {
+			idle[pr.ID] = struct{}{}
+			payload.Idle = append(payload.Idle, entry)
+		}
+	}
+
+	authored, err := d.listOpen(ctx, &types.PullReqFilter{
+		CreatedBy: []int64{user.ID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests of the user: %w", err)
+	}
+
+	for _, pr := range authored {
+		entry, ok, err := d.digestPullReq(ctx, principal, repos, pr)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		changesRequested, err := d.hasChangesRequested(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		if changesRequested {
+			payload.ChangesRequested = append(payload.ChangesRequested, entry)
+		}
+
+		failingChecks, err := d.hasFailingChecks(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+
+		if failingChecks {
+			payload.FailingChecks = append(payload.FailingChecks, entry)
+		}
+
+		if _, ok := idle[pr.ID]; !ok && pr.Updated < idleBefore {
+			payload.Idle = append(payload.Idle, entry)
+		}
+	}
+
+	return payload, nil
+}
+
+func (d *Digest) listOpen(ctx context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	filter.Page = 1
+	filter.Size = digestMaxPullReqs
+	filter.States = []enum.PullReqState{enum.PullReqStateOpen}
+	filter.Sort = enum.PullReqSortUpdated
+	filter.Order = enum.OrderAsc
+	filter.ExcludeDescription = true
+
+	list, err := d.pullReqStore.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*types.PullReq, 0, len(list))
+	for _, pr := range list {
+		if !pr.IsDraft {
+			result = append(result, pr)
+		}
+	}
+
+	return result, nil
+}
+
+func (d *Digest) hasChangesRequested(ctx context.Context, pr *types.PullReq) (bool, error) {
+	reviewers, err := d.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	for _, reviewer := range reviewers {
+		if reviewer.ReviewDecision == enum.PullReqReviewDecisionChangeReq {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (d *Digest) hasFailingChecks(ctx context.Context, pr *types.PullReq) (bool, error) {
+	summaries, err := d.checkStore.ResultSummary(ctx, pr.TargetRepoID, []string{pr.SourceSHA})
+	if err != nil {
+		return false, fmt.Errorf("failed to get check summary: %w", err)
+	}
+
+	for _, summary := range summaries {
+		if summary.Failure > 0 || summary.Error > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// digestPullReq returns the digest entry of the pull request. It returns false if the pull request
+// must not be included in the digest, because its repository got deleted or the user can't access it anymore.
+func (d *Digest) digestPullReq(
+	ctx context.Context,
+	principal *types.Principal,
+	repos map[int64]*types.Repository,
+	pr *types.PullReq,
+) (DigestPullReq, bool, error) {
+	repo, ok := repos[pr.TargetRepoID]
+	if !ok {
+		var err error
+		repo, err = d.findRepo(ctx, principal, pr.TargetRepoID)
+		if err != nil {
+			return DigestPullReq{}, false, err
+		}
+
+		repos[pr.TargetRepoID] = repo
+	}
+
+	if repo == nil {
+		return DigestPullReq{}, false, nil
+	}
+
+	return DigestPullReq{
+		PullReq:    pr,
+		RepoPath:   repo.Path,
+		PullReqURL: d.urlProvider.GenerateUIPRURL(ctx, repo.Path, pr.Number),
+	}, true, nil
+}
+
+// findRepo returns the repository if it exists and the principal can view it, nil otherwise.
+func (d *Digest) findRepo(
+	ctx context.Context,
+	principal *types.Principal,
+	repoID int64,
+) (*types.Repository, error) {
+	repo, err := d.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	canView, err := canViewRepo(ctx, d.authorizer, principal, repo.Core())
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, nil //nolint:nilnil
+	}
+
+	return repo, nil
+}
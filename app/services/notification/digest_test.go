@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestIsDigestDue(t *testing.T) {
+	// 2024-03-15 08:30 UTC is 09:30 in Europe/Berlin and 04:30 in America/New_York.
+	now := time.Date(2024, 3, 15, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		digest types.NotificationDigest
+		expDue bool
+	}{
+		{
+			name:   "due-utc",
+			digest: types.NotificationDigest{Enabled: true, Hour: 8, Timezone: "UTC"},
+			expDue: true,
+		},
+		{
+			name:   "due-local-timezone",
+			digest: types.NotificationDigest{Enabled: true, Hour: 9, Timezone: "Europe/Berlin"},
+			expDue: true,
+		},
+		{
+			name:   "other-hour",
+			digest: types.NotificationDigest{Enabled: true, Hour: 9, Timezone: "America/New_York"},
+			expDue: false,
+		},
+		{
+			name:   "disabled",
+			digest: types.NotificationDigest{Enabled: false, Hour: 8, Timezone: "UTC"},
+			expDue: false,
+		},
+		{
+			name: "already-sent",
+			digest: types.NotificationDigest{
+				Enabled:  true,
+				Hour:     8,
+				Timezone: "UTC",
+				LastSent: now.Add(-10 * time.Minute).UnixMilli(),
+			},
+			expDue: false,
+		},
+		{
+			name: "sent-yesterday",
+			digest: types.NotificationDigest{
+				Enabled:  true,
+				Hour:     8,
+				Timezone: "UTC",
+				LastSent: now.Add(-24 * time.Hour).UnixMilli(),
+			},
+			expDue: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isDigestDue(&test.digest, now); got != test.expDue {
+				t.Errorf("want=%t got=%t", test.expDue, got)
+			}
+		})
+	}
+}
+
+func TestDigestTemplate(t *testing.T) {
+	payload := &DigestPayload{
+		Recipient: &types.PrincipalInfo{DisplayName: "Jane"},
+		AwaitingReview: []DigestPullReq{{
+			PullReq:    &types.PullReq{Number: 7, Title: "Add feature"},
+			RepoPath:   "space/repo",
+			PullReqURL: "https://example.com/space/repo/pulls/7",
+		}},
+		IdleDays: 7,
+	}
+
+	body, err := GetHTMLBody(TemplatePullReqDigest, payload)
+	if err != nil {
+		t.Fatalf("failed to render digest: %s", err)
+	}
+
+	html := string(body)
+	if !strings.Contains(html, "space/repo #7: Add feature") {
+		t.Errorf("digest doesn't contain the pull request awaiting review:\n%s", html)
+	}
+
+	if strings.Contains(html, "No activity") {
+		t.Errorf("digest contains the empty idle section:\n%s", html)
+	}
+}
+
+type digestRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s digestRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+type digestURLProvider struct {
+	url.Provider
+}
+
+func (digestURLProvider) GenerateUIPRURL(_ context.Context, repoPath string, prID int64) string {
+	return fmt.Sprintf("https://example.com/%s/pulls/%d", repoPath, prID)
+}
+
+func TestDigestPullReq(t *testing.T) {
+	d := &Digest{
+		repoStore: digestRepoStore{repos: map[int64]*types.Repository{
+			1: {ID: 1, Path: "space/repo"},
+			2: {ID: 2, Path: "space/private"},
+		}},
+		authorizer:  repoViewAuthorizer{allowed: map[int64]bool{1: true}},
+		urlProvider: digestURLProvider{},
+	}
+	principal := &types.Principal{ID: 1}
+	repos := map[int64]*types.Repository{}
+
+	tests := []struct {
+		name   string
+		repoID int64
+		expOK  bool
+	}{
+		{name: "accessible", repoID: 1, expOK: true},
+		{name: "no-access", repoID: 2},
+		{name: "deleted", repoID: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pr := &types.PullReq{Number: 5, TargetRepoID: test.repoID}
+
+			entry, ok, err := d.digestPullReq(context.Background(), principal, repos, pr)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ok != test.expOK {
+				t.Fatalf("want ok=%t, got %t", test.expOK, ok)
+			}
+			if ok && entry.RepoPath != "space/repo" {
+				t.Errorf("unexpected digest entry: %+v", entry)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Inbox manages the in-app notifications of the users.
+// Every change of the number of unread notifications is published to the event stream of the user.
+type Inbox struct {
+	notificationStore store.NotificationStore
+	repoFinder        refcache.RepoFinder
+	sseStreamer       sse.Streamer
+}
+
+func NewInbox(
+	notificationStore store.NotificationStore,
+	repoFinder refcache.RepoFinder,
+	sseStreamer sse.Streamer,
+) *Inbox {
+	return &Inbox{
+		notificationStore: notificationStore,
+		repoFinder:        repoFinder,
+		sseStreamer:       sseStreamer,
+	}
+}
+
+// Add stores a new in-app notification.
+func (i *Inbox) Add(ctx context.Context, notification *types.Notification) error {
+	if err := i.notificationStore.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	i.publishUnreadCount(ctx, notification.PrincipalID)
+
+	return nil
+}
+
+// List returns the in-app notifications of the principal and their total count.
+func (i *Inbox) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, int64, error) {
+	notifications, err := i.notificationStore.List(ctx, principalID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	if filter.Page == 1 && len(notifications) < filter.Size {
+		return i.withRepoPaths(ctx, notifications), int64(len(notifications)), nil
+	}
+
+	count, err := i.notificationStore.Count(ctx, principalID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return i.withRepoPaths(ctx, notifications), count, nil
+}
+
+// UnreadCount returns the number of unread in-app notifications of the principal.
+func (i *Inbox) UnreadCount(ctx context.Context, principalID int64) (int64, error) {
+	read := false
+	count, err := i.notificationStore.Count(ctx, principalID, &types.NotificationFilter{Read: &read})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead marks the in-app notification of the principal as read or unread.
+func (i *Inbox) MarkRead(
+	ctx context.Context,
+	principalID int64,
+	id int64,
+	read bool,
+) (*types.Notification, error) {
+	notification, err := i.notificationStore.Find(ctx, principalID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification: %w", err)
+	}
+
+	if notification.Read == read {
+		return i.withRepoPath(ctx, notification), nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	err = i.notificationStore.UpdateRead(ctx, principalID, id, read, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification read state: %w", err)
+	}
+
+	notification.Read = read
+	notification.Updated = now
+
+	i.publishUnreadCount(ctx, principalID)
+
+	return i.withRepoPath(ctx, notification), nil
+}
+
+// MarkAllRead marks all in-app notifications of the principal as read.
+func (i *Inbox) MarkAllRead(ctx context.Context, principalID int64) error {
+	err := i.notificationStore.MarkAllRead(ctx, principalID, time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	i.publishUnreadCount(ctx, principalID)
+
+	return nil
+}
+
+func (i *Inbox) publishUnreadCount(ctx context.Context, principalID int64) {
+	count, err := i.UnreadCount(ctx, principalID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish unread notification count")
+		return
+	}
+
+	i.sseStreamer.PublishToPrincipal(ctx, principalID, enum.SSETypeNotificationUnreadCount,
+		types.NotificationUnreadCount{UnreadCount: count})
+}
+
+func (i *Inbox) withRepoPaths(ctx context.Context, notifications []*types.Notification) []*types.Notification {
+	for _, notification := range notifications {
+		i.withRepoPath(ctx, notification)
+	}
+	return notifications
+}
+
+func (i *Inbox) withRepoPath(ctx context.Context, notification *types.Notification) *types.Notification {
+	repo, err := i.repoFinder.FindByID(ctx, notification.RepoID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find repository %d of notification", notification.RepoID)
+		return notification
+	}
+
+	notification.RepoPath = repo.Path
+	return notification
+}
@@ -32,6 +32,12 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplatePullReqDigest        = "pullreq_digest.html"
+	TemplatePullReqCreated       = "pullreq_created.html"
+	TemplateWebhookDisabled      = "webhook_disabled.html"
+
+	subjectPullReqDigest   = "Pull request digest"
+	subjectWebhookDisabled = "[%s] Webhook %s has been disabled"
 )
 
 type MailClient struct {
@@ -169,6 +175,54 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	email, err := GenerateEmailFromPayload(TemplatePullReqCreated, recipients, payload.Base, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.CreatedEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
+func (m MailClient) SendDigest(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *DigestPayload,
+) error {
+	body, err := GetHTMLBody(TemplatePullReqDigest, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail request for pull request digest: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      subjectPullReqDigest,
+		Body:         string(body),
+	})
+}
+
+func (m MailClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	body, err := GetHTMLBody(TemplateWebhookDisabled, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail request for disabled webhook: %w", err)
+	}
+
+	return m.Mailer.Send(ctx, mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      fmt.Sprintf(subjectWebhookDisabled, payload.ParentPath, payload.WebhookIdentifier),
+		Body:         string(body),
+	})
+}
+
 func GetSubjectPullRequest(
 	repoIdentifier string,
 	prNum int64,
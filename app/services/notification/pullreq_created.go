@@ -21,8 +21,13 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
+type PullReqCreatedPayload struct {
+	Base *BasePullReqPayload
+}
+
 func (s *Service) notifyPullReqCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
@@ -37,14 +42,30 @@ func (s *Service) notifyPullReqCreated(
 		return fmt.Errorf("failed to get principal infos from cache: %w", err)
 	}
 
+	notified := map[int64]bool{}
+
 	for _, reviewer := range reviewers {
+		recipients, err := s.deliver(ctx, delivery{
+			event:      enum.NotificationEventReviewerAdded,
+			base:       base,
+			actorID:    event.Payload.PrincipalID,
+			recipients: []*types.PrincipalInfo{base.Author, reviewer},
+			notified:   notified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+		}
+		if len(recipients) == 0 {
+			continue
+		}
+
 		payload := &ReviewerAddedPayload{
 			Base:     base,
 			Reviewer: reviewer,
 		}
 		if err := s.notificationClient.SendReviewerAdded(
 			ctx,
-			[]*types.PrincipalInfo{base.Author, reviewer},
+			recipients,
 			payload,
 		); err != nil {
 			return fmt.Errorf(
@@ -56,5 +77,35 @@ func (s *Service) notifyPullReqCreated(
 		}
 	}
 
+	// the author is not notified about the own pull request, even when watching the repository.
+	notified[base.Author.ID] = true
+
+	watchers, err := s.deliver(ctx, delivery{
+		event:        enum.NotificationEventPullReqCreated,
+		base:         base,
+		actorID:      event.Payload.PrincipalID,
+		withWatchers: true,
+		notified:     notified,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+	if len(watchers) == 0 {
+		return nil
+	}
+
+	if err = s.notificationClient.SendPullReqCreated(
+		ctx,
+		watchers,
+		&PullReqCreatedPayload{Base: base},
+	); err != nil {
+		return fmt.Errorf(
+			"failed to send email to watchers for event %s for pullReqID %d: %w",
+			pullreqevents.CreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
 	return nil
 }
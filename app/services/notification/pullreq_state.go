@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -41,74 +42,55 @@ func (s *Service) notifyPullReqStateMerged(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateMerged)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to process %s event for pullReqID %d: %w",
-			pullreqevents.MergedEvent,
-			event.Payload.PullReqID,
-			err,
-		)
-	}
-
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.MergedEvent,
-			payload.Base.PullReq.ID,
-			err,
-		)
-	}
-	return nil
+	return s.notifyPullReqStateChanged(ctx, event.Payload.Base, PullReqStateMerged, pullreqevents.MergedEvent)
 }
 
 func (s *Service) notifyPullReqStateClosed(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateClosed)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to process %s event for pullReqID %d: %w",
-			pullreqevents.ClosedEvent,
-			event.Payload.PullReqID,
-			err,
-		)
-	}
-
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.ClosedEvent,
-			payload.Base.PullReq.ID,
-			err,
-		)
-	}
-	return nil
+	return s.notifyPullReqStateChanged(ctx, event.Payload.Base, PullReqStateClosed, pullreqevents.ClosedEvent)
 }
 
 func (s *Service) notifyPullReqStateReOpened(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateReopened)
+	return s.notifyPullReqStateChanged(ctx, event.Payload.Base, PullReqStateReopened, pullreqevents.ReopenedEvent)
+}
+
+func (s *Service) notifyPullReqStateChanged(
+	ctx context.Context,
+	baseEvent pullreqevents.Base,
+	state PullReqState,
+	eventType events.EventType,
+) error {
+	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, baseEvent, state)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
-			pullreqevents.ReopenedEvent,
-			event.Payload.PullReqID,
+			eventType,
+			baseEvent.PullReqID,
 			err,
 		)
 	}
 
+	recipients, err = s.deliver(ctx, delivery{
+		event:        enum.NotificationEventStateChanged,
+		base:         payload.Base,
+		actorID:      baseEvent.PrincipalID,
+		text:         string(state),
+		recipients:   recipients,
+		withWatchers: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", baseEvent.PullReqID, err)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -116,7 +98,7 @@ func (s *Service) notifyPullReqStateReOpened(
 	); err != nil {
 		return fmt.Errorf(
 			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.ReopenedEvent,
+			eventType,
 			payload.Base.PullReq.ID,
 			err,
 		)
@@ -17,6 +17,8 @@ package notification
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
@@ -29,6 +31,13 @@ type ReviewSubmittedPayload struct {
 	Author   *types.PrincipalInfo
 	Reviewer *types.PrincipalInfo
 	Decision enum.PullReqReviewDecision
+	Comments []*ReviewCommentPayload
+}
+
+// ReviewCommentPayload describes a pending comment published with the review.
+type ReviewCommentPayload struct {
+	Path string
+	Text string
 }
 
 func (s *Service) notifyReviewSubmitted(
@@ -45,6 +54,22 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
+	recipients, err = s.deliver(ctx, delivery{
+		event:        enum.NotificationEventReviewSubmitted,
+		base:         notificationPayload.Base,
+		actorID:      event.Payload.ReviewerID,
+		text:         string(event.Payload.Decision),
+		recipients:   recipients,
+		withWatchers: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewSubmitted(
 		ctx,
 		recipients,
@@ -91,10 +116,59 @@ func (s *Service) processReviewSubmittedEvent(
 		)
 	}
 
+	recipients := []*types.PrincipalInfo{authorPrincipal}
+
+	seen := map[int64]bool{
+		authorPrincipal.ID:   true,
+		reviewerPrincipal.ID: true,
+	}
+
+	comments := make([]*ReviewCommentPayload, 0, len(event.Payload.CommentIDs))
+	for _, commentID := range event.Payload.CommentIDs {
+		activity, err := s.pullReqActivityStore.Find(ctx, commentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch activity from pullReqActivityStore: %w", err)
+		}
+		if activity.Deleted != nil {
+			continue
+		}
+
+		comment := &ReviewCommentPayload{
+			Text: activity.Text,
+		}
+		if activity.IsValidCodeComment() {
+			comment.Path = activity.CodeComment.Path
+		}
+
+		// the mentioned users are notified with the review, not with the individual comments.
+		mentionsMap, err := s.processMentions(ctx, activity.Metadata, seen)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, mention := range mentionsMap {
+			recipients = append(recipients, mention)
+		}
+
+		if activity.Metadata != nil && activity.Metadata.Mentions != nil {
+			mentions, err := s.principalInfoCache.Map(ctx, activity.Metadata.Mentions.IDs)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to fetch comment mentions from principalInfoCache: %w", err)
+			}
+			for id, mention := range mentions {
+				comment.Text = strings.ReplaceAll(
+					comment.Text, "@["+strconv.FormatInt(id, 10)+"]", mention.DisplayName,
+				)
+			}
+		}
+
+		comments = append(comments, comment)
+	}
+
 	return &ReviewSubmittedPayload{
 		Base:     base,
 		Author:   authorPrincipal,
 		Decision: event.Payload.Decision,
 		Reviewer: reviewerPrincipal,
-	}, []*types.PrincipalInfo{authorPrincipal}, nil
+		Comments: comments,
+	}, recipients, nil
 }
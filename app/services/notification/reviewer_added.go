@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ReviewerAddedPayload struct {
@@ -42,6 +43,20 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
+	recipients, err = s.deliver(ctx, delivery{
+		event:      enum.NotificationEventReviewerAdded,
+		base:       payload.Base,
+		actorID:    event.Payload.PrincipalID,
+		recipients: recipients,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deliver notifications for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
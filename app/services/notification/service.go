@@ -22,7 +22,9 @@ import (
 	"io/fs"
 	"path"
 
+	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -95,6 +97,12 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	preferenceStore       store.NotificationPreferenceStore
+	watchStore            store.NotificationWatchStore
+	principalStore        store.PrincipalStore
+	spaceFinder           refcache.SpaceFinder
+	authorizer            authz.Authorizer
+	inbox                 *Inbox
 	urlProvider           url.Provider
 }
 
@@ -110,6 +118,12 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	preferenceStore store.NotificationPreferenceStore,
+	watchStore store.NotificationWatchStore,
+	principalStore store.PrincipalStore,
+	spaceFinder refcache.SpaceFinder,
+	authorizer authz.Authorizer,
+	inbox *Inbox,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
@@ -123,6 +137,12 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		preferenceStore:       preferenceStore,
+		watchStore:            watchStore,
+		principalStore:        principalStore,
+		spaceFinder:           spaceFinder,
+		authorizer:            authorizer,
+		inbox:                 inbox,
 		urlProvider:           urlProvider,
 	}
 
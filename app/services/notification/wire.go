@@ -17,11 +17,16 @@ package notification
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -29,6 +34,8 @@ import (
 var WireSet = wire.NewSet(
 	ProvideMailClient,
 	ProvideNotificationService,
+	ProvideDigest,
+	ProvideInbox,
 )
 
 func ProvideNotificationService(
@@ -43,6 +50,12 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	preferenceStore store.NotificationPreferenceStore,
+	watchStore store.NotificationWatchStore,
+	principalStore store.PrincipalStore,
+	spaceFinder refcache.SpaceFinder,
+	authorizer authz.Authorizer,
+	inbox *Inbox,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	return NewService(
@@ -57,6 +70,12 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		preferenceStore,
+		watchStore,
+		principalStore,
+		spaceFinder,
+		authorizer,
+		inbox,
 		urlProvider,
 	)
 }
@@ -64,3 +83,55 @@ func ProvideNotificationService(
 func ProvideMailClient(mailer mailer.Mailer) Client {
 	return NewMailClient(mailer)
 }
+
+func ProvideDigest(
+	config *types.Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	notificationClient Client,
+	principalStore store.PrincipalStore,
+	digestStore store.NotificationDigestStore,
+	pullReqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	checkStore store.CheckStore,
+	repoStore store.RepoStore,
+	authorizer authz.Authorizer,
+	urlProvider url.Provider,
+) (*Digest, error) {
+	digest := &Digest{
+		config: DigestConfig{
+			// the digest can't be sent without a configured mail server.
+			Enabled:         config.Notification.Digest.Enabled && config.SMTP.Host != "",
+			CRON:            config.Notification.Digest.CRON,
+			MaxDuration:     config.Notification.Digest.MaxDuration,
+			DefaultHour:     config.Notification.Digest.DefaultHour,
+			DefaultTimezone: config.Notification.Digest.DefaultTimezone,
+			DefaultIdleDays: config.Notification.Digest.DefaultIdleDays,
+		},
+		scheduler:          scheduler,
+		notificationClient: notificationClient,
+		principalStore:     principalStore,
+		digestStore:        digestStore,
+		pullReqStore:       pullReqStore,
+		reviewerStore:      reviewerStore,
+		checkStore:         checkStore,
+		repoStore:          repoStore,
+		authorizer:         authorizer,
+		urlProvider:        urlProvider,
+	}
+
+	err := executor.Register(digestJobType, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return digest, nil
+}
+
+func ProvideInbox(
+	notificationStore store.NotificationStore,
+	repoFinder refcache.RepoFinder,
+	sseStreamer sse.Streamer,
+) *Inbox {
+	return NewInbox(notificationStore, repoFinder, sseStreamer)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// ProtectedRefNames returns the branches and tags protected by an active branch or tag rule respectively.
+func ProtectedRefNames(
+	protection Protection,
+	defaultBranch string,
+	branchNames []string,
+	tagNames []string,
+) (protectedBranches []string, protectedTags []string, err error) {
+	v, ok := protection.(ruleSet)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	branchSet := make(map[string]struct{})
+	tagSet := make(map[string]struct{})
+
+	for i := range v.rules {
+		r := &v.rules[i]
+
+		if r.State != enum.RuleStateActive {
+			continue
+		}
+
+		var (
+			names []string
+			set   map[string]struct{}
+		)
+
+		switch r.Type {
+		case TypeBranch:
+			names, set = branchNames, branchSet
+		case TypeTag:
+			names, set = tagNames, tagSet
+		default:
+			continue
+		}
+
+		var matched []string
+		matched, err = matchedNames(r.Pattern, defaultBranch, names...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to match names of rule ID=%d: %w", r.ID, err)
+		}
+
+		for _, name := range matched {
+			set[name] = struct{}{}
+		}
+	}
+
+	return sortedKeys(branchSet), sortedKeys(tagSet), nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
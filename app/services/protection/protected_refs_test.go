@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestProtectedRefNames(t *testing.T) {
+	rule := func(ruleType types.RuleType, state enum.RuleState, pattern string) types.RuleInfoInternal {
+		return types.RuleInfoInternal{
+			RuleInfo: types.RuleInfo{Type: ruleType, State: state},
+			Pattern:  []byte(pattern),
+		}
+	}
+
+	branches := []string{"main", "dev", "release/1.0", "release/2.0"}
+	tags := []string{"v1.0", "v2.0", "nightly"}
+
+	tests := []struct {
+		name        string
+		rules       []types.RuleInfoInternal
+		expBranches []string
+		expTags     []string
+	}{
+		{
+			name:        "no-rules",
+			expBranches: []string{},
+			expTags:     []string{},
+		},
+		{
+			name: "branch-and-tag-rules",
+			rules: []types.RuleInfoInternal{
+				rule(TypeBranch, enum.RuleStateActive, `{"default":true}`),
+				rule(TypeBranch, enum.RuleStateActive, `{"include":["release/*"],"exclude":["release/2.0"]}`),
+				rule(TypeTag, enum.RuleStateActive, `{"include":["v*"]}`),
+			},
+			expBranches: []string{"main", "release/1.0"},
+			expTags:     []string{"v1.0", "v2.0"},
+		},
+		{
+			name: "inactive-and-push-rules-ignored",
+			rules: []types.RuleInfoInternal{
+				rule(TypeBranch, enum.RuleStateMonitor, `{"default":true}`),
+				rule(TypeTag, enum.RuleStateDisabled, `{"include":["v*"]}`),
+				rule(TypePush, enum.RuleStateActive, `{"include":["*"]}`),
+			},
+			expBranches: []string{},
+			expTags:     []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			protectedBranches, protectedTags, err := ProtectedRefNames(
+				ruleSet{rules: test.rules}, "main", branches, tags)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if want, got := test.expBranches, protectedBranches; !reflect.DeepEqual(want, got) {
+				t.Errorf("branches: want=%v got=%v", want, got)
+			}
+
+			if want, got := test.expTags, protectedTags; !reflect.DeepEqual(want, got) {
+				t.Errorf("tags: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
 )
 
 const TypeBranch types.RuleType = "branch"
@@ -130,6 +132,29 @@ func (v *Branch) RefChangeVerify(
 	return
 }
 
+func (v *Branch) PushVerify(
+	ctx context.Context,
+	in PushVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if in.RefType != RefTypeBranch {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Lifecycle.PushVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle error: %w", err)
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
 func (v *Branch) UserIDs() ([]int64, error) {
 	uniqueUserMap := make(map[int64]struct{}, len(v.Bypass.UserIDs)+len(v.PullReq.Reviewers.DefaultReviewerIDs))
 	for _, id := range v.Bypass.UserIDs {
@@ -148,7 +173,14 @@ func (v *Branch) UserIDs() ([]int64, error) {
 }
 
 func (v *Branch) UserGroupIDs() ([]int64, error) {
-	return v.Bypass.UserGroupIDs, nil
+	ids := slices.Clone(v.Bypass.UserGroupIDs)
+	for _, autoAssign := range v.PullReq.Reviewers.AutoAssign {
+		if autoAssign.UserGroupID > 0 && !slices.Contains(ids, autoAssign.UserGroupID) {
+			ids = append(ids, autoAssign.UserGroupID)
+		}
+	}
+
+	return ids, nil
 }
 
 func (v *Branch) Sanitize() error {
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypePush types.RuleType = "push"
+
+// Push implements protection rules for the rule type TypePush.
+type Push struct {
+	Bypass  DefBypass  `json:"bypass"`
+	Commits DefCommits `json:"commits"`
+}
+
+var (
+	// ensures that the Push type implements Definition interface.
+	_ Definition = (*Push)(nil)
+	_ Protection = (*Push)(nil)
+)
+
+// MergeVerify doesn't restrict anything because push rules don't apply to pull requests.
+func (v *Push) MergeVerify(
+	context.Context,
+	MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	return MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}, nil, nil
+}
+
+// RequiredChecks doesn't require any status checks because push rules don't apply to pull requests.
+func (v *Push) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+// CreatePullReqVerify doesn't restrict anything because push rules don't apply to pull requests.
+func (v *Push) CreatePullReqVerify(
+	context.Context,
+	CreatePullReqVerifyInput,
+) (CreatePullReqVerifyOutput, []types.RuleViolations, error) {
+	return CreatePullReqVerifyOutput{}, nil, nil
+}
+
+// RefChangeVerify doesn't restrict anything because push rules only verify the pushed commits.
+func (v *Push) RefChangeVerify(
+	context.Context,
+	RefChangeVerifyInput,
+) ([]types.RuleViolations, error) {
+	return []types.RuleViolations{}, nil
+}
+
+func (v *Push) PushVerify(
+	ctx context.Context,
+	in PushVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	violations, err = v.Commits.PushVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("push error: %w", err)
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
+func (v *Push) UserIDs() ([]int64, error) {
+	return v.Bypass.UserIDs, nil
+}
+
+func (v *Push) UserGroupIDs() ([]int64, error) {
+	return v.Bypass.UserGroupIDs, nil
+}
+
+func (v *Push) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.Commits.Sanitize(); err != nil {
+		return fmt.Errorf("commits: %w", err)
+	}
+
+	return nil
+}
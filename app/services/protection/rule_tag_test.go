@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestTag_RefChangeVerify(t *testing.T) {
+	user := &types.Principal{ID: 42}
+
+	tests := []struct {
+		name        string
+		tag         Tag
+		in          RefChangeVerifyInput
+		expCodes    []string
+		expBypass   bool
+		expBypassed bool
+	}{
+		{
+			name: "branch-ignored",
+			tag:  Tag{Lifecycle: DefLifecycle{CreateForbidden: true}},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionCreate,
+				RefType:   RefTypeBranch,
+				RefNames:  []string{"v1.0.0"},
+			},
+		},
+		{
+			name: "create-forbidden",
+			tag:  Tag{Lifecycle: DefLifecycle{CreateForbidden: true}},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionCreate,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"v1.0.0"},
+			},
+			expCodes: []string{codeLifecycleCreate},
+		},
+		{
+			name: "delete-allowed",
+			tag:  Tag{Lifecycle: DefLifecycle{CreateForbidden: true}},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionDelete,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"v1.0.0"},
+			},
+		},
+		{
+			name: "update-force-forbidden",
+			tag:  Tag{Lifecycle: DefLifecycle{UpdateForceForbidden: true}},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionUpdate,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"v1.0.0"},
+			},
+			expCodes: []string{codeLifecycleUpdateForce},
+		},
+		{
+			name: "delete-forbidden-bypassed",
+			tag: Tag{
+				Bypass:    DefBypass{UserIDs: []int64{user.ID}},
+				Lifecycle: DefLifecycle{DeleteForbidden: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				RefAction:   RefActionDelete,
+				RefType:     RefTypeTag,
+				RefNames:    []string{"v1.0.0"},
+			},
+			expCodes:    []string{codeLifecycleDelete},
+			expBypass:   true,
+			expBypassed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.tag.Sanitize(); err != nil {
+				t.Fatalf("def invalid: %s", err.Error())
+			}
+
+			violations, err := test.tag.RefChangeVerify(context.Background(), test.in)
+			if err != nil {
+				t.Fatalf("got an error: %s", err.Error())
+			}
+
+			if len(test.expCodes) == 0 {
+				if len(violations) != 0 {
+					t.Errorf("expected no violations, got %+v", violations)
+				}
+				return
+			}
+
+			if len(violations) != 1 {
+				t.Fatalf("expected one rule violation, got %d", len(violations))
+			}
+
+			codes := make([]string, len(violations[0].Violations))
+			for i, violation := range violations[0].Violations {
+				codes[i] = violation.Code
+			}
+
+			if want, got := test.expCodes, codes; !reflect.DeepEqual(want, got) {
+				t.Errorf("codes mismatch: want=%v got=%v", want, got)
+			}
+			if want, got := test.expBypass, violations[0].Bypassable; want != got {
+				t.Errorf("bypassable mismatch: want=%t got=%t", want, got)
+			}
+			if want, got := test.expBypassed, violations[0].Bypassed; want != got {
+				t.Errorf("bypassed mismatch: want=%t got=%t", want, got)
+			}
+		})
+	}
+}
+
+func TestTag_MergeVerify(t *testing.T) {
+	tag := Tag{Lifecycle: DefLifecycle{CreateForbidden: true}}
+
+	out, violations, err := tag.MergeVerify(context.Background(), MergeVerifyInput{})
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %+v", violations)
+	}
+	if want, got := enum.MergeMethods, out.AllowedMethods; !reflect.DeepEqual(want, got) {
+		t.Errorf("allowed methods mismatch: want=%v got=%v", want, got)
+	}
+}
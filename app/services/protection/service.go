@@ -36,6 +36,7 @@ type (
 		MergeVerifier
 		RefChangeVerifier
 		CreatePullReqVerifier
+		PushVerifier
 		UserIDs() ([]int64, error)
 		UserGroupIDs() ([]int64, error)
 	}
@@ -58,6 +58,7 @@ func (s ruleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
 			out.DefaultReviewerApprovals = append(out.DefaultReviewerApprovals, rOut.DefaultReviewerApprovals...)
 
 			return nil
@@ -123,6 +124,7 @@ func (s ruleSet) CreatePullReqVerify(
 			violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
 			out.RequestCodeOwners = out.RequestCodeOwners || rOut.RequestCodeOwners
 			out.DefaultReviewerIDs = append(out.DefaultReviewerIDs, rOut.DefaultReviewerIDs...)
+			out.ReviewerAutoAssign = append(out.ReviewerAutoAssign, rOut.ReviewerAutoAssign...)
 
 			return nil
 		})
@@ -159,6 +161,32 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 	return violations, nil
 }
 
+func (s ruleSet) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	var violations []types.RuleViolations
+
+	// the commits are loaded at most once and only if a rule requires them.
+	if in.Commits != nil {
+		in.Commits = onceCommits(in.Commits)
+	}
+
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.RefName,
+		func(r *types.RuleInfoInternal, p Protection) error {
+			rVs, err := p.PushVerify(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to process each rule in ruleSet: %w", err)
+	}
+
+	return violations, nil
+}
+
 func (s ruleSet) UserIDs() ([]int64, error) {
 	mapIDs := make(map[int64]struct{})
 	err := s.forEachRule(func(_ *types.RuleInfoInternal, p Protection) error {
@@ -297,6 +325,24 @@ func (s ruleSet) forEachRuleMatchRefs(
 	return nil
 }
 
+func onceCommits(
+	fn func(ctx context.Context) ([]PushCommit, error),
+) func(ctx context.Context) ([]PushCommit, error) {
+	var (
+		loaded  bool
+		commits []PushCommit
+		err     error
+	)
+
+	return func(ctx context.Context) ([]PushCommit, error) {
+		if !loaded {
+			commits, err = fn(ctx)
+			loaded = true
+		}
+		return commits, err
+	}
+}
+
 func backFillRule(vs []types.RuleViolations, rule types.RuleInfo) []types.RuleViolations {
 	for i := range vs {
 		vs[i].Rule = rule
@@ -0,0 +1,276 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	PushVerifier interface {
+		PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error)
+	}
+
+	PushVerifyInput struct {
+		ResolveUserGroupID func(ctx context.Context, userGroupIDs []int64) ([]int64, error)
+		Actor              *types.Principal
+		AllowBypass        bool
+		IsRepoOwner        bool
+		Repo               *types.RepositoryCore
+		RefType            RefType
+		// RefName is the name of the pushed branch or tag, or the full name of any other reference.
+		RefName string
+
+		// Commits returns the commits that are new to the repository and pushed to the reference.
+		// It's only called if a rule applies to the reference. It returns more than MaxPushCommits commits
+		// if the push contains more commits than can be verified.
+		Commits func(ctx context.Context) ([]PushCommit, error)
+
+		// SignatureStatuses returns the signature verification statuses of the provided commits.
+		// Unsigned commits are not included in the returned map.
+		SignatureStatuses func(ctx context.Context, commitSHAs []string) (map[string]enum.GitSignatureStatus, error)
+	}
+
+	// PushCommit holds the information about a pushed commit required to verify push rules.
+	PushCommit struct {
+		SHA            string
+		Message        string
+		AuthorEmail    string
+		CommitterEmail string
+		// Paths contains the paths of the files added or modified by the commit.
+		Paths []string
+	}
+
+	DefCommits struct {
+		CommitMessagePattern string   `json:"commit_message_pattern,omitempty"`
+		DeniedPaths          []string `json:"denied_paths,omitempty"`
+		AllowedEmailDomains  []string `json:"allowed_email_domains,omitempty"`
+		MaxCommits           int      `json:"max_commits,omitempty"`
+	}
+)
+
+// MaxPushCommits is the max number of pushed commits that can be verified against push rules.
+// Pushes of more commits are rejected by the rules that verify the pushed commits.
+const MaxPushCommits = 1000
+
+// maxPushViolationsPerCode limits the number of reported violations of the same kind.
+const maxPushViolationsPerCode = 10
+
+// ensures that the DefCommits type implements Sanitizer and PushVerifier interfaces.
+var (
+	_ Sanitizer    = (*DefCommits)(nil)
+	_ PushVerifier = (*DefCommits)(nil)
+)
+
+const (
+	codePushCommitMessage  = "push.commit_message"
+	codePushDeniedPath     = "push.denied_path"
+	codePushAuthorEmail    = "push.author_email"
+	codePushCommitterEmail = "push.committer_email"
+	codePushMaxCommits     = "push.max_commits"
+	codePushTooManyCommits = "push.too_many_commits"
+)
+
+func (v *DefCommits) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	if v.isEmpty() || in.Commits == nil {
+		return nil, nil
+	}
+
+	commits, err := in.Commits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pushed commits: %w", err)
+	}
+
+	var violations types.RuleViolations
+
+	if len(commits) > MaxPushCommits {
+		violations.Addf(codePushTooManyCommits,
+			"Push of more than %d commits to %s can't be verified.",
+			MaxPushCommits, in.refDescription())
+		return []types.RuleViolations{violations}, nil
+	}
+
+	if v.MaxCommits > 0 && len(commits) > v.MaxCommits {
+		violations.Addf(codePushMaxCommits,
+			"Push of more than %d commits to %s is not allowed.",
+			v.MaxCommits, in.refDescription())
+	}
+
+	var messageRegexp *regexp.Regexp
+	if v.CommitMessagePattern != "" {
+		messageRegexp, err = regexp.Compile(v.CommitMessagePattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile commit message pattern: %w", err)
+		}
+	}
+
+	counts := map[string]int{}
+	addf := func(code, format string, params ...any) {
+		if counts[code] < maxPushViolationsPerCode {
+			violations.Addf(code, format, params...)
+		}
+		counts[code]++
+	}
+
+	for _, commit := range commits {
+		if messageRegexp != nil && !messageRegexp.MatchString(commit.Message) {
+			addf(codePushCommitMessage,
+				"Message of commit %s doesn't match the required pattern %q.",
+				commit.SHA, v.CommitMessagePattern)
+		}
+
+		for _, path := range commit.Paths {
+			for _, pattern := range v.DeniedPaths {
+				if pathPatternMatches(pattern, path) {
+					addf(codePushDeniedPath,
+						"Commit %s contains file %q which matches the denied path pattern %q.",
+						commit.SHA, path, pattern)
+					break
+				}
+			}
+		}
+
+		if len(v.AllowedEmailDomains) > 0 {
+			if !emailDomainAllowed(commit.AuthorEmail, v.AllowedEmailDomains) {
+				addf(codePushAuthorEmail,
+					"Author email %q of commit %s is not from an allowed domain.",
+					commit.AuthorEmail, commit.SHA)
+			}
+			if !emailDomainAllowed(commit.CommitterEmail, v.AllowedEmailDomains) {
+				addf(codePushCommitterEmail,
+					"Committer email %q of commit %s is not from an allowed domain.",
+					commit.CommitterEmail, commit.SHA)
+			}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefCommits) isEmpty() bool {
+	return v.CommitMessagePattern == "" &&
+		len(v.DeniedPaths) == 0 &&
+		len(v.AllowedEmailDomains) == 0 &&
+		v.MaxCommits == 0
+}
+
+func (v *DefCommits) Sanitize() error {
+	if v.CommitMessagePattern != "" {
+		if _, err := regexp.Compile(v.CommitMessagePattern); err != nil {
+			return fmt.Errorf("invalid commit message pattern: %w", err)
+		}
+	}
+
+	if err := validateIdentifierSlice(v.DeniedPaths); err != nil {
+		return fmt.Errorf("denied paths: %w", err)
+	}
+
+	for _, pattern := range v.DeniedPaths {
+		if err := patternValidate(strings.Trim(pattern, "/")); err != nil {
+			return fmt.Errorf("denied path %q: %w", pattern, err)
+		}
+	}
+
+	if err := validateIdentifierSlice(v.AllowedEmailDomains); err != nil {
+		return fmt.Errorf("allowed email domains: %w", err)
+	}
+
+	for i, domain := range v.AllowedEmailDomains {
+		domain = strings.ToLower(strings.TrimPrefix(domain, "@"))
+		if domain == "" || strings.Contains(domain, "@") {
+			return fmt.Errorf("invalid email domain %q", v.AllowedEmailDomains[i])
+		}
+		v.AllowedEmailDomains[i] = domain
+	}
+
+	if v.MaxCommits < 0 {
+		return errors.New("max commits must not be negative")
+	}
+
+	if v.MaxCommits >= MaxPushCommits {
+		return fmt.Errorf("max commits must be less than %d", MaxPushCommits)
+	}
+
+	return nil
+}
+
+// refDescription returns the type and the name of the pushed reference for use in violation messages.
+func (in PushVerifyInput) refDescription() string {
+	switch in.RefType {
+	case RefTypeBranch:
+		return fmt.Sprintf("branch %q", in.RefName)
+	case RefTypeTag:
+		return fmt.Sprintf("tag %q", in.RefName)
+	case RefTypeRaw:
+	}
+	return fmt.Sprintf("reference %q", in.RefName)
+}
+
+// pathPatternMatches matches the file path against a pattern using gitignore-like semantics:
+// A pattern without a slash matches any path segment (e.g. "*.pem" matches "certs/key.pem"),
+// a pattern with a slash matches from the repository root (e.g. "config/*.yaml")
+// and a pattern with a trailing slash matches directories only (e.g. "vendor/").
+func pathPatternMatches(pattern, filePath string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	segments := strings.Split(filePath, "/")
+	if dirOnly {
+		segments = segments[:len(segments)-1]
+	}
+
+	for i := range segments {
+		candidate := segments[i]
+		if anchored {
+			candidate = strings.Join(segments[:i+1], "/")
+		}
+
+		if patternMatches(pattern, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emailDomainAllowed returns true if the domain of the email is one of the allowed domains or their subdomain.
+func emailDomainAllowed(email string, domains []string) bool {
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 {
+		return false
+	}
+
+	emailDomain := strings.ToLower(email[idx+1:])
+	for _, domain := range domains {
+		if emailDomain == domain || strings.HasSuffix(emailDomain, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
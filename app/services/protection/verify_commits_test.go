@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDefCommits_PushVerify(t *testing.T) {
+	commits := []PushCommit{
+		{
+			SHA:            "a1",
+			Message:        "PROJ-1: add feature",
+			AuthorEmail:    "dev@example.com",
+			CommitterEmail: "dev@ci.example.com",
+			Paths:          []string{"main.go", "certs/server.pem"},
+		},
+		{
+			SHA:            "a2",
+			Message:        "fix typo",
+			AuthorEmail:    "someone@gmail.com",
+			CommitterEmail: "dev@example.com",
+			Paths:          []string{"vendor/lib/lib.go", "docs/vendor.md"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		def      DefCommits
+		expCodes []string
+	}{
+		{
+			name:     "empty",
+			def:      DefCommits{},
+			expCodes: nil,
+		},
+		{
+			name:     "commit-message",
+			def:      DefCommits{CommitMessagePattern: `^[A-Z]+-\d+: `},
+			expCodes: []string{codePushCommitMessage},
+		},
+		{
+			name:     "denied-paths",
+			def:      DefCommits{DeniedPaths: []string{"*.pem", "vendor/"}},
+			expCodes: []string{codePushDeniedPath, codePushDeniedPath},
+		},
+		{
+			name:     "email-domains",
+			def:      DefCommits{AllowedEmailDomains: []string{"@Example.com"}},
+			expCodes: []string{codePushAuthorEmail},
+		},
+		{
+			name:     "max-commits",
+			def:      DefCommits{MaxCommits: 1},
+			expCodes: []string{codePushMaxCommits},
+		},
+		{
+			name:     "max-commits-not-exceeded",
+			def:      DefCommits{MaxCommits: 2},
+			expCodes: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.def.Sanitize(); err != nil {
+				t.Fatalf("def invalid: %s", err.Error())
+			}
+
+			violations, err := test.def.PushVerify(context.Background(), PushVerifyInput{
+				RefType: RefTypeBranch,
+				RefName: "main",
+				Commits: func(context.Context) ([]PushCommit, error) {
+					return commits, nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("got an error: %s", err.Error())
+			}
+
+			var codes []string
+			for _, ruleViolations := range violations {
+				for _, violation := range ruleViolations.Violations {
+					codes = append(codes, violation.Code)
+				}
+			}
+
+			if want, got := test.expCodes, codes; !reflect.DeepEqual(want, got) {
+				t.Errorf("codes mismatch: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
+
+func TestDefCommits_PushVerifyTooManyCommits(t *testing.T) {
+	commits := make([]PushCommit, MaxPushCommits+1)
+	for i := range commits {
+		commits[i] = PushCommit{SHA: fmt.Sprintf("a%d", i), Message: "PROJ-1: add feature"}
+	}
+
+	def := DefCommits{CommitMessagePattern: `^[A-Z]+-\d+: `}
+
+	violations, err := def.PushVerify(context.Background(), PushVerifyInput{
+		RefType: RefTypeTag,
+		RefName: "v1.0.0",
+		Commits: func(context.Context) ([]PushCommit, error) {
+			return commits, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	if len(violations) != 1 || len(violations[0].Violations) != 1 ||
+		violations[0].Violations[0].Code != codePushTooManyCommits {
+		t.Errorf("expected a single %s violation, got: %+v", codePushTooManyCommits, violations)
+	}
+}
+
+func TestDefCommits_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    DefCommits
+		expErr bool
+	}{
+		{name: "valid", def: DefCommits{CommitMessagePattern: `JIRA-\d+`, MaxCommits: 10}},
+		{name: "invalid-regex", def: DefCommits{CommitMessagePattern: `(`}, expErr: true},
+		{name: "invalid-path", def: DefCommits{DeniedPaths: []string{"["}}, expErr: true},
+		{name: "invalid-domain", def: DefCommits{AllowedEmailDomains: []string{"a@b.com"}}, expErr: true},
+		{name: "negative-max-commits", def: DefCommits{MaxCommits: -1}, expErr: true},
+		{name: "too-large-max-commits", def: DefCommits{MaxCommits: MaxPushCommits}, expErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestPathPatternMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		exp     bool
+	}{
+		{pattern: "*.pem", path: "key.pem", exp: true},
+		{pattern: "*.pem", path: "a/b/key.pem", exp: true},
+		{pattern: "*.pem", path: "key.pem.txt", exp: false},
+		{pattern: "vendor/", path: "vendor/a.go", exp: true},
+		{pattern: "vendor/", path: "lib/vendor/a.go", exp: true},
+		{pattern: "vendor/", path: "vendor", exp: false},
+		{pattern: "config/*.yaml", path: "config/app.yaml", exp: true},
+		{pattern: "config/*.yaml", path: "sub/config/app.yaml", exp: false},
+		{pattern: "/build", path: "build/out.bin", exp: true},
+		{pattern: "/build", path: "src/build", exp: false},
+	}
+
+	for _, test := range tests {
+		if got := pathPatternMatches(test.pattern, test.path); got != test.exp {
+			t.Errorf("pattern=%q path=%q: want=%t got=%t", test.pattern, test.path, test.exp, got)
+		}
+	}
+}
@@ -16,8 +16,10 @@ package protection
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type (
@@ -45,6 +47,9 @@ type (
 		DeleteForbidden      bool `json:"delete_forbidden,omitempty"`
 		UpdateForbidden      bool `json:"update_forbidden,omitempty"`
 		UpdateForceForbidden bool `json:"update_force_forbidden,omitempty"`
+		// RequireSignedCommits requires commits pushed to a branch to have a verified signature.
+		// It has no effect on tags.
+		RequireSignedCommits bool `json:"require_signed_commits,omitempty"`
 	}
 )
 
@@ -65,18 +70,35 @@ const (
 var (
 	_ Sanitizer         = (*DefLifecycle)(nil)
 	_ RefChangeVerifier = (*DefLifecycle)(nil)
+	_ PushVerifier      = (*DefLifecycle)(nil)
 )
 
 const (
-	codeLifecycleCreate      = "lifecycle.create"
-	codeLifecycleDelete      = "lifecycle.delete"
-	codeLifecycleUpdate      = "lifecycle.update"
-	codeLifecycleUpdateForce = "lifecycle.update.force"
+	codeLifecycleCreate         = "lifecycle.create"
+	codeLifecycleDelete         = "lifecycle.delete"
+	codeLifecycleUpdate         = "lifecycle.update"
+	codeLifecycleUpdateForce    = "lifecycle.update.force"
+	codeLifecycleSignedCommit   = "lifecycle.signed_commit"
+	codeLifecycleTooManyCommits = "lifecycle.too_many_commits"
 )
 
 func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations types.RuleViolations
 
+	if in.RefType == RefTypeTag {
+		v.verifyTag(&violations, in)
+	} else {
+		v.verifyBranch(&violations, in)
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefLifecycle) verifyBranch(violations *types.RuleViolations, in RefChangeVerifyInput) {
 	switch in.RefAction {
 	case RefActionCreate:
 		if v.CreateForbidden {
@@ -99,6 +121,89 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 				"Force push to branch %q is not allowed. Please use pull requests.", in.RefNames[0])
 		}
 	}
+}
+
+func (v *DefLifecycle) verifyTag(violations *types.RuleViolations, in RefChangeVerifyInput) {
+	switch in.RefAction {
+	case RefActionCreate:
+		if v.CreateForbidden {
+			violations.Addf(codeLifecycleCreate,
+				"Creation of tag %q is not allowed.", in.RefNames[0])
+		}
+	case RefActionDelete:
+		if v.DeleteForbidden {
+			violations.Addf(codeLifecycleDelete,
+				"Delete of tag %q is not allowed.", in.RefNames[0])
+		}
+	case RefActionUpdate, RefActionUpdateForce:
+		// Moving an existing tag always requires a force push, so either flag forbids it.
+		if v.UpdateForceForbidden || v.UpdateForbidden {
+			violations.Addf(codeLifecycleUpdateForce,
+				"Update of tag %q is not allowed.", in.RefNames[0])
+		}
+	}
+}
+
+// PushVerify verifies that all new commits pushed to a branch are signed with a verified signature.
+func (v *DefLifecycle) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	if !v.RequireSignedCommits || in.Commits == nil || in.SignatureStatuses == nil {
+		return nil, nil
+	}
+
+	commits, err := in.Commits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pushed commits: %w", err)
+	}
+
+	if len(commits) == 0 {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	// signatures of commits that weren't listed can't be verified, so such pushes are rejected.
+	if len(commits) > MaxPushCommits {
+		violations.Addf(codeLifecycleTooManyCommits,
+			"Signatures of more than %d commits pushed to %s can't be verified.",
+			MaxPushCommits, in.refDescription())
+		return []types.RuleViolations{violations}, nil
+	}
+
+	commitSHAs := make([]string, len(commits))
+	for i, commit := range commits {
+		commitSHAs[i] = commit.SHA
+	}
+
+	statuses, err := in.SignatureStatuses(ctx, commitSHAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signature statuses of pushed commits: %w", err)
+	}
+
+	count := 0
+	for _, commit := range commits {
+		status := statuses[commit.SHA]
+		if status == enum.GitSignatureStatusVerified {
+			continue
+		}
+
+		if count < maxPushViolationsPerCode {
+			if status == "" {
+				violations.Addf(codeLifecycleSignedCommit,
+					"Commit %s pushed to %s is not signed.", commit.SHA, in.refDescription())
+			} else {
+				violations.Addf(codeLifecycleSignedCommit,
+					"Commit %s pushed to %s doesn't have a verified signature (%s).",
+					commit.SHA, in.refDescription(), status)
+			}
+		}
+		count++
+	}
+
+	if count > maxPushViolationsPerCode {
+		violations.Addf(codeLifecycleSignedCommit,
+			"%d more commits pushed to %s don't have a verified signature.",
+			count-maxPushViolationsPerCode, in.refDescription())
+	}
 
 	if len(violations.Violations) > 0 {
 		return []types.RuleViolations{violations}, nil
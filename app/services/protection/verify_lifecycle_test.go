@@ -16,10 +16,12 @@ package protection
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // nolint:gocognit // it's a unit test
@@ -89,6 +91,57 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 	}
 }
 
+func TestDefLifecycle_PushVerify(t *testing.T) {
+	const branchName = "main"
+	const refDescription = `branch "main"`
+	commits := []PushCommit{{SHA: "a1"}, {SHA: "a2"}, {SHA: "a3"}}
+	statuses := map[string]enum.GitSignatureStatus{
+		"a1": enum.GitSignatureStatusVerified,
+		"a2": enum.GitSignatureStatusUnknownKey,
+	}
+
+	tests := []struct {
+		name      string
+		def       DefLifecycle
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "lifecycle.signed_commit-fail",
+			def:      DefLifecycle{RequireSignedCommits: true},
+			expCodes: []string{"lifecycle.signed_commit", "lifecycle.signed_commit"},
+			expParams: [][]any{
+				{"a2", refDescription, enum.GitSignatureStatusUnknownKey},
+				{"a3", refDescription},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := test.def.PushVerify(context.Background(), PushVerifyInput{
+				RefType: RefTypeBranch,
+				RefName: branchName,
+				Commits: func(context.Context) ([]PushCommit, error) {
+					return commits, nil
+				},
+				SignatureStatuses: func(context.Context, []string) (map[string]enum.GitSignatureStatus, error) {
+					return statuses, nil
+				},
+			})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
 func inspectBranchViolations(t *testing.T,
 	expCodes []string,
 	expParams [][]any,
@@ -119,3 +172,33 @@ func inspectBranchViolations(t *testing.T,
 		}
 	}
 }
+
+func TestDefLifecycle_PushVerifyTooManyCommits(t *testing.T) {
+	commits := make([]PushCommit, MaxPushCommits+1)
+	statuses := make(map[string]enum.GitSignatureStatus, len(commits))
+	for i := range commits {
+		commits[i] = PushCommit{SHA: fmt.Sprintf("a%d", i)}
+		statuses[commits[i].SHA] = enum.GitSignatureStatusVerified
+	}
+
+	def := DefLifecycle{RequireSignedCommits: true}
+
+	violations, err := def.PushVerify(context.Background(), PushVerifyInput{
+		RefType: RefTypeBranch,
+		RefName: "main",
+		Commits: func(context.Context) ([]PushCommit, error) {
+			return commits, nil
+		},
+		SignatureStatuses: func(context.Context, []string) (map[string]enum.GitSignatureStatus, error) {
+			return statuses, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	inspectBranchViolations(t,
+		[]string{codeLifecycleTooManyCommits},
+		[][]any{{MaxPushCommits, `branch "main"`}},
+		violations)
+}
@@ -46,6 +46,8 @@ type (
 		Method             enum.MergeMethod
 		CheckResults       []types.CheckResult
 		CodeOwners         *codeowners.Evaluation
+		// MergeQueue should be set if the pull request is going to be merged via the merge queue.
+		MergeQueue bool
 	}
 
 	MergeVerifyOutput struct {
@@ -57,6 +59,7 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		RequiresMergeQueue                  bool
 		DefaultReviewerApprovals            []*types.DefaultReviewerApprovalsResponse
 	}
 
@@ -92,6 +95,7 @@ type (
 	CreatePullReqVerifyOutput struct {
 		RequestCodeOwners  bool
 		DefaultReviewerIDs []int64
+		ReviewerAutoAssign []DefReviewerAutoAssign
 	}
 )
 
@@ -115,10 +119,12 @@ const (
 	codePullReqApprovalReqCodeOwnersNoApproval       = "pullreq.approvals.require_code_owners:no_approval"
 	codePullReqApprovalReqCodeOwnersChangeRequested  = "pullreq.approvals.require_code_owners:change_requested"
 	codePullReqApprovalReqCodeOwnersNoLatestApproval = "pullreq.approvals.require_code_owners:no_latest_approval"
+	codePullReqApprovalReqCodeOwnersMinCount         = "pullreq.approvals.require_code_owners:minimum_count"
 
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
+	codePullReqMergeReqMergeQueue     = "pullreq.merge.require_merge_queue"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
@@ -136,6 +142,7 @@ func (v *DefPullReq) MergeVerify(
 	out.DeleteSourceBranch = v.Merge.DeleteBranch
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
+	out.RequiresMergeQueue = v.Merge.RequireMergeQueue
 
 	// output that depends on approval of latest commit
 	if v.Approvals.RequireLatestCommit {
@@ -236,32 +243,8 @@ func (v *DefPullReq) MergeVerify(
 	}
 
 	if v.Approvals.RequireCodeOwners {
-		for _, entry := range in.CodeOwners.EvaluationEntries {
-			reviewDecision, approvers := getCodeOwnerApprovalStatus(entry)
-
-			if reviewDecision == enum.PullReqReviewDecisionPending {
-				violations.Addf(codePullReqApprovalReqCodeOwnersNoApproval,
-					"Code owners approval pending for %q", entry.Pattern)
-				continue
-			}
-
-			if reviewDecision == enum.PullReqReviewDecisionChangeReq {
-				violations.Addf(codePullReqApprovalReqCodeOwnersChangeRequested,
-					"Code owners requested changes for %q", entry.Pattern)
-				continue
-			}
-
-			// pull req approved. check other settings
-			if !v.Approvals.RequireLatestCommit {
-				continue
-			}
-			latestSHAApproved := slices.ContainsFunc(approvers, func(ev codeowners.OwnerEvaluation) bool {
-				return ev.ReviewSHA == in.PullReq.SourceSHA
-			})
-			if !latestSHAApproved {
-				violations.Addf(codePullReqApprovalReqCodeOwnersNoLatestApproval,
-					"Code owners approval pending on latest commit for %q", entry.Pattern)
-			}
+		for _, section := range in.CodeOwners.EvaluationSections {
+			verifyCodeOwnersSection(&violations, section, in.PullReq.SourceSHA, v.Approvals.RequireLatestCommit)
 		}
 	}
 
@@ -323,6 +306,12 @@ func (v *DefPullReq) MergeVerify(
 			"The merge for the branch %s is not allowed.", in.PullReq.TargetBranch)
 	}
 
+	if v.Merge.RequireMergeQueue && !in.MergeQueue {
+		violations.Addf(
+			codePullReqMergeReqMergeQueue,
+			"Pull requests targeting the branch %s must be merged via the merge queue.", in.PullReq.TargetBranch)
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -351,6 +340,7 @@ func (v *DefPullReq) CreatePullReqVerify(
 
 	out.RequestCodeOwners = v.Reviewers.RequestCodeOwners
 	out.DefaultReviewerIDs = v.Reviewers.DefaultReviewerIDs
+	out.ReviewerAutoAssign = v.Reviewers.AutoAssign
 
 	return out, nil, nil
 }
@@ -436,6 +426,7 @@ type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
 	Block             bool               `json:"block,omitempty"`
+	RequireMergeQueue bool               `json:"require_merge_queue,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -458,8 +449,49 @@ func (v *DefMerge) Sanitize() error {
 }
 
 type DefReviewers struct {
-	RequestCodeOwners  bool    `json:"request_code_owners,omitempty"`
-	DefaultReviewerIDs []int64 `json:"default_reviewer_ids,omitempty"`
+	RequestCodeOwners  bool                    `json:"request_code_owners,omitempty"`
+	DefaultReviewerIDs []int64                 `json:"default_reviewer_ids,omitempty"`
+	AutoAssign         []DefReviewerAutoAssign `json:"auto_assign,omitempty"`
+}
+
+func (v *DefReviewers) Sanitize() error {
+	for i := range v.AutoAssign {
+		if err := v.AutoAssign[i].Sanitize(); err != nil {
+			return fmt.Errorf("auto assign: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DefReviewerAutoAssign defines automatic assignment of a number of reviewers
+// picked either from a user group or from the code owners of the changed files.
+type DefReviewerAutoAssign struct {
+	UserGroupID int64                           `json:"user_group_id,omitempty"`
+	CodeOwners  bool                            `json:"code_owners,omitempty"`
+	Count       int                             `json:"count"`
+	Strategy    enum.ReviewerAssignmentStrategy `json:"strategy"`
+}
+
+func (v *DefReviewerAutoAssign) Sanitize() error {
+	if (v.UserGroupID > 0) == v.CodeOwners {
+		return errors.New("exactly one of user group or code owners must be provided")
+	}
+
+	if v.UserGroupID < 0 {
+		return errors.New("user group ID must be a positive integer")
+	}
+
+	if v.Count < 1 {
+		return errors.New("count must be a positive integer")
+	}
+
+	var ok bool
+	if v.Strategy, ok = v.Strategy.Sanitize(); !ok {
+		return fmt.Errorf("unrecognized strategy: %s", v.Strategy)
+	}
+
+	return nil
 }
 
 type DefPush struct {
@@ -495,9 +527,81 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.Reviewers.Sanitize(); err != nil {
+		return fmt.Errorf("reviewers: %w", err)
+	}
+
 	return nil
 }
 
+// verifyCodeOwnersSection verifies that every entry of a required code owners section
+// has the required number of approvals from its owners. Optional sections are skipped.
+func verifyCodeOwnersSection(
+	violations *types.RuleViolations,
+	section codeowners.EvaluationSection,
+	sourceSHA string,
+	requireLatestCommit bool,
+) {
+	if section.Optional {
+		return
+	}
+
+	required := max(section.RequiredApprovals, 1)
+
+	// the section name is reported only for named sections.
+	addf := func(code, format string, entry codeowners.EvaluationEntry, params ...any) {
+		if section.Name == "" {
+			violations.Addf(code, format, append([]any{entry.Pattern}, params...)...)
+			return
+		}
+		violations.Addf(code, format+" in section %q", append(append([]any{entry.Pattern}, params...), section.Name)...)
+	}
+
+	for _, entry := range section.EvaluationEntries {
+		reviewDecision, approvers := getCodeOwnerApprovalStatus(entry)
+
+		if reviewDecision == enum.PullReqReviewDecisionPending {
+			addf(codePullReqApprovalReqCodeOwnersNoApproval, "Code owners approval pending for %q", entry)
+			continue
+		}
+
+		if reviewDecision == enum.PullReqReviewDecisionChangeReq {
+			addf(codePullReqApprovalReqCodeOwnersChangeRequested, "Code owners requested changes for %q", entry)
+			continue
+		}
+
+		if count := countCodeOwnerApprovers(approvers, ""); count < required {
+			addf(codePullReqApprovalReqCodeOwnersMinCount,
+				"Insufficient number of code owners approvals for %q. Have %d but need at least %d",
+				entry, count, required)
+			continue
+		}
+
+		// pull req approved. check other settings
+		if !requireLatestCommit {
+			continue
+		}
+
+		if countCodeOwnerApprovers(approvers, sourceSHA) < required {
+			addf(codePullReqApprovalReqCodeOwnersNoLatestApproval,
+				"Code owners approval pending on latest commit for %q", entry)
+		}
+	}
+}
+
+// countCodeOwnerApprovers returns the number of distinct approvers, optionally only of the provided commit SHA.
+func countCodeOwnerApprovers(approvers []codeowners.OwnerEvaluation, sha string) int {
+	approverIDs := make(map[int64]struct{}, len(approvers))
+	for _, approver := range approvers {
+		if sha != "" && approver.ReviewSHA != sha {
+			continue
+		}
+		approverIDs[approver.Owner.ID] = struct{}{}
+	}
+
+	return len(approverIDs)
+}
+
 func getCodeOwnerApprovalStatus(
 	entry codeowners.EvaluationEntry,
 ) (enum.PullReqReviewDecision, []codeowners.OwnerEvaluation) {
@@ -378,24 +378,27 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			in: MergeVerifyInput{
 				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
 				CodeOwners: &codeowners.Evaluation{
-					EvaluationEntries: []codeowners.EvaluationEntry{
-						{
-							Pattern: "app",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionPending, ReviewSHA: "abc"},
+					EvaluationSections: []codeowners.EvaluationSection{{
+						RequiredApprovals: 1,
+						EvaluationEntries: []codeowners.EvaluationEntry{
+							{
+								Pattern: "app",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionPending, ReviewSHA: "abc"},
+								},
 							},
-						},
-						{
-							Pattern: "doc",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+							{
+								Pattern: "doc",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+								},
+							},
+							{
+								Pattern:          "data",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{},
 							},
 						},
-						{
-							Pattern:          "data",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{},
-						},
-					},
+					}},
 					FileSha: "xyz",
 				},
 				Method: enum.MergeMethodMerge,
@@ -416,20 +419,23 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			in: MergeVerifyInput{
 				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
 				CodeOwners: &codeowners.Evaluation{
-					EvaluationEntries: []codeowners.EvaluationEntry{
-						{
-							Pattern: "app",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+					EvaluationSections: []codeowners.EvaluationSection{{
+						RequiredApprovals: 1,
+						EvaluationEntries: []codeowners.EvaluationEntry{
+							{
+								Pattern: "app",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+								},
 							},
-						},
-						{
-							Pattern: "doc",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+							{
+								Pattern: "doc",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+								},
 							},
 						},
-					},
+					}},
 					FileSha: "xyz",
 				},
 				Method: enum.MergeMethodMerge,
@@ -445,22 +451,25 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			in: MergeVerifyInput{
 				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
 				CodeOwners: &codeowners.Evaluation{
-					EvaluationEntries: []codeowners.EvaluationEntry{
-						{
-							Pattern: "app",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
-								{ReviewDecision: enum.PullReqReviewDecisionChangeReq, ReviewSHA: "abc"},
-								{ReviewDecision: enum.PullReqReviewDecisionPending, ReviewSHA: "abc"},
+					EvaluationSections: []codeowners.EvaluationSection{{
+						RequiredApprovals: 1,
+						EvaluationEntries: []codeowners.EvaluationEntry{
+							{
+								Pattern: "app",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+									{ReviewDecision: enum.PullReqReviewDecisionChangeReq, ReviewSHA: "abc"},
+									{ReviewDecision: enum.PullReqReviewDecisionPending, ReviewSHA: "abc"},
+								},
 							},
-						},
-						{
-							Pattern: "data",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+							{
+								Pattern: "data",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+								},
 							},
 						},
-					},
+					}},
 					FileSha: "xyz",
 				},
 				Method: enum.MergeMethodMerge,
@@ -478,21 +487,24 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			in: MergeVerifyInput{
 				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
 				CodeOwners: &codeowners.Evaluation{
-					EvaluationEntries: []codeowners.EvaluationEntry{
-						{
-							Pattern: "data",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+					EvaluationSections: []codeowners.EvaluationSection{{
+						RequiredApprovals: 1,
+						EvaluationEntries: []codeowners.EvaluationEntry{
+							{
+								Pattern: "data",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+								},
 							},
-						},
-						{
-							Pattern: "app",
-							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+							{
+								Pattern: "app",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+									{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+								},
 							},
 						},
-					},
+					}},
 					FileSha: "xyz",
 				},
 				Method: enum.MergeMethodMerge,
@@ -504,6 +516,98 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				RequiresCodeOwnersApprovalLatest: true,
 			},
 		},
+		{
+			name: codePullReqApprovalReqCodeOwnersMinCount + "-fail",
+			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				CodeOwners: &codeowners.Evaluation{
+					EvaluationSections: []codeowners.EvaluationSection{
+						{
+							Name:              "Backend",
+							RequiredApprovals: 2,
+							EvaluationEntries: []codeowners.EvaluationEntry{
+								{
+									Pattern: "app",
+									OwnerEvaluations: []codeowners.OwnerEvaluation{
+										{
+											Owner:          types.PrincipalInfo{ID: 1},
+											ReviewDecision: enum.PullReqReviewDecisionApproved,
+											ReviewSHA:      "abc",
+										},
+										{
+											Owner:          types.PrincipalInfo{ID: 2},
+											ReviewDecision: enum.PullReqReviewDecisionPending,
+										},
+									},
+								},
+							},
+						},
+						{
+							Name:              "Docs",
+							Optional:          true,
+							RequiredApprovals: 1,
+							EvaluationEntries: []codeowners.EvaluationEntry{
+								{
+									Pattern: "docs",
+									OwnerEvaluations: []codeowners.OwnerEvaluation{
+										{ReviewDecision: enum.PullReqReviewDecisionPending},
+									},
+								},
+							},
+						},
+					},
+					FileSha: "xyz",
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqCodeOwnersMinCount},
+			expParams: [][]any{{"app", 1, 2, "Backend"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:             enum.MergeMethods,
+				RequiresCodeOwnersApproval: true,
+			},
+		},
+		{
+			name: codePullReqApprovalReqCodeOwnersMinCount + "-success",
+			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				CodeOwners: &codeowners.Evaluation{
+					EvaluationSections: []codeowners.EvaluationSection{{
+						Name:              "Backend",
+						RequiredApprovals: 2,
+						EvaluationEntries: []codeowners.EvaluationEntry{
+							{
+								Pattern: "app",
+								OwnerEvaluations: []codeowners.OwnerEvaluation{
+									{
+										Owner:          types.PrincipalInfo{ID: 1},
+										ReviewDecision: enum.PullReqReviewDecisionApproved,
+										ReviewSHA:      "abc",
+									},
+								},
+								UserGroupOwnerEvaluations: []codeowners.UserGroupOwnerEvaluation{{
+									Evaluations: []codeowners.OwnerEvaluation{
+										{
+											Owner:          types.PrincipalInfo{ID: 2},
+											ReviewDecision: enum.PullReqReviewDecisionApproved,
+											ReviewSHA:      "abc",
+										},
+									},
+								}},
+							},
+						},
+					}},
+					FileSha: "xyz",
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:             enum.MergeMethods,
+				RequiresCodeOwnersApproval: true,
+			},
+		},
 		{
 			name: codePullReqCommentsReqResolveAll + "-fail",
 			def:  DefPullReq{Comments: DefComments{RequireResolveAll: true}},
@@ -715,6 +819,33 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqMergeReqMergeQueue + "-fail",
+			def:  DefPullReq{Merge: DefMerge{RequireMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodMerge,
+				PullReq: &types.PullReq{TargetBranch: "abc"},
+			},
+			expCodes:  []string{codePullReqMergeReqMergeQueue},
+			expParams: [][]any{{"abc"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
+		{
+			name: codePullReqMergeReqMergeQueue + "-success",
+			def:  DefPullReq{Merge: DefMerge{RequireMergeQueue: true}},
+			in: MergeVerifyInput{
+				Method:     enum.MergeMethodMerge,
+				PullReq:    &types.PullReq{TargetBranch: "abc"},
+				MergeQueue: true,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -738,3 +869,58 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestDefReviewers_Sanitize(t *testing.T) {
+	tests := []struct {
+		name        string
+		def         DefReviewers
+		expErr      bool
+		expStrategy enum.ReviewerAssignmentStrategy
+	}{
+		{
+			name:        "user-group-default-strategy",
+			def:         DefReviewers{AutoAssign: []DefReviewerAutoAssign{{UserGroupID: 1, Count: 2}}},
+			expStrategy: enum.ReviewerAssignmentStrategyRoundRobin,
+		},
+		{
+			name: "code-owners-least-loaded",
+			def: DefReviewers{AutoAssign: []DefReviewerAutoAssign{
+				{CodeOwners: true, Count: 1, Strategy: enum.ReviewerAssignmentStrategyLeastLoaded},
+			}},
+			expStrategy: enum.ReviewerAssignmentStrategyLeastLoaded,
+		},
+		{
+			name:   "no-source",
+			def:    DefReviewers{AutoAssign: []DefReviewerAutoAssign{{Count: 1}}},
+			expErr: true,
+		},
+		{
+			name:   "both-sources",
+			def:    DefReviewers{AutoAssign: []DefReviewerAutoAssign{{UserGroupID: 1, CodeOwners: true, Count: 1}}},
+			expErr: true,
+		},
+		{
+			name:   "zero-count",
+			def:    DefReviewers{AutoAssign: []DefReviewerAutoAssign{{UserGroupID: 1}}},
+			expErr: true,
+		},
+		{
+			name:   "unknown-strategy",
+			def:    DefReviewers{AutoAssign: []DefReviewerAutoAssign{{UserGroupID: 1, Count: 1, Strategy: "random"}}},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t, got: %v", test.expErr, err)
+			}
+
+			if err == nil && test.def.AutoAssign[0].Strategy != test.expStrategy {
+				t.Errorf("strategy mismatch: want=%s got=%s", test.expStrategy, test.def.AutoAssign[0].Strategy)
+			}
+		})
+	}
+}
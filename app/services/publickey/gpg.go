@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// TypeGPG is the key type of GPG public keys.
+const TypeGPG = "gpg"
+
+const gpgPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// IsGPG returns true if the key data is an armored GPG public key.
+func IsGPG(keyData string) bool {
+	return strings.HasPrefix(strings.TrimSpace(keyData), gpgPublicKeyHeader)
+}
+
+func ParseGPG(keyData string) (GPGKeyInfo, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return GPGKeyInfo{}, errors.InvalidArgument("failed to read GPG key: %s", err)
+	}
+
+	if len(entities) != 1 {
+		return GPGKeyInfo{}, errors.InvalidArgument("exactly one GPG key must be provided")
+	}
+
+	return GPGKeyInfo{
+		Entity: entities[0],
+	}, nil
+}
+
+type GPGKeyInfo struct {
+	Entity *openpgp.Entity
+}
+
+// Fingerprint returns the fingerprint of the primary key as an upper case hex string.
+func (key GPGKeyInfo) Fingerprint() string {
+	return strings.ToUpper(hex.EncodeToString(key.Entity.PrimaryKey.Fingerprint))
+}
+
+// Comment returns the primary identity of the key, usually in form of "Full Name <email@example.com>".
+func (key GPGKeyInfo) Comment() string {
+	identity := key.Entity.PrimaryIdentity()
+	if identity == nil {
+		return ""
+	}
+
+	return identity.Name
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/rs/zerolog/log"
+)
+
+// maxSigningKeysPerPrincipal is the maximum number of signing keys of a principal used for verification.
+const maxSigningKeysPerPrincipal = 100
+
+// SignatureVerifier verifies commit signatures against the signing keys registered by the users.
+type SignatureVerifier struct {
+	git            git.Interface
+	publicKeyStore store.PublicKeyStore
+	principalStore store.PrincipalStore
+}
+
+func NewSignatureVerifier(
+	gitInterface git.Interface,
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+) *SignatureVerifier {
+	return &SignatureVerifier{
+		git:            gitInterface,
+		publicKeyStore: publicKeyStore,
+		principalStore: principalStore,
+	}
+}
+
+// VerifyCommits returns the signature verification status of the provided commits.
+// Unsigned commits are not included in the returned map.
+func (v *SignatureVerifier) VerifyCommits(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commitSHAs []string,
+) (map[string]enum.GitSignatureStatus, error) {
+	if len(commitSHAs) == 0 {
+		return map[string]enum.GitSignatureStatus{}, nil
+	}
+
+	out, err := v.git.GetCommitSignatures(ctx, &git.GetCommitSignaturesParams{
+		ReadParams: readParams,
+		CommitSHAs: commitSHAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	keyCache := map[string][]types.PublicKey{}
+	statuses := make(map[string]enum.GitSignatureStatus, len(out.Signatures))
+
+	for _, signature := range out.Signatures {
+		status, err := v.verify(ctx, signature, keyCache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", signature.CommitSHA, err)
+		}
+
+		statuses[signature.CommitSHA.String()] = status
+	}
+
+	return statuses, nil
+}
+
+// FillCommitSignatureStatuses sets the signature status of the provided commits.
+// Failure to verify the signatures is logged, but isn't considered to be an error.
+func (v *SignatureVerifier) FillCommitSignatureStatuses(
+	ctx context.Context,
+	readParams git.ReadParams,
+	commits []types.Commit,
+) {
+	commitSHAs := make([]string, len(commits))
+	for i := range commits {
+		commitSHAs[i] = commits[i].SHA
+	}
+
+	statuses, err := v.VerifyCommits(ctx, readParams, commitSHAs)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to verify commit signatures")
+		return
+	}
+
+	for i := range commits {
+		commits[i].SignatureStatus = statuses[commits[i].SHA]
+	}
+}
+
+func (v *SignatureVerifier) verify(
+	ctx context.Context,
+	signature git.CommitSignature,
+	keyCache map[string][]types.PublicKey,
+) (enum.GitSignatureStatus, error) {
+	email := strings.ToLower(signature.Committer.Identity.Email)
+
+	keys, ok := keyCache[email]
+	if !ok {
+		var err error
+		keys, err = v.listSigningKeys(ctx, email)
+		if err != nil {
+			return "", err
+		}
+
+		keyCache[email] = keys
+	}
+
+	if isSSHSignature(signature.Signature) {
+		return verifySSH(signature, keys), nil
+	}
+
+	return verifyGPG(signature, keys), nil
+}
+
+// listSigningKeys returns the signing keys of the principal with the provided email address.
+func (v *SignatureVerifier) listSigningKeys(ctx context.Context, email string) ([]types.PublicKey, error) {
+	principal, err := v.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return []types.PublicKey{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	keys, err := v.publicKeyStore.List(ctx, principal.ID, &types.PublicKeyFilter{
+		ListQueryFilter: types.ListQueryFilter{
+			Pagination: types.Pagination{
+				Page: 1,
+				Size: maxSigningKeysPerPrincipal,
+			},
+		},
+		Sort:   enum.PublicKeySortCreated,
+		Order:  enum.OrderAsc,
+		Usages: []enum.PublicKeyUsage{enum.PublicKeyUsageSign},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys of principal: %w", err)
+	}
+
+	return keys, nil
+}
+
+func verifySSH(signature git.CommitSignature, keys []types.PublicKey) enum.GitSignatureStatus {
+	sshSig, err := parseSSHSignature(signature.Signature)
+	if err != nil {
+		return enum.GitSignatureStatusUnverified
+	}
+
+	key := From(sshSig.PublicKey)
+
+	for _, k := range keys {
+		if k.Type == TypeGPG || !key.Matches(k.Content) {
+			continue
+		}
+
+		if err = sshSig.verify([]byte(signature.Payload)); err != nil {
+			return enum.GitSignatureStatusUnverified
+		}
+
+		return enum.GitSignatureStatusVerified
+	}
+
+	return enum.GitSignatureStatusUnknownKey
+}
+
+func verifyGPG(signature git.CommitSignature, keys []types.PublicKey) enum.GitSignatureStatus {
+	var keyRing openpgp.EntityList
+	for _, k := range keys {
+		if k.Type != TypeGPG {
+			continue
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.Content))
+		if err != nil {
+			continue
+		}
+
+		keyRing = append(keyRing, entities...)
+	}
+
+	if len(keyRing) == 0 {
+		return enum.GitSignatureStatusUnknownKey
+	}
+
+	_, err := openpgp.CheckArmoredDetachedSignature(
+		keyRing,
+		strings.NewReader(signature.Payload),
+		strings.NewReader(signature.Signature),
+		&packet.Config{Time: func() time.Time { return signature.Committer.When }},
+	)
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		return enum.GitSignatureStatusUnknownKey
+	}
+	if err != nil {
+		return enum.GitSignatureStatusUnverified
+	}
+
+	return enum.GitSignatureStatusVerified
+}
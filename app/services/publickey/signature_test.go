@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const testPayload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+	"author Jane <jane@example.com> 1700000000 +0000\n" +
+	"committer Jane <jane@example.com> 1700000000 +0000\n\ninitial commit\n"
+
+func TestVerifySSH(t *testing.T) {
+	signer, authorizedKey := generateSSHKey(t)
+	_, otherKey := generateSSHKey(t)
+
+	signature := git.CommitSignature{
+		Signature: signSSH(t, signer, testPayload),
+		Payload:   testPayload,
+	}
+
+	tests := []struct {
+		name      string
+		keys      []types.PublicKey
+		payload   string
+		expStatus enum.GitSignatureStatus
+	}{
+		{
+			name:      "verified",
+			keys:      []types.PublicKey{{Content: otherKey}, {Content: authorizedKey}},
+			payload:   testPayload,
+			expStatus: enum.GitSignatureStatusVerified,
+		},
+		{
+			name:      "unverified",
+			keys:      []types.PublicKey{{Content: authorizedKey}},
+			payload:   testPayload + "tampered",
+			expStatus: enum.GitSignatureStatusUnverified,
+		},
+		{
+			name:      "unknown-key",
+			keys:      []types.PublicKey{{Content: otherKey}},
+			payload:   testPayload,
+			expStatus: enum.GitSignatureStatusUnknownKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature.Payload = test.payload
+			if got := verifySSH(signature, test.keys); got != test.expStatus {
+				t.Errorf("want=%s got=%s", test.expStatus, got)
+			}
+		})
+	}
+}
+
+func TestVerifyGPG(t *testing.T) {
+	entity, armoredKey := generateGPGKey(t, "Jane <jane@example.com>")
+	_, otherKey := generateGPGKey(t, "John <john@example.com>")
+
+	sig := &bytes.Buffer{}
+	if err := openpgp.ArmoredDetachSign(sig, entity, strings.NewReader(testPayload), nil); err != nil {
+		t.Fatalf("failed to sign: %s", err)
+	}
+
+	tests := []struct {
+		name      string
+		keys      []types.PublicKey
+		payload   string
+		expStatus enum.GitSignatureStatus
+	}{
+		{
+			name:      "verified",
+			keys:      []types.PublicKey{{Type: TypeGPG, Content: otherKey}, {Type: TypeGPG, Content: armoredKey}},
+			payload:   testPayload,
+			expStatus: enum.GitSignatureStatusVerified,
+		},
+		{
+			name:      "unverified",
+			keys:      []types.PublicKey{{Type: TypeGPG, Content: armoredKey}},
+			payload:   testPayload + "tampered",
+			expStatus: enum.GitSignatureStatusUnverified,
+		},
+		{
+			name:      "unknown-key",
+			keys:      []types.PublicKey{{Type: TypeGPG, Content: otherKey}},
+			payload:   testPayload,
+			expStatus: enum.GitSignatureStatusUnknownKey,
+		},
+		{
+			name:      "no-keys",
+			payload:   testPayload,
+			expStatus: enum.GitSignatureStatusUnknownKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature := git.CommitSignature{
+				Committer: git.Signature{When: time.Now()},
+				Signature: sig.String(),
+				Payload:   test.payload,
+			}
+			if got := verifyGPG(signature, test.keys); got != test.expStatus {
+				t.Errorf("want=%s got=%s", test.expStatus, got)
+			}
+		})
+	}
+}
+
+func TestParseGPG(t *testing.T) {
+	entity, armoredKey := generateGPGKey(t, "Jane <jane@example.com>")
+
+	if !IsGPG(armoredKey) {
+		t.Fatal("expected key to be recognized as GPG key")
+	}
+
+	key, err := ParseGPG(armoredKey)
+	if err != nil {
+		t.Fatalf("failed to parse GPG key: %s", err)
+	}
+
+	if want, got := entity.PrimaryKey.KeyIdString(), key.Fingerprint()[24:]; want != got {
+		t.Errorf("fingerprint mismatch: key id=%s fingerprint=%s", want, key.Fingerprint())
+	}
+
+	if want, got := "Jane <jane@example.com>", key.Comment(); want != got {
+		t.Errorf("comment mismatch: want=%s got=%s", want, got)
+	}
+}
+
+func generateSSHKey(t *testing.T) (gossh.Signer, string) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %s", err)
+	}
+
+	return signer, string(gossh.MarshalAuthorizedKey(signer.PublicKey()))
+}
+
+func signSSH(t *testing.T, signer gossh.Signer, message string) string {
+	h := sha512.Sum512([]byte(message))
+	signed := gossh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          h[:],
+	})
+
+	signature, err := signer.Sign(rand.Reader, append([]byte(sshSignatureMagic), signed...))
+	if err != nil {
+		t.Fatalf("failed to sign: %s", err)
+	}
+
+	blob := gossh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{
+		Version:       sshSignatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     gossh.Marshal(signature),
+	})
+
+	return sshSignatureHeader + "\n" +
+		base64.StdEncoding.EncodeToString(append([]byte(sshSignatureMagic), blob...)) + "\n" +
+		sshSignatureFooter + "\n"
+}
+
+func generateGPGKey(t *testing.T, identity string) (*openpgp.Entity, string) {
+	name, email, _ := strings.Cut(strings.TrimSuffix(identity, ">"), " <")
+	entity, err := openpgp.NewEntity(name, "", email, nil)
+	if err != nil {
+		t.Fatalf("failed to generate GPG key: %s", err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("failed to create armor encoder: %s", err)
+	}
+
+	if err = entity.Serialize(w); err != nil {
+		t.Fatalf("failed to serialize GPG key: %s", err)
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatalf("failed to close armor encoder: %s", err)
+	}
+
+	return entity, buf.String()
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// The SSH signature format is described here:
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+
+const (
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureFooter = "-----END SSH SIGNATURE-----"
+
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignatureNamespace = "git"
+)
+
+type sshSignature struct {
+	PublicKey     gossh.PublicKey
+	Namespace     string
+	HashAlgorithm string
+	Signature     *gossh.Signature
+}
+
+func isSSHSignature(signature string) bool {
+	return strings.HasPrefix(strings.TrimSpace(signature), sshSignatureHeader)
+}
+
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	armored = strings.TrimSpace(armored)
+	armored = strings.TrimPrefix(armored, sshSignatureHeader)
+	armored = strings.TrimSuffix(armored, sshSignatureFooter)
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(armored), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ssh signature: %w", err)
+	}
+
+	if !strings.HasPrefix(string(data), sshSignatureMagic) {
+		return nil, errors.New("invalid ssh signature magic preamble")
+	}
+
+	var raw struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+
+	if err = gossh.Unmarshal(data[len(sshSignatureMagic):], &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ssh signature: %w", err)
+	}
+
+	if raw.Version != sshSignatureVersion {
+		return nil, fmt.Errorf("unsupported ssh signature version %d", raw.Version)
+	}
+
+	publicKey, err := gossh.ParsePublicKey(raw.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ssh signature public key: %w", err)
+	}
+
+	signature := &gossh.Signature{}
+	if err = gossh.Unmarshal(raw.Signature, signature); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ssh signature blob: %w", err)
+	}
+
+	return &sshSignature{
+		PublicKey:     publicKey,
+		Namespace:     raw.Namespace,
+		HashAlgorithm: raw.HashAlgorithm,
+		Signature:     signature,
+	}, nil
+}
+
+func (s *sshSignature) verify(message []byte) error {
+	if s.Namespace != sshSignatureNamespace {
+		return fmt.Errorf("unexpected ssh signature namespace %q", s.Namespace)
+	}
+
+	var h hash.Hash
+	switch s.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported ssh signature hash algorithm %q", s.HashAlgorithm)
+	}
+
+	h.Write(message)
+
+	signed := gossh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     s.Namespace,
+		HashAlgorithm: s.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})
+
+	return s.PublicKey.Verify(append([]byte(sshSignatureMagic), signed...), s.Signature)
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ChangeTargetBranch changes the target branch of an open pull request.
+// It writes the target branch change activity and triggers the TargetBranchChanged event.
+// The mergedPullReqNumber is the number of the parent pull request if the change
+// is a result of its merge, otherwise it should be zero.
+func (s *Service) ChangeTargetBranch(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principalID int64,
+	newTargetBranch string,
+	mergedPullReqNumber int64,
+) (*types.PullReq, error) {
+	ref1, err := git.GetRefPath(pr.SourceBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ref path: %w", err)
+	}
+	ref2, err := git.GetRefPath(newTargetBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ref path: %w", err)
+	}
+	mergeBase, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Ref1:       ref1,
+		Ref2:       ref2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+
+	if mergeBase.MergeBaseSHA.String() == pr.SourceSHA {
+		return nil,
+			usererror.BadRequest("The source branch doesn't contain any new commits")
+	}
+
+	oldTargetBranch := pr.TargetBranch
+	oldMergeBaseSHA := pr.MergeBaseSHA
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		// clear merge and stats related fields
+		pr.MergeSHA = nil
+		pr.MergeTargetSHA = nil
+		pr.Stats.DiffStats = types.DiffStats{}
+
+		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
+		pr.TargetBranch = newTargetBranch
+
+		pr.MarkAsMergeUnchecked()
+
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update PR target branch in db with error: %w", err)
+	}
+
+	_, err = s.activityStore.CreateWithPayload(
+		ctx, pr, principalID,
+		&types.PullRequestActivityPayloadBranchChangeTarget{
+			Old:                 oldTargetBranch,
+			New:                 newTargetBranch,
+			MergedPullReqNumber: mergedPullReqNumber,
+		},
+		nil,
+	)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for target branch change")
+	}
+
+	s.pullreqEvReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		SourceSHA:       pr.SourceSHA,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: newTargetBranch,
+		OldMergeBaseSHA: oldMergeBaseSHA,
+		NewMergeBaseSHA: mergeBase.MergeBaseSHA.String(),
+	})
+
+	return pr, nil
+}
@@ -24,6 +24,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -80,17 +81,22 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to get commit info from git")
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
-		// First check if the merge base has changed
-
 		targetRepo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
 		if err != nil {
 			return fmt.Errorf("failed to get target repo git info: %w", err)
 		}
 
+		// For pull requests from a fork, the new commits must be fetched into the target repository first.
+
+		if pr.SourceRepoID != pr.TargetRepoID {
+			if err = s.fetchSourceCommits(ctx, targetRepo, pr.SourceRepoID, event.Payload.NewSHA); err != nil {
+				return err
+			}
+		}
+
+		// First check if the merge base has changed
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -298,3 +304,32 @@ func getBranchFromRef(ref string) (string, error) {
 	}
 	return branch, nil
 }
+
+// fetchSourceCommits fetches the commit from the pull request's source repository into the target repository.
+func (s *Service) fetchSourceCommits(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	sourceRepoID int64,
+	commitSHA string,
+) error {
+	sourceRepo, err := s.repoFinder.FindByID(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	writeParams, err := createRPCSystemReferencesWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{sha.Must(commitSHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit %s from source repo: %w", commitSHA, err)
+	}
+
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/harness/gitness/app/bootstrap"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/events"
@@ -196,7 +197,7 @@ func (s *Service) updateMergeData(
 	}
 
 	// Update DB in both cases (failure or success)
-	_, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		// to avoid racing conditions with merge
 		if pr.State != enum.PullReqStateOpen {
 			return errPRNotOpen
@@ -229,5 +230,17 @@ func (s *Service) updateMergeData(
 
 	s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
 
+	s.pullreqEvReporter.MergeCheckCompleted(ctx, &pullreqevents.MergeCheckCompletedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  bootstrap.NewSystemServiceSession().Principal.ID,
+			Number:       pr.Number,
+		},
+		SourceSHA: pr.SourceSHA,
+		Mergeable: pr.MergeCheckStatus == enum.MergeCheckStatusMergeable,
+	})
+
 	return nil
 }
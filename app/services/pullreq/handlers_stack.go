@@ -0,0 +1,278 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxStackDepth is the maximum number of parent pull requests returned for a pull request stack.
+	maxStackDepth = 20
+
+	// maxStackRebaseCommits is the maximum number of commits of a stacked pull request that are rebased
+	// after the pull request below it has been squash or rebase merged.
+	maxStackRebaseCommits = 250
+)
+
+// Stack returns the pull requests stacked below and above the provided pull request.
+// Only open pull requests within the same repository are considered to be part of a stack.
+func (s *Service) Stack(ctx context.Context, pr *types.PullReq) (*types.PullReqStack, error) {
+	stack := &types.PullReqStack{
+		Parents:  []*types.PullReq{},
+		Children: []*types.PullReq{},
+	}
+
+	if pr.SourceRepoID != pr.TargetRepoID {
+		return stack, nil
+	}
+
+	visited := map[int64]struct{}{pr.ID: {}}
+
+	for current := pr; len(stack.Parents) < maxStackDepth; {
+		parents, err := s.listStackedPullReqs(ctx, current.TargetRepoID, "", current.TargetBranch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list parent pull requests: %w", err)
+		}
+
+		if len(parents) == 0 {
+			break
+		}
+
+		parent := parents[0]
+		if _, ok := visited[parent.ID]; ok {
+			break
+		}
+
+		visited[parent.ID] = struct{}{}
+		stack.Parents = append(stack.Parents, parent)
+		current = parent
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return stack, nil
+	}
+
+	children, err := s.listStackedPullReqs(ctx, pr.TargetRepoID, pr.SourceBranch, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child pull requests: %w", err)
+	}
+
+	for _, child := range children {
+		if _, ok := visited[child.ID]; !ok {
+			stack.Children = append(stack.Children, child)
+		}
+	}
+
+	return stack, nil
+}
+
+// listStackedPullReqs returns open pull requests of the repository that aren't created from a fork.
+// The pull requests are filtered by the target branch or the source branch, whichever is provided.
+func (s *Service) listStackedPullReqs(
+	ctx context.Context,
+	repoID int64,
+	targetBranch string,
+	sourceBranch string,
+) ([]*types.PullReq, error) {
+	const largeLimit = 1000
+
+	pullreqs, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         largeLimit,
+		SourceRepoID: repoID,
+		SourceBranch: sourceBranch,
+		TargetRepoID: repoID,
+		TargetBranch: targetBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pullreqs, nil
+}
+
+// retargetStackOnMerge handles pull request Merged events. Open pull requests that target the source branch
+// of the merged pull request are retargeted to the target branch of the merged pull request.
+// If the merged pull request was squashed or rebased, the source branches of the retargeted pull requests
+// are rebased on top of the new target branch.
+func (s *Service) retargetStackOnMerge(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	merged, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to get merged pull request: %w", err)
+	}
+
+	if merged.SourceRepoID != merged.TargetRepoID {
+		return nil
+	}
+
+	children, err := s.listStackedPullReqs(ctx, merged.TargetRepoID, merged.SourceBranch, "")
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests stacked on merged pull request: %w", err)
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, merged.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo info: %w", err)
+	}
+
+	for _, child := range children {
+		if child.SourceBranch == merged.TargetBranch {
+			continue
+		}
+
+		err = s.retargetStackedPullReq(ctx, repo, merged, child, event.Payload)
+		if err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_number", child.Number).
+				Msg("failed to retarget stacked pull request")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) retargetStackedPullReq(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	merged *types.PullReq,
+	child *types.PullReq,
+	payload *pullreqevents.MergedPayload,
+) error {
+	sourceSHA := child.SourceSHA
+
+	child, err := s.ChangeTargetBranch(ctx, repo, child, payload.PrincipalID, merged.TargetBranch, merged.Number)
+	if err != nil {
+		return fmt.Errorf("failed to change target branch: %w", err)
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, child)
+
+	if payload.MergeMethod != enum.MergeMethodSquash && payload.MergeMethod != enum.MergeMethodRebase {
+		return nil
+	}
+
+	// The commits of the merged pull request have been rewritten, so the commits of the stacked
+	// pull request are applied on top of the new target branch. The source branch update is processed
+	// as any other push, because git hooks are executed.
+
+	if err = s.rebaseStackedPullReq(ctx, repo, child, payload.SourceSHA, sourceSHA); err != nil {
+		return fmt.Errorf("failed to rebase source branch: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) rebaseStackedPullReq(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	mergedSourceSHA string,
+	sourceSHA string,
+) error {
+	output, err := s.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     sourceSHA,
+		After:      mergedSourceSHA,
+		Limit:      maxStackRebaseCommits + 1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	if len(output.Commits) > maxStackRebaseCommits {
+		log.Ctx(ctx).Info().Msgf("skipping rebase of stacked pull request with more than %d commits",
+			maxStackRebaseCommits)
+		return nil
+	}
+
+	commitSHAs := make([]sha.SHA, 0, len(output.Commits))
+	for i := len(output.Commits) - 1; i >= 0; i-- {
+		if len(output.Commits[i].ParentSHAs) > 1 {
+			log.Ctx(ctx).Info().Msg("skipping rebase of stacked pull request with merge commits")
+			return nil
+		}
+		commitSHAs = append(commitSHAs, output.Commits[i].SHA)
+	}
+
+	if len(commitSHAs) == 0 {
+		return nil
+	}
+
+	branchRef, err := git.GetRefPath(pr.SourceBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to get ref path: %w", err)
+	}
+
+	writeParams, err := createRPCSystemWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	now := time.Now()
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	cherryPickOut, err := s.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams: writeParams,
+		BaseBranch:  pr.TargetBranch,
+		CommitSHAs:  commitSHAs,
+		Committer: &git.Identity{
+			Name:  systemPrincipal.DisplayName,
+			Email: systemPrincipal.Email,
+		},
+		CommitterDate: &now,
+		Refs: []git.RefUpdate{
+			{
+				Name: branchRef,
+				Old:  sha.Must(sourceSHA),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cherry-pick commits: %w", err)
+	}
+
+	if len(cherryPickOut.ConflictFiles) > 0 {
+		log.Ctx(ctx).Info().
+			Str("conflict_commit_sha", cherryPickOut.ConflictCommitSHA.String()).
+			Strs("conflict_files", cherryPickOut.ConflictFiles).
+			Msg("stacked pull request can't be rebased because of conflicts")
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	stackMergeBaseSHA    = "1111111111111111111111111111111111111111"
+	stackParentSourceSHA = "2222222222222222222222222222222222222222"
+	stackChildSourceSHA  = "3333333333333333333333333333333333333333"
+	stackChildCommitSHA  = "4444444444444444444444444444444444444444"
+)
+
+type stackPullReqStore struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s *stackPullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	pr, ok := s.prs[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	prCopy := *pr
+	return &prCopy, nil
+}
+
+func (s *stackPullReqStore) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	var prs []*types.PullReq
+	for _, pr := range s.prs {
+		switch {
+		case opts.SourceRepoID != 0 && pr.SourceRepoID != opts.SourceRepoID,
+			opts.SourceBranch != "" && pr.SourceBranch != opts.SourceBranch,
+			opts.TargetRepoID != 0 && pr.TargetRepoID != opts.TargetRepoID,
+			opts.TargetBranch != "" && pr.TargetBranch != opts.TargetBranch,
+			len(opts.States) > 0 && pr.State != opts.States[0]:
+			continue
+		}
+		prCopy := *pr
+		prs = append(prs, &prCopy)
+	}
+
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Number < prs[j].Number })
+
+	return prs, nil
+}
+
+func (s *stackPullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	prCopy := *s.prs[pr.ID]
+	if err := mutateFn(&prCopy); err != nil {
+		return nil, err
+	}
+	s.prs[pr.ID] = &prCopy
+	return &prCopy, nil
+}
+
+type stackActivityStore struct {
+	store.PullReqActivityStore
+	targetChanges []*types.PullRequestActivityPayloadBranchChangeTarget
+}
+
+func (s *stackActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	if p, ok := payload.(*types.PullRequestActivityPayloadBranchChangeTarget); ok {
+		s.targetChanges = append(s.targetChanges, p)
+	}
+	return &types.PullReqActivity{PullReqID: pr.ID}, nil
+}
+
+type stackRepoIDCache struct {
+	repo *types.RepositoryCore
+}
+
+func (c stackRepoIDCache) Stats() (int64, int64) { return 0, 0 }
+
+func (c stackRepoIDCache) Evict(context.Context, int64) {}
+
+func (c stackRepoIDCache) Get(context.Context, int64) (*types.RepositoryCore, error) {
+	return c.repo, nil
+}
+
+type stackGit struct {
+	git.Interface
+	cherryPicks []*git.CherryPickParams
+}
+
+func (g *stackGit) MergeBase(context.Context, git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	return git.MergeBaseOutput{MergeBaseSHA: sha.Must(stackMergeBaseSHA)}, nil
+}
+
+func (g *stackGit) ListCommits(context.Context, *git.ListCommitsParams) (*git.ListCommitsOutput, error) {
+	return &git.ListCommitsOutput{
+		Commits: []git.Commit{
+			{
+				SHA:        sha.Must(stackChildCommitSHA),
+				ParentSHAs: []sha.SHA{sha.Must(stackParentSourceSHA)},
+			},
+		},
+	}, nil
+}
+
+func (g *stackGit) CherryPick(_ context.Context, params *git.CherryPickParams) (git.CherryPickOutput, error) {
+	g.cherryPicks = append(g.cherryPicks, params)
+	return git.CherryPickOutput{}, nil
+}
+
+type stackURLProvider struct {
+	url.Provider
+}
+
+func (stackURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000/api"
+}
+
+type stackStreamer struct {
+	sse.Streamer
+}
+
+func (stackStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
+
+type stackPrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (stackPrincipalStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: 1, UID: uid, Admin: true}, nil
+}
+
+func setupStackService(t *testing.T, prs ...*types.PullReq) (
+	*Service,
+	*stackPullReqStore,
+	*stackActivityStore,
+	*stackGit,
+) {
+	t.Helper()
+
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+
+	err := bootstrap.SystemService(context.Background(), config,
+		service.NewController(nil, nil, stackPrincipalStore{}))
+	if err != nil {
+		t.Fatalf("failed to set up system service: %v", err)
+	}
+
+	eventSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create event system: %v", err)
+	}
+	eventReporter, err := pullreqevents.NewReporter(eventSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request event reporter: %v", err)
+	}
+
+	repo := &types.RepositoryCore{ID: 1, ParentID: 1, GitUID: "repo-uid", DefaultBranch: "main"}
+
+	pullreqStore := &stackPullReqStore{prs: map[int64]*types.PullReq{}}
+	for _, pr := range prs {
+		pullreqStore.prs[pr.ID] = pr
+	}
+
+	activityStore := &stackActivityStore{}
+	gitInterface := &stackGit{}
+
+	s := &Service{
+		pullreqEvReporter: eventReporter,
+		git:               gitInterface,
+		repoFinder: refcache.NewRepoFinder(nil, nil, stackRepoIDCache{repo: repo}, nil,
+			cache.Evictor[*types.RepositoryCore]{}),
+		pullreqStore:  pullreqStore,
+		activityStore: activityStore,
+		sseStreamer:   stackStreamer{},
+		urlProvider:   stackURLProvider{},
+	}
+
+	return s, pullreqStore, activityStore, gitInterface
+}
+
+func TestService_RetargetStackOnMerge(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         enum.MergeMethod
+		wantCherryPick bool
+	}{
+		{
+			name:           "merge",
+			method:         enum.MergeMethodMerge,
+			wantCherryPick: false,
+		},
+		{
+			name:           "squash",
+			method:         enum.MergeMethodSquash,
+			wantCherryPick: true,
+		},
+		{
+			name:           "rebase",
+			method:         enum.MergeMethodRebase,
+			wantCherryPick: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			bottom := &types.PullReq{
+				ID:           1,
+				Number:       1,
+				State:        enum.PullReqStateMerged,
+				SourceRepoID: 1,
+				SourceBranch: "feature",
+				SourceSHA:    stackParentSourceSHA,
+				TargetRepoID: 1,
+				TargetBranch: "main",
+			}
+			dependent := &types.PullReq{
+				ID:           2,
+				Number:       2,
+				State:        enum.PullReqStateOpen,
+				SourceRepoID: 1,
+				SourceBranch: "feature-next",
+				SourceSHA:    stackChildSourceSHA,
+				TargetRepoID: 1,
+				TargetBranch: "feature",
+			}
+			unrelated := &types.PullReq{
+				ID:           3,
+				Number:       3,
+				State:        enum.PullReqStateOpen,
+				SourceRepoID: 1,
+				SourceBranch: "other",
+				TargetRepoID: 1,
+				TargetBranch: "develop",
+			}
+
+			s, pullreqStore, activityStore, gitInterface := setupStackService(t, bottom, dependent, unrelated)
+
+			err := s.retargetStackOnMerge(ctx, &events.Event[*pullreqevents.MergedPayload]{
+				Payload: &pullreqevents.MergedPayload{
+					Base: pullreqevents.Base{
+						PullReqID:    bottom.ID,
+						SourceRepoID: 1,
+						TargetRepoID: 1,
+						PrincipalID:  1,
+						Number:       bottom.Number,
+					},
+					MergeMethod: test.method,
+					SourceSHA:   stackParentSourceSHA,
+				},
+			})
+			if err != nil {
+				t.Fatalf("failed to retarget stack: %v", err)
+			}
+
+			retargeted := pullreqStore.prs[dependent.ID]
+			if want, got := "main", retargeted.TargetBranch; want != got {
+				t.Errorf("dependent target branch: want=%s got=%s", want, got)
+			}
+			if want, got := stackMergeBaseSHA, retargeted.MergeBaseSHA; want != got {
+				t.Errorf("dependent merge base: want=%s got=%s", want, got)
+			}
+			if want, got := enum.MergeCheckStatusUnchecked, retargeted.MergeCheckStatus; want != got {
+				t.Errorf("dependent merge check status: want=%s got=%s", want, got)
+			}
+
+			if want, got := "develop", pullreqStore.prs[unrelated.ID].TargetBranch; want != got {
+				t.Errorf("unrelated target branch: want=%s got=%s", want, got)
+			}
+
+			if want, got := 1, len(activityStore.targetChanges); want != got {
+				t.Fatalf("target branch change activities: want=%d got=%d", want, got)
+			}
+			activity := activityStore.targetChanges[0]
+			if activity.Old != "feature" || activity.New != "main" || activity.MergedPullReqNumber != bottom.Number {
+				t.Errorf("unexpected target branch change activity: %+v", activity)
+			}
+
+			if !test.wantCherryPick {
+				if len(gitInterface.cherryPicks) != 0 {
+					t.Errorf("expected no rebase of the dependent source branch, got %d", len(gitInterface.cherryPicks))
+				}
+				return
+			}
+
+			if want, got := 1, len(gitInterface.cherryPicks); want != got {
+				t.Fatalf("cherry-picks: want=%d got=%d", want, got)
+			}
+
+			cherryPick := gitInterface.cherryPicks[0]
+			if want, got := "main", cherryPick.BaseBranch; want != got {
+				t.Errorf("cherry-pick base branch: want=%s got=%s", want, got)
+			}
+			if len(cherryPick.CommitSHAs) != 1 || cherryPick.CommitSHAs[0].String() != stackChildCommitSHA {
+				t.Errorf("cherry-pick commits: want=[%s] got=%v", stackChildCommitSHA, cherryPick.CommitSHAs)
+			}
+			if len(cherryPick.Refs) != 1 ||
+				cherryPick.Refs[0].Name != "refs/heads/feature-next" ||
+				cherryPick.Refs[0].Old.String() != stackChildSourceSHA {
+				t.Errorf("unexpected cherry-pick reference update: %+v", cherryPick.Refs)
+			}
+		})
+	}
+}
@@ -116,6 +116,27 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// pull request stack maintenance
+
+	const groupPullReqStack = "gitness:pullreq:stack"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqStack, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMerged(service.retargetStackOnMerge)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	// pull request ref maintenance
 
 	const groupPullReqHeadRef = "gitness:pullreq:headref"
@@ -254,16 +275,37 @@ func createRPCSystemReferencesWriteParams(
 	urlProvider url.Provider,
 	repoID int64,
 	repoGITUID string,
+) (git.WriteParams, error) {
+	// skip githook execution since it's system references only
+	return createRPCSystemWriteParamsWithHooks(ctx, urlProvider, repoID, repoGITUID, true)
+}
+
+// createRPCSystemWriteParams creates base write parameters for write operations of the system principal
+// that update branches. Git hooks are executed, but as internal, so the branch events are triggered.
+func createRPCSystemWriteParams(
+	ctx context.Context,
+	urlProvider url.Provider,
+	repoID int64,
+	repoGITUID string,
+) (git.WriteParams, error) {
+	return createRPCSystemWriteParamsWithHooks(ctx, urlProvider, repoID, repoGITUID, false)
+}
+
+func createRPCSystemWriteParamsWithHooks(
+	ctx context.Context,
+	urlProvider url.Provider,
+	repoID int64,
+	repoGITUID string,
+	disableHooks bool,
 ) (git.WriteParams, error) {
 	principal := bootstrap.NewSystemServiceSession().Principal
 
-	// generate envars - skip githook execution since it's system references only
 	envVars, err := githook.GenerateEnvironmentVariables(
 		ctx,
 		urlProvider.GetInternalAPIURL(ctx),
 		repoID,
 		principal.ID,
-		true,
+		disableHooks,
 		true,
 	)
 	if err != nil {
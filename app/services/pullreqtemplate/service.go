@@ -0,0 +1,331 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultName is the name of the template defined in a single template file
+	// or inherited from the space.
+	DefaultName = "default"
+
+	templateFileName  = "pull_request_template.md"
+	templateDirName   = "pull_request_template"
+	templateExtension = ".md"
+
+	// maxTemplateSize is the maximum size of a template file, larger templates are ignored.
+	maxTemplateSize = 64 * 1024
+	// maxTemplateCount is the maximum number of templates loaded from a template directory.
+	maxTemplateCount = 20
+)
+
+// templateDirs are the directories, in order of precedence, searched for the pull request templates.
+// Every directory can contain a single template file named "pull_request_template.md"
+// and a "PULL_REQUEST_TEMPLATE" directory with multiple named templates. Names are matched case-insensitive.
+var templateDirs = []string{".harness", ".github", "docs"}
+
+// Service discovers pull request templates in repositories.
+type Service struct {
+	git         git.Interface
+	spaceFinder refcache.SpaceFinder
+	settings    *settings.Service
+}
+
+func NewService(
+	git git.Interface,
+	spaceFinder refcache.SpaceFinder,
+	settings *settings.Service,
+) *Service {
+	return &Service{
+		git:         git,
+		spaceFinder: spaceFinder,
+		settings:    settings,
+	}
+}
+
+// List returns all pull request templates of the repository at the provided git reference.
+// If the repository doesn't have any, the default template of the nearest space that has one is returned.
+func (s *Service) List(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+) ([]*types.PullReqTemplate, error) {
+	templates, err := s.listRepoTemplates(ctx, repo, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) > 0 {
+		return templates, nil
+	}
+
+	template, err := s.findSpaceTemplate(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return []*types.PullReqTemplate{}, nil
+	}
+
+	return []*types.PullReqTemplate{template}, nil
+}
+
+// Find returns the pull request template with the provided name.
+// If the name is empty, the default template is returned. The default template is optional,
+// so nil is returned without an error if the repository and its spaces don't have one.
+func (s *Service) Find(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	templates, err := s.List(ctx, repo, gitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		for _, template := range templates {
+			if template.Name == DefaultName {
+				return template, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	for _, template := range templates {
+		if strings.EqualFold(template.Name, name) {
+			return template, nil
+		}
+	}
+
+	return nil, usererror.NotFoundf("Pull request template %q not found.", name)
+}
+
+// SpaceFind returns the default pull request template defined for the space, or nil if there is none.
+func (s *Service) SpaceFind(ctx context.Context, spaceID int64) (*types.PullReqTemplate, error) {
+	var content string
+
+	ok, err := s.settings.SpaceGet(ctx, spaceID, settings.KeyPullReqTemplate, &content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request template setting: %w", err)
+	}
+
+	if !ok || content == "" {
+		return nil, nil
+	}
+
+	return &types.PullReqTemplate{
+		Name:    DefaultName,
+		Content: content,
+	}, nil
+}
+
+// SpaceSet sets the default pull request template of the space. Empty content removes the template.
+func (s *Service) SpaceSet(ctx context.Context, spaceID int64, content string) error {
+	if len(content) > maxTemplateSize {
+		return usererror.BadRequestf("Pull request template is too long (maximum is %d bytes).", maxTemplateSize)
+	}
+
+	err := s.settings.SpaceSet(ctx, spaceID, settings.KeyPullReqTemplate, content)
+	if err != nil {
+		return fmt.Errorf("failed to set pull request template setting: %w", err)
+	}
+
+	return nil
+}
+
+// findSpaceTemplate returns the default template of the nearest space in the space hierarchy.
+func (s *Service) findSpaceTemplate(ctx context.Context, spaceID int64) (*types.PullReqTemplate, error) {
+	for spaceID != 0 {
+		template, err := s.SpaceFind(ctx, spaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		space, err := s.spaceFinder.FindByID(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if template != nil {
+			template.Space = space.Path
+			return template, nil
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return nil, nil
+}
+
+func (s *Service) listRepoTemplates(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+) ([]*types.PullReqTemplate, error) {
+	readParams := git.CreateReadParams(repo)
+
+	var defaultNode *git.TreeNode
+	var namedNodes []git.TreeNode
+
+	for _, dir := range templateDirs {
+		nodes, err := s.listTreeNodes(ctx, readParams, gitRef, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range nodes {
+			switch {
+			case node.Type == git.TreeNodeTypeBlob && strings.EqualFold(node.Name, templateFileName):
+				if defaultNode == nil {
+					defaultNode = &node
+				}
+			case node.Type == git.TreeNodeTypeTree && strings.EqualFold(node.Name, templateDirName):
+				dirNodes, err := s.listTreeNodes(ctx, readParams, gitRef, node.Path)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, dirNode := range dirNodes {
+					if dirNode.Type == git.TreeNodeTypeBlob &&
+						strings.EqualFold(path.Ext(dirNode.Name), templateExtension) {
+						namedNodes = append(namedNodes, dirNode)
+					}
+				}
+			}
+		}
+	}
+
+	templates := make([]*types.PullReqTemplate, 0, len(namedNodes)+1)
+	names := map[string]struct{}{}
+
+	addTemplate := func(name string, node git.TreeNode) error {
+		if _, ok := names[strings.ToLower(name)]; ok || len(templates) >= maxTemplateCount {
+			return nil
+		}
+
+		content, err := s.readTemplate(ctx, readParams, node)
+		if err != nil {
+			return err
+		}
+
+		if content == nil {
+			return nil
+		}
+
+		names[strings.ToLower(name)] = struct{}{}
+		templates = append(templates, &types.PullReqTemplate{
+			Name:    name,
+			Path:    node.Path,
+			Content: *content,
+		})
+
+		return nil
+	}
+
+	if defaultNode != nil {
+		if err := addTemplate(DefaultName, *defaultNode); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, node := range namedNodes {
+		if err := addTemplate(templateName(node.Name), node); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+func (s *Service) listTreeNodes(
+	ctx context.Context,
+	readParams git.ReadParams,
+	gitRef string,
+	dir string,
+) ([]git.TreeNode, error) {
+	output, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       dir,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request template directory %q: %w", dir, err)
+	}
+
+	return output.Nodes, nil
+}
+
+// readTemplate returns the content of the template file. Nil is returned for files that are too large.
+func (s *Service) readTemplate(
+	ctx context.Context,
+	readParams git.ReadParams,
+	node git.TreeNode,
+) (*string, error) {
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.SHA,
+		SizeLimit:  maxTemplateSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request template content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	if output.Size > maxTemplateSize {
+		log.Ctx(ctx).Warn().Msgf("ignoring pull request template %q of size %d", node.Path, output.Size)
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pull request template content: %w", err)
+	}
+
+	result := string(content)
+
+	return &result, nil
+}
+
+// templateName returns the name of a template from the template directory, which is the file name without extension.
+func templateName(fileName string) string {
+	return strings.TrimSuffix(fileName, path.Ext(fileName))
+}
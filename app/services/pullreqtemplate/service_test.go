@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqtemplate
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+type gitMock struct {
+	git.Interface
+	files map[string]string
+}
+
+func (m gitMock) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	var nodes []git.TreeNode
+	dirs := map[string]struct{}{}
+
+	for filePath := range m.files {
+		rest, ok := strings.CutPrefix(filePath, params.Path+"/")
+		if !ok {
+			continue
+		}
+
+		if name, _, isDir := strings.Cut(rest, "/"); isDir {
+			if _, ok := dirs[name]; !ok {
+				dirs[name] = struct{}{}
+				nodes = append(nodes, git.TreeNode{
+					Type: git.TreeNodeTypeTree,
+					Name: name,
+					Path: path.Join(params.Path, name),
+				})
+			}
+			continue
+		}
+
+		nodes = append(nodes, git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Name: rest,
+			Path: filePath,
+			SHA:  filePath,
+		})
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+
+	return &git.ListTreeNodeOutput{Nodes: nodes}, nil
+}
+
+func (m gitMock) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content := m.files[params.SHA]
+	return &git.GetBlobOutput{
+		Size:    int64(len(content)),
+		Content: io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func TestService_Find(t *testing.T) {
+	files := map[string]string{
+		".github/PULL_REQUEST_TEMPLATE.md":             "github default",
+		".github/PULL_REQUEST_TEMPLATE/bugfix.md":      "bugfix",
+		".github/PULL_REQUEST_TEMPLATE/readme.txt":     "not a template",
+		".harness/pull_request_template.md":            "harness default",
+		"docs/pull_request_template/feature.md":        "feature",
+		"docs/pull_request_template/nested/ignored.md": "ignored",
+	}
+
+	s := &Service{git: gitMock{files: files}}
+	repo := &types.RepositoryCore{GitUID: "repo"}
+
+	templates, err := s.List(context.Background(), repo, "main")
+	if err != nil {
+		t.Fatalf("failed to list templates: %s", err)
+	}
+
+	names := make([]string, len(templates))
+	for i, template := range templates {
+		names[i] = template.Name
+	}
+
+	if want, got := "default,bugfix,feature", strings.Join(names, ","); want != got {
+		t.Errorf("template names mismatch: want=%s got=%s", want, got)
+	}
+
+	tests := []struct {
+		name       string
+		template   string
+		expContent string
+		expErr     bool
+	}{
+		{
+			name:       "default",
+			template:   "",
+			expContent: "harness default",
+		},
+		{
+			name:       "case-insensitive",
+			template:   "BugFix",
+			expContent: "bugfix",
+		},
+		{
+			name:     "not-found",
+			template: "readme",
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			template, err := s.Find(context.Background(), repo, "main", test.template)
+			if test.expErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to find template: %s", err)
+			}
+
+			if template.Content != test.expContent {
+				t.Errorf("content mismatch: want=%s got=%s", test.expContent, template.Content)
+			}
+		})
+	}
+}
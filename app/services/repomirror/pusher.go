@@ -0,0 +1,372 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/secret"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	pushJobType = "repo-push-mirror"
+
+	// pushJobUIDPrefix is the prefix of the UID of the job pushing to a single push mirror.
+	// Every push mirror has a single job, so pushes made while the job is pending are coalesced.
+	pushJobUIDPrefix = "repo-push-mirror-"
+
+	groupPushMirror = "gitness:repo:pushmirror"
+)
+
+// Pusher pushes all changes of a repository to its push mirrors.
+// Every push of branches or tags to the repository starts a background job for each of its push mirrors,
+// unless the job of the push mirror is already pending. Failed pushes are retried by the job scheduler.
+type Pusher struct {
+	maxDur            time.Duration
+	maxRetries        int
+	git               git.Interface
+	repoStore         store.RepoStore
+	pushMirrorStore   store.RepoPushMirrorStore
+	spaceFinder       refcache.SpaceFinder
+	secretService     secret.Service
+	protectionManager *protection.Manager
+	locker            *locker.Locker
+	scheduler         *job.Scheduler
+	sseStreamer       sse.Streamer
+	remoteAccess      remoteAccess
+}
+
+var _ job.Handler = (*Pusher)(nil)
+
+func NewPusher(
+	ctx context.Context,
+	config *types.Config,
+	repoEvReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	pushMirrorStore store.RepoPushMirrorStore,
+	spaceFinder refcache.SpaceFinder,
+	secretService secret.Service,
+	protectionManager *protection.Manager,
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	sseStreamer sse.Streamer,
+) (*Pusher, error) {
+	pusher := &Pusher{
+		maxDur:            config.RepoMirror.PushMaxDuration,
+		maxRetries:        config.RepoMirror.PushMaxRetries,
+		git:               git,
+		repoStore:         repoStore,
+		pushMirrorStore:   pushMirrorStore,
+		spaceFinder:       spaceFinder,
+		secretService:     secretService,
+		protectionManager: protectionManager,
+		locker:            locker,
+		scheduler:         scheduler,
+		sseStreamer:       sseStreamer,
+		remoteAccess: remoteAccess{
+			allowLoopback:       config.RepoMirror.AllowLoopback,
+			allowPrivateNetwork: config.RepoMirror.AllowPrivateNetwork,
+		},
+	}
+
+	err := executor.Register(pushJobType, pusher)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = repoEvReaderFactory.Launch(ctx, groupPushMirror, config.InstanceID,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 10 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterPushed(pusher.handleEventPushed)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader: %w", err)
+	}
+
+	return pusher, nil
+}
+
+func (p *Pusher) handleEventPushed(ctx context.Context, event *events.Event[*repoevents.PushedPayload]) error {
+	// internal references (e.g. of pull requests or the merge queue) are never pushed to push mirrors.
+	if event.Payload.InternalRefsOnly {
+		return nil
+	}
+
+	mirrors, err := p.pushMirrorStore.List(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to list repository push mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		if err := p.Run(ctx, mirror.ID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("push_mirror_id", mirror.ID).
+				Msg("failed to start push to repository push mirror")
+		}
+	}
+
+	return nil
+}
+
+// Run starts a background job that pushes the repository to the push mirror.
+// If the job is already scheduled it's not started again, as it pushes the latest state of the repository anyway.
+// If the job is running, it repeats the push in case the repository got pushed to in the meantime.
+func (p *Pusher) Run(ctx context.Context, pushMirrorID int64) error {
+	jobUID := pushJobUIDPrefix + strconv.FormatInt(pushMirrorID, 10)
+
+	progress, err := p.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get job progress: %w", err)
+	}
+	if err == nil && !progress.State.IsCompleted() {
+		return nil
+	}
+
+	if err = p.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+		return fmt.Errorf("failed to purge previous job: %w", err)
+	}
+
+	err = p.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       pushJobType,
+		MaxRetries: p.maxRetries,
+		Timeout:    p.maxDur,
+		Data:       strconv.FormatInt(pushMirrorID, 10),
+
+		ExponentialBackoff: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the repository push mirror background job handler.
+func (p *Pusher) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	pushMirrorID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid job input: %w", err)
+	}
+
+	return "", p.Push(ctx, pushMirrorID)
+}
+
+// Push pushes the repository to the push mirror and stores the outcome as the status of the push mirror.
+func (p *Pusher) Push(ctx context.Context, pushMirrorID int64) error {
+	mirror, err := p.pushMirrorStore.Find(ctx, pushMirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the push mirror got deleted in the meantime
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository push mirror: %w", err)
+	}
+
+	unlock, err := p.locker.LockRepoPushMirror(ctx, mirror.RepoID, mirror.ID, p.maxDur)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	repo, err := p.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.IsEmpty {
+		return nil
+	}
+
+	mirror.SyncState = enum.RepoMirrorSyncStateRunning
+	if err = p.pushMirrorStore.UpdateSyncStatus(ctx, mirror); err != nil {
+		return fmt.Errorf("failed to update repository push mirror status: %w", err)
+	}
+
+	pushErr := p.pushLatest(ctx, repo, mirror)
+
+	now := time.Now().UnixMilli()
+	mirror.LastSync = now
+	if pushErr != nil {
+		mirror.SyncState = enum.RepoMirrorSyncStateFailure
+		mirror.LastError = pushErr.Error()
+	} else {
+		mirror.SyncState = enum.RepoMirrorSyncStateSuccess
+		mirror.LastSuccess = now
+		mirror.LastError = ""
+	}
+
+	// the status must be stored even if the job got canceled, otherwise it would stay in the running state.
+	if err = p.pushMirrorStore.UpdateSyncStatus(context.WithoutCancel(ctx), mirror); err != nil {
+		return fmt.Errorf("failed to update repository push mirror status: %w", err)
+	}
+
+	p.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeRepositoryPushMirrorCompleted, mirror)
+
+	return pushErr
+}
+
+// pushLatest pushes the repository to the push mirror until the repository doesn't get pushed to during the push.
+// Pushes made while the job is running don't start another job, so they must be pushed by the running one.
+func (p *Pusher) pushLatest(ctx context.Context, repo *types.Repository, mirror *types.RepoPushMirror) error {
+	for {
+		started := time.Now().UnixMilli()
+
+		if err := p.push(ctx, repo, mirror); err != nil {
+			return err
+		}
+
+		var err error
+		repo, err = p.repoStore.Find(ctx, repo.ID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the repository got deleted in the meantime
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		if repo.LastGITPush < started {
+			return nil
+		}
+	}
+}
+
+func (p *Pusher) push(ctx context.Context, repo *types.Repository, mirror *types.RepoPushMirror) error {
+	readParams := git.ReadParams{RepoUID: repo.GitUID}
+
+	refSpecs := []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"}
+	prune := true
+
+	if mirror.ProtectedOnly {
+		var err error
+		refSpecs, err = p.protectedRefSpecs(ctx, repo, readParams)
+		if err != nil {
+			return err
+		}
+
+		// only the protected references that exist are pushed, so deleted ones can't be pruned.
+		prune = false
+
+		if len(refSpecs) == 0 {
+			return nil
+		}
+	}
+
+	resolve, err := p.remoteAccess.resolve(ctx, mirror.URL)
+	if err != nil {
+		return err
+	}
+
+	remote, password, err := authURL(ctx, p.spaceFinder, p.secretService,
+		mirror.URL, mirror.Username, mirror.PasswordSecretSpaceID, mirror.PasswordSecretIdentifier)
+	if err != nil {
+		return err
+	}
+
+	err = p.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: readParams,
+		RemoteURL:  remote,
+		RefSpecs:   refSpecs,
+		Prune:      prune,
+		Resolve:    resolve,
+	})
+	if err != nil {
+		return sanitizeError(err, remote, mirror.URL, password)
+	}
+
+	return nil
+}
+
+// protectedRefSpecs returns the refspecs of all branches and tags of the repository protected by a rule.
+func (p *Pusher) protectedRefSpecs(
+	ctx context.Context,
+	repo *types.Repository,
+	readParams git.ReadParams,
+) ([]string, error) {
+	branchesOut, err := p.git.ListBranches(ctx, &git.ListBranchesParams{ReadParams: readParams})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	tagsOut, err := p.git.ListCommitTags(ctx, &git.ListCommitTagsParams{ReadParams: readParams})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	branchNames := make([]string, len(branchesOut.Branches))
+	for i, branch := range branchesOut.Branches {
+		branchNames[i] = branch.Name
+	}
+
+	tagNames := make([]string, len(tagsOut.Tags))
+	for i, tag := range tagsOut.Tags {
+		tagNames[i] = tag.Name
+	}
+
+	protectionRules, err := p.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	protectedBranches, protectedTags, err := protection.ProtectedRefNames(
+		protectionRules, repo.DefaultBranch, branchNames, tagNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find protected references: %w", err)
+	}
+
+	refSpecs := make([]string, 0, len(protectedBranches)+len(protectedTags))
+	for _, branch := range protectedBranches {
+		ref := api.BranchPrefix + branch
+		refSpecs = append(refSpecs, "+"+ref+":"+ref)
+	}
+	for _, tag := range protectedTags {
+		ref := api.TagPrefix + tag
+		refSpecs = append(refSpecs, "+"+ref+":"+ref)
+	}
+
+	return refSpecs, nil
+}
@@ -0,0 +1,430 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/secret"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "repo-mirror-sync"
+
+	// jobUIDPrefix is the prefix of the UID of the jobs synchronizing a single mirror on demand.
+	jobUIDPrefix = "repo-mirror-sync-"
+
+	// maxDueMirrors is the maximum number of mirrors synchronized in a single run of the recurring job.
+	maxDueMirrors = 1000
+)
+
+// ErrSyncInProgress is returned if a synchronization of the mirror is already scheduled or running.
+var ErrSyncInProgress = errors.New("mirror synchronization is already in progress")
+
+// Syncer keeps repository mirrors in sync with their upstream repositories.
+// The recurring job synchronizes all mirrors that are due, the single mirror jobs are used to synchronize
+// a mirror on demand. Both are handled by the same job handler: the job data contains the repository ID
+// for the on demand synchronization and is empty for the recurring job.
+type Syncer struct {
+	enabled       bool
+	cron          string
+	maxDur        time.Duration
+	numWorkers    int
+	urlProvider   gitnessurl.Provider
+	git           git.Interface
+	repoStore     store.RepoStore
+	mirrorStore   store.RepoMirrorStore
+	repoFinder    refcache.RepoFinder
+	spaceFinder   refcache.SpaceFinder
+	secretService secret.Service
+	locker        *locker.Locker
+	scheduler     *job.Scheduler
+	gitReporter   *gitevents.Reporter
+	repoReporter  *repoevents.Reporter
+	sseStreamer   sse.Streamer
+	remoteAccess  remoteAccess
+}
+
+var _ job.Handler = (*Syncer)(nil)
+
+func (s *Syncer) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repo mirror syncer: %w", err)
+	}
+
+	return nil
+}
+
+// Run starts a background job that synchronizes the mirror of the repository.
+func (s *Syncer) Run(ctx context.Context, repoID int64) error {
+	jobUID := jobUIDPrefix + strconv.FormatInt(repoID, 10)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get job progress: %w", err)
+	}
+	if err == nil && !progress.State.IsCompleted() {
+		return ErrSyncInProgress
+	}
+
+	if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+		return fmt.Errorf("failed to purge previous job: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: 0,
+		Timeout:    s.maxDur,
+		Data:       strconv.FormatInt(repoID, 10),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the repository mirror synchronization background job handler.
+func (s *Syncer) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	if data != "" {
+		repoID, err := strconv.ParseInt(data, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid job input: %w", err)
+		}
+
+		return "", s.Sync(ctx, repoID)
+	}
+
+	if !s.enabled {
+		return "", nil
+	}
+
+	mirrors, err := s.mirrorStore.ListDue(ctx, time.Now().UnixMilli(), maxDueMirrors)
+	if err != nil {
+		return "", fmt.Errorf("failed to list due repository mirrors: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	taskCh := make(chan int64)
+	for i := 0; i < s.numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repoID := range taskCh {
+				if err := s.Sync(ctx, repoID); err != nil {
+					log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to sync repository mirror")
+				}
+			}
+		}()
+	}
+
+loop:
+	for _, mirror := range mirrors {
+		select {
+		case <-ctx.Done():
+			break loop
+		case taskCh <- mirror.RepoID:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return "", nil
+}
+
+// Sync synchronizes the mirror of the repository with its upstream repository
+// and stores the outcome as the synchronization status of the mirror.
+func (s *Syncer) Sync(ctx context.Context, repoID int64) error {
+	unlock, err := s.locker.LockRepoMirror(ctx, repoID, s.maxDur)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	mirror, err := s.mirrorStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// mirroring got disabled in the meantime
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if repo.State != enum.RepoStateMirror {
+		return fmt.Errorf("repository %s is not a mirror", repo.Identifier)
+	}
+
+	mirror.SyncState = enum.RepoMirrorSyncStateRunning
+	if err = s.mirrorStore.UpdateSyncStatus(ctx, mirror); err != nil {
+		return fmt.Errorf("failed to update repository mirror sync status: %w", err)
+	}
+
+	started := time.Now()
+
+	syncErr := s.sync(ctx, repo, mirror)
+
+	now := time.Now().UnixMilli()
+	mirror.LastSync = now
+	mirror.NextSync = started.Add(time.Duration(mirror.Interval) * time.Second).UnixMilli()
+	if syncErr != nil {
+		mirror.SyncState = enum.RepoMirrorSyncStateFailure
+		mirror.LastError = syncErr.Error()
+	} else {
+		mirror.SyncState = enum.RepoMirrorSyncStateSuccess
+		mirror.LastSuccess = now
+		mirror.LastError = ""
+	}
+
+	// the status must be stored even if the job got canceled, otherwise it would stay in the running state.
+	if err = s.mirrorStore.UpdateSyncStatus(context.WithoutCancel(ctx), mirror); err != nil {
+		return fmt.Errorf("failed to update repository mirror sync status: %w", err)
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeRepositoryMirrorSyncCompleted, mirror)
+
+	return syncErr
+}
+
+func (s *Syncer) sync(ctx context.Context, repo *types.Repository, mirror *types.RepoMirror) error {
+	resolve, err := s.remoteAccess.resolve(ctx, mirror.URL)
+	if err != nil {
+		return err
+	}
+
+	source, password, err := authURL(ctx, s.spaceFinder, s.secretService,
+		mirror.URL, mirror.Username, mirror.PasswordSecretSpaceID, mirror.PasswordSecretIdentifier)
+	if err != nil {
+		return err
+	}
+
+	systemSession := bootstrap.NewSystemServiceSession()
+
+	writeParams, err := controller.CreateRPCSystemReferencesWriteParams(ctx, s.urlProvider, systemSession, repo.Core())
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	out, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams:      writeParams,
+		Source:           source,
+		RefSpecs:         []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"},
+		ReportRefUpdates: true,
+		Resolve:          resolve,
+	})
+	if err != nil {
+		return sanitizeError(err, source, mirror.URL, password)
+	}
+
+	s.updateRepo(ctx, systemSession, repo, out)
+
+	for _, refUpdate := range out.RefUpdates {
+		switch {
+		case strings.HasPrefix(refUpdate.Ref, api.BranchPrefix):
+			s.reportBranchEvent(ctx, systemSession.Principal.ID, repo, refUpdate)
+		case strings.HasPrefix(refUpdate.Ref, api.TagPrefix):
+			s.reportTagEvent(ctx, systemSession.Principal.ID, repo, refUpdate)
+		}
+	}
+
+	return nil
+}
+
+// updateRepo updates the default branch of the repository to match the upstream repository.
+func (s *Syncer) updateRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	out *git.SyncRepositoryOutput,
+) {
+	isEmpty := repo.IsEmpty
+	for _, refUpdate := range out.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, api.BranchPrefix) && !refUpdate.New.IsNil() {
+			isEmpty = false
+			break
+		}
+	}
+
+	if repo.DefaultBranch == out.DefaultBranch && repo.IsEmpty == isEmpty {
+		return
+	}
+
+	oldDefaultBranch := repo.DefaultBranch
+
+	repo, err := s.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.DefaultBranch = out.DefaultBranch
+		r.IsEmpty = isEmpty
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update repository after mirror sync")
+		return
+	}
+
+	s.repoFinder.MarkChanged(ctx, repo.Core())
+
+	if repo.DefaultBranch != oldDefaultBranch {
+		s.repoReporter.DefaultBranchUpdated(ctx, &repoevents.DefaultBranchUpdatedPayload{
+			Base: repoevents.Base{
+				RepoID:      repo.ID,
+				PrincipalID: session.Principal.ID,
+			},
+			OldName: oldDefaultBranch,
+			NewName: repo.DefaultBranch,
+		})
+	}
+}
+
+func (s *Syncer) reportBranchEvent(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	branchUpdate hook.ReferenceUpdate,
+) {
+	switch {
+	case branchUpdate.Old.IsNil():
+		payload := &gitevents.BranchCreatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         branchUpdate.Ref,
+			SHA:         branchUpdate.New.String(),
+		}
+
+		s.gitReporter.BranchCreated(ctx, payload)
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchCreated, payload)
+
+	case branchUpdate.New.IsNil():
+		payload := &gitevents.BranchDeletedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         branchUpdate.Ref,
+			SHA:         branchUpdate.Old.String(),
+		}
+
+		s.gitReporter.BranchDeleted(ctx, payload)
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchDeleted, payload)
+
+	default:
+		result, err := s.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			AncestorCommitSHA:   branchUpdate.Old,
+			DescendantCommitSHA: branchUpdate.New,
+		})
+		forced := err != nil || !result.Ancestor
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("ref", branchUpdate.Ref).
+				Msg("failed to check ancestor")
+		}
+
+		payload := &gitevents.BranchUpdatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         branchUpdate.Ref,
+			OldSHA:      branchUpdate.Old.String(),
+			NewSHA:      branchUpdate.New.String(),
+			Forced:      forced,
+		}
+
+		s.gitReporter.BranchUpdated(ctx, payload)
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeBranchUpdated, payload)
+	}
+}
+
+func (s *Syncer) reportTagEvent(
+	ctx context.Context,
+	principalID int64,
+	repo *types.Repository,
+	tagUpdate hook.ReferenceUpdate,
+) {
+	switch {
+	case tagUpdate.Old.IsNil():
+		payload := &gitevents.TagCreatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         tagUpdate.Ref,
+			SHA:         tagUpdate.New.String(),
+		}
+
+		s.gitReporter.TagCreated(ctx, payload)
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeTagCreated, payload)
+
+	case tagUpdate.New.IsNil():
+		payload := &gitevents.TagDeletedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         tagUpdate.Ref,
+			SHA:         tagUpdate.Old.String(),
+		}
+
+		s.gitReporter.TagDeleted(ctx, payload)
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeTagDeleted, payload)
+
+	default:
+		payload := &gitevents.TagUpdatedPayload{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         tagUpdate.Ref,
+			OldSHA:      tagUpdate.Old.String(),
+			NewSHA:      tagUpdate.New.String(),
+			// tags can only be force updated!
+			Forced: true,
+		}
+
+		s.gitReporter.TagUpdated(ctx, payload)
+
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeTagUpdated, payload)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/secret"
+)
+
+// authURL returns the repository URL with the credentials stored in the password secret
+// and the password (to be able to strip it from error messages).
+func authURL(
+	ctx context.Context,
+	spaceFinder refcache.SpaceFinder,
+	secretService secret.Service,
+	repoURL string,
+	username string,
+	secretSpaceID int64,
+	secretIdentifier string,
+) (string, string, error) {
+	if secretIdentifier == "" {
+		return repoURL, "", nil
+	}
+
+	space, err := spaceFinder.FindByID(ctx, secretSpaceID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find space of the password secret: %w", err)
+	}
+
+	password, err := secretService.DecryptSecret(ctx, space.Path, secretIdentifier)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get password secret: %w", err)
+	}
+
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse repository URL: %w", err)
+	}
+
+	u.User = url.UserPassword(username, password)
+
+	return u.String(), password, nil
+}
+
+// sanitizeError makes sure that the credentials don't leak through the error message.
+func sanitizeError(err error, authenticatedURL, repoURL, password string) error {
+	msg := strings.ReplaceAll(err.Error(), authenticatedURL, repoURL)
+	if password != "" {
+		msg = strings.ReplaceAll(msg, password, "*****")
+	}
+
+	return errors.New(msg)
+}
+
+// CheckAddress returns an error if mirrors from and to the IP address aren't allowed.
+// Link-local and unspecified addresses are never allowed, as they can be used to reach cloud metadata services.
+func CheckAddress(ip net.IP, allowLoopback bool, allowPrivateNetwork bool) error {
+	switch {
+	case ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast():
+		return fmt.Errorf("address %s is not allowed", ip)
+	case !allowLoopback && ip.IsLoopback():
+		return fmt.Errorf("loopback address %s is not allowed", ip)
+	case !allowPrivateNetwork && ip.IsPrivate():
+		return fmt.Errorf("private network address %s is not allowed", ip)
+	}
+
+	return nil
+}
+
+// remoteAccess defines the addresses the remotes of mirrors are allowed to resolve to.
+type remoteAccess struct {
+	allowLoopback       bool
+	allowPrivateNetwork bool
+
+	// lookupIPAddr resolves host names, net.DefaultResolver is used if nil.
+	lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// resolve resolves the host of the repository URL and verifies that all its addresses are allowed.
+// It returns the addresses in the format `host:port:address[,address...]` expected by git,
+// which pins the connection to the verified addresses, so the host can't be rebound in the meantime.
+func (a remoteAccess) resolve(ctx context.Context, repoURL string) ([]string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repository URL: %w", err)
+	}
+
+	host := u.Hostname()
+
+	// IP addresses can't be rebound, there is nothing to pin.
+	if ip := net.ParseIP(host); ip != nil {
+		if err := CheckAddress(ip, a.allowLoopback, a.allowPrivateNetwork); err != nil {
+			return nil, fmt.Errorf("host %s can't be used as remote: %w", host, err)
+		}
+		return nil, nil
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	lookupIPAddr := a.lookupIPAddr
+	if lookupIPAddr == nil {
+		lookupIPAddr = net.DefaultResolver.LookupIPAddr
+	}
+
+	ipAddrs, err := lookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve host %s: %w", host, err)
+	}
+
+	addrs := make([]string, len(ipAddrs))
+	for i, ipAddr := range ipAddrs {
+		if err := CheckAddress(ipAddr.IP, a.allowLoopback, a.allowPrivateNetwork); err != nil {
+			return nil, fmt.Errorf("host %s can't be used as remote: %w", host, err)
+		}
+
+		addrs[i] = ipAddr.IP.String()
+		if ipAddr.IP.To4() == nil {
+			addrs[i] = "[" + addrs[i] + "]"
+		}
+	}
+
+	return []string{host + ":" + port + ":" + strings.Join(addrs, ",")}, nil
+}
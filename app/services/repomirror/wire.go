@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomirror
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideSyncer,
+	ProvidePusher,
+)
+
+func ProvideSyncer(
+	config *types.Config,
+	urlProvider url.Provider,
+	git git.Interface,
+	repoStore store.RepoStore,
+	mirrorStore store.RepoMirrorStore,
+	repoFinder refcache.RepoFinder,
+	spaceFinder refcache.SpaceFinder,
+	secretService secret.Service,
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	gitReporter *gitevents.Reporter,
+	repoReporter *repoevents.Reporter,
+	sseStreamer sse.Streamer,
+) (*Syncer, error) {
+	syncer := &Syncer{
+		enabled:       config.RepoMirror.Enabled,
+		cron:          config.RepoMirror.CRON,
+		maxDur:        config.RepoMirror.MaxDuration,
+		numWorkers:    config.RepoMirror.NumWorkers,
+		urlProvider:   urlProvider,
+		git:           git,
+		repoStore:     repoStore,
+		mirrorStore:   mirrorStore,
+		repoFinder:    repoFinder,
+		spaceFinder:   spaceFinder,
+		secretService: secretService,
+		locker:        locker,
+		scheduler:     scheduler,
+		gitReporter:   gitReporter,
+		repoReporter:  repoReporter,
+		sseStreamer:   sseStreamer,
+		remoteAccess: remoteAccess{
+			allowLoopback:       config.RepoMirror.AllowLoopback,
+			allowPrivateNetwork: config.RepoMirror.AllowPrivateNetwork,
+		},
+	}
+
+	err := executor.Register(jobType, syncer)
+	if err != nil {
+		return nil, err
+	}
+
+	return syncer, nil
+}
+
+func ProvidePusher(
+	ctx context.Context,
+	config *types.Config,
+	repoEvReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	git git.Interface,
+	repoStore store.RepoStore,
+	pushMirrorStore store.RepoPushMirrorStore,
+	spaceFinder refcache.SpaceFinder,
+	secretService secret.Service,
+	protectionManager *protection.Manager,
+	locker *locker.Locker,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	sseStreamer sse.Streamer,
+) (*Pusher, error) {
+	return NewPusher(
+		ctx,
+		config,
+		repoEvReaderFactory,
+		git,
+		repoStore,
+		pushMirrorStore,
+		spaceFinder,
+		secretService,
+		protectionManager,
+		locker,
+		scheduler,
+		executor,
+		sseStreamer,
+	)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -98,6 +99,18 @@ func (s *Service) parseRule(rule *types.Rule) (protection.Protection, error) {
 	return protection, nil
 }
 
+// auditResourceType returns the audit resource type of the protection rule type.
+func auditResourceType(ruleType types.RuleType) audit.ResourceType {
+	switch ruleType {
+	case protection.TypeTag:
+		return audit.ResourceTypeTagRule
+	case protection.TypePush:
+		return audit.ResourceTypePushRule
+	default:
+		return audit.ResourceTypeBranchRule
+	}
+}
+
 func (s *Service) sendSSE(
 	ctx context.Context,
 	parentID int64,
@@ -136,13 +136,13 @@ func (s *Service) Create(ctx context.Context,
 
 	err = s.auditService.Log(ctx,
 		*principal,
-		audit.NewResource(audit.ResourceTypeBranchRule, rule.Identifier, nameKey, scopeIdentifier),
+		audit.NewResource(auditResourceType(rule.Type), rule.Identifier, nameKey, scopeIdentifier),
 		audit.ActionCreated,
 		spacePath,
 		audit.WithNewObject(rule),
 	)
 	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create branch rule operation: %s", err)
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create %s rule operation: %s", rule.Type, err)
 	}
 
 	userMap, userGroupMap, err := s.getRuleUserAndUserGroups(ctx, rule)
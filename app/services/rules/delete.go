@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	ruleevents "github.com/harness/gitness/app/events/rule"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
@@ -52,7 +53,7 @@ func (s *Service) Delete(ctx context.Context,
 	err = s.auditService.Log(ctx,
 		*principal,
 		audit.NewResource(
-			audit.ResourceTypeBranchRule,
+			auditResourceType(rule.Type),
 			rule.Identifier,
 			nameKey,
 			scopeIdentifier,
@@ -62,10 +63,21 @@ func (s *Service) Delete(ctx context.Context,
 		audit.WithOldObject(rule),
 	)
 	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete branch rule operation: %s", err)
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete %s rule operation: %s", rule.Type, err)
 	}
 
 	s.sendSSE(ctx, parentID, parentType, enum.SSETypeRuleDeleted, rule)
 
+	s.eventReporter.Deleted(ctx, &ruleevents.DeletedPayload{
+		Base: ruleevents.Base{
+			RuleID:      rule.ID,
+			SpaceID:     rule.SpaceID,
+			RepoID:      rule.RepoID,
+			PrincipalID: principal.ID,
+		},
+		Identifier: rule.Identifier,
+		Type:       rule.Type,
+	})
+
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/usererror"
+	ruleevents "github.com/harness/gitness/app/events/rule"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/audit"
@@ -153,17 +154,26 @@ func (s *Service) Update(ctx context.Context,
 	}
 	err = s.auditService.Log(ctx,
 		*principal,
-		audit.NewResource(audit.ResourceTypeBranchRule, rule.Identifier, nameKey, scopeIdentifier),
+		audit.NewResource(auditResourceType(rule.Type), rule.Identifier, nameKey, scopeIdentifier),
 		audit.ActionUpdated,
 		paths.Parent(path),
 		audit.WithOldObject(oldRule),
 		audit.WithNewObject(rule),
 	)
 	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update branch rule operation: %s", err)
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update %s rule operation: %s", rule.Type, err)
 	}
 
 	s.sendSSE(ctx, parentID, parentType, enum.SSETypeRuleUpdated, rule)
 
+	s.eventReporter.Updated(ctx, &ruleevents.UpdatedPayload{
+		Base: ruleevents.Base{
+			RuleID:      rule.ID,
+			SpaceID:     rule.SpaceID,
+			RepoID:      rule.RepoID,
+			PrincipalID: principal.ID,
+		},
+	})
+
 	return rule, nil
 }
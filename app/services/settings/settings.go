@@ -28,4 +28,7 @@ var (
 	DefaultPrincipalCommitterMatch     = false
 	KeyGitLFSEnabled               Key = "git_lfs_enabled"
 	DefaultGitLFSEnabled               = true
+	// KeyPullReqTemplate [string] is the default pull request template of a space.
+	KeyPullReqTemplate     Key = "pullreq_template"
+	DefaultPullReqTemplate     = string("")
 )
@@ -102,6 +102,27 @@ func (s *Service) handleEventPullReqMerged(
 	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqMerged, hook)
 }
 
+func (s *Service) handleEventMergeQueueChecksRequested(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueChecksRequestedPayload],
+) error {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionMergeQueueChecksRequested,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		After:       event.Payload.MergeSHA,
+	}
+	err := s.augmentPullReqInfo(ctx, hook, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("could not augment pull request info: %w", err)
+	}
+	// the pipeline runs on the speculative merge commit and not on the pull request head.
+	hook.Ref = event.Payload.Ref
+	hook.Before = event.Payload.BaseSHA
+	// the speculative merge commit lives in the target repository.
+	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionMergeQueueChecksRequested, hook)
+}
+
 // augmentPullReqInfo adds in information into the hook pertaining to the pull request
 // by querying the database.
 func (s *Service) augmentPullReqInfo(
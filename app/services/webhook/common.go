@@ -16,15 +16,22 @@ package webhook
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"net"
+	"net/http"
 	"net/url"
+	"strings"
+	"text/template"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/net/http/httpguts"
 )
 
 const (
@@ -32,8 +39,25 @@ const (
 	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
+	// webhookMaxTemplateLength defines the max allowed length of a webhook chat message template.
+	webhookMaxTemplateLength = 4096
+	// webhookMaxFilterEntries defines the max allowed number of entries of a single webhook filter.
+	webhookMaxFilterEntries = 50
+	// webhookMaxFilterEntryLength defines the max allowed length of a single webhook filter entry.
+	webhookMaxFilterEntryLength = 256
+	// webhookMaxDeliveryHeaders defines the max allowed number of static headers of a webhook.
+	webhookMaxDeliveryHeaders = 20
 )
 
+// reservedDeliveryHeaders contains the headers that can't be overwritten by the static headers of a webhook.
+var reservedDeliveryHeaders = map[string]struct{}{
+	"Authorization":  {},
+	"Content-Length": {},
+	"Content-Type":   {},
+	"Host":           {},
+	"User-Agent":     {},
+}
+
 var ErrInternalWebhookOperationNotAllowed = errors.Forbidden("changes to internal webhooks are not allowed")
 
 // CheckURL validates the url of a webhook.
@@ -93,6 +117,148 @@ func CheckSecret(secret string) error {
 	return nil
 }
 
+// CheckFormat validates the format of a webhook.
+func CheckFormat(format enum.WebhookFormat) error {
+	if _, ok := format.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided webhook format '%s' is invalid.", format)
+	}
+
+	return nil
+}
+
+// CheckTemplate validates the chat message template of a webhook.
+func CheckTemplate(tmpl string) error {
+	if len(tmpl) > webhookMaxTemplateLength {
+		return check.NewValidationErrorf("The template of a webhook can be at most %d characters long.",
+			webhookMaxTemplateLength)
+	}
+
+	if _, err := template.New("chat").Parse(tmpl); err != nil {
+		return check.NewValidationErrorf("The provided webhook template is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// CheckFilters validates the filters of a webhook.
+func CheckFilters(filters types.WebhookTriggerFilters) error {
+	patterns := []struct {
+		name    string
+		entries []string
+	}{
+		{name: "branch", entries: filters.Branches},
+		{name: "tag", entries: filters.Tags},
+		{name: "path", entries: filters.Paths},
+		{name: "target branch", entries: filters.TargetBranches},
+	}
+	for _, p := range patterns {
+		if err := checkFilterEntries(p.name, p.entries); err != nil {
+			return err
+		}
+		for _, pattern := range p.entries {
+			if !doublestar.ValidatePattern(pattern) {
+				return check.NewValidationErrorf("The provided webhook %s filter '%s' is invalid.", p.name, pattern)
+			}
+		}
+	}
+
+	return checkFilterEntries("label", filters.Labels)
+}
+
+func checkFilterEntries(name string, entries []string) error {
+	if len(entries) > webhookMaxFilterEntries {
+		return check.NewValidationErrorf("A webhook can have at most %d %s filters.",
+			webhookMaxFilterEntries, name)
+	}
+
+	for _, entry := range entries {
+		if entry == "" {
+			return check.NewValidationErrorf("The webhook %s filters can't contain empty entries.", name)
+		}
+		if len(entry) > webhookMaxFilterEntryLength {
+			return check.NewValidationErrorf("A webhook %s filter can be at most %d characters long.",
+				name, webhookMaxFilterEntryLength)
+		}
+	}
+
+	return nil
+}
+
+// SanitizeDelivery validates the delivery options of a webhook and backfills the default values.
+func SanitizeDelivery(delivery *types.WebhookDelivery) error {
+	var ok bool
+	if delivery.CloudEvents, ok = delivery.CloudEvents.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided CloudEvents mode '%s' is invalid.", delivery.CloudEvents)
+	}
+	if delivery.Signature, ok = delivery.Signature.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided webhook signature '%s' is invalid.", delivery.Signature)
+	}
+	if delivery.Auth, ok = delivery.Auth.Sanitize(); !ok {
+		return check.NewValidationErrorf("The provided webhook auth '%s' is invalid.", delivery.Auth)
+	}
+
+	if len(delivery.Headers) > webhookMaxDeliveryHeaders {
+		return check.NewValidationErrorf("A webhook can have at most %d headers.", webhookMaxDeliveryHeaders)
+	}
+	for i := range delivery.Headers {
+		h := &delivery.Headers[i]
+		h.Key = http.CanonicalHeaderKey(strings.TrimSpace(h.Key))
+		if !httpguts.ValidHeaderFieldName(h.Key) {
+			return check.NewValidationErrorf("The provided webhook header '%s' is invalid.", h.Key)
+		}
+		if !httpguts.ValidHeaderFieldValue(h.Value) {
+			return check.NewValidationErrorf("The value of the webhook header '%s' is invalid.", h.Key)
+		}
+		if _, reserved := reservedDeliveryHeaders[h.Key]; reserved || strings.HasPrefix(h.Key, "Ce-") {
+			return check.NewValidationErrorf("The webhook header '%s' can't be overwritten.", h.Key)
+		}
+	}
+
+	switch delivery.Auth {
+	case enum.WebhookAuthTypeNone:
+		delivery.AuthSecretSpaceID = 0
+		delivery.AuthSecretIdentifier = ""
+		delivery.ClientCertificate = ""
+	case enum.WebhookAuthTypeBearer:
+		if delivery.AuthSecretIdentifier == "" {
+			return check.NewValidationError("The bearer token auth requires a secret.")
+		}
+		delivery.ClientCertificate = ""
+	case enum.WebhookAuthTypeMTLS:
+		if delivery.AuthSecretIdentifier == "" {
+			return check.NewValidationError("The mTLS auth requires a secret with the private key.")
+		}
+		if err := checkClientCertificate(delivery.ClientCertificate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkClientCertificate(certPEM string) error {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return check.NewValidationError("The mTLS auth requires a PEM encoded client certificate.")
+	}
+
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return check.NewValidationErrorf("The provided client certificate is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// CheckFormatWithDelivery validates that the format of a webhook can be used with its delivery options.
+func CheckFormatWithDelivery(format enum.WebhookFormat, delivery types.WebhookDelivery) error {
+	cloudEvents := delivery.CloudEvents != "" && delivery.CloudEvents != enum.WebhookCloudEventsModeDisabled
+	if format != enum.WebhookFormatJSON && cloudEvents {
+		return check.NewValidationErrorf("CloudEvents can't be used with the %s format.", format)
+	}
+
+	return nil
+}
+
 // CheckTriggers validates the triggers of a webhook.
 func CheckTriggers(triggers []enum.WebhookTrigger) error {
 	// ignore duplicates here, should be deduplicated later
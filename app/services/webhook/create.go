@@ -65,7 +65,25 @@ func (s *Service) sanitizeCreateInput(in *types.WebhookCreateInput, internal boo
 	if err := CheckSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := CheckTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := CheckTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if in.Format == "" {
+		in.Format = enum.WebhookFormatJSON
+	}
+	if err := CheckFormat(in.Format); err != nil {
+		return err
+	}
+	if err := CheckTemplate(in.Template); err != nil {
+		return err
+	}
+	if err := CheckFilters(in.Filters); err != nil {
+		return err
+	}
+	if err := SanitizeDelivery(&in.Delivery); err != nil {
+		return err
+	}
+	if err := CheckFormatWithDelivery(in.Format, in.Delivery); err != nil { //nolint:revive
 		return err
 	}
 
@@ -121,6 +139,10 @@ func (s *Service) Create(
 		Insecure:              in.Insecure,
 		Triggers:              DeduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		Format:                in.Format,
+		Template:              in.Template,
+		Filters:               in.Filters,
+		Delivery:              in.Delivery,
 	}
 
 	err = s.webhookStore.Create(ctx, hook)
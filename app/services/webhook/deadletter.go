@@ -0,0 +1,293 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	redeliveryJobType = "webhook-redelivery"
+
+	// redeliveryJobUIDPrefix is the prefix of the UID of the jobs redelivering a single webhook execution.
+	redeliveryJobUIDPrefix = "webhook-redelivery-"
+
+	// redeliveryJobTimeout is the time limit of a single redelivery attempt.
+	redeliveryJobTimeout = time.Minute
+
+	// redeliverMaxExecutions is the maximum number of failed executions redelivered by a single request.
+	redeliverMaxExecutions = 500
+)
+
+// deadLetterQueue keeps track of failed webhook executions.
+// Executions that failed with a retriable error are redelivered in background jobs,
+// which the job scheduler retries with an exponential backoff, so receivers can recover from longer outages.
+// Webhooks that keep failing are disabled and their creators are notified.
+type deadLetterQueue struct {
+	maxRetries           int
+	autoDisableThreshold int
+	executor             *WebhookExecutor
+	scheduler            *job.Scheduler
+	webhookStore         store.WebhookStore
+	repoStore            store.RepoStore
+	spaceStore           store.SpaceStore
+	principalStore       store.PrincipalStore
+	notificationClient   notification.Client
+}
+
+var _ job.Handler = (*deadLetterQueue)(nil)
+
+// Schedule starts a background job for every execution that redelivers it until it succeeds.
+// Executions that already have a pending redelivery job are skipped.
+func (q *deadLetterQueue) Schedule(ctx context.Context, executionIDs ...int64) error {
+	defs := make([]job.Definition, 0, len(executionIDs))
+	for _, executionID := range executionIDs {
+		jobUID := redeliveryJobUIDPrefix + strconv.FormatInt(executionID, 10)
+
+		progress, err := q.scheduler.GetJobProgress(ctx, jobUID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to get progress of job %s: %w", jobUID, err)
+		}
+		if err == nil && !progress.State.IsCompleted() {
+			continue
+		}
+
+		if err = q.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous job %s: %w", jobUID, err)
+		}
+
+		defs = append(defs, job.Definition{
+			UID:        jobUID,
+			Type:       redeliveryJobType,
+			MaxRetries: q.maxRetries,
+			Timeout:    redeliveryJobTimeout,
+			Data:       strconv.FormatInt(executionID, 10),
+
+			ExponentialBackoff: true,
+		})
+	}
+
+	err := q.scheduler.RunJobs(ctx, redeliveryJobType, defs)
+	if err != nil {
+		return fmt.Errorf("failed to run jobs: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the webhook redelivery background job handler.
+// It returns an error if the redelivery failed with a retriable error, so the job scheduler retries it later.
+func (q *deadLetterQueue) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	executionID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid job input: %w", err)
+	}
+
+	execution, err := q.executor.webhookExecutorStore.Find(ctx, executionID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the execution got purged or the webhook got deleted in the meantime
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook execution: %w", err)
+	}
+
+	webhook, err := q.executor.webhookExecutorStore.FindWebhook(ctx, execution.WebhookID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	// disabled webhooks aren't redelivered, the user can redeliver the failed executions after enabling it
+	if !webhook.Enabled || !execution.Retriggerable {
+		return "", nil
+	}
+
+	result := q.executor.retriggerWebhookExecution(ctx, webhook, execution)
+	if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
+		return "", fmt.Errorf("redelivery of webhook execution %d failed: %w", executionID, result.Err)
+	}
+
+	return "", nil
+}
+
+// recordExecution updates the number of consecutive failed executions of the webhook
+// and disables the webhook in case it reached the configured threshold.
+// Only the first execution of a trigger can count as a failure, failed redeliveries of the same trigger don't.
+func (q *deadLetterQueue) recordExecution(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	execution *types.WebhookExecutionCore,
+) {
+	failed := execution.Result == enum.WebhookExecutionResultRetriableError ||
+		execution.Result == enum.WebhookExecutionResultFatalError
+
+	if failed && execution.RetriggerOf != nil {
+		return
+	}
+
+	// avoid updating the webhook after every successful execution
+	if !failed && webhook.ConsecutiveFailures == 0 {
+		return
+	}
+
+	var disabled bool
+	hook, err := q.webhookStore.UpdateOptLock(ctx, CoreWebhookToGitnessWebhook(webhook),
+		func(hook *types.Webhook) error {
+			disabled = false
+
+			if !failed {
+				hook.ConsecutiveFailures = 0
+				return nil
+			}
+
+			hook.ConsecutiveFailures++
+
+			if hook.Enabled && q.autoDisableThreshold > 0 && hook.ConsecutiveFailures >= q.autoDisableThreshold {
+				hook.Enabled = false
+				hook.DisabledReason = fmt.Sprintf(
+					"Disabled automatically after %d consecutive failed executions.", hook.ConsecutiveFailures)
+				disabled = true
+			}
+
+			return nil
+		})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to update consecutive failures of webhook %d", webhook.ID)
+		return
+	}
+
+	if !disabled {
+		return
+	}
+
+	log.Ctx(ctx).Info().Msgf("webhook %d got disabled after %d consecutive failed executions",
+		hook.ID, hook.ConsecutiveFailures)
+
+	if err := q.notifyDisabled(ctx, hook, execution); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to notify creator of disabled webhook %d", hook.ID)
+	}
+}
+
+// notifyDisabled notifies the creator of the webhook that it got disabled.
+func (q *deadLetterQueue) notifyDisabled(
+	ctx context.Context,
+	hook *types.Webhook,
+	execution *types.WebhookExecutionCore,
+) error {
+	creator, err := q.principalStore.Find(ctx, hook.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find creator of webhook: %w", err)
+	}
+
+	var parentPath string
+	switch hook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := q.repoStore.Find(ctx, hook.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find repo of webhook: %w", err)
+		}
+		parentPath = repo.Path
+	case enum.WebhookParentSpace:
+		space, err := q.spaceStore.Find(ctx, hook.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to find space of webhook: %w", err)
+		}
+		parentPath = space.Path
+	case enum.WebhookParentRegistry:
+		return nil
+	}
+
+	recipient := creator.ToPrincipalInfo()
+
+	return q.notificationClient.SendWebhookDisabled(ctx, []*types.PrincipalInfo{recipient},
+		&notification.WebhookDisabledPayload{
+			Recipient:           recipient,
+			ParentPath:          parentPath,
+			WebhookIdentifier:   hook.Identifier,
+			ConsecutiveFailures: hook.ConsecutiveFailures,
+			LatestError:         execution.Error,
+		})
+}
+
+// RedeliverFailedExecutions schedules the redelivery of the executions of the webhook
+// that failed since the provided time. Only the latest failed execution of every trigger is redelivered.
+func (s *Service) RedeliverFailedExecutions(
+	ctx context.Context,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	in *types.WebhookRedeliverInput,
+) (*types.WebhookRedeliverOutput, error) {
+	webhook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.Type == enum.WebhookTypeInternal {
+		return nil, ErrInternalWebhookOperationNotAllowed
+	}
+
+	if in.Since < 0 {
+		return nil, check.NewValidationError("Since must be a non-negative unix timestamp in milliseconds.")
+	}
+
+	if !webhook.Enabled {
+		return nil, check.NewValidationError("Failed executions can't be redelivered to a disabled webhook.")
+	}
+
+	executions, err := s.webhookExecutionStore.ListFailedSince(ctx, webhook.ID, in.Since, redeliverMaxExecutions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed executions of webhook %d: %w", webhook.ID, err)
+	}
+
+	// executions are listed newest first, only keep the latest execution of every trigger
+	triggers := make(map[string]struct{}, len(executions))
+	executionIDs := make([]int64, 0, len(executions))
+	for _, execution := range executions {
+		if _, ok := triggers[execution.TriggerID]; ok {
+			continue
+		}
+		triggers[execution.TriggerID] = struct{}{}
+		executionIDs = append(executionIDs, execution.ID)
+	}
+
+	if len(executionIDs) > 0 {
+		err = s.WebhookExecutor.deadLetter.Schedule(ctx, executionIDs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule redelivery of webhook %d: %w", webhook.ID, err)
+		}
+	}
+
+	return &types.WebhookRedeliverOutput{Count: len(executionIDs)}, nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type consecutiveFailuresWebhookStore struct {
+	store.WebhookStore
+	hook *types.Webhook
+}
+
+func (s *consecutiveFailuresWebhookStore) UpdateOptLock(
+	_ context.Context,
+	_ *types.Webhook,
+	mutateFn func(hook *types.Webhook) error,
+) (*types.Webhook, error) {
+	if err := mutateFn(s.hook); err != nil {
+		return nil, err
+	}
+	return s.hook, nil
+}
+
+func TestDeadLetterQueue_RecordExecution(t *testing.T) {
+	retriggerOf := int64(1)
+
+	tests := []struct {
+		name      string
+		failures  int
+		execution types.WebhookExecutionCore
+		exp       int
+	}{
+		{
+			name:      "failed",
+			failures:  1,
+			execution: types.WebhookExecutionCore{Result: enum.WebhookExecutionResultRetriableError},
+			exp:       2,
+		},
+		{
+			name:     "failed-redelivery",
+			failures: 1,
+			execution: types.WebhookExecutionCore{
+				Result:      enum.WebhookExecutionResultRetriableError,
+				RetriggerOf: &retriggerOf,
+			},
+			exp: 1,
+		},
+		{
+			name:     "succeeded-redelivery",
+			failures: 1,
+			execution: types.WebhookExecutionCore{
+				Result:      enum.WebhookExecutionResultSuccess,
+				RetriggerOf: &retriggerOf,
+			},
+			exp: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webhookStore := &consecutiveFailuresWebhookStore{
+				hook: &types.Webhook{Enabled: true, ConsecutiveFailures: test.failures},
+			}
+			q := &deadLetterQueue{webhookStore: webhookStore}
+
+			q.recordExecution(context.Background(),
+				&types.WebhookCore{ConsecutiveFailures: test.failures}, &test.execution)
+
+			if got := webhookStore.hook.ConsecutiveFailures; got != test.exp {
+				t.Errorf("want %d consecutive failures, got %d", test.exp, got)
+			}
+		})
+	}
+}
+
+type redeliveryExecutorStore struct {
+	WebhookExecutorStore
+	webhooks   []*types.WebhookCore
+	executions []*types.WebhookExecutionCore
+}
+
+func (s *redeliveryExecutorStore) ListWebhooks(
+	context.Context,
+	[]types.WebhookParentInfo,
+) ([]*types.WebhookCore, error) {
+	return s.webhooks, nil
+}
+
+func (s *redeliveryExecutorStore) ListForTrigger(context.Context, string) ([]*types.WebhookExecutionCore, error) {
+	return nil, nil
+}
+
+func (s *redeliveryExecutorStore) CreateWebhookExecution(
+	_ context.Context,
+	execution *types.WebhookExecutionCore,
+) error {
+	execution.ID = int64(len(s.executions) + 1)
+	s.executions = append(s.executions, execution)
+	return nil
+}
+
+func (s *redeliveryExecutorStore) UpdateOptLock(
+	_ context.Context,
+	hook *types.WebhookCore,
+	_ *types.WebhookExecutionCore,
+) (*types.WebhookCore, error) {
+	return hook, nil
+}
+
+type redeliveryJobStore struct {
+	job.Store
+	jobs []*job.Job
+}
+
+func (s *redeliveryJobStore) Find(context.Context, string) (*job.Job, error) {
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *redeliveryJobStore) DeleteByUID(context.Context, string) error {
+	return nil
+}
+
+func (s *redeliveryJobStore) Create(_ context.Context, j *job.Job) error {
+	s.jobs = append(s.jobs, j)
+	return nil
+}
+
+type redeliveryURLProvider struct{}
+
+func (redeliveryURLProvider) GetWebhookURL(_ context.Context, webhook *types.WebhookCore) (string, error) {
+	return webhook.URL, nil
+}
+
+func TestTriggerForEvent_ScheduleRedelivery(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  func(t *testing.T) string
+		timeout time.Duration
+	}{
+		{
+			name: "receiver-down",
+			server: func(*testing.T) string {
+				srv := httptest.NewServer(http.NotFoundHandler())
+				srv.Close()
+				return srv.URL
+			},
+			timeout: time.Minute,
+		},
+		{
+			name: "receiver-hangs",
+			server: func(t *testing.T) string {
+				done := make(chan struct{})
+				srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					<-done
+				}))
+				t.Cleanup(func() {
+					close(done)
+					srv.Close()
+				})
+				return srv.URL
+			},
+			timeout: 200 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executorStore := &redeliveryExecutorStore{
+				webhooks: []*types.WebhookCore{{ID: 1, Enabled: true, URL: test.server(t)}},
+			}
+			jobStore := &redeliveryJobStore{}
+
+			scheduler, err := job.NewScheduler(jobStore, nil, nil, nil, "test", 1, time.Hour)
+			if err != nil {
+				t.Fatalf("failed to create scheduler: %s", err)
+			}
+
+			executor := NewWebhookExecutor(Config{AllowLoopback: true}, redeliveryURLProvider{},
+				nil, nil, nil, nil, executorStore, "gitness")
+			executor.deadLetter = &deadLetterQueue{
+				maxRetries:   3,
+				executor:     executor,
+				scheduler:    scheduler,
+				webhookStore: &consecutiveFailuresWebhookStore{hook: &types.Webhook{Enabled: true}},
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+
+			err = executor.TriggerForEvent(ctx, "event", nil, enum.WebhookTriggerBranchCreated, struct{}{})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(executorStore.executions) != 1 {
+				t.Fatalf("want 1 execution, got %d", len(executorStore.executions))
+			}
+			if got := executorStore.executions[0].Result; got != enum.WebhookExecutionResultRetriableError {
+				t.Errorf("want execution result %s, got %s", enum.WebhookExecutionResultRetriableError, got)
+			}
+
+			if len(jobStore.jobs) != 1 {
+				t.Fatalf("want 1 redelivery job, got %d", len(jobStore.jobs))
+			}
+			if got := jobStore.jobs[0].Data; got != "1" {
+				t.Errorf("want redelivery of execution 1, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	cloudEventsSpecVersion         = "1.0"
+	cloudEventsStructuredMediaType = "application/cloudevents+json"
+
+	// redactedHeaderValue replaces the value of sensitive headers in the stored webhook executions.
+	redactedHeaderValue = "[REDACTED]"
+)
+
+// cloudEvent is a CloudEvents 1.0 event as sent in the structured HTTP content mode.
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            any    `json:"data,omitempty"`
+}
+
+// cloudEventFor returns the CloudEvents attributes for the execution of the webhook.
+// NOTE: The id is the same for all executions of a webhook for the same trigger,
+// which allows receivers to detect retries and retriggers of an already processed event.
+func (w *WebhookExecutor) cloudEventFor(
+	webhook *types.WebhookCore,
+	triggerID string,
+	triggerType enum.WebhookTrigger,
+	data any,
+) cloudEvent {
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              fmt.Sprintf("%s-%d", triggerID, webhook.ID),
+		Source:          fmt.Sprintf("/%s/%d", webhook.ParentType, webhook.ParentID),
+		Type:            fmt.Sprintf("%s.%s", strings.ToLower(w.config.HeaderIdentity), triggerType),
+		Time:            time.Now().UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// setBinaryHeaders sets the CloudEvents attributes as headers, as required by the binary HTTP content mode.
+func (e cloudEvent) setBinaryHeaders(header http.Header) {
+	header.Set("ce-specversion", e.SpecVersion)
+	header.Set("ce-id", e.ID)
+	header.Set("ce-source", e.Source)
+	header.Set("ce-type", e.Type)
+	header.Set("ce-time", e.Time)
+}
+
+// getAuthSecretValue returns the value of the auth secret of the webhook.
+func (w *WebhookExecutor) getAuthSecretValue(ctx context.Context, webhook *types.WebhookCore) (string, error) {
+	if webhook.Delivery.AuthSecretIdentifier == "" {
+		return "", fmt.Errorf("auth %s requires a secret", webhook.Delivery.Auth)
+	}
+
+	return getSecretValue(ctx, w.spacePathStore, w.secretService,
+		webhook.Delivery.AuthSecretSpaceID, webhook.Delivery.AuthSecretIdentifier)
+}
+
+// clientCertificateFor returns the client certificate of the webhook in case it uses mTLS, nil otherwise.
+func (w *WebhookExecutor) clientCertificateFor(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+) (*tls.Certificate, error) {
+	if webhook.Type == enum.WebhookTypeInternal || webhook.Delivery.Auth != enum.WebhookAuthTypeMTLS {
+		return nil, nil //nolint:nilnil
+	}
+
+	key, err := w.getAuthSecretValue(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private key of client certificate: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair([]byte(webhook.Delivery.ClientCertificate), []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	return &cert, nil
+}
+
+// redactHeaders returns a copy of the headers with the values of sensitive headers replaced.
+// The static headers of the webhook are considered sensitive, as they might contain credentials.
+func redactHeaders(header http.Header, staticHeaders []types.ExtraHeader) http.Header {
+	redacted := header.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", redactedHeaderValue)
+	}
+	for _, h := range staticHeaders {
+		if redacted.Get(h.Key) != "" {
+			redacted.Set(h.Key, redactedHeaderValue)
+		}
+	}
+	return redacted
+}
+
+// keepHeaderValues sets the value of the static headers without value to the value
+// of the existing header with the same key, as the values of the headers are never returned to the users.
+// In case the URL of the webhook changes, the values have to be provided again,
+// as they would otherwise be sent to a URL chosen by a user who might not know them.
+func keepHeaderValues(headers []types.ExtraHeader, existing []types.ExtraHeader, urlChanged bool) error {
+	for i := range headers {
+		if headers[i].Value != "" {
+			continue
+		}
+		for _, h := range existing {
+			if h.Key != headers[i].Key {
+				continue
+			}
+			if urlChanged {
+				return check.NewValidationErrorf(
+					"The value of the webhook header '%s' has to be provided again when the URL changes.", h.Key)
+			}
+			headers[i].Value = h.Value
+			break
+		}
+	}
+
+	return nil
+}
+
+// checkHeaderValuesKept verifies that the webhook has no static headers with values in case its URL changes
+// without providing the headers again, as their values would otherwise be sent to the new URL.
+func checkHeaderValuesKept(existing []types.ExtraHeader, urlChanged bool) error {
+	if !urlChanged {
+		return nil
+	}
+
+	for _, h := range existing {
+		if h.Value != "" {
+			return check.NewValidationError(
+				"The webhook headers have to be provided again with their values when the URL changes.")
+		}
+	}
+
+	return nil
+}
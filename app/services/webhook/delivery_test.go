@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/crypto"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type staticURLProvider string
+
+func (p staticURLProvider) GetWebhookURL(context.Context, *types.WebhookCore) (string, error) {
+	return string(p), nil
+}
+
+func TestPrepareHTTPRequestDelivery(t *testing.T) {
+	const secret = "secret"
+	w := &WebhookExecutor{
+		config: Config{
+			UserAgentIdentity: "Gitness",
+			HeaderIdentity:    "Gitness",
+			InternalSecret:    secret,
+		},
+		webhookURLProvider: staticURLProvider("https://example.com/hook"),
+		source:             RepoTrigger,
+	}
+	body := map[string]string{"key": "value"}
+
+	t.Run("structured", func(t *testing.T) {
+		webhook := &types.WebhookCore{
+			ID:         7,
+			ParentID:   1,
+			ParentType: enum.WebhookParentRepo,
+			Type:       enum.WebhookTypeInternal,
+			Delivery: types.WebhookDelivery{
+				CloudEvents: enum.WebhookCloudEventsModeStructured,
+				Headers:     []types.ExtraHeader{{Key: "X-Custom", Value: "custom"}},
+			},
+		}
+		execution := &types.WebhookExecutionCore{}
+
+		req, err := w.prepareHTTPRequest(context.Background(), execution, "event-1",
+			enum.WebhookTriggerBranchCreated, webhook, body)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := req.Header.Get("Content-Type"); got != cloudEventsStructuredMediaType {
+			t.Errorf("want content type %q, got %q", cloudEventsStructuredMediaType, got)
+		}
+		if got := req.Header.Get("X-Custom"); got != "custom" {
+			t.Errorf("want custom header %q, got %q", "custom", got)
+		}
+		if !strings.Contains(execution.Request.Headers, "X-Custom: "+redactedHeaderValue) {
+			t.Errorf("value of custom header must be redacted in the execution: %s", execution.Request.Headers)
+		}
+
+		event := map[string]any{}
+		if err = json.Unmarshal([]byte(execution.Request.Body), &event); err != nil {
+			t.Fatalf("failed to unmarshal body: %s", err)
+		}
+		if event["specversion"] != "1.0" || event["id"] != "event-1-7" ||
+			event["source"] != "/repo/1" || event["type"] != "gitness.branch_created" {
+			t.Errorf("unexpected event attributes: %v", event)
+		}
+		if data, _ := event["data"].(map[string]any); data["key"] != "value" {
+			t.Errorf("unexpected event data: %v", event["data"])
+		}
+	})
+
+	t.Run("binary-timestamped", func(t *testing.T) {
+		webhook := &types.WebhookCore{
+			ID:         7,
+			ParentID:   1,
+			ParentType: enum.WebhookParentRepo,
+			Type:       enum.WebhookTypeInternal,
+			Delivery: types.WebhookDelivery{
+				CloudEvents: enum.WebhookCloudEventsModeBinary,
+				Signature:   enum.WebhookSignatureTypeHMACSHA256Timestamped,
+			},
+		}
+		execution := &types.WebhookExecutionCore{}
+
+		req, err := w.prepareHTTPRequest(context.Background(), execution, "event-1",
+			enum.WebhookTriggerBranchCreated, webhook, body)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := req.Header.Get("ce-id"); got != "event-1-7" {
+			t.Errorf("want ce-id %q, got %q", "event-1-7", got)
+		}
+		if got := req.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("want content type %q, got %q", "application/json", got)
+		}
+		if strings.Contains(execution.Request.Body, "specversion") {
+			t.Errorf("payload of binary mode mustn't contain the event envelope: %s", execution.Request.Body)
+		}
+
+		timestamp := req.Header.Get("X-Gitness-Signature-Timestamp")
+		if timestamp == "" {
+			t.Fatal("expected signature timestamp header")
+		}
+		want, _ := crypto.GenerateHMACSHA256([]byte(timestamp+"."+execution.Request.Body), []byte(secret))
+		if got := req.Header.Get("X-Gitness-Signature"); got != want {
+			t.Errorf("want signature %q, got %q", want, got)
+		}
+	})
+}
+
+func TestKeepHeaderValues(t *testing.T) {
+	existing := []types.ExtraHeader{{Key: "X-Api-Key", Value: "key"}, {Key: "X-Other", Value: "other"}}
+	headers := []types.ExtraHeader{{Key: "X-Api-Key"}, {Key: "X-Other", Value: "changed"}, {Key: "X-New"}}
+
+	if err := keepHeaderValues(headers, existing, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []types.ExtraHeader{{Key: "X-Api-Key", Value: "key"}, {Key: "X-Other", Value: "changed"}, {Key: "X-New"}}
+	if !reflect.DeepEqual(want, headers) {
+		t.Errorf("want headers %v, got %v", want, headers)
+	}
+
+	// the hidden values aren't sent to a new URL, they have to be provided again.
+	headers = []types.ExtraHeader{{Key: "X-Api-Key"}}
+	if err := keepHeaderValues(headers, existing, true); err == nil {
+		t.Errorf("expected an error for a hidden header value with a changed URL")
+	}
+
+	headers = []types.ExtraHeader{{Key: "X-Api-Key", Value: "new-key"}, {Key: "X-New"}}
+	if err := keepHeaderValues(headers, existing, true); err != nil {
+		t.Errorf("unexpected error for provided header values with a changed URL: %s", err)
+	}
+
+	if err := checkHeaderValuesKept(existing, true); err == nil {
+		t.Errorf("expected an error for a changed URL without the headers")
+	}
+	if err := checkHeaderValuesKept(existing, false); err != nil {
+		t.Errorf("unexpected error for an unchanged URL: %s", err)
+	}
+}
+
+func TestSanitizeDelivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery types.WebhookDelivery
+		wantErr  bool
+	}{
+		{
+			name:     "defaults",
+			delivery: types.WebhookDelivery{},
+		},
+		{
+			name: "custom-header",
+			delivery: types.WebhookDelivery{
+				Headers: []types.ExtraHeader{{Key: "x-api-key", Value: "value"}},
+			},
+		},
+		{
+			name: "reserved-header",
+			delivery: types.WebhookDelivery{
+				Headers: []types.ExtraHeader{{Key: "authorization", Value: "value"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cloudevents-header",
+			delivery: types.WebhookDelivery{
+				Headers: []types.ExtraHeader{{Key: "ce-id", Value: "value"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bearer-without-secret",
+			delivery: types.WebhookDelivery{
+				Auth: enum.WebhookAuthTypeBearer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "mtls-without-certificate",
+			delivery: types.WebhookDelivery{
+				Auth:                 enum.WebhookAuthTypeMTLS,
+				AuthSecretIdentifier: "key",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-cloudevents-mode",
+			delivery: types.WebhookDelivery{
+				CloudEvents: "http",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SanitizeDelivery(&test.delivery)
+			if test.wantErr != (err != nil) {
+				t.Errorf("want error=%t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
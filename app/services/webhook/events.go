@@ -65,7 +65,9 @@ func (s *Service) triggerForEventWithRepo(
 		return fmt.Errorf("failed to get webhook parent info for parents: %w", err)
 	}
 
-	return s.WebhookExecutor.TriggerForEvent(ctx, eventID, parents, triggerType, body)
+	filterInput := s.filterInputForRepo(triggerType, repo, body)
+
+	return s.WebhookExecutor.TriggerForEventWithFilterInput(ctx, eventID, parents, triggerType, body, filterInput)
 }
 
 // triggerForEventWithPullReq triggers all webhooks for the given repo and triggerType
@@ -115,7 +117,9 @@ func (s *Service) triggerForEventWithPullReq(
 		return fmt.Errorf("failed to get webhook parent info: %w", err)
 	}
 
-	return s.WebhookExecutor.TriggerForEvent(ctx, eventID, parents, triggerType, body)
+	filterInput := s.filterInputForPullReq(pr)
+
+	return s.WebhookExecutor.TriggerForEventWithFilterInput(ctx, eventID, parents, triggerType, body, filterInput)
 }
 
 // findRepositoryForEvent finds the repository for the provided repoID.
@@ -174,10 +178,23 @@ func (w *WebhookExecutor) TriggerForEvent(
 	parents []types.WebhookParentInfo,
 	triggerType enum.WebhookTrigger,
 	body any,
+) error {
+	return w.TriggerForEventWithFilterInput(ctx, eventID, parents, triggerType, body, nil)
+}
+
+// TriggerForEventWithFilterInput triggers all webhooks the same way as TriggerForEvent,
+// but skips the webhooks whose filters don't match the provided filter input.
+func (w *WebhookExecutor) TriggerForEventWithFilterInput(
+	ctx context.Context,
+	eventID string,
+	parents []types.WebhookParentInfo,
+	triggerType enum.WebhookTrigger,
+	body any,
+	filterInput *FilterInput,
 ) error {
 	triggerID := generateTriggerIDFromEventID(eventID)
 
-	results, err := w.triggerWebhooksFor(ctx, parents, triggerID, triggerType, body)
+	results, err := w.triggerWebhooksFor(ctx, parents, triggerID, triggerType, body, filterInput)
 
 	// return all errors and force the event to be reprocessed (it's not webhook execution specific!)
 	if err != nil {
@@ -189,7 +206,7 @@ func (w *WebhookExecutor) TriggerForEvent(
 
 	// go through all events and figure out if we need to retry the event.
 	// Combine all errors into a single error to log (to reduce number of logs)
-	retryRequired := false
+	var retryIDs []int64
 	var errs error
 	for _, result := range results {
 		if result.Skipped() {
@@ -204,7 +221,7 @@ func (w *WebhookExecutor) TriggerForEvent(
 		}
 
 		if result.Execution.Result == enum.WebhookExecutionResultRetriableError {
-			retryRequired = true
+			retryIDs = append(retryIDs, result.Execution.ID)
 		}
 	}
 
@@ -213,10 +230,20 @@ func (w *WebhookExecutor) TriggerForEvent(
 		log.Ctx(ctx).Warn().Err(errs).Msgf("webhook execution for %#v had errors", parents)
 	}
 
-	// in case at least one webhook has to be retried, return an error to the event framework to have it reprocessed
-	if retryRequired {
-		return fmt.Errorf("at least one webhook execution resulted in a retry for %#v", parents)
+	if len(retryIDs) == 0 {
+		return nil
+	}
+
+	// redeliver the failed executions in background jobs, which are retried with exponential backoff
+	if w.deadLetter != nil {
+		err = w.deadLetter.Schedule(ctx, retryIDs...)
+		if err == nil {
+			return nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to schedule webhook redelivery for %#v", parents)
 	}
 
-	return nil
+	// in case at least one webhook has to be retried, return an error to the event framework to have it reprocessed
+	return fmt.Errorf("at least one webhook execution resulted in a retry for %#v", parents)
 }
@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	handlerTestPrincipalID = 7
+	handlerTestRepoID      = 3
+	handlerTestSpaceID     = 2
+	handlerTestRootSpaceID = 1
+)
+
+type handlerPrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (handlerPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if id != handlerTestPrincipalID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Principal{ID: id, UID: "user", Type: enum.PrincipalTypeUser}, nil
+}
+
+type handlerRepoStore struct {
+	store.RepoStore
+	repos   map[int64]*types.Repository
+	deleted map[int64]*types.Repository
+}
+
+func (s *handlerRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	repo, ok := s.repos[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+func (s *handlerRepoStore) FindDeleted(_ context.Context, id int64, deleted *int64) (*types.Repository, error) {
+	repo, ok := s.deleted[id]
+	if !ok || repo.Deleted == nil || *repo.Deleted != *deleted {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+type handlerSpaceStore struct {
+	store.SpaceStore
+	spaces map[int64]*types.Space
+}
+
+func (s *handlerSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	space, ok := s.spaces[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return space, nil
+}
+
+func (s *handlerSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	var ids []int64
+	for space, ok := s.spaces[spaceID]; ok; space, ok = s.spaces[space.ParentID] {
+		ids = append(ids, space.ID)
+	}
+	return ids, nil
+}
+
+type handlerURLProvider struct {
+	url.Provider
+}
+
+func (handlerURLProvider) GenerateUIRepoURL(_ context.Context, repoPath string) string {
+	return "http://localhost/" + repoPath
+}
+
+func (handlerURLProvider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
+	return "http://localhost/git/" + repoPath + ".git"
+}
+
+func (handlerURLProvider) GenerateGITCloneSSHURL(_ context.Context, repoPath string) string {
+	return "ssh://localhost/" + repoPath + ".git"
+}
+
+func (handlerURLProvider) GenerateUIBuildURL(
+	_ context.Context,
+	repoPath string,
+	pipelineIdentifier string,
+	seqNumber int64,
+) string {
+	return fmt.Sprintf("http://localhost/%s/pipelines/%s/executions/%d", repoPath, pipelineIdentifier, seqNumber)
+}
+
+// parentsExecutorStore records the parents the webhooks were listed for.
+type parentsExecutorStore struct {
+	*redeliveryExecutorStore
+	parents []types.WebhookParentInfo
+}
+
+func (s *parentsExecutorStore) ListWebhooks(
+	ctx context.Context,
+	parents []types.WebhookParentInfo,
+) ([]*types.WebhookCore, error) {
+	s.parents = parents
+	return s.redeliveryExecutorStore.ListWebhooks(ctx, parents)
+}
+
+type handlerTest struct {
+	s          *Service
+	executions *parentsExecutorStore
+	repos      *handlerRepoStore
+	spaces     *handlerSpaceStore
+}
+
+// setupHandlerTest creates a webhook service with a single webhook registered for all triggers,
+// and a repo "root/space/repo" in the space "root/space".
+func setupHandlerTest(t *testing.T) *handlerTest {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	executorStore := &parentsExecutorStore{
+		redeliveryExecutorStore: &redeliveryExecutorStore{
+			webhooks: []*types.WebhookCore{{ID: 1, Enabled: true, URL: srv.URL}},
+		},
+	}
+
+	test := &handlerTest{
+		executions: executorStore,
+		repos: &handlerRepoStore{
+			repos: map[int64]*types.Repository{
+				handlerTestRepoID: {
+					ID:            handlerTestRepoID,
+					ParentID:      handlerTestSpaceID,
+					Identifier:    "repo",
+					Path:          "root/space/repo",
+					DefaultBranch: "main",
+				},
+			},
+			deleted: map[int64]*types.Repository{},
+		},
+		spaces: &handlerSpaceStore{
+			spaces: map[int64]*types.Space{
+				handlerTestRootSpaceID: {ID: handlerTestRootSpaceID, Identifier: "root", Path: "root"},
+				handlerTestSpaceID: {
+					ID:         handlerTestSpaceID,
+					ParentID:   handlerTestRootSpaceID,
+					Identifier: "space",
+					Path:       "root/space",
+				},
+			},
+		},
+	}
+
+	test.s = &Service{
+		WebhookExecutor: NewWebhookExecutor(Config{AllowLoopback: true}, redeliveryURLProvider{},
+			nil, nil, nil, handlerPrincipalStore{}, executorStore, "gitness"),
+		urlProvider: handlerURLProvider{},
+		repoStore:   test.repos,
+		spaceStore:  test.spaces,
+	}
+
+	return test
+}
+
+// repoParents returns the webhook parents of the test repo, including its parent spaces.
+func repoParents() []types.WebhookParentInfo {
+	return []types.WebhookParentInfo{
+		{ID: handlerTestRepoID, Type: enum.WebhookParentRepo},
+		{ID: handlerTestSpaceID, Type: enum.WebhookParentSpace},
+		{ID: handlerTestRootSpaceID, Type: enum.WebhookParentSpace},
+	}
+}
+
+// assertTriggered verifies that the webhook got executed exactly once for the provided trigger and parents
+// and decodes the body of the request into the provided payload.
+func (test *handlerTest) assertTriggered(
+	t *testing.T,
+	trigger enum.WebhookTrigger,
+	parents []types.WebhookParentInfo,
+	payload any,
+) {
+	t.Helper()
+
+	executions := test.executions.executions
+	if len(executions) != 1 {
+		t.Fatalf("want 1 execution, got %d", len(executions))
+	}
+
+	execution := executions[0]
+	if execution.TriggerType != trigger {
+		t.Errorf("want trigger %s, got %s", trigger, execution.TriggerType)
+	}
+	if execution.Result != enum.WebhookExecutionResultSuccess {
+		t.Errorf("want execution result %s, got %s (%s)",
+			enum.WebhookExecutionResultSuccess, execution.Result, execution.Error)
+	}
+
+	if fmt.Sprint(parents) != fmt.Sprint(test.executions.parents) {
+		t.Errorf("want webhook parents %v, got %v", parents, test.executions.parents)
+	}
+
+	if err := json.Unmarshal([]byte(execution.Request.Body), payload); err != nil {
+		t.Fatalf("failed to decode webhook payload: %s", err)
+	}
+}
+
+// assertDiscarded verifies that the event got discarded without executing any webhook.
+func (test *handlerTest) assertDiscarded(t *testing.T, err error) {
+	t.Helper()
+
+	// all discard event errors match each other
+	if !errors.Is(err, events.NewDiscardEventError(nil)) {
+		t.Errorf("expected the event to be discarded, got: %v", err)
+	}
+	if len(test.executions.executions) != 0 {
+		t.Errorf("want no executions, got %d", len(test.executions.executions))
+	}
+}
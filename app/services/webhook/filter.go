@@ -0,0 +1,243 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	// gitReferenceNamePrefixTag is the prefix of references of type tag.
+	gitReferenceNamePrefixTag = "refs/tags/"
+)
+
+// FilterInput contains the event information the filters of webhooks are evaluated against.
+// Information that doesn't apply to an event is left empty, in which case the respective filters are ignored.
+type FilterInput struct {
+	// Ref is the full name of the branch or tag of the event (e.g. refs/heads/main).
+	Ref string
+	// TargetBranch is the target branch of the pull request of the event.
+	TargetBranch string
+	// ChangedFiles returns the files changed by the event, or nil if they can't be determined,
+	// in which case path filters are ignored. It's only called in case a webhook has path filters.
+	ChangedFiles func(ctx context.Context) ([]string, error)
+	// Labels returns the keys of the labels assigned to the pull request of the event.
+	// It's only called in case a webhook has label filters.
+	Labels func(ctx context.Context) ([]string, error)
+}
+
+// filterEvaluator evaluates the filters of webhooks for a single event.
+// The changed files and labels are loaded at most once and shared by all webhooks.
+type filterEvaluator struct {
+	in *FilterInput
+
+	changedFiles       []string
+	changedFilesLoaded bool
+	labels             []string
+	labelsLoaded       bool
+}
+
+func newFilterEvaluator(in *FilterInput) *filterEvaluator {
+	if in == nil {
+		in = &FilterInput{}
+	}
+	return &filterEvaluator{in: in}
+}
+
+// SkipReason returns the reason why the webhook with the provided filters should be skipped for the event.
+// An empty string is returned in case the event passes all filters.
+func (e *filterEvaluator) SkipReason(ctx context.Context, filters types.WebhookTriggerFilters) (string, error) {
+	if branch, ok := strings.CutPrefix(e.in.Ref, gitReferenceNamePrefixBranch); ok &&
+		!matchesAnyPattern(filters.Branches, branch) {
+		return fmt.Sprintf("branch '%s' doesn't match any branch filter", branch), nil
+	}
+
+	if tag, ok := strings.CutPrefix(e.in.Ref, gitReferenceNamePrefixTag); ok &&
+		!matchesAnyPattern(filters.Tags, tag) {
+		return fmt.Sprintf("tag '%s' doesn't match any tag filter", tag), nil
+	}
+
+	if e.in.TargetBranch != "" && !matchesAnyPattern(filters.TargetBranches, e.in.TargetBranch) {
+		return fmt.Sprintf("target branch '%s' doesn't match any target branch filter", e.in.TargetBranch), nil
+	}
+
+	if len(filters.Paths) > 0 && e.in.ChangedFiles != nil {
+		if !e.changedFilesLoaded {
+			files, err := e.in.ChangedFiles(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to get changed files: %w", err)
+			}
+			e.changedFiles = files
+			e.changedFilesLoaded = true
+		}
+
+		if e.changedFiles != nil && !anyMatchesAnyPattern(filters.Paths, e.changedFiles) {
+			return "none of the changed files match any path filter", nil
+		}
+	}
+
+	if len(filters.Labels) > 0 && e.in.Labels != nil {
+		if !e.labelsLoaded {
+			labels, err := e.in.Labels(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to get labels: %w", err)
+			}
+			e.labels = labels
+			e.labelsLoaded = true
+		}
+
+		if !anyLabelMatches(filters.Labels, e.labels) {
+			return "none of the assigned labels match any label filter", nil
+		}
+	}
+
+	return "", nil
+}
+
+// matchesAnyPattern returns true if the value matches any of the glob patterns, or if there are no patterns.
+func matchesAnyPattern(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anyMatchesAnyPattern returns true if any of the values matches any of the glob patterns.
+func anyMatchesAnyPattern(patterns []string, values []string) bool {
+	for _, value := range values {
+		if matchesAnyPattern(patterns, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anyLabelMatches returns true if any of the label keys is in the filter (case-insensitive).
+func anyLabelMatches(filter []string, keys []string) bool {
+	for _, key := range keys {
+		for _, f := range filter {
+			if strings.EqualFold(f, key) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// filterInputForRepo returns the filter input for repo related events based on the payload of the event.
+func (s *Service) filterInputForRepo(
+	triggerType enum.WebhookTrigger,
+	repo *types.Repository,
+	body any,
+) *FilterInput {
+	switch payload := body.(type) {
+	case *ReferencePayload:
+		in := &FilterInput{Ref: payload.Ref.Name}
+
+		switch {
+		case triggerType == enum.WebhookTriggerBranchUpdated:
+			in.ChangedFiles = func(ctx context.Context) ([]string, error) {
+				return s.changedFiles(ctx, repo, payload.OldSHA, payload.SHA, false)
+			}
+		case triggerType == enum.WebhookTriggerBranchCreated &&
+			payload.Ref.Name != gitReferenceNamePrefixBranch+repo.DefaultBranch:
+			// changes of new branches are the changes since the branch diverged from the default branch
+			in.ChangedFiles = func(ctx context.Context) ([]string, error) {
+				_, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+					ReadParams: git.CreateReadParams(repo),
+					BranchName: repo.DefaultBranch,
+				})
+				if errors.IsNotFound(err) {
+					return nil, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to get default branch: %w", err)
+				}
+
+				return s.changedFiles(ctx, repo, repo.DefaultBranch, payload.SHA, true)
+			}
+		}
+
+		return in
+
+	case *ExecutionPayload:
+		return &FilterInput{Ref: payload.Execution.Ref}
+
+	default:
+		return nil
+	}
+}
+
+// changedFiles returns the files changed between the two git references.
+func (s *Service) changedFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	baseRef string,
+	headRef string,
+	mergeBase bool,
+) ([]string, error) {
+	out, err := s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    baseRef,
+		HeadRef:    headRef,
+		MergeBase:  mergeBase,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Files == nil {
+		return []string{}, nil
+	}
+
+	return out.Files, nil
+}
+
+// filterInputForPullReq returns the filter input for pull request related events.
+func (s *Service) filterInputForPullReq(pr *types.PullReq) *FilterInput {
+	return &FilterInput{
+		TargetBranch: pr.TargetBranch,
+		Labels: func(ctx context.Context) ([]string, error) {
+			assignments, err := s.labelAssignmentStore.ListAssigned(ctx, pr.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			keys := make([]string, 0, len(assignments))
+			for _, assignment := range assignments {
+				keys = append(keys, assignment.Key)
+			}
+			return keys, nil
+		},
+	}
+}
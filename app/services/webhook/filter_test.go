@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestFilterEvaluatorSkipReason(t *testing.T) {
+	changedFiles := func(context.Context) ([]string, error) {
+		return []string{"docs/readme.md", "app/main.go"}, nil
+	}
+	labels := func(context.Context) ([]string, error) {
+		return []string{"Deploy"}, nil
+	}
+
+	tests := []struct {
+		name     string
+		in       *FilterInput
+		filters  types.WebhookTriggerFilters
+		wantSkip bool
+	}{
+		{
+			name:     "no-filters",
+			in:       &FilterInput{Ref: "refs/heads/feature/x"},
+			wantSkip: false,
+		},
+		{
+			name:     "no-input",
+			in:       nil,
+			filters:  types.WebhookTriggerFilters{Branches: []string{"main"}, Labels: []string{"deploy"}},
+			wantSkip: false,
+		},
+		{
+			name: "changed-files-unknown",
+			in: &FilterInput{
+				Ref:          "refs/heads/feature/x",
+				ChangedFiles: func(context.Context) ([]string, error) { return nil, nil },
+			},
+			filters:  types.WebhookTriggerFilters{Paths: []string{"docs/**"}},
+			wantSkip: false,
+		},
+		{
+			name:     "branch-matches",
+			in:       &FilterInput{Ref: "refs/heads/release/1.0"},
+			filters:  types.WebhookTriggerFilters{Branches: []string{"main", "release/*"}},
+			wantSkip: false,
+		},
+		{
+			name:     "branch-doesnt-match",
+			in:       &FilterInput{Ref: "refs/heads/feature/x"},
+			filters:  types.WebhookTriggerFilters{Branches: []string{"main", "release/*"}},
+			wantSkip: true,
+		},
+		{
+			name:     "branch-filter-ignored-for-tags",
+			in:       &FilterInput{Ref: "refs/tags/v1.0.0"},
+			filters:  types.WebhookTriggerFilters{Branches: []string{"main"}, Tags: []string{"v*"}},
+			wantSkip: false,
+		},
+		{
+			name:     "tag-doesnt-match",
+			in:       &FilterInput{Ref: "refs/tags/nightly"},
+			filters:  types.WebhookTriggerFilters{Tags: []string{"v*"}},
+			wantSkip: true,
+		},
+		{
+			name:     "path-matches",
+			in:       &FilterInput{Ref: "refs/heads/main", ChangedFiles: changedFiles},
+			filters:  types.WebhookTriggerFilters{Paths: []string{"app/**"}},
+			wantSkip: false,
+		},
+		{
+			name:     "path-doesnt-match",
+			in:       &FilterInput{Ref: "refs/heads/main", ChangedFiles: changedFiles},
+			filters:  types.WebhookTriggerFilters{Paths: []string{"deploy/**", "*.yaml"}},
+			wantSkip: true,
+		},
+		{
+			name:     "target-branch-doesnt-match",
+			in:       &FilterInput{TargetBranch: "develop", Labels: labels},
+			filters:  types.WebhookTriggerFilters{TargetBranches: []string{"main"}},
+			wantSkip: true,
+		},
+		{
+			name:     "label-matches",
+			in:       &FilterInput{TargetBranch: "main", Labels: labels},
+			filters:  types.WebhookTriggerFilters{TargetBranches: []string{"main"}, Labels: []string{"deploy"}},
+			wantSkip: false,
+		},
+		{
+			name:     "label-doesnt-match",
+			in:       &FilterInput{TargetBranch: "main", Labels: labels},
+			filters:  types.WebhookTriggerFilters{Labels: []string{"bug"}},
+			wantSkip: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason, err := newFilterEvaluator(test.in).SkipReason(context.Background(), test.filters)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if skip := reason != ""; skip != test.wantSkip {
+				t.Errorf("want skip=%t, got skip=%t (reason: %q)", test.wantSkip, skip, reason)
+			}
+		})
+	}
+}
+
+func TestTriggerForEvent_FilterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer srv.Close()
+
+	executorStore := &redeliveryExecutorStore{
+		webhooks: []*types.WebhookCore{
+			{ID: 1, Enabled: true, URL: srv.URL, Filters: types.WebhookTriggerFilters{Paths: []string{"docs/**"}}},
+			{ID: 2, Enabled: true, URL: srv.URL},
+		},
+	}
+	executor := NewWebhookExecutor(Config{AllowLoopback: true}, redeliveryURLProvider{},
+		nil, nil, nil, nil, executorStore, "gitness")
+
+	in := &FilterInput{
+		Ref: "refs/heads/main",
+		ChangedFiles: func(context.Context) ([]string, error) {
+			return nil, errors.New("git is unavailable")
+		},
+	}
+
+	err := executor.TriggerForEventWithFilterInput(context.Background(), "event", nil,
+		enum.WebhookTriggerBranchUpdated, struct{}{}, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	results := map[int64]enum.WebhookExecutionResult{}
+	for _, execution := range executorStore.executions {
+		results[execution.WebhookID] = execution.Result
+	}
+
+	// the webhook with the failing filter is marked as failed, the other one is executed regardless.
+	if got := results[1]; got != enum.WebhookExecutionResultFatalError {
+		t.Errorf("want execution result %s for webhook 1, got %s", enum.WebhookExecutionResultFatalError, got)
+	}
+	if got := results[2]; got != enum.WebhookExecutionResultSuccess {
+		t.Errorf("want execution result %s for webhook 2, got %s", enum.WebhookExecutionResultSuccess, got)
+	}
+}
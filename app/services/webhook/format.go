@@ -0,0 +1,370 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// chatTitleMaxLength and chatTextMaxLength keep messages within the limits of all supported chat services.
+	chatTitleMaxLength = 256
+	chatTextMaxLength  = 4000
+
+	// chatTemplateMaxOutputSize is the number of bytes after which the execution of a chat template is aborted.
+	// It's well above the max length of the text, so the text is only truncated after rendering.
+	chatTemplateMaxOutputSize = 64 << 10 // 64 KiB
+
+	chatColorDefault = 0x0278D5
+	chatColorSuccess = 0x2EA44F
+	chatColorFailure = 0xD73A49
+)
+
+// defaultChatTemplate is used to render the text of chat messages if the webhook doesn't define its own template.
+// Templates are executed on the JSON representation of the generic webhook payload,
+// so the same field names as in the JSON payload are available (e.g. {{.pull_req.title}}).
+const defaultChatTemplate = `{{with .principal}}{{.display_name}}{{end}}
+{{- with .pull_req}} · #{{.number}} {{.title}} ({{.source_branch}} → {{.target_branch}}){{end}}
+{{- with .comment}}: {{.text}}{{end}}
+{{- with .review_decision}} · {{.}}{{end}}
+{{- with .execution}} · {{.pipeline_identifier}} #{{.number}} {{.status}}{{end}}
+{{- if not .pull_req}}{{with .ref}} · {{.name}}{{end}}{{end}}
+{{- with .rule}} · {{.identifier}}{{end}}
+{{- with .old_identifier}} · renamed from {{.}}{{end}}
+{{- with .new_default_branch}} · default branch changed to {{.}}{{end}}`
+
+var defaultChatTmpl = template.Must(template.New("chat").Parse(defaultChatTemplate))
+
+// chatMessage is the chat service independent content of a webhook message.
+type chatMessage struct {
+	Title string
+	Text  string
+	URL   string
+	Color int
+}
+
+// renderChatBody converts the generic webhook payload into the native message payload of the chat service.
+func renderChatBody(
+	format enum.WebhookFormat,
+	tmpl string,
+	triggerType enum.WebhookTrigger,
+	body any,
+) (any, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize payload: %w", err)
+	}
+
+	data := map[string]any{}
+	if err = json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to deserialize payload: %w", err)
+	}
+
+	t := defaultChatTmpl
+	if tmpl != "" {
+		t, err = template.New("chat").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("template is invalid: %w", err)
+		}
+	}
+
+	// templates producing too much output are aborted, the text rendered so far gets truncated.
+	text := &bytes.Buffer{}
+	err = t.Execute(&limitedWriter{w: text, n: chatTemplateMaxOutputSize}, data)
+	if err != nil && !errors.Is(err, errOutputLimitExceeded) {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	msg := chatMessage{
+		Title: truncate(chatTitle(triggerType, data), chatTitleMaxLength),
+		Text:  truncate(strings.TrimSpace(text.String()), chatTextMaxLength),
+		URL: firstNonEmpty(
+			lookup(data, "pull_req", "pr_url"),
+			lookup(data, "execution", "url"),
+			lookup(data, "repo", "url"),
+		),
+		Color: chatColor(triggerType),
+	}
+
+	switch format {
+	case enum.WebhookFormatSlack:
+		return slackMessageFrom(msg), nil
+	case enum.WebhookFormatTeams:
+		return teamsMessageFrom(msg), nil
+	case enum.WebhookFormatDiscord:
+		return discordMessageFrom(msg), nil
+	case enum.WebhookFormatMattermost:
+		return mattermostMessageFrom(msg), nil
+	case enum.WebhookFormatJSON:
+		return body, nil
+	default:
+		return nil, fmt.Errorf("webhook format %q is not supported", format)
+	}
+}
+
+// chatTitle returns a human readable title, e.g. "[space/repo] Pullreq comment created".
+func chatTitle(triggerType enum.WebhookTrigger, data map[string]any) string {
+	title := strings.ReplaceAll(string(triggerType), "_", " ")
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+
+	scope := firstNonEmpty(lookup(data, "repo", "path"), lookup(data, "rule", "space_path"))
+	if scope == "" {
+		return title
+	}
+
+	return fmt.Sprintf("[%s] %s", scope, title)
+}
+
+func chatColor(triggerType enum.WebhookTrigger) int {
+	//nolint:exhaustive
+	switch triggerType {
+	case enum.WebhookTriggerExecutionSucceeded, enum.WebhookTriggerPullReqMerged:
+		return chatColorSuccess
+	case enum.WebhookTriggerExecutionFailed,
+		enum.WebhookTriggerBranchDeleted,
+		enum.WebhookTriggerTagDeleted,
+		enum.WebhookTriggerRepoDeleted,
+		enum.WebhookTriggerRuleDeleted:
+		return chatColorFailure
+	default:
+		return chatColorDefault
+	}
+}
+
+// lookup returns the string value of the nested key in the payload, or an empty string if it doesn't exist.
+func lookup(data map[string]any, keys ...string) string {
+	var v any = data
+	for _, key := range keys {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return ""
+		}
+		v = m[key]
+	}
+
+	s, _ := v.(string)
+	return s
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// errOutputLimitExceeded is returned by limitedWriter once the limit is exceeded.
+var errOutputLimitExceeded = errors.New("output limit exceeded")
+
+// limitedWriter writes at most n bytes to the underlying writer and fails once the limit is exceeded.
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > l.n {
+		written, err := l.w.Write(p[:l.n])
+		l.n -= written
+		if err != nil {
+			return written, err
+		}
+		return written, errOutputLimitExceeded
+	}
+
+	written, err := l.w.Write(p)
+	l.n -= written
+	return written, err
+}
+
+func truncate(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	return string([]rune(s)[:maxLength-1]) + "…"
+}
+
+// slackMessage is a Slack message using Block Kit.
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackMessageFrom(msg chatMessage) *slackMessage {
+	title := "*" + slackEscaper.Replace(msg.Title) + "*"
+	if msg.URL != "" {
+		title = fmt.Sprintf("*<%s|%s>*", msg.URL, slackEscaper.Replace(msg.Title))
+	}
+
+	blocks := []slackBlock{{
+		Type: "section",
+		Text: &slackText{Type: "mrkdwn", Text: title},
+	}}
+	if msg.Text != "" {
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: slackEscaper.Replace(msg.Text)},
+		})
+	}
+
+	return &slackMessage{
+		// text is used as fallback for notifications
+		Text:   msg.Title,
+		Blocks: blocks,
+	}
+}
+
+// teamsMessage is a Microsoft Teams message containing an Adaptive Card.
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []teamsTextBlock  `json:"body"`
+	Actions []teamsOpenAction `json:"actions,omitempty"`
+}
+
+type teamsTextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+type teamsOpenAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func teamsMessageFrom(msg chatMessage) *teamsMessage {
+	card := teamsCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []teamsTextBlock{{
+			Type:   "TextBlock",
+			Text:   msg.Title,
+			Weight: "Bolder",
+			Size:   "Medium",
+			Wrap:   true,
+		}},
+	}
+	if msg.Text != "" {
+		card.Body = append(card.Body, teamsTextBlock{
+			Type: "TextBlock",
+			Text: msg.Text,
+			Wrap: true,
+		})
+	}
+	if msg.URL != "" {
+		card.Actions = []teamsOpenAction{{
+			Type:  "Action.OpenUrl",
+			Title: "View",
+			URL:   msg.URL,
+		}}
+	}
+
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+// discordMessage is a Discord message containing an embed.
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Color       int    `json:"color"`
+}
+
+func discordMessageFrom(msg chatMessage) *discordMessage {
+	return &discordMessage{
+		Embeds: []discordEmbed{{
+			Title:       msg.Title,
+			Description: msg.Text,
+			URL:         msg.URL,
+			Color:       msg.Color,
+		}},
+	}
+}
+
+// mattermostMessage is a Mattermost message containing an attachment.
+type mattermostMessage struct {
+	Attachments []mattermostAttachment `json:"attachments"`
+}
+
+type mattermostAttachment struct {
+	Fallback  string `json:"fallback"`
+	Color     string `json:"color"`
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+func mattermostMessageFrom(msg chatMessage) *mattermostMessage {
+	return &mattermostMessage{
+		Attachments: []mattermostAttachment{{
+			Fallback:  msg.Title,
+			Color:     fmt.Sprintf("#%06X", msg.Color),
+			Title:     msg.Title,
+			TitleLink: msg.URL,
+			Text:      msg.Text,
+		}},
+	}
+}
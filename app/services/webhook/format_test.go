@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRenderChatBodyOutputLimit(t *testing.T) {
+	// without the output limit the template would render 10^10 bytes.
+	tmpl := "{{range 100000}}{{range 100000}}x{{end}}{{end}}"
+
+	got, err := renderChatBody(enum.WebhookFormatDiscord, tmpl, enum.WebhookTriggerBranchCreated, &ReferencePayload{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	msg, ok := got.(*discordMessage)
+	if !ok || len(msg.Embeds) != 1 {
+		t.Fatalf("unexpected message type %T", got)
+	}
+	if n := utf8.RuneCountInString(msg.Embeds[0].Description); n != chatTextMaxLength {
+		t.Errorf("want text truncated to %d characters, got %d", chatTextMaxLength, n)
+	}
+}
+
+func TestRenderChatBody(t *testing.T) {
+	body := &PullReqCreatedPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerPullReqCreated,
+			Repo:      RepositoryInfo{Path: "space/repo", URL: "https://example.com/space/repo"},
+			Principal: PrincipalInfo{DisplayName: "Jane"},
+		},
+		PullReqSegment: PullReqSegment{PullReq: PullReqInfo{
+			Number:       7,
+			Title:        "Fix <bug>",
+			SourceBranch: "fix",
+			TargetBranch: "main",
+			PrURL:        "https://example.com/space/repo/pulls/7",
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		format   enum.WebhookFormat
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:   "slack",
+			format: enum.WebhookFormatSlack,
+			want: `{"text":"[space/repo] Pullreq created","blocks":[` +
+				`{"type":"section","text":{"type":"mrkdwn",` +
+				`"text":"*<https://example.com/space/repo/pulls/7|[space/repo] Pullreq created>*"}},` +
+				`{"type":"section","text":{"type":"mrkdwn","text":"Jane · #7 Fix &lt;bug&gt; (fix → main)"}}]}`,
+		},
+		{
+			name:     "discord-custom-template",
+			format:   enum.WebhookFormatDiscord,
+			template: "{{.pull_req.title}} by {{.principal.display_name}}",
+			want: `{"embeds":[{"title":"[space/repo] Pullreq created","description":"Fix <bug> by Jane",` +
+				`"url":"https://example.com/space/repo/pulls/7","color":162005}]}`,
+		},
+		{
+			name:   "mattermost",
+			format: enum.WebhookFormatMattermost,
+			want: `{"attachments":[{"fallback":"[space/repo] Pullreq created","color":"#0278D5",` +
+				`"title":"[space/repo] Pullreq created","title_link":"https://example.com/space/repo/pulls/7",` +
+				`"text":"Jane · #7 Fix <bug> (fix → main)"}]}`,
+		},
+		{
+			name:    "unknown-format",
+			format:  "irc",
+			wantErr: true,
+		},
+		{
+			name:     "invalid-template",
+			format:   enum.WebhookFormatSlack,
+			template: "{{.pull_req.title",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderChatBody(test.format, test.template, enum.WebhookTriggerPullReqCreated, body)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			raw := &bytes.Buffer{}
+			enc := json.NewEncoder(raw)
+			enc.SetEscapeHTML(false)
+			if err = enc.Encode(got); err != nil {
+				t.Fatalf("failed to marshal: %s", err)
+			}
+
+			if s := strings.TrimSpace(raw.String()); s != test.want {
+				t.Errorf("want=%s\ngot=%s", test.want, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	handlerTestPipelineID   = 5
+	handlerTestExecutionNum = 9
+)
+
+type handlerPipelineStore struct {
+	store.PipelineStore
+}
+
+func (handlerPipelineStore) Find(_ context.Context, id int64) (*types.Pipeline, error) {
+	if id != handlerTestPipelineID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Pipeline{ID: id, RepoID: handlerTestRepoID, Identifier: "build"}, nil
+}
+
+type handlerExecutionStore struct {
+	store.ExecutionStore
+	status enum.CIStatus
+}
+
+func (s handlerExecutionStore) FindByNumber(_ context.Context, pipelineID int64, num int64) (*types.Execution, error) {
+	if pipelineID != handlerTestPipelineID || num != handlerTestExecutionNum {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Execution{
+		PipelineID: pipelineID,
+		RepoID:     handlerTestRepoID,
+		CreatedBy:  handlerTestPrincipalID,
+		Number:     num,
+		Status:     s.status,
+		Ref:        "refs/heads/main",
+		After:      "abc",
+	}, nil
+}
+
+func TestService_HandleEventExecution(t *testing.T) {
+	newEvent := func(status enum.CIStatus) func(s *Service) error {
+		return func(s *Service) error {
+			return s.handleEventExecutionExecuted(context.Background(),
+				&events.Event[*pipelineevents.ExecutedPayload]{
+					ID: "event",
+					Payload: &pipelineevents.ExecutedPayload{
+						PipelineID:   handlerTestPipelineID,
+						RepoID:       handlerTestRepoID,
+						ExecutionNum: handlerTestExecutionNum,
+						Status:       status,
+					},
+				})
+		}
+	}
+
+	tests := []struct {
+		name    string
+		status  enum.CIStatus
+		handle  func(s *Service) error
+		trigger enum.WebhookTrigger
+	}{
+		{
+			name:   "started",
+			status: enum.CIStatusRunning,
+			handle: func(s *Service) error {
+				return s.handleEventExecutionStarted(context.Background(),
+					&events.Event[*pipelineevents.StartedPayload]{
+						ID: "event",
+						Payload: &pipelineevents.StartedPayload{
+							PipelineID:   handlerTestPipelineID,
+							RepoID:       handlerTestRepoID,
+							ExecutionNum: handlerTestExecutionNum,
+						},
+					})
+			},
+			trigger: enum.WebhookTriggerExecutionStarted,
+		},
+		{
+			name:   "canceled",
+			status: enum.CIStatusKilled,
+			handle: func(s *Service) error {
+				return s.handleEventExecutionCanceled(context.Background(),
+					&events.Event[*pipelineevents.CanceledPayload]{
+						ID: "event",
+						Payload: &pipelineevents.CanceledPayload{
+							PipelineID:   handlerTestPipelineID,
+							RepoID:       handlerTestRepoID,
+							ExecutionNum: handlerTestExecutionNum,
+						},
+					})
+			},
+			trigger: enum.WebhookTriggerExecutionCanceled,
+		},
+		{
+			name:    "succeeded",
+			status:  enum.CIStatusSuccess,
+			handle:  newEvent(enum.CIStatusSuccess),
+			trigger: enum.WebhookTriggerExecutionSucceeded,
+		},
+		{
+			name:    "failed",
+			status:  enum.CIStatusFailure,
+			handle:  newEvent(enum.CIStatusFailure),
+			trigger: enum.WebhookTriggerExecutionFailed,
+		},
+		{
+			name:    "errored",
+			status:  enum.CIStatusError,
+			handle:  newEvent(enum.CIStatusError),
+			trigger: enum.WebhookTriggerExecutionFailed,
+		},
+		{
+			// killed executions are covered by the canceled event
+			name:   "killed",
+			status: enum.CIStatusKilled,
+			handle: newEvent(enum.CIStatusKilled),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := setupHandlerTest(t)
+			h.s.pipelineStore = handlerPipelineStore{}
+			h.s.executionStore = handlerExecutionStore{status: test.status}
+
+			if err := test.handle(h.s); err != nil {
+				t.Fatalf("failed to handle event: %s", err)
+			}
+
+			if test.trigger == "" {
+				if len(h.executions.executions) != 0 {
+					t.Fatalf("want no executions, got %d", len(h.executions.executions))
+				}
+				return
+			}
+
+			payload := &ExecutionPayload{}
+			h.assertTriggered(t, test.trigger, repoParents(), payload)
+
+			if payload.Trigger != test.trigger {
+				t.Errorf("want payload trigger %s, got %s", test.trigger, payload.Trigger)
+			}
+			if payload.Repo.ID != handlerTestRepoID || payload.Principal.ID != handlerTestPrincipalID {
+				t.Errorf("unexpected repo %d or principal %d", payload.Repo.ID, payload.Principal.ID)
+			}
+
+			execution := payload.Execution
+			if execution.Number != handlerTestExecutionNum || execution.PipelineIdentifier != "build" ||
+				execution.Status != test.status || execution.SHA != "abc" {
+				t.Errorf("unexpected execution info: %+v", execution)
+			}
+			if want := "http://localhost/root/space/repo/pipelines/build/executions/9"; execution.URL != want {
+				t.Errorf("want execution url %s, got %s", want, execution.URL)
+			}
+		})
+	}
+}
+
+func TestService_HandleEventExecutionDeletedPipeline(t *testing.T) {
+	h := setupHandlerTest(t)
+	h.s.pipelineStore = handlerPipelineStore{}
+	h.s.executionStore = handlerExecutionStore{}
+
+	err := h.s.handleEventExecutionStarted(context.Background(), &events.Event[*pipelineevents.StartedPayload]{
+		ID: "event",
+		Payload: &pipelineevents.StartedPayload{
+			PipelineID:   handlerTestPipelineID + 1,
+			RepoID:       handlerTestRepoID,
+			ExecutionNum: handlerTestExecutionNum,
+		},
+	})
+
+	h.assertDiscarded(t, err)
+}
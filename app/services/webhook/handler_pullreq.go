@@ -674,6 +674,28 @@ func (s *Service) handleEventPullReqReviewSubmitted(
 				return nil, fmt.Errorf("failed to get reviewer by id for reviewer id %d: %w", event.Payload.ReviewerID, err)
 			}
 
+			comments := make([]PullReqCommentSegment, 0, len(event.Payload.CommentIDs))
+			for _, commentID := range event.Payload.CommentIDs {
+				activity, err := s.activityStore.Find(ctx, commentID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w", commentID, err)
+				}
+				if activity.Deleted != nil {
+					continue
+				}
+				comments = append(comments, PullReqCommentSegment{
+					CommentInfo: CommentInfo{
+						Text:     activity.Text,
+						ID:       activity.ID,
+						ParentID: activity.ParentID,
+						Kind:     activity.Kind,
+						Created:  activity.Created,
+						Updated:  activity.Updated,
+					},
+					CodeCommentInfo: extractCodeCommentInfoIfAvailable(activity),
+				})
+			}
+
 			return &PullReqReviewSubmittedPayload{
 				BaseSegment: BaseSegment{
 					Trigger:   enum.WebhookTriggerPullReqReviewSubmitted,
@@ -698,6 +720,7 @@ func (s *Service) handleEventPullReqReviewSubmitted(
 				PullReqReviewSegment: PullReqReviewSegment{
 					ReviewDecision: event.Payload.Decision,
 					ReviewerInfo:   principalInfoFrom(reviewer.ToPrincipalInfo()),
+					Comments:       comments,
 				},
 			}, nil
 		})
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoPayload describes the body of the repo created and repo deleted webhook triggers.
+type RepoPayload struct {
+	BaseSegment
+}
+
+// RepoRenamedPayload describes the body of the repo renamed webhook trigger.
+type RepoRenamedPayload struct {
+	BaseSegment
+	RepoRenamedSegment
+}
+
+// RepoDefaultBranchChangedPayload describes the body of the repo default branch changed webhook trigger.
+type RepoDefaultBranchChangedPayload struct {
+	BaseSegment
+	RepoDefaultBranchChangedSegment
+}
+
+// handleEventRepoCreated handles repo created events
+// and triggers repo created webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoCreated(ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoCreated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoRenamed handles repo renamed events
+// and triggers repo renamed webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoRenamed(ctx context.Context,
+	event *events.Event[*repoevents.RenamedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoRenamed,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoRenamedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoRenamed,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepoRenamedSegment: RepoRenamedSegment{
+					OldIdentifier: event.Payload.OldIdentifier,
+					OldPath:       event.Payload.OldPath,
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoDefaultBranchUpdated handles repo default branch updated events
+// and triggers repo default branch changed webhooks for the repo and its parent spaces.
+func (s *Service) handleEventRepoDefaultBranchUpdated(ctx context.Context,
+	event *events.Event[*repoevents.DefaultBranchUpdatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoDefaultBranchChanged,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepoDefaultBranchChangedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoDefaultBranchChanged,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepoDefaultBranchChangedSegment: RepoDefaultBranchChangedSegment{
+					OldDefaultBranch: event.Payload.OldName,
+					NewDefaultBranch: event.Payload.NewName,
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoSoftDeleted handles repo soft deleted events
+// and triggers repo deleted webhooks for the repo and its parent spaces.
+// NOTE: The repo is already deleted at this point, so it's fetched by its deleted timestamp.
+func (s *Service) handleEventRepoSoftDeleted(ctx context.Context,
+	event *events.Event[*repoevents.SoftDeletedPayload]) error {
+	principal, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := s.repoStore.FindDeleted(ctx, event.Payload.RepoID, &event.Payload.Deleted)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the repo got purged or restored in the meantime
+		return events.NewDiscardEventErrorf("deleted repo with id '%d' doesn't exist anymore", event.Payload.RepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deleted repo for id '%d': %w", event.Payload.RepoID, err)
+	}
+
+	parents := []types.WebhookParentInfo{{
+		ID:   repo.ID,
+		Type: enum.WebhookParentRepo,
+	}}
+
+	ids, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get parent space ids: %w", err)
+	}
+
+	for _, id := range ids {
+		parents = append(parents, types.WebhookParentInfo{
+			Type: enum.WebhookParentSpace,
+			ID:   id,
+		})
+	}
+
+	body := &RepoPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerRepoDeleted,
+			Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+	}
+
+	return s.WebhookExecutor.TriggerForEvent(ctx, event.ID, parents, enum.WebhookTriggerRepoDeleted, body)
+}
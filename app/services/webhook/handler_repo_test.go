@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+func repoEventBase() repoevents.Base {
+	return repoevents.Base{RepoID: handlerTestRepoID, PrincipalID: handlerTestPrincipalID}
+}
+
+func TestService_HandleEventRepoCreated(t *testing.T) {
+	h := setupHandlerTest(t)
+
+	err := h.s.handleEventRepoCreated(context.Background(), &events.Event[*repoevents.CreatedPayload]{
+		ID:      "event",
+		Payload: &repoevents.CreatedPayload{Base: repoEventBase()},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	payload := &RepoPayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRepoCreated, repoParents(), payload)
+
+	if payload.Trigger != enum.WebhookTriggerRepoCreated {
+		t.Errorf("want payload trigger %s, got %s", enum.WebhookTriggerRepoCreated, payload.Trigger)
+	}
+	if payload.Repo.Path != "root/space/repo" || payload.Repo.URL != "http://localhost/root/space/repo" {
+		t.Errorf("unexpected repo info: %+v", payload.Repo)
+	}
+	if payload.Principal.ID != handlerTestPrincipalID {
+		t.Errorf("want principal %d, got %d", handlerTestPrincipalID, payload.Principal.ID)
+	}
+}
+
+func TestService_HandleEventRepoRenamed(t *testing.T) {
+	h := setupHandlerTest(t)
+
+	err := h.s.handleEventRepoRenamed(context.Background(), &events.Event[*repoevents.RenamedPayload]{
+		ID: "event",
+		Payload: &repoevents.RenamedPayload{
+			Base:          repoEventBase(),
+			OldIdentifier: "old-repo",
+			NewIdentifier: "repo",
+			OldPath:       "root/space/old-repo",
+			NewPath:       "root/space/repo",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	payload := &RepoRenamedPayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRepoRenamed, repoParents(), payload)
+
+	if payload.OldIdentifier != "old-repo" || payload.OldPath != "root/space/old-repo" {
+		t.Errorf("unexpected old identifier %q or path %q", payload.OldIdentifier, payload.OldPath)
+	}
+	if payload.Repo.Identifier != "repo" || payload.Repo.Path != "root/space/repo" {
+		t.Errorf("unexpected repo info: %+v", payload.Repo)
+	}
+}
+
+func TestService_HandleEventRepoDefaultBranchUpdated(t *testing.T) {
+	h := setupHandlerTest(t)
+
+	err := h.s.handleEventRepoDefaultBranchUpdated(context.Background(),
+		&events.Event[*repoevents.DefaultBranchUpdatedPayload]{
+			ID: "event",
+			Payload: &repoevents.DefaultBranchUpdatedPayload{
+				Base:    repoEventBase(),
+				OldName: "master",
+				NewName: "main",
+			},
+		})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	payload := &RepoDefaultBranchChangedPayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRepoDefaultBranchChanged, repoParents(), payload)
+
+	if payload.OldDefaultBranch != "master" || payload.NewDefaultBranch != "main" {
+		t.Errorf("want default branch change master->main, got %s->%s",
+			payload.OldDefaultBranch, payload.NewDefaultBranch)
+	}
+}
+
+func TestService_HandleEventRepoSoftDeleted(t *testing.T) {
+	h := setupHandlerTest(t)
+
+	// the repo is only found as a deleted repo.
+	deleted := int64(1000)
+	repo := *h.repos.repos[handlerTestRepoID]
+	repo.Deleted = &deleted
+	h.repos.deleted[handlerTestRepoID] = &repo
+	delete(h.repos.repos, handlerTestRepoID)
+
+	err := h.s.handleEventRepoSoftDeleted(context.Background(), &events.Event[*repoevents.SoftDeletedPayload]{
+		ID: "event",
+		Payload: &repoevents.SoftDeletedPayload{
+			Base:     repoEventBase(),
+			RepoPath: "root/space/repo",
+			Deleted:  deleted,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	payload := &RepoPayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRepoDeleted, repoParents(), payload)
+
+	if payload.Repo.ID != handlerTestRepoID || payload.Repo.Path != "root/space/repo" {
+		t.Errorf("unexpected repo info: %+v", payload.Repo)
+	}
+}
+
+func TestService_HandleEventRepoSoftDeletedRestored(t *testing.T) {
+	h := setupHandlerTest(t)
+
+	// the repo got restored before the event got processed.
+	err := h.s.handleEventRepoSoftDeleted(context.Background(), &events.Event[*repoevents.SoftDeletedPayload]{
+		ID: "event",
+		Payload: &repoevents.SoftDeletedPayload{
+			Base:    repoEventBase(),
+			Deleted: 1000,
+		},
+	})
+
+	h.assertDiscarded(t, err)
+}
+
+func TestService_HandleEventRepoCreatedUnknownPrincipal(t *testing.T) {
+	h := setupHandlerTest(t)
+
+	err := h.s.handleEventRepoCreated(context.Background(), &events.Event[*repoevents.CreatedPayload]{
+		ID: "event",
+		Payload: &repoevents.CreatedPayload{
+			Base: repoevents.Base{RepoID: handlerTestRepoID, PrincipalID: handlerTestPrincipalID + 1},
+		},
+	})
+
+	h.assertDiscarded(t, err)
+
+	// the webhooks aren't even listed if the principal doesn't exist.
+	if h.executions.parents != nil {
+		t.Errorf("want no webhook lookup, got parents %v", h.executions.parents)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RulePayload describes the body of protection rule related webhook triggers.
+// NOTE: For rules defined on a space the repo of the base segment is empty,
+// and the space of the rule is provided via the rule info instead.
+type RulePayload struct {
+	BaseSegment
+	RuleSegment
+}
+
+// handleEventRuleCreated handles rule created events
+// and triggers rule created webhooks for the scope of the rule.
+func (s *Service) handleEventRuleCreated(ctx context.Context,
+	event *events.Event[*ruleevents.CreatedPayload]) error {
+	return s.triggerForEventWithExistingRule(ctx, enum.WebhookTriggerRuleCreated, event.ID, event.Payload.Base)
+}
+
+// handleEventRuleUpdated handles rule updated events
+// and triggers rule updated webhooks for the scope of the rule.
+func (s *Service) handleEventRuleUpdated(ctx context.Context,
+	event *events.Event[*ruleevents.UpdatedPayload]) error {
+	return s.triggerForEventWithExistingRule(ctx, enum.WebhookTriggerRuleUpdated, event.ID, event.Payload.Base)
+}
+
+// handleEventRuleDeleted handles rule deleted events
+// and triggers rule deleted webhooks for the scope of the rule.
+// NOTE: The rule doesn't exist anymore, so the rule info is taken from the event.
+func (s *Service) handleEventRuleDeleted(ctx context.Context,
+	event *events.Event[*ruleevents.DeletedPayload]) error {
+	rule := &types.Rule{
+		ID:         event.Payload.RuleID,
+		RepoID:     event.Payload.RepoID,
+		SpaceID:    event.Payload.SpaceID,
+		Identifier: event.Payload.Identifier,
+		Type:       event.Payload.Type,
+	}
+
+	return s.triggerForEventWithRule(ctx, enum.WebhookTriggerRuleDeleted, event.ID, event.Payload.PrincipalID, rule)
+}
+
+// triggerForEventWithExistingRule finds the rule of the event and triggers the webhooks for its scope.
+func (s *Service) triggerForEventWithExistingRule(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	base ruleevents.Base,
+) error {
+	rule, err := s.ruleStore.Find(ctx, base.RuleID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// most likely a racing condition of the rule being deleted by now
+		return events.NewDiscardEventErrorf("rule with id '%d' doesn't exist anymore", base.RuleID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get rule for id '%d': %w", base.RuleID, err)
+	}
+
+	return s.triggerForEventWithRule(ctx, triggerType, eventID, base.PrincipalID, rule)
+}
+
+// triggerForEventWithRule triggers all webhooks for the repo or the space the rule is defined on.
+func (s *Service) triggerForEventWithRule(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	principalID int64,
+	rule *types.Rule,
+) error {
+	if rule.RepoID != nil {
+		return s.triggerForEventWithRepo(ctx, triggerType, eventID, principalID, *rule.RepoID,
+			func(principal *types.Principal, repo *types.Repository) (any, error) {
+				return &RulePayload{
+					BaseSegment: BaseSegment{
+						Trigger:   triggerType,
+						Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+						Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+					},
+					RuleSegment: RuleSegment{
+						Rule: ruleInfoFrom(rule, ""),
+					},
+				}, nil
+			})
+	}
+
+	if rule.SpaceID == nil {
+		return events.NewDiscardEventErrorf("rule with id '%d' has neither a repo nor a space", rule.ID)
+	}
+
+	principal, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	space, err := s.spaceStore.Find(ctx, *rule.SpaceID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("space with id '%d' doesn't exist anymore", *rule.SpaceID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get space for id '%d': %w", *rule.SpaceID, err)
+	}
+
+	parents, err := s.getParentInfoSpace(ctx, space.ID, true)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook parent info: %w", err)
+	}
+
+	body := &RulePayload{
+		BaseSegment: BaseSegment{
+			Trigger:   triggerType,
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+		RuleSegment: RuleSegment{
+			Rule: ruleInfoFrom(rule, space.Path),
+		},
+	}
+
+	return s.WebhookExecutor.TriggerForEvent(ctx, eventID, parents, triggerType, body)
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type handlerRuleStore struct {
+	store.RuleStore
+	rules map[int64]*types.Rule
+}
+
+func (s handlerRuleStore) Find(_ context.Context, id int64) (*types.Rule, error) {
+	rule, ok := s.rules[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return rule, nil
+}
+
+func setupRuleHandlerTest(t *testing.T) *handlerTest {
+	t.Helper()
+
+	repoID := int64(handlerTestRepoID)
+	spaceID := int64(handlerTestSpaceID)
+
+	h := setupHandlerTest(t)
+	h.s.ruleStore = handlerRuleStore{
+		rules: map[int64]*types.Rule{
+			1: {
+				ID:         1,
+				RepoID:     &repoID,
+				Identifier: "repo-rule",
+				Type:       protection.TypeBranch,
+				State:      enum.RuleStateActive,
+			},
+			2: {
+				ID:          2,
+				SpaceID:     &spaceID,
+				Identifier:  "space-rule",
+				Type:        protection.TypeTag,
+				State:       enum.RuleStateMonitor,
+				Description: "protects the release tags",
+			},
+		},
+	}
+
+	return h
+}
+
+func TestService_HandleEventRuleCreatedOnRepo(t *testing.T) {
+	h := setupRuleHandlerTest(t)
+
+	err := h.s.handleEventRuleCreated(context.Background(), &events.Event[*ruleevents.CreatedPayload]{
+		ID:      "event",
+		Payload: &ruleevents.CreatedPayload{Base: ruleevents.Base{RuleID: 1, PrincipalID: handlerTestPrincipalID}},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	payload := &RulePayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRuleCreated, repoParents(), payload)
+
+	if payload.Repo.ID != handlerTestRepoID {
+		t.Errorf("want repo %d, got %d", handlerTestRepoID, payload.Repo.ID)
+	}
+	if payload.Rule.Identifier != "repo-rule" || payload.Rule.Type != protection.TypeBranch ||
+		payload.Rule.State != enum.RuleStateActive || payload.Rule.SpacePath != "" {
+		t.Errorf("unexpected rule info: %+v", payload.Rule)
+	}
+}
+
+func TestService_HandleEventRuleUpdatedOnSpace(t *testing.T) {
+	h := setupRuleHandlerTest(t)
+
+	err := h.s.handleEventRuleUpdated(context.Background(), &events.Event[*ruleevents.UpdatedPayload]{
+		ID:      "event",
+		Payload: &ruleevents.UpdatedPayload{Base: ruleevents.Base{RuleID: 2, PrincipalID: handlerTestPrincipalID}},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	// rules of a space trigger the webhooks of the space and its parents, but not of the repos within.
+	payload := &RulePayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRuleUpdated, []types.WebhookParentInfo{
+		{ID: handlerTestSpaceID, Type: enum.WebhookParentSpace},
+		{ID: handlerTestRootSpaceID, Type: enum.WebhookParentSpace},
+	}, payload)
+
+	if payload.Repo.ID != 0 {
+		t.Errorf("want no repo for a space rule, got %d", payload.Repo.ID)
+	}
+	if payload.Principal.ID != handlerTestPrincipalID {
+		t.Errorf("want principal %d, got %d", handlerTestPrincipalID, payload.Principal.ID)
+	}
+	if payload.Rule.Identifier != "space-rule" || payload.Rule.Type != protection.TypeTag ||
+		payload.Rule.Description != "protects the release tags" || payload.Rule.SpacePath != "root/space" {
+		t.Errorf("unexpected rule info: %+v", payload.Rule)
+	}
+}
+
+func TestService_HandleEventRuleDeleted(t *testing.T) {
+	h := setupRuleHandlerTest(t)
+
+	// the rule doesn't exist anymore, its details are taken from the event.
+	repoID := int64(handlerTestRepoID)
+	err := h.s.handleEventRuleDeleted(context.Background(), &events.Event[*ruleevents.DeletedPayload]{
+		ID: "event",
+		Payload: &ruleevents.DeletedPayload{
+			Base:       ruleevents.Base{RuleID: 3, RepoID: &repoID, PrincipalID: handlerTestPrincipalID},
+			Identifier: "deleted-rule",
+			Type:       protection.TypePush,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	payload := &RulePayload{}
+	h.assertTriggered(t, enum.WebhookTriggerRuleDeleted, repoParents(), payload)
+
+	if payload.Rule.ID != 3 || payload.Rule.Identifier != "deleted-rule" || payload.Rule.Type != protection.TypePush {
+		t.Errorf("unexpected rule info: %+v", payload.Rule)
+	}
+}
+
+func TestService_HandleEventRuleUpdatedDeletedRule(t *testing.T) {
+	h := setupRuleHandlerTest(t)
+
+	err := h.s.handleEventRuleUpdated(context.Background(), &events.Event[*ruleevents.UpdatedPayload]{
+		ID:      "event",
+		Payload: &ruleevents.UpdatedPayload{Base: ruleevents.Base{RuleID: 3, PrincipalID: handlerTestPrincipalID}},
+	})
+
+	h.assertDiscarded(t, err)
+}
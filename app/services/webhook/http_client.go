@@ -16,6 +16,7 @@ package webhook
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -36,9 +37,30 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 		return http.DefaultClient
 	}
 
+	// httpClient is similar to http.DefaultClient, just with custom http.Transport
+	return &http.Client{Transport: newHTTPTransport(allowLoopback, allowPrivateNetwork, disableSSLVerification)}
+}
+
+// newHTTPClientWithClientCertificate creates a new http client that presents the client certificate (mTLS).
+func newHTTPClientWithClientCertificate(
+	allowLoopback bool,
+	allowPrivateNetwork bool,
+	disableSSLVerification bool,
+	cert tls.Certificate,
+) *http.Client {
+	tr := newHTTPTransport(allowLoopback, allowPrivateNetwork, disableSSLVerification)
+	tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+
+	return &http.Client{Transport: tr}
+}
+
+func newHTTPTransport(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Transport {
 	// Clone http.DefaultTransport (used by http.DefaultClient)
 	tr := http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck
 
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{} //nolint:gosec // defaults are set by the http package
+	}
 	tr.TLSClientConfig.InsecureSkipVerify = disableSSLVerification
 
 	// create basic net.Dialer (Similar to what is used by http.DefaultTransport)
@@ -96,6 +118,5 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 		return con, nil
 	}
 
-	// httpClient is similar to http.DefaultClient, just with custom http.Transport
-	return &http.Client{Transport: tr}
+	return tr
 }
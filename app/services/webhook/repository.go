@@ -68,7 +68,11 @@ func (s *GitnessWebhookExecutorStore) CreateWebhookExecution(
 	hook *types.WebhookExecutionCore,
 ) error {
 	webhookExecution := CoreWebhookExecutionToGitnessWebhookExecution(hook)
-	return s.webhookExecutionStore.Create(ctx, webhookExecution)
+	if err := s.webhookExecutionStore.Create(ctx, webhookExecution); err != nil {
+		return err
+	}
+	hook.ID = webhookExecution.ID
+	return nil
 }
 
 func (s *GitnessWebhookExecutorStore) UpdateOptLock(
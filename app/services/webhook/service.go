@@ -22,13 +22,18 @@ import (
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/stream"
@@ -52,6 +57,13 @@ type Config struct {
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
 	InternalSecret      string
+	// RedeliveryMaxRetries is the number of times a failed delivery is retried by a background job.
+	RedeliveryMaxRetries int
+	// AutoDisableThreshold is the number of consecutive failed executions after which a webhook is disabled.
+	// NOTE: Zero means webhooks are never disabled automatically.
+	AutoDisableThreshold int
+	// RecordSkippedExecutions stores an execution for every event a webhook skipped because of its filters.
+	RecordSkippedExecutions bool
 }
 
 func (c *Config) Prepare() error {
@@ -70,6 +82,12 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("Config.MaxRetries can't be negative")
 	}
+	if c.RedeliveryMaxRetries < 0 {
+		return errors.New("Config.RedeliveryMaxRetries can't be negative")
+	}
+	if c.AutoDisableThreshold < 0 {
+		return errors.New("Config.AutoDisableThreshold can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
@@ -119,6 +137,10 @@ type WebhookExecutor struct {
 	principalStore             store.PrincipalStore
 	webhookExecutorStore       WebhookExecutorStore
 	source                     string
+
+	// deadLetter redelivers failed executions in background jobs.
+	// NOTE: If nil, failed executions are retried by reprocessing the event.
+	deadLetter *deadLetterQueue
 }
 
 func NewWebhookExecutor(
@@ -165,6 +187,10 @@ type Service struct {
 	encrypter             encrypt.Encrypter
 	config                Config
 	sseStreamer           sse.Streamer
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
+	ruleStore             store.RuleStore
+	labelAssignmentStore  store.PullReqLabelAssignmentStore
 }
 
 func NewService(
@@ -173,6 +199,9 @@ func NewService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	ruleReaderFactory *events.ReaderFactory[*ruleevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -189,6 +218,13 @@ func NewService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	ruleStore store.RuleStore,
+	labelAssignmentStore store.PullReqLabelAssignmentStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	notificationClient notification.Client,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service Config is invalid: %w", err)
@@ -200,6 +236,22 @@ func NewService(
 	executor := NewWebhookExecutor(config, webhookURLProvider, encrypter, spacePathStore,
 		secretService, principalStore, webhookExecutorStore, RepoTrigger)
 
+	executor.deadLetter = &deadLetterQueue{
+		maxRetries:           config.RedeliveryMaxRetries,
+		autoDisableThreshold: config.AutoDisableThreshold,
+		executor:             executor,
+		scheduler:            scheduler,
+		webhookStore:         webhookStore,
+		repoStore:            repoStore,
+		spaceStore:           spaceStore,
+		principalStore:       principalStore,
+		notificationClient:   notificationClient,
+	}
+
+	if err := jobExecutor.Register(redeliveryJobType, executor.deadLetter); err != nil {
+		return nil, fmt.Errorf("failed to register webhook redelivery job handler: %w", err)
+	}
+
 	service := &Service{
 		WebhookExecutor:       executor,
 		tx:                    tx,
@@ -217,6 +269,10 @@ func NewService(
 		labelStore:            labelStore,
 		labelValueStore:       labelValueStore,
 		sseStreamer:           sseStreamer,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
+		ruleStore:             ruleStore,
+		labelAssignmentStore:  labelAssignmentStore,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -274,5 +330,69 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterStarted(service.handleEventExecutionStarted)
+			_ = r.RegisterExecuted(service.handleEventExecutionExecuted)
+			_ = r.RegisterCanceled(service.handleEventExecutionCanceled)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for webhooks: %w", err)
+	}
+
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterRenamed(service.handleEventRepoRenamed)
+			_ = r.RegisterDefaultBranchUpdated(service.handleEventRepoDefaultBranchUpdated)
+			_ = r.RegisterSoftDeleted(service.handleEventRepoSoftDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
+	_, err = ruleReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *ruleevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRuleCreated)
+			_ = r.RegisterUpdated(service.handleEventRuleUpdated)
+			_ = r.RegisterDeleted(service.handleEventRuleDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch rule event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
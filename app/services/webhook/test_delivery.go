@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	testDeliverySHA        = "0000000000000000000000000000000000000001"
+	testDeliveryRepo       = "sample-repo"
+	testDeliveryBranch     = "sample-branch"
+	testDeliveryTag        = "v1.0.0"
+	testDeliveryPipeline   = "sample-pipeline"
+	testDeliveryRule       = "sample-rule"
+	testDeliveryPullReqNum = int64(1)
+)
+
+// TestDelivery sends a sample payload for the requested trigger to the webhook.
+// The sample payload is rendered using the format and the template of the webhook,
+// so the execution can be used to preview the message.
+func (s *Service) TestDelivery(
+	ctx context.Context,
+	principal *types.Principal,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	in *types.WebhookTestInput,
+) (*types.WebhookExecutionCore, error) {
+	webhook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.Type == enum.WebhookTypeInternal {
+		return nil, ErrInternalWebhookOperationNotAllowed
+	}
+
+	trigger := in.Trigger
+	if trigger == "" && len(webhook.Triggers) > 0 {
+		trigger = webhook.Triggers[0]
+	}
+	if trigger == "" {
+		trigger = enum.WebhookTriggerBranchCreated
+	}
+	if _, ok := trigger.Sanitize(); !ok {
+		return nil, check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", trigger)
+	}
+
+	repo, err := s.testDeliveryRepo(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	body := s.testDeliveryPayload(ctx, trigger, repo, principal)
+
+	result := s.WebhookExecutor.TriggerTestDelivery(ctx, GitnessWebhookToWebhookCore(webhook), trigger, body)
+	if result.Err != nil {
+		log.Ctx(ctx).Warn().Err(result.Err).Msgf("test delivery of webhook %d had an error", webhook.ID)
+	}
+
+	return result.Execution, nil
+}
+
+// testDeliveryRepo returns the repo of a repo webhook, or a sample repo inside the space of a space webhook.
+func (s *Service) testDeliveryRepo(ctx context.Context, webhook *types.Webhook) (*types.Repository, error) {
+	if webhook.ParentType == enum.WebhookParentRepo {
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo: %w", err)
+		}
+		return repo, nil
+	}
+
+	space, err := s.spaceStore.Find(ctx, webhook.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	return &types.Repository{
+		ParentID:      space.ID,
+		Identifier:    testDeliveryRepo,
+		Path:          paths.Concatenate(space.Path, testDeliveryRepo),
+		DefaultBranch: "main",
+	}, nil
+}
+
+// testDeliveryPayload builds a sample payload with the same structure as the payload of the real trigger.
+func (s *Service) testDeliveryPayload(
+	ctx context.Context,
+	trigger enum.WebhookTrigger,
+	repo *types.Repository,
+	principal *types.Principal,
+) any {
+	repoInfo := repositoryInfoFrom(ctx, repo, s.urlProvider)
+	base := BaseSegment{
+		Trigger:   trigger,
+		Repo:      repoInfo,
+		Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+	}
+
+	commit := CommitInfo{
+		SHA:     testDeliverySHA,
+		Message: "Sample commit",
+	}
+
+	switch {
+	case strings.HasPrefix(string(trigger), "branch_"), strings.HasPrefix(string(trigger), "tag_"):
+		ref := paths.Concatenate("refs/heads", testDeliveryBranch)
+		if strings.HasPrefix(string(trigger), "tag_") {
+			ref = paths.Concatenate("refs/tags", testDeliveryTag)
+		}
+		return &ReferencePayload{
+			BaseSegment:      base,
+			ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: ref, Repo: repoInfo}},
+			ReferenceDetailsSegment: ReferenceDetailsSegment{
+				SHA:        testDeliverySHA,
+				Commit:     &commit,
+				HeadCommit: &commit,
+			},
+			ReferenceUpdateSegment: ReferenceUpdateSegment{OldSHA: types.NilSHA},
+		}
+
+	case strings.HasPrefix(string(trigger), "pullreq_"):
+		return &PullReqCreatedPayload{
+			BaseSegment: base,
+			PullReqSegment: PullReqSegment{PullReq: PullReqInfo{
+				Number:       testDeliveryPullReqNum,
+				State:        enum.PullReqStateOpen,
+				Title:        "Sample pull request",
+				SourceRepoID: repo.ID,
+				SourceBranch: testDeliveryBranch,
+				TargetRepoID: repo.ID,
+				TargetBranch: repo.DefaultBranch,
+				MergeBaseSHA: types.NilSHA,
+				Author:       base.Principal,
+				PrURL:        s.urlProvider.GenerateUIPRURL(ctx, repo.Path, testDeliveryPullReqNum),
+			}},
+			PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
+				TargetRef: ReferenceInfo{Name: paths.Concatenate("refs/heads", repo.DefaultBranch), Repo: repoInfo},
+			},
+			ReferenceSegment: ReferenceSegment{
+				Ref: ReferenceInfo{Name: paths.Concatenate("refs/heads", testDeliveryBranch), Repo: repoInfo},
+			},
+			ReferenceDetailsSegment: ReferenceDetailsSegment{
+				SHA:        testDeliverySHA,
+				Commit:     &commit,
+				HeadCommit: &commit,
+			},
+		}
+
+	case strings.HasPrefix(string(trigger), "execution_"):
+		status := enum.CIStatusRunning
+		//nolint:exhaustive
+		switch trigger {
+		case enum.WebhookTriggerExecutionSucceeded:
+			status = enum.CIStatusSuccess
+		case enum.WebhookTriggerExecutionFailed:
+			status = enum.CIStatusFailure
+		case enum.WebhookTriggerExecutionCanceled:
+			status = enum.CIStatusKilled
+		}
+		return &ExecutionPayload{
+			BaseSegment: base,
+			ExecutionSegment: ExecutionSegment{Execution: ExecutionInfo{
+				Number:             1,
+				PipelineIdentifier: testDeliveryPipeline,
+				Status:             status,
+				Trigger:            base.Principal.UID,
+				Event:              enum.TriggerEventManual,
+				Ref:                paths.Concatenate("refs/heads", repo.DefaultBranch),
+				SHA:                testDeliverySHA,
+				URL:                s.urlProvider.GenerateUIBuildURL(ctx, repo.Path, testDeliveryPipeline, 1),
+			}},
+		}
+
+	case strings.HasPrefix(string(trigger), "rule_"):
+		return &RulePayload{
+			BaseSegment: base,
+			RuleSegment: RuleSegment{Rule: RuleInfo{
+				Identifier: testDeliveryRule,
+				Type:       protection.TypeBranch,
+				State:      enum.RuleStateActive,
+			}},
+		}
+
+	default:
+		return &RepoPayload{BaseSegment: base}
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	gitnessstore "github.com/harness/gitness/app/store"
@@ -68,7 +70,7 @@ type TriggerResult struct {
 }
 
 func (r *TriggerResult) Skipped() bool {
-	return r.Execution == nil
+	return r.Execution == nil || r.Execution.Result == enum.WebhookExecutionResultSkipped
 }
 
 func (w *WebhookExecutor) triggerWebhooksFor(
@@ -77,18 +79,19 @@ func (w *WebhookExecutor) triggerWebhooksFor(
 	triggerID string,
 	triggerType enum.WebhookTrigger,
 	body any,
+	filterInput *FilterInput,
 ) ([]TriggerResult, error) {
 	webhooks, err := w.webhookExecutorStore.ListWebhooks(ctx, parents)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list webhooks for: %w", err)
 	}
-	return w.triggerWebhooks(ctx, webhooks, triggerID, triggerType, body)
+	return w.triggerWebhooks(ctx, webhooks, triggerID, triggerType, body, filterInput)
 }
 
 //nolint:gocognit // refactor if needed
 func (w *WebhookExecutor) triggerWebhooks(
 	ctx context.Context, webhooks []*types.WebhookCore,
-	triggerID string, triggerType enum.WebhookTrigger, body any,
+	triggerID string, triggerType enum.WebhookTrigger, body any, filterInput *FilterInput,
 ) ([]TriggerResult, error) {
 	// return immediately if webhooks are empty
 	if len(webhooks) == 0 {
@@ -104,13 +107,16 @@ func (w *WebhookExecutor) triggerWebhooks(
 	// precalculate whether a webhook should be executed
 	skipExecution := make(map[int64]bool)
 	for _, execution := range executions {
-		// skip execution in case of success or unrecoverable error
+		// skip execution in case of success, unrecoverable error, or if it was skipped by the filters
 		if execution.Result == enum.WebhookExecutionResultSuccess ||
-			execution.Result == enum.WebhookExecutionResultFatalError {
+			execution.Result == enum.WebhookExecutionResultFatalError ||
+			execution.Result == enum.WebhookExecutionResultSkipped {
 			skipExecution[execution.WebhookID] = true
 		}
 	}
 
+	filters := newFilterEvaluator(filterInput)
+
 	results := make([]TriggerResult, len(webhooks))
 	for i, webhook := range webhooks {
 		results[i] = TriggerResult{
@@ -141,6 +147,24 @@ func (w *WebhookExecutor) triggerWebhooks(
 			continue
 		}
 
+		// check if the event passes the filters of the webhook (record skipped executions only for debugging)
+		skipReason, err := filters.SkipReason(ctx, webhook.Filters)
+		if err != nil {
+			// the filters of one webhook must not block the other webhooks of the event
+			results[i].Execution = w.failWebhookFilters(ctx, webhook, triggerID, triggerType, err)
+			results[i].Err = err
+			continue
+		}
+		if skipReason != "" {
+			log.Ctx(ctx).Debug().Msgf("skipped %s (id: '%s') for webhook %d: %s",
+				triggerType, triggerID, webhook.ID, skipReason)
+
+			if w.config.RecordSkippedExecutions {
+				results[i].Execution = w.skipWebhook(ctx, webhook, triggerID, triggerType, skipReason)
+			}
+			continue
+		}
+
 		// execute trigger and store output in result
 		results[i].Execution, results[i].Err = w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
 	}
@@ -169,6 +193,15 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 		return nil, fmt.Errorf("failed to find webhook with id %d: %w", webhookExecution.WebhookID, err)
 	}
 
+	return w.retriggerWebhookExecution(ctx, webhook, webhookExecution), nil
+}
+
+// retriggerWebhookExecution executes the webhook again with the request body of the provided execution.
+func (w *WebhookExecutor) retriggerWebhookExecution(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	webhookExecution *types.WebhookExecutionCore,
+) *TriggerResult {
 	// reuse same trigger id as original execution
 	triggerID := webhookExecution.TriggerID
 	triggerType := webhookExecution.TriggerType
@@ -185,7 +218,85 @@ func (w *WebhookExecutor) RetriggerWebhookExecution(
 		Webhook:     webhook,
 		Execution:   newExecution,
 		Err:         err,
-	}, nil
+	}
+}
+
+// skipWebhook records a skipped execution of the webhook with the reason why it was skipped.
+// NOTE: The latest execution result of the webhook isn't updated, as no request was sent.
+func (w *WebhookExecutor) skipWebhook(
+	ctx context.Context, webhook *types.WebhookCore, triggerID string,
+	triggerType enum.WebhookTrigger, reason string,
+) *types.WebhookExecutionCore {
+	execution := types.WebhookExecutionCore{
+		Created:     time.Now().UnixMilli(),
+		WebhookID:   webhook.ID,
+		TriggerID:   triggerID,
+		TriggerType: triggerType,
+		Result:      enum.WebhookExecutionResultSkipped,
+		Error:       reason,
+	}
+
+	err := w.webhookExecutorStore.CreateWebhookExecution(ctx, &execution)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf(
+			"failed to store skipped execution of webhook %d with reason: '%s'", webhook.ID, reason)
+	}
+
+	return &execution
+}
+
+// failWebhookFilters records a failed execution of the webhook in case its filters couldn't be evaluated.
+// NOTE: No request was sent, so the execution can't be retriggered.
+func (w *WebhookExecutor) failWebhookFilters(
+	ctx context.Context, webhook *types.WebhookCore, triggerID string,
+	triggerType enum.WebhookTrigger, filtersErr error,
+) *types.WebhookExecutionCore {
+	log.Ctx(ctx).Warn().Err(filtersErr).Msgf("failed to evaluate filters of webhook %d for %s (id: '%s')",
+		webhook.ID, triggerType, triggerID)
+
+	execution := types.WebhookExecutionCore{
+		Created:     time.Now().UnixMilli(),
+		WebhookID:   webhook.ID,
+		TriggerID:   triggerID,
+		TriggerType: triggerType,
+		Result:      enum.WebhookExecutionResultFatalError,
+		Error:       "failed to evaluate the filters of the webhook",
+	}
+
+	err := w.webhookExecutorStore.CreateWebhookExecution(ctx, &execution)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to store failed execution of webhook %d", webhook.ID)
+	}
+
+	// update latest execution result of webhook IFF it's different from before (best effort)
+	if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
+		_, err = w.webhookExecutorStore.UpdateOptLock(ctx, webhook, &execution)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf(
+				"failed to update latest execution result to %s for webhook %d", execution.Result, webhook.ID)
+		}
+	}
+
+	return &execution
+}
+
+// TriggerTestDelivery executes the webhook once with the provided body, independent of its state and triggers.
+func (w *WebhookExecutor) TriggerTestDelivery(
+	ctx context.Context,
+	webhook *types.WebhookCore,
+	triggerType enum.WebhookTrigger,
+	body any,
+) *TriggerResult {
+	triggerID := types.WebhookTestTriggerIDPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	execution, err := w.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+	return &TriggerResult{
+		TriggerID:   triggerID,
+		TriggerType: triggerType,
+		Webhook:     webhook,
+		Execution:   execution,
+		Err:         err,
+	}
 }
 
 //nolint:gocognit // refactor into smaller chunks if necessary.
@@ -216,15 +327,28 @@ func (w *WebhookExecutor) executeWebhook(
 				execution.Result, execution.Response.Status, execution.Error)
 		}
 
+		// test deliveries don't affect the state of the webhook
+		if strings.HasPrefix(triggerID, types.WebhookTestTriggerIDPrefix) {
+			return
+		}
+
 		// update latest execution result of webhook IFF it's different from before (best effort)
+		hook := webhook
 		if webhook.LatestExecutionResult == nil || *webhook.LatestExecutionResult != execution.Result {
-			_, err = w.webhookExecutorStore.UpdateOptLock(oCtx, webhook, &execution)
+			updated, err := w.webhookExecutorStore.UpdateOptLock(oCtx, webhook, &execution)
 			if err != nil {
 				log.Ctx(ctx).Warn().Err(err).Msgf(
 					"failed to update latest execution result to %s for webhook %d",
 					execution.Result, webhook.ID)
+			} else {
+				hook = updated
 			}
 		}
+
+		// keep track of consecutive failures (best effort)
+		if w.deadLetter != nil {
+			w.deadLetter.recordExecution(oCtx, hook, &execution)
+		}
 	}(ctx, time.Now())
 
 	// derive context with time limit
@@ -232,8 +356,17 @@ func (w *WebhookExecutor) executeWebhook(
 	defer cancel()
 
 	// create request from webhook and body
-	req, err := w.prepareHTTPRequest(ctx, &execution, triggerType, webhook, body)
+	req, err := w.prepareHTTPRequest(ctx, &execution, triggerID, triggerType, webhook, body)
+	if err != nil {
+		return &execution, err
+	}
+
+	// load client certificate in case mTLS is configured
+	cert, err := w.clientCertificateFor(ctx, webhook)
 	if err != nil {
+		// ASSUMPTION: the secret got deleted or the webhook is misconfigured, not retriable
+		execution.Error = err.Error()
+		execution.Result = enum.WebhookExecutionResultFatalError
 		return &execution, err
 	}
 
@@ -244,6 +377,12 @@ func (w *WebhookExecutor) executeWebhook(
 		resp, err = w.insecureHTTPClientInternal.Do(req)
 	case webhook.Type == enum.WebhookTypeInternal:
 		resp, err = w.secureHTTPClientInternal.Do(req)
+	case cert != nil:
+		// clients with a client certificate are specific to the webhook and not reused
+		client := newHTTPClientWithClientCertificate(
+			w.config.AllowLoopback, w.config.AllowPrivateNetwork, webhook.Insecure, *cert)
+		defer client.CloseIdleConnections()
+		resp, err = client.Do(req)
 	case webhook.Insecure:
 		resp, err = w.insecureHTTPClient.Do(req)
 	default:
@@ -264,24 +403,31 @@ func (w *WebhookExecutor) executeWebhook(
 	var dnsError *net.DNSError
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
-		// we assume timeout without any response is not worth retrying - protect the system
+		// the receiver might be overloaded or down, the execution is redelivered later
 		tErr := fmt.Errorf("request exceeded time limit of %s", webhookTimeLimit)
 		execution.Error = tErr.Error()
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = enum.WebhookExecutionResultRetriableError
 		return &execution, tErr
 
 	case errors.As(err, &dnsError) && dnsError.IsNotFound:
-		// this error is assumed unrecoverable - mark status accordingly and fail execution
+		// the host might be (temporarily) unavailable, the execution is redelivered later
 		execution.Error = fmt.Sprintf("host '%s' was not found", dnsError.Name)
-		execution.Result = enum.WebhookExecutionResultFatalError
+		execution.Result = enum.WebhookExecutionResultRetriableError
 		return &execution, fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
 
-	case err != nil:
-		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
+	case errors.Is(err, errLoopbackNotAllowed) || errors.Is(err, errPrivateNetworkNotAllowed):
+		// the target address is blocked by the configuration, redelivering it won't help
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
 		execution.Error = tErr.Error()
 		execution.Result = enum.WebhookExecutionResultFatalError
 		return &execution, tErr
+
+	case err != nil:
+		// transport errors (e.g. connection refused) are likely temporary, the execution is redelivered later
+		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
+		execution.Error = tErr.Error()
+		execution.Result = enum.WebhookExecutionResultRetriableError
+		return &execution, tErr
 	}
 
 	// handle response
@@ -294,7 +440,7 @@ func (w *WebhookExecutor) executeWebhook(
 // All execution.Request.XXX values are set accordingly.
 // NOTE: if the body is an io.Reader, the value is used as response body as is, otherwise it'll be JSON serialized.
 func (w *WebhookExecutor) prepareHTTPRequest(
-	ctx context.Context, execution *types.WebhookExecutionCore,
+	ctx context.Context, execution *types.WebhookExecutionCore, triggerID string,
 	triggerType enum.WebhookTrigger, webhook *types.WebhookCore, body any,
 ) (*http.Request, error) {
 	url, err := w.webhookURLProvider.GetWebhookURL(ctx, webhook)
@@ -324,6 +470,23 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 		bBuff.Write(bBytes)
 
 	default:
+		// chat formats replace the generic payload with the native message of the chat service
+		if webhook.Format != "" && webhook.Format != enum.WebhookFormatJSON {
+			body, err = renderChatBody(webhook.Format, webhook.Template, triggerType, body)
+			if err != nil {
+				// ASSUMPTION: there was an issue with the user provided template, not retriable
+				tErr := fmt.Errorf("failed to render %s message: %w", webhook.Format, err)
+				execution.Error = tErr.Error()
+				execution.Result = enum.WebhookExecutionResultFatalError
+				return nil, tErr
+			}
+		}
+
+		// the structured CloudEvents mode wraps the payload in the event envelope
+		if webhook.Delivery.CloudEvents == enum.WebhookCloudEventsModeStructured {
+			body = w.cloudEventFor(webhook, triggerID, triggerType, body)
+		}
+
 		// all other types we json serialize
 		err := json.NewEncoder(bBuff).Encode(body)
 		if err != nil {
@@ -349,7 +512,11 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 
 	// setup headers
 	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", w.config.UserAgentIdentity, version.Version))
-	req.Header.Add("Content-Type", "application/json")
+	contentType := "application/json"
+	if webhook.Delivery.CloudEvents == enum.WebhookCloudEventsModeStructured {
+		contentType = cloudEventsStructuredMediaType
+	}
+	req.Header.Add("Content-Type", contentType)
 
 	req.Header.Add(w.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
 	req.Header.Add(w.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
@@ -364,6 +531,26 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 		}
 	}
 
+	if webhook.Delivery.CloudEvents == enum.WebhookCloudEventsModeBinary {
+		w.cloudEventFor(webhook, triggerID, triggerType, nil).setBinaryHeaders(req.Header)
+	}
+
+	for _, h := range webhook.Delivery.Headers {
+		req.Header.Add(h.Key, h.Value)
+	}
+
+	if webhook.Type != enum.WebhookTypeInternal && webhook.Delivery.Auth == enum.WebhookAuthTypeBearer {
+		token, err := w.getAuthSecretValue(ctx, webhook)
+		if err != nil {
+			// ASSUMPTION: the secret got deleted or the webhook is misconfigured, not retriable
+			tErr := fmt.Errorf("failed to get bearer token: %w", err)
+			execution.Error = tErr.Error()
+			execution.Result = enum.WebhookExecutionResultFatalError
+			return nil, tErr
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
 	var secretValue string
 	//nolint:gocritic
 	if webhook.Type == enum.WebhookTypeInternal {
@@ -385,8 +572,17 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 
 	// add HMAC only if a secret was provided
 	if secretValue != "" {
+		signedData := bBuff.Bytes()
+
+		// timestamped signatures cover the timestamp as well, to allow receivers to reject replayed requests
+		if webhook.Delivery.Signature == enum.WebhookSignatureTypeHMACSHA256Timestamped {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			req.Header.Add(w.toXHeader("Signature-Timestamp"), timestamp)
+			signedData = append([]byte(timestamp+"."), signedData...)
+		}
+
 		var hmac string
-		hmac, err = crypto.GenerateHMACSHA256(bBuff.Bytes(), []byte(secretValue))
+		hmac, err = crypto.GenerateHMACSHA256(signedData, []byte(secretValue))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate SHA256 based HMAC: %w", err)
 		}
@@ -394,7 +590,7 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 	}
 
 	hBuffer := &bytes.Buffer{}
-	err = req.Header.Write(hBuffer)
+	err = redactHeaders(req.Header, webhook.Delivery.Headers).Write(hBuffer)
 	if err != nil {
 		tErr := fmt.Errorf("failed to write request headers: %w", err)
 		execution.Error = tErr.Error()
@@ -581,6 +777,12 @@ func GitnessWebhookToWebhookCore(webhook *types.Webhook) *types.WebhookCore {
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		Format:                webhook.Format,
+		Template:              webhook.Template,
+		Filters:               webhook.Filters,
+		Delivery:              webhook.Delivery,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		DisabledReason:        webhook.DisabledReason,
 	}
 }
 
@@ -604,6 +806,12 @@ func CoreWebhookToGitnessWebhook(webhook *types.WebhookCore) *types.Webhook {
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		Format:                webhook.Format,
+		Template:              webhook.Template,
+		Filters:               webhook.Filters,
+		Delivery:              webhook.Delivery,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
+		DisabledReason:        webhook.DisabledReason,
 	}
 }
 
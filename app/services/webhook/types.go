@@ -103,6 +103,8 @@ type PullReqUpdateSegment struct {
 type PullReqReviewSegment struct {
 	ReviewDecision enum.PullReqReviewDecision `json:"review_decision"`
 	ReviewerInfo   PrincipalInfo              `json:"reviewer"`
+	// Comments contains the pending comments published with the review.
+	Comments []PullReqCommentSegment `json:"comments,omitempty"`
 }
 
 type PullReqTargetBrancheChangedSegment struct {
@@ -110,6 +112,28 @@ type PullReqTargetBrancheChangedSegment struct {
 	OldMergeBaseSHA string `json:"old_merge_base_sha"`
 }
 
+// ExecutionSegment contains details for all pipeline execution related payloads for webhooks.
+type ExecutionSegment struct {
+	Execution ExecutionInfo `json:"execution"`
+}
+
+// RepoRenamedSegment contains the identifier and path the repo had before it got renamed.
+type RepoRenamedSegment struct {
+	OldIdentifier string `json:"old_identifier"`
+	OldPath       string `json:"old_path"`
+}
+
+// RepoDefaultBranchChangedSegment contains details of the default branch change of a repo.
+type RepoDefaultBranchChangedSegment struct {
+	OldDefaultBranch string `json:"old_default_branch"`
+	NewDefaultBranch string `json:"new_default_branch"`
+}
+
+// RuleSegment contains details for all protection rule related payloads for webhooks.
+type RuleSegment struct {
+	Rule RuleInfo `json:"rule"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -348,3 +372,76 @@ type CodeCommentInfo struct {
 	LineOld      int    `json:"line_old"`
 	SpanOld      int    `json:"span_old"`
 }
+
+// ExecutionInfo describes the pipeline execution related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ExecutionInfo struct {
+	Number             int64              `json:"number"`
+	PipelineID         int64              `json:"pipeline_id"`
+	PipelineIdentifier string             `json:"pipeline_identifier"`
+	Status             enum.CIStatus      `json:"status"`
+	Error              string             `json:"error,omitempty"`
+	Trigger            string             `json:"trigger,omitempty"`
+	Event              enum.TriggerEvent  `json:"event,omitempty"`
+	Action             enum.TriggerAction `json:"action,omitempty"`
+	Ref                string             `json:"ref,omitempty"`
+	Source             string             `json:"source,omitempty"`
+	Target             string             `json:"target,omitempty"`
+	SHA                string             `json:"sha,omitempty"`
+	Started            int64              `json:"started,omitempty"`
+	Finished           int64              `json:"finished,omitempty"`
+	Created            int64              `json:"created"`
+	URL                string             `json:"url"`
+}
+
+// executionInfoFrom gets the ExecutionInfo from a types.Execution.
+func executionInfoFrom(
+	ctx context.Context,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) ExecutionInfo {
+	return ExecutionInfo{
+		Number:             execution.Number,
+		PipelineID:         pipeline.ID,
+		PipelineIdentifier: pipeline.Identifier,
+		Status:             execution.Status,
+		Error:              execution.Error,
+		Trigger:            execution.Trigger,
+		Event:              execution.Event,
+		Action:             execution.Action,
+		Ref:                execution.Ref,
+		Source:             execution.Source,
+		Target:             execution.Target,
+		SHA:                execution.After,
+		Started:            execution.Started,
+		Finished:           execution.Finished,
+		Created:            execution.Created,
+		URL:                urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier, execution.Number),
+	}
+}
+
+// RuleInfo describes the protection rule related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type RuleInfo struct {
+	ID          int64          `json:"id"`
+	Identifier  string         `json:"identifier"`
+	Type        types.RuleType `json:"type"`
+	State       enum.RuleState `json:"state,omitempty"`
+	Description string         `json:"description,omitempty"`
+	// SpacePath is only set for rules defined on a space, which have no repo in the payload.
+	SpacePath string `json:"space_path,omitempty"`
+}
+
+// ruleInfoFrom gets the RuleInfo from a types.Rule.
+func ruleInfoFrom(rule *types.Rule, spacePath string) RuleInfo {
+	return RuleInfo{
+		ID:          rule.ID,
+		Identifier:  rule.Identifier,
+		Type:        rule.Type,
+		State:       rule.State,
+		Description: rule.Description,
+		SpacePath:   spacePath,
+	}
+}
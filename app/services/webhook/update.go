@@ -61,6 +61,26 @@ func (s *Service) sanitizeUpdateInput(in *types.WebhookUpdateInput) error {
 			return err
 		}
 	}
+	if in.Format != nil {
+		if err := CheckFormat(*in.Format); err != nil {
+			return err
+		}
+	}
+	if in.Template != nil {
+		if err := CheckTemplate(*in.Template); err != nil {
+			return err
+		}
+	}
+	if in.Filters != nil {
+		if err := CheckFilters(*in.Filters); err != nil {
+			return err
+		}
+	}
+	if in.Delivery != nil {
+		if err := SanitizeDelivery(in.Delivery); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -86,6 +106,8 @@ func (s *Service) Update(
 		return nil, errors.New("changing type is not allowed")
 	}
 
+	urlChanged := in.URL != nil && *in.URL != hook.URL
+
 	// update webhook struct (only for values that are provided)
 	if in.Identifier != nil {
 		hook.Identifier = *in.Identifier
@@ -107,6 +129,11 @@ func (s *Service) Update(
 		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
+		// enabling the webhook resets the state of an automatically disabled webhook
+		if *in.Enabled && !hook.Enabled {
+			hook.ConsecutiveFailures = 0
+			hook.DisabledReason = ""
+		}
 		hook.Enabled = *in.Enabled
 	}
 	if in.Insecure != nil {
@@ -115,6 +142,27 @@ func (s *Service) Update(
 	if in.Triggers != nil {
 		hook.Triggers = DeduplicateTriggers(in.Triggers)
 	}
+	if in.Format != nil {
+		hook.Format = *in.Format
+	}
+	if in.Template != nil {
+		hook.Template = *in.Template
+	}
+	if in.Filters != nil {
+		hook.Filters = *in.Filters
+	}
+	if in.Delivery != nil {
+		if err := keepHeaderValues(in.Delivery.Headers, hook.Delivery.Headers, urlChanged); err != nil {
+			return nil, err
+		}
+		hook.Delivery = *in.Delivery
+	} else if err := checkHeaderValuesKept(hook.Delivery.Headers, urlChanged); err != nil {
+		return nil, err
+	}
+
+	if err := CheckFormatWithDelivery(hook.Format, hook.Delivery); err != nil {
+		return nil, err
+	}
 
 	if err := s.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
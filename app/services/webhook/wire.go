@@ -18,13 +18,18 @@ import (
 	"context"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -43,6 +48,9 @@ func ProvideService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	ruleReaderFactory *events.ReaderFactory[*ruleevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -59,6 +67,13 @@ func ProvideService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	ruleStore store.RuleStore,
+	labelAssignmentStore store.PullReqLabelAssignmentStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	notificationClient notification.Client,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -66,6 +81,9 @@ func ProvideService(
 		tx,
 		gitReaderFactory,
 		prReaderFactory,
+		pipelineReaderFactory,
+		repoReaderFactory,
+		ruleReaderFactory,
 		webhookStore,
 		webhookExecutionStore,
 		spaceStore, repoStore,
@@ -81,6 +99,13 @@ func ProvideService(
 		sseStreamer,
 		secretService,
 		spacePathStore,
+		pipelineStore,
+		executionStore,
+		ruleStore,
+		labelAssignmentStore,
+		scheduler,
+		jobExecutor,
+		notificationClient,
 	)
 }
 
@@ -15,6 +15,7 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/automerge"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspacedeleteevent"
@@ -24,10 +25,12 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repomirror"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
@@ -47,9 +50,13 @@ type Services struct {
 	JobScheduler            *job.Scheduler
 	MetricCollector         *metric.CollectorJob
 	RepoSizeCalculator      *repo.SizeCalculator
+	RepoMirrorSyncer        *repomirror.Syncer
 	Repo                    *repo.Service
 	Cleanup                 *cleanup.Service
 	Notification            *notification.Service
+	NotificationDigest      *notification.Digest
+	AutoMerge               *automerge.Service
+	MergeQueue              *mergequeue.Service
 	Keywordsearch           *keywordsearch.Service
 	GitspaceService         *GitspaceServices
 	Instrumentation         instrument.Service
@@ -92,9 +99,13 @@ func ProvideServices(
 	jobScheduler *job.Scheduler,
 	metricCollector *metric.CollectorJob,
 	repoSizeCalculator *repo.SizeCalculator,
+	repoMirrorSyncer *repomirror.Syncer,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
+	notificationDigest *notification.Digest,
+	autoMergeSvc *automerge.Service,
+	mergeQueueSvc *mergequeue.Service,
 	keywordsearchSvc *keywordsearch.Service,
 	gitspaceSvc *GitspaceServices,
 	instrumentation instrument.Service,
@@ -109,9 +120,13 @@ func ProvideServices(
 		JobScheduler:            jobScheduler,
 		MetricCollector:         metricCollector,
 		RepoSizeCalculator:      repoSizeCalculator,
+		RepoMirrorSyncer:        repoMirrorSyncer,
 		Repo:                    repo,
 		Cleanup:                 cleanupSvc,
 		Notification:            notificationSvc,
+		NotificationDigest:      notificationDigest,
+		AutoMerge:               autoMergeSvc,
+		MergeQueue:              mergeQueueSvc,
 		Keywordsearch:           keywordsearchSvc,
 		GitspaceService:         gitspaceSvc,
 		Instrumentation:         instrumentation,
@@ -309,6 +309,145 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// CountForks returns the number of forks of a repo.
+		CountForks(ctx context.Context, repoID int64, opts *types.RepoFilter) (int64, error)
+
+		// ListForks returns a list of forks of a repo.
+		ListForks(ctx context.Context, repoID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+
+		// DetachForks removes the fork lineage of all forks of a repo.
+		DetachForks(ctx context.Context, repoID int64) error
+	}
+
+	// RepoMirrorStore defines the data storage for repository mirrors.
+	RepoMirrorStore interface {
+		// Find returns the mirror configuration of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoMirror, error)
+
+		// Create stores the mirror configuration of a repository.
+		// It returns store.ErrDuplicate if the repository is already a mirror.
+		Create(ctx context.Context, mirror *types.RepoMirror) error
+
+		// Update updates the mirror configuration of the repository.
+		Update(ctx context.Context, mirror *types.RepoMirror) error
+
+		// UpdateSyncStatus updates the synchronization status of the mirror.
+		UpdateSyncStatus(ctx context.Context, mirror *types.RepoMirror) error
+
+		// Delete removes the mirror configuration of the repository.
+		// It returns store.ErrResourceNotFound if the repository isn't a mirror.
+		Delete(ctx context.Context, repoID int64) error
+
+		// ListDue returns the mirrors which should be synchronized before the provided time.
+		ListDue(ctx context.Context, before int64, limit int) ([]*types.RepoMirror, error)
+	}
+
+	// NotificationDigestStore defines the pull request digest email configuration storage.
+	NotificationDigestStore interface {
+		// Find returns the digest configuration of the principal.
+		Find(ctx context.Context, principalID int64) (*types.NotificationDigest, error)
+
+		// Upsert creates or updates the digest configuration of the principal.
+		// The time of the last sent digest is set only when the configuration is created.
+		Upsert(ctx context.Context, digest *types.NotificationDigest) error
+
+		// UpdateLastSent updates the time when the last digest was sent to the principal.
+		UpdateLastSent(ctx context.Context, principalID int64, lastSent int64) error
+	}
+
+	// OutOfOfficeStore defines the storage of the user absences.
+	OutOfOfficeStore interface {
+		// Find returns the absence of the principal.
+		Find(ctx context.Context, principalID int64) (*types.OutOfOffice, error)
+
+		// Upsert creates or updates the absence of the principal.
+		Upsert(ctx context.Context, ooo *types.OutOfOffice) error
+
+		// Delete deletes the absence of the principal.
+		Delete(ctx context.Context, principalID int64) error
+
+		// ListActive returns IDs of the provided principals who are out of office at the provided time.
+		ListActive(ctx context.Context, principalIDs []int64, now int64) ([]int64, error)
+	}
+
+	// NotificationPreferenceStore defines the notification preference storage.
+	NotificationPreferenceStore interface {
+		// List returns all notification preferences of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.NotificationPreference, error)
+
+		// ListByEvent returns the notification preferences of the principals for the provided event.
+		ListByEvent(
+			ctx context.Context,
+			principalIDs []int64,
+			event enum.NotificationEvent,
+		) ([]*types.NotificationPreference, error)
+
+		// Upsert creates or updates the notification preference of the principal
+		// for the scope, event and channel of the provided preference.
+		Upsert(ctx context.Context, pref *types.NotificationPreference) error
+
+		// Delete deletes the notification preference of the principal.
+		Delete(ctx context.Context, principalID, id int64) error
+	}
+
+	// NotificationWatchStore defines the storage of watched repositories and pull requests.
+	NotificationWatchStore interface {
+		// Find returns the watch of the principal on the repository, or on the pull request if pullReqID isn't nil.
+		Find(ctx context.Context, principalID, repoID int64, pullReqID *int64) (*types.NotificationWatch, error)
+
+		// Create stores a new watch. Creating an already existing watch isn't an error.
+		Create(ctx context.Context, watch *types.NotificationWatch) error
+
+		// Delete deletes the watch of the principal on the repository,
+		// or on the pull request if pullReqID isn't nil.
+		Delete(ctx context.Context, principalID, repoID int64, pullReqID *int64) error
+
+		// ListPrincipalIDs returns IDs of the principals watching the repository or the pull request.
+		ListPrincipalIDs(ctx context.Context, repoID, pullReqID int64) ([]int64, error)
+	}
+
+	// NotificationStore defines the in-app notification storage.
+	NotificationStore interface {
+		// Find returns the in-app notification of the principal.
+		Find(ctx context.Context, principalID, id int64) (*types.Notification, error)
+
+		// Create stores a new in-app notification.
+		Create(ctx context.Context, notification *types.Notification) error
+
+		// List returns the in-app notifications of the principal, the most recent first.
+		List(ctx context.Context, principalID int64, filter *types.NotificationFilter) ([]*types.Notification, error)
+
+		// Count returns the number of the in-app notifications of the principal.
+		Count(ctx context.Context, principalID int64, filter *types.NotificationFilter) (int64, error)
+
+		// UpdateRead marks the in-app notification of the principal as read or unread.
+		UpdateRead(ctx context.Context, principalID, id int64, read bool, updated int64) error
+
+		// MarkAllRead marks all in-app notifications of the principal as read.
+		MarkAllRead(ctx context.Context, principalID int64, updated int64) error
+	}
+
+	// RepoPushMirrorStore defines the repository push mirror storage.
+	RepoPushMirrorStore interface {
+		// Find returns the push mirror with the provided ID.
+		Find(ctx context.Context, id int64) (*types.RepoPushMirror, error)
+
+		// List returns all push mirrors of the repository.
+		List(ctx context.Context, repoID int64) ([]*types.RepoPushMirror, error)
+
+		// Create stores a new push mirror of a repository.
+		Create(ctx context.Context, mirror *types.RepoPushMirror) error
+
+		// Update updates the configuration of the push mirror.
+		Update(ctx context.Context, mirror *types.RepoPushMirror) error
+
+		// UpdateSyncStatus updates the status of the last push to the push mirror.
+		UpdateSyncStatus(ctx context.Context, mirror *types.RepoPushMirror) error
+
+		// Delete removes the push mirror.
+		// It returns store.ErrResourceNotFound if the push mirror doesn't exist.
+		Delete(ctx context.Context, id int64) error
 	}
 
 	// SettingsStore defines the settings storage.
@@ -441,6 +580,24 @@ type (
 		) (map[string][]*types.PullReq, error)
 	}
 
+	// PullReqReactionStore defines the storage of reactions on pull request comments and descriptions.
+	PullReqReactionStore interface {
+		// Create stores a new reaction. Creating an already existing reaction isn't an error.
+		Create(ctx context.Context, reaction *types.PullReqReaction) error
+
+		// Delete deletes the reaction of the principal.
+		Delete(ctx context.Context, reaction *types.PullReqReaction) error
+
+		// ListSummaries returns the reactions on the pull request aggregated by the comment ID and the reaction.
+		// The reactions on the pull request description are under the zero key.
+		// The Reacted field of the summaries is set for the reactions of the provided principal.
+		ListSummaries(
+			ctx context.Context,
+			pullReqID int64,
+			principalID int64,
+		) (map[int64][]types.PullReqReactionSummary, error)
+	}
+
 	PullReqActivityStore interface {
 		// Find the pull request activity by id.
 		Find(ctx context.Context, id int64) (*types.PullReqActivity, error)
@@ -479,6 +636,12 @@ type (
 
 		// ListAuthorIDs returns a list of pull request activity author ids in a thread (order).
 		ListAuthorIDs(ctx context.Context, prID int64, order int64) ([]int64, error)
+
+		// PublishPending publishes all pending comments of the principal in a pull request.
+		PublishPending(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqActivity, error)
+
+		// DeletePending deletes all pending comments of the principal in a pull request.
+		DeletePending(ctx context.Context, prID int64, principalID int64) (int64, error)
 	}
 
 	// CodeCommentView is to manipulate only code-comment subset of PullReqActivity.
@@ -502,6 +665,9 @@ type (
 		// Find returns the pull request review entity or an error if it doesn't exist.
 		Find(ctx context.Context, id int64) (*types.PullReqReview, error)
 
+		// List returns all reviews of the pull request, ordered from the oldest to the newest.
+		List(ctx context.Context, prID int64) ([]*types.PullReqReview, error)
+
 		// Create creates a new pull request review.
 		Create(ctx context.Context, v *types.PullReqReview) error
 	}
@@ -521,6 +687,13 @@ type (
 
 		// List returns all pull request reviewers for the pull request.
 		List(ctx context.Context, prID int64) ([]*types.PullReqReviewer, error)
+
+		// ListAssignmentStats returns the reviewer assignment statistics of the provided principals.
+		// Principals who were never reviewers aren't included in the result.
+		ListAssignmentStats(
+			ctx context.Context,
+			principalIDs []int64,
+		) (map[int64]types.ReviewerAssignmentStats, error)
 	}
 
 	// UserGroupReviewersStore defines the pull request usergroup reviewer storage.
@@ -550,6 +723,47 @@ type (
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
 
+	// PullReqAutoMergeStore defines the data storage for armed pull request auto-merges.
+	PullReqAutoMergeStore interface {
+		// Find returns the armed auto-merge of the pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, error)
+
+		// Upsert arms the auto-merge of a pull request or updates an already armed one.
+		Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error
+
+		// Delete disarms the auto-merge of the pull request.
+		// It returns store.ErrResourceNotFound if the auto-merge isn't armed.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// ListPullReqNumbersBySourceSHA returns numbers of open pull requests of the target repository
+		// with the provided source SHA that have auto-merge armed.
+		ListPullReqNumbersBySourceSHA(ctx context.Context, repoID int64, sourceSHA string) ([]int64, error)
+	}
+
+	// MergeQueueEntryStore defines the data storage for pull requests waiting in merge queues.
+	MergeQueueEntryStore interface {
+		// Find returns the merge queue entry of the pull request.
+		Find(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds the pull request to the merge queue.
+		// It returns store.ErrDuplicate if the pull request is already in a merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state and the speculative merge commit of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes the pull request from the merge queue.
+		// It returns store.ErrResourceNotFound if the pull request isn't in a merge queue.
+		Delete(ctx context.Context, pullReqID int64) error
+
+		// ListByBranch returns entries of the merge queue of the branch in the order of merging.
+		ListByBranch(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error)
+
+		// ListBranchesByMergeSHA returns the branches of the repository with a merge queue entry
+		// that has the provided speculative merge commit.
+		ListBranchesByMergeSHA(ctx context.Context, repoID int64, mergeSHA string) ([]string, error)
+	}
+
 	// RuleStore defines database interface for protection rules.
 	RuleStore interface {
 		// Find finds a protection rule by ID.
@@ -657,6 +871,16 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// ListFailedSince lists the failed and retriggerable executions of the webhook created since the provided
+		// time (unix milliseconds), excluding the executions of triggers that succeeded in the meantime.
+		// The newest executions are listed first.
+		ListFailedSince(
+			ctx context.Context,
+			webhookID int64,
+			since int64,
+			limit int,
+		) ([]*types.WebhookExecution, error)
 	}
 
 	CheckStore interface {
@@ -1357,4 +1581,18 @@ type (
 		Upsert(ctx context.Context, in *types.CDEGateway) error
 		List(ctx context.Context, filter *types.CDEGatewayFilter) ([]*types.CDEGateway, error)
 	}
+
+	AuditLogStore interface {
+		// Create creates a new audit log entry.
+		Create(ctx context.Context, auditLog *types.AuditLog) error
+
+		// List returns the audit logs matching the provided filter, newest first.
+		List(ctx context.Context, filter *types.AuditLogFilter) ([]*types.AuditLog, error)
+
+		// Count returns the number of audit logs matching the provided filter.
+		Count(ctx context.Context, filter *types.AuditLogFilter) (int64, error)
+
+		// DeleteOld removes all audit logs that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
 )
@@ -0,0 +1,359 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AuditLogStore = (*AuditLogStore)(nil)
+
+// NewAuditLogStore returns a new AuditLogStore.
+func NewAuditLogStore(db *sqlx.DB) *AuditLogStore {
+	return &AuditLogStore{
+		db: db,
+	}
+}
+
+// AuditLogStore implements store.AuditLogStore backed by a relational database.
+type AuditLogStore struct {
+	db *sqlx.DB
+}
+
+type auditLog struct {
+	ID                 int64           `db:"audit_log_id"`
+	Created            int64           `db:"audit_log_created"`
+	Action             string          `db:"audit_log_action"`
+	PrincipalID        int64           `db:"audit_log_principal_id"`
+	PrincipalUID       string          `db:"audit_log_principal_uid"`
+	PrincipalEmail     string          `db:"audit_log_principal_email"`
+	PrincipalName      string          `db:"audit_log_principal_name"`
+	PrincipalType      string          `db:"audit_log_principal_type"`
+	SpacePath          string          `db:"audit_log_space_path"`
+	ResourceType       string          `db:"audit_log_resource_type"`
+	ResourceIdentifier string          `db:"audit_log_resource_identifier"`
+	ResourceData       json.RawMessage `db:"audit_log_resource_data"`
+	ClientIP           string          `db:"audit_log_client_ip"`
+	RequestMethod      string          `db:"audit_log_request_method"`
+	RequestID          string          `db:"audit_log_request_id"`
+	RequestPath        string          `db:"audit_log_request_path"`
+	Data               json.RawMessage `db:"audit_log_data"`
+	OldObject          []byte          `db:"audit_log_old_object"`
+	NewObject          []byte          `db:"audit_log_new_object"`
+}
+
+const (
+	auditLogColumns = `
+		 audit_log_id
+		,audit_log_created
+		,audit_log_action
+		,audit_log_principal_id
+		,audit_log_principal_uid
+		,audit_log_principal_email
+		,audit_log_principal_name
+		,audit_log_principal_type
+		,audit_log_space_path
+		,audit_log_resource_type
+		,audit_log_resource_identifier
+		,audit_log_resource_data
+		,audit_log_client_ip
+		,audit_log_request_method
+		,audit_log_request_id
+		,audit_log_request_path
+		,audit_log_data
+		,audit_log_old_object
+		,audit_log_new_object`
+)
+
+// Create creates a new audit log entry.
+func (s *AuditLogStore) Create(ctx context.Context, in *types.AuditLog) error {
+	const sqlQuery = `
+	INSERT INTO audit_logs (
+		 audit_log_created
+		,audit_log_action
+		,audit_log_principal_id
+		,audit_log_principal_uid
+		,audit_log_principal_email
+		,audit_log_principal_name
+		,audit_log_principal_type
+		,audit_log_space_path
+		,audit_log_resource_type
+		,audit_log_resource_identifier
+		,audit_log_resource_data
+		,audit_log_client_ip
+		,audit_log_request_method
+		,audit_log_request_id
+		,audit_log_request_path
+		,audit_log_data
+		,audit_log_old_object
+		,audit_log_new_object
+	) values (
+		 :audit_log_created
+		,:audit_log_action
+		,:audit_log_principal_id
+		,:audit_log_principal_uid
+		,:audit_log_principal_email
+		,:audit_log_principal_name
+		,:audit_log_principal_type
+		,:audit_log_space_path
+		,:audit_log_resource_type
+		,:audit_log_resource_identifier
+		,:audit_log_resource_data
+		,:audit_log_client_ip
+		,:audit_log_request_method
+		,:audit_log_request_id
+		,:audit_log_request_path
+		,:audit_log_data
+		,:audit_log_old_object
+		,:audit_log_new_object
+	) RETURNING audit_log_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbAuditLog, err := mapToInternalAuditLog(in)
+	if err != nil {
+		return fmt.Errorf("failed to map audit log: %w", err)
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbAuditLog)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit log object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&in.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert audit log query failed")
+	}
+
+	return nil
+}
+
+// List returns the audit logs matching the provided filter, newest first.
+func (s *AuditLogStore) List(ctx context.Context, filter *types.AuditLogFilter) ([]*types.AuditLog, error) {
+	stmt := database.Builder.
+		Select(auditLogColumns).
+		From("audit_logs")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	// fixed ordering by desc id (new ones first) - add customized ordering if deemed necessary.
+	stmt = stmt.OrderBy("audit_log_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*auditLog{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing audit log list query")
+	}
+
+	return mapToAuditLogs(dst)
+}
+
+// Count returns the number of audit logs matching the provided filter.
+func (s *AuditLogStore) Count(ctx context.Context, filter *types.AuditLogFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("audit_logs")
+
+	stmt = s.applyFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing audit log count query")
+	}
+
+	return count, nil
+}
+
+// DeleteOld removes all audit logs that are older than the provided time.
+func (s *AuditLogStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("audit_logs").
+		Where("audit_log_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete audit logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete audit logs query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted audit logs")
+	}
+
+	return n, nil
+}
+
+func (*AuditLogStore) applyFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditLogFilter,
+) squirrel.SelectBuilder {
+	if filter.SpacePath != "" {
+		spacePath := strings.ToLower(filter.SpacePath)
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Eq{"LOWER(audit_log_space_path)": spacePath},
+			squirrel.Expr(PrefixMatch("audit_log_space_path", spacePath+"/")),
+		})
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("audit_log_resource_identifier", filter.Query))
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_log_action": filter.Actions})
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_log_resource_type": filter.ResourceTypes})
+	}
+
+	if len(filter.PrincipalIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_log_principal_id": filter.PrincipalIDs})
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_log_created < ?", filter.CreatedLt)
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_log_created > ?", filter.CreatedGt)
+	}
+
+	return stmt
+}
+
+func mapToInternalAuditLog(in *types.AuditLog) (*auditLog, error) {
+	resourceData, err := json.Marshal(mapOrEmpty(in.ResourceData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource data: %w", err)
+	}
+
+	data, err := json.Marshal(mapOrEmpty(in.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	return &auditLog{
+		ID:                 in.ID,
+		Created:            in.Created,
+		Action:             in.Action,
+		PrincipalID:        in.Principal.ID,
+		PrincipalUID:       in.Principal.UID,
+		PrincipalEmail:     in.Principal.Email,
+		PrincipalName:      in.Principal.DisplayName,
+		PrincipalType:      string(in.Principal.Type),
+		SpacePath:          in.SpacePath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourceData:       resourceData,
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+		RequestID:          in.RequestID,
+		RequestPath:        in.RequestPath,
+		Data:               data,
+		OldObject:          in.OldObject,
+		NewObject:          in.NewObject,
+	}, nil
+}
+
+func mapToAuditLog(in *auditLog) (*types.AuditLog, error) {
+	var resourceData map[string]string
+	if err := json.Unmarshal(in.ResourceData, &resourceData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource data of audit log %d: %w", in.ID, err)
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal(in.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data of audit log %d: %w", in.ID, err)
+	}
+
+	return &types.AuditLog{
+		ID:      in.ID,
+		Created: in.Created,
+		Action:  in.Action,
+		Principal: types.PrincipalInfo{
+			ID:          in.PrincipalID,
+			UID:         in.PrincipalUID,
+			DisplayName: in.PrincipalName,
+			Email:       in.PrincipalEmail,
+			Type:        enum.PrincipalType(in.PrincipalType),
+		},
+		SpacePath:          in.SpacePath,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourceData:       resourceData,
+		ClientIP:           in.ClientIP,
+		RequestMethod:      in.RequestMethod,
+		RequestID:          in.RequestID,
+		RequestPath:        in.RequestPath,
+		Data:               data,
+		OldObject:          in.OldObject,
+		NewObject:          in.NewObject,
+	}, nil
+}
+
+func mapToAuditLogs(in []*auditLog) ([]*types.AuditLog, error) {
+	m := make([]*types.AuditLog, len(in))
+	for i, l := range in {
+		var err error
+		if m[i], err = mapToAuditLog(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func mapOrEmpty(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
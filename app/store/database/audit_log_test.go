@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	auditLogStore := database.NewAuditLogStore(db)
+
+	ctx := context.Background()
+
+	now := time.Now()
+	logs := []*types.AuditLog{
+		{Created: now.Add(-48 * time.Hour).UnixMilli(), SpacePath: "space", ResourceIdentifier: "old"},
+		{Created: now.UnixMilli(), SpacePath: "space", ResourceIdentifier: "repo1"},
+		{Created: now.UnixMilli(), SpacePath: "space/sub", ResourceIdentifier: "repo2",
+			Data: map[string]string{"key": "value"}, NewObject: json.RawMessage(`{"a":1}`)},
+		{Created: now.UnixMilli(), SpacePath: "space_other", ResourceIdentifier: "repo3"},
+	}
+	for _, l := range logs {
+		l.Action = "created"
+		l.ResourceType = "repository"
+		l.Principal = types.PrincipalInfo{ID: userID, UID: "admin"}
+		require.NoError(t, auditLogStore.Create(ctx, l))
+	}
+
+	filter := &types.AuditLogFilter{
+		ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+		SpacePath:       "SPACE",
+	}
+
+	count, err := auditLogStore.Count(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	list, err := auditLogStore.List(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	require.Equal(t, "repo2", list[0].ResourceIdentifier)
+	require.Equal(t, map[string]string{"key": "value"}, list[0].Data)
+	require.JSONEq(t, `{"a":1}`, string(list[0].NewObject))
+	require.Equal(t, "admin", list[0].Principal.UID)
+
+	n, err := auditLogStore.DeleteOld(ctx, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	filter.SpacePath = ""
+	count, err = auditLogStore.Count(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
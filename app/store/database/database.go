@@ -33,6 +33,17 @@ const (
 // https://www.postgresql.org/docs/current/functions-matching.html#FUNCTIONS-LIKE
 // https://www.sqlite.org/lang_expr.html#the_like_glob_regexp_match_and_extract_operators
 func PartialMatch(column, value string) (string, string) {
+	return likeMatch(column, value, "'%' || LOWER(?) || '%'")
+}
+
+// PrefixMatch builds a string pair that can be passed as a parameter to squirrel's Where() function
+// for a SQL "LIKE" expression matching all values of the column starting with the input value.
+// Same as PartialMatch, it escapes the '_' and '%' metacharacters supported in SQL "LIKE" expressions.
+func PrefixMatch(column, value string) (string, string) {
+	return likeMatch(column, value, "LOWER(?) || '%'")
+}
+
+func likeMatch(column, value, pattern string) (string, string) {
 	var (
 		n       int
 		escaped bool
@@ -51,7 +62,8 @@ func PartialMatch(column, value string) (string, string) {
 	sb := strings.Builder{}
 	sb.WriteString("LOWER(")
 	sb.WriteString(column)
-	sb.WriteString(") LIKE '%' || LOWER(?) || '%'")
+	sb.WriteString(") LIKE ")
+	sb.WriteString(pattern)
 	if escaped {
 		sb.WriteString(` ESCAPE '\'`)
 	}
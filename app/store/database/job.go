@@ -64,7 +64,8 @@ const (
 		,job_recurring_cron
 		,job_consecutive_failures
 		,job_last_failure_error
-		,job_group_id`
+		,job_group_id
+		,job_exponential_backoff`
 
 	jobSelectBase = `
 	SELECT` + jobColumns + `
@@ -153,6 +154,7 @@ func (s *JobStore) Create(ctx context.Context, job *job.Job) error {
 			,:job_consecutive_failures
 			,:job_last_failure_error
 			,:job_group_id
+			,:job_exponential_backoff
 		)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -196,6 +198,7 @@ func (s *JobStore) Upsert(ctx context.Context, job *job.Job) error {
 			,:job_consecutive_failures
 			,:job_last_failure_error
 			,:job_group_id
+			,:job_exponential_backoff
 		)
 		ON CONFLICT (job_uid) DO
 		UPDATE SET
@@ -210,6 +213,7 @@ func (s *JobStore) Upsert(ctx context.Context, job *job.Job) error {
 			,job_scheduled = :job_scheduled
 			,job_is_recurring = :job_is_recurring
 			,job_recurring_cron = :job_recurring_cron
+			,job_exponential_backoff = :job_exponential_backoff
 		WHERE
 			jobs.job_type <> :job_type OR
 			jobs.job_priority <> :job_priority OR
@@ -217,7 +221,8 @@ func (s *JobStore) Upsert(ctx context.Context, job *job.Job) error {
 			jobs.job_max_duration_seconds <> :job_max_duration_seconds OR
 			jobs.job_max_retries <> :job_max_retries OR
 			jobs.job_is_recurring <> :job_is_recurring OR
-			jobs.job_recurring_cron <> :job_recurring_cron`
+			jobs.job_recurring_cron <> :job_recurring_cron OR
+			jobs.job_exponential_backoff <> :job_exponential_backoff`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -250,6 +255,7 @@ func (s *JobStore) UpdateDefinition(ctx context.Context, job *job.Job) error {
 		,job_is_recurring = :job_is_recurring
 		,job_recurring_cron = :job_recurring_cron
 		,job_group_id = :job_group_id
+		,job_exponential_backoff = :job_exponential_backoff
 	WHERE job_uid = :job_uid`
 
 	db := dbtx.GetAccessor(ctx, s.db)
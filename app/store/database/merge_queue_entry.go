@@ -0,0 +1,315 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.MergeQueueEntryStore = (*MergeQueueEntryStore)(nil)
+
+// NewMergeQueueEntryStore returns a new MergeQueueEntryStore.
+func NewMergeQueueEntryStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *MergeQueueEntryStore {
+	return &MergeQueueEntryStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// MergeQueueEntryStore implements store.MergeQueueEntryStore backed by a relational database.
+type MergeQueueEntryStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type mergeQueueEntry struct {
+	PullReqID     int64                     `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber int64                     `db:"pullreq_number"`
+	RepoID        int64                     `db:"merge_queue_entry_repo_id"`
+	Branch        string                    `db:"merge_queue_entry_branch"`
+	CreatedBy     int64                     `db:"merge_queue_entry_created_by"`
+	Created       int64                     `db:"merge_queue_entry_created"`
+	Updated       int64                     `db:"merge_queue_entry_updated"`
+	State         enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+	MergeMethod   enum.MergeMethod          `db:"merge_queue_entry_merge_method"`
+	Title         string                    `db:"merge_queue_entry_title"`
+	Message       string                    `db:"merge_queue_entry_message"`
+	SourceSHA     string                    `db:"merge_queue_entry_source_sha"`
+	BaseSHA       string                    `db:"merge_queue_entry_base_sha"`
+	MergeSHA      string                    `db:"merge_queue_entry_merge_sha"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_pullreq_id
+		,pullreq_number
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_branch
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated
+		,merge_queue_entry_state
+		,merge_queue_entry_merge_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha`
+
+	mergeQueueEntrySelectBase = `
+	SELECT` + mergeQueueEntryColumns + `
+	FROM merge_queue_entries
+	INNER JOIN pullreqs ON pullreq_id = merge_queue_entry_pullreq_id`
+)
+
+// Find returns the merge queue entry of the pull request.
+func (s *MergeQueueEntryStore) Find(ctx context.Context, pullReqID int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+	WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return s.mapMergeQueueEntry(ctx, dst), nil
+}
+
+// Create adds the pull request to the merge queue.
+func (s *MergeQueueEntryStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+	INSERT INTO merge_queue_entries (
+		 merge_queue_entry_pullreq_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_branch
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated
+		,merge_queue_entry_state
+		,merge_queue_entry_merge_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha
+	) VALUES (
+		 :merge_queue_entry_pullreq_id
+		,:merge_queue_entry_repo_id
+		,:merge_queue_entry_branch
+		,:merge_queue_entry_created_by
+		,:merge_queue_entry_created
+		,:merge_queue_entry_updated
+		,:merge_queue_entry_state
+		,:merge_queue_entry_merge_method
+		,:merge_queue_entry_title
+		,:merge_queue_entry_message
+		,:merge_queue_entry_source_sha
+		,:merge_queue_entry_base_sha
+		,:merge_queue_entry_merge_sha
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state and the speculative merge commit of a merge queue entry.
+func (s *MergeQueueEntryStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+	UPDATE merge_queue_entries
+	SET
+		 merge_queue_entry_updated = :merge_queue_entry_updated
+		,merge_queue_entry_state = :merge_queue_entry_state
+		,merge_queue_entry_source_sha = :merge_queue_entry_source_sha
+		,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+	WHERE merge_queue_entry_pullreq_id = :merge_queue_entry_pullreq_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the pull request from the merge queue.
+func (s *MergeQueueEntryStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+	DELETE FROM merge_queue_entries
+	WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListByBranch returns entries of the merge queue of the branch in the order of merging.
+func (s *MergeQueueEntryStore) ListByBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+	WHERE merge_queue_entry_repo_id = $1 AND merge_queue_entry_branch = $2
+	ORDER BY merge_queue_entry_created ASC, merge_queue_entry_pullreq_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, branch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	entries := make([]*types.MergeQueueEntry, len(dst))
+	for i, entry := range dst {
+		entries[i] = s.mapMergeQueueEntry(ctx, entry)
+	}
+
+	return entries, nil
+}
+
+// ListBranchesByMergeSHA returns the branches of the repository with a merge queue entry
+// that has the provided speculative merge commit.
+func (s *MergeQueueEntryStore) ListBranchesByMergeSHA(
+	ctx context.Context,
+	repoID int64,
+	mergeSHA string,
+) ([]string, error) {
+	stmt := database.Builder.
+		Select("DISTINCT merge_queue_entry_branch").
+		From("merge_queue_entries").
+		Where("merge_queue_entry_repo_id = ?", repoID).
+		Where("merge_queue_entry_merge_sha = ?", mergeSHA)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var branches []string
+	if err = db.SelectContext(ctx, &branches, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue branches")
+	}
+
+	return branches, nil
+}
+
+func mapToInternalMergeQueueEntry(v *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		PullReqID:     v.PullReqID,
+		PullReqNumber: v.PullReqNumber,
+		RepoID:        v.RepoID,
+		Branch:        v.Branch,
+		CreatedBy:     v.CreatedBy,
+		Created:       v.Created,
+		Updated:       v.Updated,
+		State:         v.State,
+		MergeMethod:   v.MergeMethod,
+		Title:         v.Title,
+		Message:       v.Message,
+		SourceSHA:     v.SourceSHA,
+		BaseSHA:       v.BaseSHA,
+		MergeSHA:      v.MergeSHA,
+	}
+}
+
+func (s *MergeQueueEntryStore) mapMergeQueueEntry(
+	ctx context.Context,
+	v *mergeQueueEntry,
+) *types.MergeQueueEntry {
+	m := &types.MergeQueueEntry{
+		PullReqID:     v.PullReqID,
+		PullReqNumber: v.PullReqNumber,
+		RepoID:        v.RepoID,
+		Branch:        v.Branch,
+		CreatedBy:     v.CreatedBy,
+		Created:       v.Created,
+		Updated:       v.Updated,
+		State:         v.State,
+		MergeMethod:   v.MergeMethod,
+		Title:         v.Title,
+		Message:       v.Message,
+		SourceSHA:     v.SourceSHA,
+		BaseSHA:       v.BaseSHA,
+		MergeSHA:      v.MergeSHA,
+	}
+
+	author, err := s.pCache.Get(ctx, v.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load merge queue entry author")
+	}
+	if author != nil {
+		m.Author = *author
+	}
+
+	return m
+}
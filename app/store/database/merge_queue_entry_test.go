@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeQueueEntryStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullReqStore := database.NewPullReqStore(db, pCache)
+	mergeQueueStore := database.NewMergeQueueEntryStore(db, pCache)
+
+	now := time.Now().UnixMilli()
+
+	createPullReq := func(number int64, targetBranch string) *types.PullReq {
+		pr := &types.PullReq{
+			Number:       number,
+			CreatedBy:    userID,
+			Created:      now,
+			Updated:      now,
+			State:        enum.PullReqStateOpen,
+			Title:        "title",
+			SourceRepoID: 1,
+			SourceBranch: "feature-" + strconv.FormatInt(number, 10),
+			TargetRepoID: 1,
+			TargetBranch: targetBranch,
+		}
+		require.NoError(t, pullReqStore.Create(ctx, pr))
+		return pr
+	}
+
+	enqueue := func(pr *types.PullReq, created int64) *types.MergeQueueEntry {
+		entry := &types.MergeQueueEntry{
+			PullReqID:   pr.ID,
+			RepoID:      pr.TargetRepoID,
+			Branch:      pr.TargetBranch,
+			CreatedBy:   userID,
+			Created:     created,
+			Updated:     created,
+			State:       enum.MergeQueueEntryStateQueued,
+			MergeMethod: enum.MergeMethodMerge,
+			SourceSHA:   "source",
+		}
+		require.NoError(t, mergeQueueStore.Create(ctx, entry))
+		return entry
+	}
+
+	pr1 := createPullReq(1, "main")
+	pr2 := createPullReq(2, "main")
+	pr3 := createPullReq(3, "main")
+	pr4 := createPullReq(4, "release")
+
+	// the position in the queue is determined by the time of enqueueing, not by the pull request number.
+	enqueue(pr2, now)
+	enqueue(pr3, now+1)
+	entry1 := enqueue(pr1, now+1)
+	enqueue(pr4, now)
+
+	listNumbers := func(branch string) []int64 {
+		entries, err := mergeQueueStore.ListByBranch(ctx, 1, branch)
+		require.NoError(t, err)
+
+		numbers := make([]int64, len(entries))
+		for i, entry := range entries {
+			numbers[i] = entry.PullReqNumber
+		}
+		return numbers
+	}
+
+	require.Equal(t, []int64{2, 1, 3}, listNumbers("main"))
+	require.Equal(t, []int64{4}, listNumbers("release"))
+
+	err := mergeQueueStore.Create(ctx, entry1)
+	require.ErrorIs(t, err, gitness_store.ErrDuplicate)
+
+	entry1.State = enum.MergeQueueEntryStateChecking
+	entry1.BaseSHA = "base"
+	entry1.MergeSHA = "merge"
+	require.NoError(t, mergeQueueStore.Update(ctx, entry1))
+
+	branches, err := mergeQueueStore.ListBranchesByMergeSHA(ctx, 1, "merge")
+	require.NoError(t, err)
+	require.Equal(t, []string{"main"}, branches)
+
+	found, err := mergeQueueStore.Find(ctx, pr1.ID)
+	require.NoError(t, err)
+	require.Equal(t, enum.MergeQueueEntryStateChecking, found.State)
+	require.Equal(t, int64(1), found.PullReqNumber)
+
+	require.NoError(t, mergeQueueStore.Delete(ctx, pr2.ID))
+	require.ErrorIs(t, mergeQueueStore.Delete(ctx, pr2.ID), gitness_store.ErrResourceNotFound)
+	require.Equal(t, []int64{1, 3}, listNumbers("main"))
+
+	_, err = mergeQueueStore.Find(ctx, pr2.ID)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+}
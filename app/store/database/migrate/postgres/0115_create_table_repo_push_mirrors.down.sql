@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_push_mirrors;
+ALTER TABLE jobs DROP COLUMN job_exponential_backoff;
@@ -0,0 +1,14 @@
+CREATE TABLE notification_digests (
+ notification_digest_principal_id INTEGER PRIMARY KEY
+,notification_digest_created BIGINT NOT NULL
+,notification_digest_updated BIGINT NOT NULL
+,notification_digest_enabled BOOLEAN NOT NULL
+,notification_digest_hour INTEGER NOT NULL
+,notification_digest_timezone TEXT NOT NULL
+,notification_digest_idle_days INTEGER NOT NULL
+,notification_digest_last_sent BIGINT NOT NULL
+,CONSTRAINT fk_notification_digest_principal_id FOREIGN KEY (notification_digest_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
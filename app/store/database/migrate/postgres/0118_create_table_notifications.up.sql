@@ -0,0 +1,85 @@
+CREATE TABLE notification_preferences (
+ notification_preference_id SERIAL PRIMARY KEY
+,notification_preference_principal_id INTEGER NOT NULL
+,notification_preference_created BIGINT NOT NULL
+,notification_preference_updated BIGINT NOT NULL
+,notification_preference_scope TEXT NOT NULL
+,notification_preference_scope_id INTEGER NOT NULL
+,notification_preference_event TEXT NOT NULL
+,notification_preference_channel TEXT NOT NULL
+,notification_preference_enabled BOOLEAN NOT NULL
+,CONSTRAINT fk_notification_preference_principal_id FOREIGN KEY (notification_preference_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX notification_preferences_principal_id_scope_event_channel
+    ON notification_preferences(
+         notification_preference_principal_id
+        ,notification_preference_scope
+        ,notification_preference_scope_id
+        ,notification_preference_event
+        ,notification_preference_channel
+    );
+
+CREATE TABLE notification_watches (
+ notification_watch_id SERIAL PRIMARY KEY
+,notification_watch_principal_id INTEGER NOT NULL
+,notification_watch_repo_id INTEGER NOT NULL
+,notification_watch_pullreq_id INTEGER
+,notification_watch_created BIGINT NOT NULL
+,CONSTRAINT fk_notification_watch_principal_id FOREIGN KEY (notification_watch_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_watch_repo_id FOREIGN KEY (notification_watch_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_watch_pullreq_id FOREIGN KEY (notification_watch_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX notification_watches_principal_id_repo_id
+    ON notification_watches(notification_watch_principal_id, notification_watch_repo_id)
+    WHERE notification_watch_pullreq_id IS NULL;
+
+CREATE UNIQUE INDEX notification_watches_principal_id_pullreq_id
+    ON notification_watches(notification_watch_principal_id, notification_watch_pullreq_id)
+    WHERE notification_watch_pullreq_id IS NOT NULL;
+
+CREATE INDEX notification_watches_repo_id
+    ON notification_watches(notification_watch_repo_id);
+
+CREATE TABLE notifications (
+ notification_id SERIAL PRIMARY KEY
+,notification_principal_id INTEGER NOT NULL
+,notification_created BIGINT NOT NULL
+,notification_updated BIGINT NOT NULL
+,notification_event TEXT NOT NULL
+,notification_actor_id INTEGER NOT NULL
+,notification_repo_id INTEGER NOT NULL
+,notification_pullreq_id INTEGER NOT NULL
+,notification_pullreq_number INTEGER NOT NULL
+,notification_title TEXT NOT NULL
+,notification_text TEXT NOT NULL
+,notification_read BOOLEAN NOT NULL
+,CONSTRAINT fk_notification_principal_id FOREIGN KEY (notification_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_repo_id FOREIGN KEY (notification_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_pullreq_id FOREIGN KEY (notification_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notifications_principal_id_read
+    ON notifications(notification_principal_id, notification_read);
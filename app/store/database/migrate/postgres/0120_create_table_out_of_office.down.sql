@@ -0,0 +1 @@
+DROP TABLE IF EXISTS out_of_office;
@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN webhook_format TEXT NOT NULL DEFAULT 'json';
+ALTER TABLE webhooks ADD COLUMN webhook_template TEXT NOT NULL DEFAULT '';
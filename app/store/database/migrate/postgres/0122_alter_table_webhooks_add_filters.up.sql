@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_filters JSONB NOT NULL DEFAULT '{}';
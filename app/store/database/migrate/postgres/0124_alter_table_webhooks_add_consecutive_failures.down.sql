@@ -0,0 +1,2 @@
+ALTER TABLE webhooks DROP COLUMN webhook_disabled_reason;
+ALTER TABLE webhooks DROP COLUMN webhook_consecutive_failures;
@@ -0,0 +1,31 @@
+CREATE TABLE audit_logs
+(
+    audit_log_id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+    audit_log_created             BIGINT NOT NULL,
+    audit_log_action              TEXT   NOT NULL,
+    audit_log_principal_id        BIGINT NOT NULL,
+    audit_log_principal_uid       TEXT   NOT NULL,
+    audit_log_principal_email     TEXT   NOT NULL,
+    audit_log_principal_name      TEXT   NOT NULL,
+    audit_log_principal_type      TEXT   NOT NULL,
+    audit_log_space_path          TEXT   NOT NULL,
+    audit_log_resource_type       TEXT   NOT NULL,
+    audit_log_resource_identifier TEXT   NOT NULL,
+    audit_log_resource_data       TEXT   NOT NULL,
+    audit_log_client_ip           TEXT   NOT NULL,
+    audit_log_request_method      TEXT   NOT NULL,
+    audit_log_request_id          TEXT   NOT NULL,
+    audit_log_request_path        TEXT   NOT NULL,
+    audit_log_data                TEXT   NOT NULL,
+    audit_log_old_object          TEXT,
+    audit_log_new_object          TEXT
+);
+
+CREATE INDEX audit_logs_created
+    ON audit_logs (audit_log_created);
+
+CREATE INDEX audit_logs_space_path_created
+    ON audit_logs (LOWER(audit_log_space_path), audit_log_created);
+
+CREATE INDEX audit_logs_principal_id_created
+    ON audit_logs (audit_log_principal_id, audit_log_created);
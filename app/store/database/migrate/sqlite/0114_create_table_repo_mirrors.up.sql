@@ -0,0 +1,27 @@
+CREATE TABLE repo_mirrors (
+ repo_mirror_repo_id INTEGER PRIMARY KEY
+,repo_mirror_created_by INTEGER NOT NULL
+,repo_mirror_created BIGINT NOT NULL
+,repo_mirror_updated BIGINT NOT NULL
+,repo_mirror_url TEXT NOT NULL
+,repo_mirror_username TEXT NOT NULL
+,repo_mirror_password_secret_space_id INTEGER
+,repo_mirror_password_secret_identifier TEXT NOT NULL
+,repo_mirror_interval INTEGER NOT NULL
+,repo_mirror_sync_state TEXT NOT NULL
+,repo_mirror_last_sync BIGINT NOT NULL
+,repo_mirror_last_success BIGINT NOT NULL
+,repo_mirror_last_error TEXT NOT NULL
+,repo_mirror_next_sync BIGINT NOT NULL
+,CONSTRAINT fk_repo_mirror_repo_id FOREIGN KEY (repo_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_mirror_created_by FOREIGN KEY (repo_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_mirrors_next_sync
+    ON repo_mirrors(repo_mirror_next_sync);
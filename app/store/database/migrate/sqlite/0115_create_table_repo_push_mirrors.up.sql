@@ -0,0 +1,29 @@
+CREATE TABLE repo_push_mirrors (
+ repo_push_mirror_id INTEGER PRIMARY KEY AUTOINCREMENT
+,repo_push_mirror_repo_id INTEGER NOT NULL
+,repo_push_mirror_created_by INTEGER NOT NULL
+,repo_push_mirror_created BIGINT NOT NULL
+,repo_push_mirror_updated BIGINT NOT NULL
+,repo_push_mirror_url TEXT NOT NULL
+,repo_push_mirror_username TEXT NOT NULL
+,repo_push_mirror_password_secret_space_id INTEGER
+,repo_push_mirror_password_secret_identifier TEXT NOT NULL
+,repo_push_mirror_protected_only BOOLEAN NOT NULL
+,repo_push_mirror_sync_state TEXT NOT NULL
+,repo_push_mirror_last_sync BIGINT NOT NULL
+,repo_push_mirror_last_success BIGINT NOT NULL
+,repo_push_mirror_last_error TEXT NOT NULL
+,CONSTRAINT fk_repo_push_mirror_repo_id FOREIGN KEY (repo_push_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_push_mirror_created_by FOREIGN KEY (repo_push_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_push_mirrors_repo_id
+    ON repo_push_mirrors(repo_push_mirror_repo_id);
+
+ALTER TABLE jobs ADD COLUMN job_exponential_backoff BOOLEAN NOT NULL DEFAULT false;
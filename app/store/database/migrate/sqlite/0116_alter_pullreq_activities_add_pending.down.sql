@@ -0,0 +1 @@
+ALTER TABLE pullreq_activities DROP COLUMN pullreq_activity_pending;
@@ -0,0 +1,12 @@
+CREATE TABLE out_of_office (
+ out_of_office_principal_id INTEGER PRIMARY KEY
+,out_of_office_created BIGINT NOT NULL
+,out_of_office_updated BIGINT NOT NULL
+,out_of_office_from BIGINT NOT NULL
+,out_of_office_until BIGINT NOT NULL
+,out_of_office_message TEXT NOT NULL
+,CONSTRAINT fk_out_of_office_principal_id FOREIGN KEY (out_of_office_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_delivery TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,301 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.NotificationStore = (*NotificationStore)(nil)
+
+// NewNotificationStore returns a new NotificationStore.
+func NewNotificationStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *NotificationStore {
+	return &NotificationStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// NotificationStore implements store.NotificationStore backed by a relational database.
+type NotificationStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type notification struct {
+	ID            int64                  `db:"notification_id"`
+	PrincipalID   int64                  `db:"notification_principal_id"`
+	Created       int64                  `db:"notification_created"`
+	Updated       int64                  `db:"notification_updated"`
+	Event         enum.NotificationEvent `db:"notification_event"`
+	ActorID       int64                  `db:"notification_actor_id"`
+	RepoID        int64                  `db:"notification_repo_id"`
+	PullReqID     int64                  `db:"notification_pullreq_id"`
+	PullReqNumber int64                  `db:"notification_pullreq_number"`
+	Title         string                 `db:"notification_title"`
+	Text          string                 `db:"notification_text"`
+	Read          bool                   `db:"notification_read"`
+}
+
+const (
+	notificationColumns = `
+		 notification_id
+		,notification_principal_id
+		,notification_created
+		,notification_updated
+		,notification_event
+		,notification_actor_id
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_pullreq_number
+		,notification_title
+		,notification_text
+		,notification_read`
+)
+
+// Find returns the in-app notification of the principal.
+func (s *NotificationStore) Find(ctx context.Context, principalID, id int64) (*types.Notification, error) {
+	const sqlQuery = `
+	SELECT` + notificationColumns + `
+	FROM notifications
+	WHERE notification_id = $1 AND notification_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notification{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification")
+	}
+
+	return s.mapNotification(ctx, dst), nil
+}
+
+// Create stores a new in-app notification.
+func (s *NotificationStore) Create(ctx context.Context, n *types.Notification) error {
+	const sqlQuery = `
+	INSERT INTO notifications (
+		 notification_principal_id
+		,notification_created
+		,notification_updated
+		,notification_event
+		,notification_actor_id
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_pullreq_number
+		,notification_title
+		,notification_text
+		,notification_read
+	) VALUES (
+		 :notification_principal_id
+		,:notification_created
+		,:notification_updated
+		,:notification_event
+		,:notification_actor_id
+		,:notification_repo_id
+		,:notification_pullreq_id
+		,:notification_pullreq_number
+		,:notification_title
+		,:notification_text
+		,:notification_read
+	) RETURNING notification_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotification(n))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&n.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns the in-app notifications of the principal, the most recent first.
+func (s *NotificationStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, error) {
+	stmt := database.Builder.
+		Select(notificationColumns).
+		From("notifications").
+		Where("notification_principal_id = ?", principalID).
+		OrderBy("notification_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+	stmt = applyNotificationFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notification
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notifications")
+	}
+
+	result := make([]*types.Notification, len(dst))
+	for i, n := range dst {
+		result[i] = s.mapNotification(ctx, n)
+	}
+
+	return result, nil
+}
+
+// Count returns the number of the in-app notifications of the principal.
+func (s *NotificationStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+	stmt = applyNotificationFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count notifications")
+	}
+
+	return count, nil
+}
+
+// UpdateRead marks the in-app notification of the principal as read or unread.
+func (s *NotificationStore) UpdateRead(
+	ctx context.Context,
+	principalID int64,
+	id int64,
+	read bool,
+	updated int64,
+) error {
+	const sqlQuery = `
+	UPDATE notifications
+	SET notification_read = $1, notification_updated = $2
+	WHERE notification_id = $3 AND notification_principal_id = $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, read, updated, id, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification read state")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// MarkAllRead marks all in-app notifications of the principal as read.
+func (s *NotificationStore) MarkAllRead(ctx context.Context, principalID int64, updated int64) error {
+	const sqlQuery = `
+	UPDATE notifications
+	SET notification_read = TRUE, notification_updated = $1
+	WHERE notification_principal_id = $2 AND notification_read = FALSE`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, updated, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark notifications as read")
+	}
+
+	return nil
+}
+
+func applyNotificationFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.NotificationFilter,
+) squirrel.SelectBuilder {
+	if filter.Read != nil {
+		stmt = stmt.Where("notification_read = ?", *filter.Read)
+	}
+	return stmt
+}
+
+func mapToInternalNotification(v *types.Notification) *notification {
+	return &notification{
+		ID:            v.ID,
+		PrincipalID:   v.PrincipalID,
+		Created:       v.Created,
+		Updated:       v.Updated,
+		Event:         v.Event,
+		ActorID:       v.ActorID,
+		RepoID:        v.RepoID,
+		PullReqID:     v.PullReqID,
+		PullReqNumber: v.PullReqNumber,
+		Title:         v.Title,
+		Text:          v.Text,
+		Read:          v.Read,
+	}
+}
+
+func (s *NotificationStore) mapNotification(ctx context.Context, v *notification) *types.Notification {
+	m := &types.Notification{
+		ID:            v.ID,
+		PrincipalID:   v.PrincipalID,
+		Created:       v.Created,
+		Updated:       v.Updated,
+		Event:         v.Event,
+		ActorID:       v.ActorID,
+		RepoID:        v.RepoID,
+		PullReqID:     v.PullReqID,
+		PullReqNumber: v.PullReqNumber,
+		Title:         v.Title,
+		Text:          v.Text,
+		Read:          v.Read,
+	}
+
+	actor, err := s.pCache.Get(ctx, v.ActorID)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load notification actor")
+	}
+	if actor != nil {
+		m.Actor = *actor
+	}
+
+	return m
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigest struct {
+	PrincipalID int64  `db:"notification_digest_principal_id"`
+	Created     int64  `db:"notification_digest_created"`
+	Updated     int64  `db:"notification_digest_updated"`
+	Enabled     bool   `db:"notification_digest_enabled"`
+	Hour        int    `db:"notification_digest_hour"`
+	Timezone    string `db:"notification_digest_timezone"`
+	IdleDays    int    `db:"notification_digest_idle_days"`
+	LastSent    int64  `db:"notification_digest_last_sent"`
+}
+
+const (
+	notificationDigestColumns = `
+		 notification_digest_principal_id
+		,notification_digest_created
+		,notification_digest_updated
+		,notification_digest_enabled
+		,notification_digest_hour
+		,notification_digest_timezone
+		,notification_digest_idle_days
+		,notification_digest_last_sent`
+)
+
+// Find returns the digest configuration of the principal.
+func (s *NotificationDigestStore) Find(ctx context.Context, principalID int64) (*types.NotificationDigest, error) {
+	const sqlQuery = `
+	SELECT` + notificationDigestColumns + `
+	FROM notification_digests
+	WHERE notification_digest_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationDigest{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification digest")
+	}
+
+	return mapNotificationDigest(dst), nil
+}
+
+// Upsert creates or updates the digest configuration of the principal.
+func (s *NotificationDigestStore) Upsert(ctx context.Context, digest *types.NotificationDigest) error {
+	const sqlQuery = `
+	INSERT INTO notification_digests (` + notificationDigestColumns + `
+	) VALUES (
+		 :notification_digest_principal_id
+		,:notification_digest_created
+		,:notification_digest_updated
+		,:notification_digest_enabled
+		,:notification_digest_hour
+		,:notification_digest_timezone
+		,:notification_digest_idle_days
+		,:notification_digest_last_sent
+	)
+	ON CONFLICT (notification_digest_principal_id) DO
+	UPDATE SET
+		 notification_digest_updated = :notification_digest_updated
+		,notification_digest_enabled = :notification_digest_enabled
+		,notification_digest_hour = :notification_digest_hour
+		,notification_digest_timezone = :notification_digest_timezone
+		,notification_digest_idle_days = :notification_digest_idle_days
+	RETURNING notification_digest_created, notification_digest_last_sent`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotificationDigest(digest))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&digest.Created, &digest.LastSent); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastSent updates the time when the last digest was sent to the principal.
+func (s *NotificationDigestStore) UpdateLastSent(ctx context.Context, principalID int64, lastSent int64) error {
+	const sqlQuery = `
+	UPDATE notification_digests
+	SET notification_digest_last_sent = $1
+	WHERE notification_digest_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, lastSent, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification digest last sent time")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapToInternalNotificationDigest(v *types.NotificationDigest) *notificationDigest {
+	return &notificationDigest{
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		Updated:     v.Updated,
+		Enabled:     v.Enabled,
+		Hour:        v.Hour,
+		Timezone:    v.Timezone,
+		IdleDays:    v.IdleDays,
+		LastSent:    v.LastSent,
+	}
+}
+
+func mapNotificationDigest(v *notificationDigest) *types.NotificationDigest {
+	return &types.NotificationDigest{
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		Updated:     v.Updated,
+		Enabled:     v.Enabled,
+		Hour:        v.Hour,
+		Timezone:    v.Timezone,
+		IdleDays:    v.IdleDays,
+		LastSent:    v.LastSent,
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreference struct {
+	ID          int64                    `db:"notification_preference_id"`
+	PrincipalID int64                    `db:"notification_preference_principal_id"`
+	Created     int64                    `db:"notification_preference_created"`
+	Updated     int64                    `db:"notification_preference_updated"`
+	Scope       enum.NotificationScope   `db:"notification_preference_scope"`
+	ScopeID     int64                    `db:"notification_preference_scope_id"`
+	Event       enum.NotificationEvent   `db:"notification_preference_event"`
+	Channel     enum.NotificationChannel `db:"notification_preference_channel"`
+	Enabled     bool                     `db:"notification_preference_enabled"`
+}
+
+const (
+	notificationPreferenceColumns = `
+		 notification_preference_id
+		,notification_preference_principal_id
+		,notification_preference_created
+		,notification_preference_updated
+		,notification_preference_scope
+		,notification_preference_scope_id
+		,notification_preference_event
+		,notification_preference_channel
+		,notification_preference_enabled`
+)
+
+// List returns all notification preferences of the principal.
+func (s *NotificationPreferenceStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]*types.NotificationPreference, error) {
+	const sqlQuery = `
+	SELECT` + notificationPreferenceColumns + `
+	FROM notification_preferences
+	WHERE notification_preference_principal_id = $1
+	ORDER BY notification_preference_scope, notification_preference_scope_id,
+		notification_preference_event, notification_preference_channel`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences")
+	}
+
+	return mapNotificationPreferences(dst), nil
+}
+
+// ListByEvent returns the notification preferences of the principals for the provided event.
+func (s *NotificationPreferenceStore) ListByEvent(
+	ctx context.Context,
+	principalIDs []int64,
+	event enum.NotificationEvent,
+) ([]*types.NotificationPreference, error) {
+	if len(principalIDs) == 0 {
+		return []*types.NotificationPreference{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where(squirrel.Eq{"notification_preference_principal_id": principalIDs}).
+		Where("notification_preference_event = ?", event)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences by event")
+	}
+
+	return mapNotificationPreferences(dst), nil
+}
+
+// Upsert creates or updates the notification preference of the principal.
+func (s *NotificationPreferenceStore) Upsert(ctx context.Context, pref *types.NotificationPreference) error {
+	const sqlQuery = `
+	INSERT INTO notification_preferences (
+		 notification_preference_principal_id
+		,notification_preference_created
+		,notification_preference_updated
+		,notification_preference_scope
+		,notification_preference_scope_id
+		,notification_preference_event
+		,notification_preference_channel
+		,notification_preference_enabled
+	) VALUES (
+		 :notification_preference_principal_id
+		,:notification_preference_created
+		,:notification_preference_updated
+		,:notification_preference_scope
+		,:notification_preference_scope_id
+		,:notification_preference_event
+		,:notification_preference_channel
+		,:notification_preference_enabled
+	)
+	ON CONFLICT (
+		 notification_preference_principal_id
+		,notification_preference_scope
+		,notification_preference_scope_id
+		,notification_preference_event
+		,notification_preference_channel
+	) DO
+	UPDATE SET
+		 notification_preference_updated = :notification_preference_updated
+		,notification_preference_enabled = :notification_preference_enabled
+	RETURNING notification_preference_id, notification_preference_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotificationPreference(pref))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preference object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pref.ID, &pref.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the notification preference of the principal.
+func (s *NotificationPreferenceStore) Delete(ctx context.Context, principalID, id int64) error {
+	const sqlQuery = `
+	DELETE FROM notification_preferences
+	WHERE notification_preference_id = $1 AND notification_preference_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification preference")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapToInternalNotificationPreference(v *types.NotificationPreference) *notificationPreference {
+	return &notificationPreference{
+		ID:          v.ID,
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		Updated:     v.Updated,
+		Scope:       v.Scope,
+		ScopeID:     v.ScopeID,
+		Event:       v.Event,
+		Channel:     v.Channel,
+		Enabled:     v.Enabled,
+	}
+}
+
+func mapNotificationPreference(v *notificationPreference) *types.NotificationPreference {
+	return &types.NotificationPreference{
+		ID:          v.ID,
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		Updated:     v.Updated,
+		Scope:       v.Scope,
+		ScopeID:     v.ScopeID,
+		Event:       v.Event,
+		Channel:     v.Channel,
+		Enabled:     v.Enabled,
+	}
+}
+
+func mapNotificationPreferences(v []*notificationPreference) []*types.NotificationPreference {
+	m := make([]*types.NotificationPreference, len(v))
+	for i, pref := range v {
+		m[i] = mapNotificationPreference(pref)
+	}
+	return m
+}
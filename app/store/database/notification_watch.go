@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationWatchStore = (*NotificationWatchStore)(nil)
+
+// NewNotificationWatchStore returns a new NotificationWatchStore.
+func NewNotificationWatchStore(db *sqlx.DB) *NotificationWatchStore {
+	return &NotificationWatchStore{
+		db: db,
+	}
+}
+
+// NotificationWatchStore implements store.NotificationWatchStore backed by a relational database.
+type NotificationWatchStore struct {
+	db *sqlx.DB
+}
+
+type notificationWatch struct {
+	ID          int64  `db:"notification_watch_id"`
+	PrincipalID int64  `db:"notification_watch_principal_id"`
+	RepoID      int64  `db:"notification_watch_repo_id"`
+	PullReqID   *int64 `db:"notification_watch_pullreq_id"`
+	Created     int64  `db:"notification_watch_created"`
+}
+
+const (
+	notificationWatchColumns = `
+		 notification_watch_id
+		,notification_watch_principal_id
+		,notification_watch_repo_id
+		,notification_watch_pullreq_id
+		,notification_watch_created`
+)
+
+// Find returns the watch of the principal on the repository, or on the pull request if pullReqID isn't nil.
+func (s *NotificationWatchStore) Find(
+	ctx context.Context,
+	principalID int64,
+	repoID int64,
+	pullReqID *int64,
+) (*types.NotificationWatch, error) {
+	stmt := database.Builder.
+		Select(notificationWatchColumns).
+		From("notification_watches").
+		Where("notification_watch_principal_id = ?", principalID).
+		Where("notification_watch_repo_id = ?", repoID)
+	if pullReqID == nil {
+		stmt = stmt.Where("notification_watch_pullreq_id IS NULL")
+	} else {
+		stmt = stmt.Where("notification_watch_pullreq_id = ?", *pullReqID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationWatch{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification watch")
+	}
+
+	return mapNotificationWatch(dst), nil
+}
+
+// Create stores a new watch. Creating an already existing watch isn't an error.
+func (s *NotificationWatchStore) Create(ctx context.Context, watch *types.NotificationWatch) error {
+	const sqlQuery = `
+	INSERT INTO notification_watches (
+		 notification_watch_principal_id
+		,notification_watch_repo_id
+		,notification_watch_pullreq_id
+		,notification_watch_created
+	) VALUES (
+		 :notification_watch_principal_id
+		,:notification_watch_repo_id
+		,:notification_watch_pullreq_id
+		,:notification_watch_created
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotificationWatch(watch))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification watch object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the watch of the principal on the repository, or on the pull request if pullReqID isn't nil.
+func (s *NotificationWatchStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	repoID int64,
+	pullReqID *int64,
+) error {
+	stmt := database.Builder.
+		Delete("notification_watches").
+		Where("notification_watch_principal_id = ?", principalID).
+		Where("notification_watch_repo_id = ?", repoID)
+	if pullReqID == nil {
+		stmt = stmt.Where("notification_watch_pullreq_id IS NULL")
+	} else {
+		stmt = stmt.Where("notification_watch_pullreq_id = ?", *pullReqID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification watch")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns IDs of the principals watching the repository or the pull request.
+func (s *NotificationWatchStore) ListPrincipalIDs(ctx context.Context, repoID, pullReqID int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT DISTINCT notification_watch_principal_id
+	FROM notification_watches
+	WHERE notification_watch_repo_id = $1 AND
+		(notification_watch_pullreq_id IS NULL OR notification_watch_pullreq_id = $2)
+	ORDER BY notification_watch_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var ids []int64
+	if err := db.SelectContext(ctx, &ids, sqlQuery, repoID, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list watching principals")
+	}
+
+	return ids, nil
+}
+
+func mapToInternalNotificationWatch(v *types.NotificationWatch) *notificationWatch {
+	return &notificationWatch{
+		ID:          v.ID,
+		PrincipalID: v.PrincipalID,
+		RepoID:      v.RepoID,
+		PullReqID:   v.PullReqID,
+		Created:     v.Created,
+	}
+}
+
+func mapNotificationWatch(v *notificationWatch) *types.NotificationWatch {
+	return &types.NotificationWatch{
+		ID:          v.ID,
+		PrincipalID: v.PrincipalID,
+		RepoID:      v.RepoID,
+		PullReqID:   v.PullReqID,
+		Created:     v.Created,
+	}
+}
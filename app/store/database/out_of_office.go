@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OutOfOfficeStore = (*OutOfOfficeStore)(nil)
+
+// NewOutOfOfficeStore returns a new OutOfOfficeStore.
+func NewOutOfOfficeStore(db *sqlx.DB) *OutOfOfficeStore {
+	return &OutOfOfficeStore{
+		db: db,
+	}
+}
+
+// OutOfOfficeStore implements store.OutOfOfficeStore backed by a relational database.
+type OutOfOfficeStore struct {
+	db *sqlx.DB
+}
+
+type outOfOffice struct {
+	PrincipalID int64  `db:"out_of_office_principal_id"`
+	Created     int64  `db:"out_of_office_created"`
+	Updated     int64  `db:"out_of_office_updated"`
+	From        int64  `db:"out_of_office_from"`
+	Until       int64  `db:"out_of_office_until"`
+	Message     string `db:"out_of_office_message"`
+}
+
+const (
+	outOfOfficeColumns = `
+		 out_of_office_principal_id
+		,out_of_office_created
+		,out_of_office_updated
+		,out_of_office_from
+		,out_of_office_until
+		,out_of_office_message`
+)
+
+// Find returns the absence of the principal.
+func (s *OutOfOfficeStore) Find(ctx context.Context, principalID int64) (*types.OutOfOffice, error) {
+	const sqlQuery = `
+	SELECT` + outOfOfficeColumns + `
+	FROM out_of_office
+	WHERE out_of_office_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &outOfOffice{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find out of office")
+	}
+
+	return mapOutOfOffice(dst), nil
+}
+
+// Upsert creates or updates the absence of the principal.
+func (s *OutOfOfficeStore) Upsert(ctx context.Context, ooo *types.OutOfOffice) error {
+	const sqlQuery = `
+	INSERT INTO out_of_office (` + outOfOfficeColumns + `
+	) VALUES (
+		 :out_of_office_principal_id
+		,:out_of_office_created
+		,:out_of_office_updated
+		,:out_of_office_from
+		,:out_of_office_until
+		,:out_of_office_message
+	)
+	ON CONFLICT (out_of_office_principal_id) DO
+	UPDATE SET
+		 out_of_office_updated = :out_of_office_updated
+		,out_of_office_from = :out_of_office_from
+		,out_of_office_until = :out_of_office_until
+		,out_of_office_message = :out_of_office_message
+	RETURNING out_of_office_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOutOfOffice(ooo))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind out of office object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&ooo.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the absence of the principal.
+func (s *OutOfOfficeStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM out_of_office
+	WHERE out_of_office_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete out of office")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListActive returns IDs of the provided principals who are out of office at the provided time.
+func (s *OutOfOfficeStore) ListActive(ctx context.Context, principalIDs []int64, now int64) ([]int64, error) {
+	if len(principalIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("out_of_office_principal_id").
+		From("out_of_office").
+		Where(squirrel.Eq{"out_of_office_principal_id": principalIDs}).
+		Where("out_of_office_from <= ?", now).
+		Where("(out_of_office_until = 0 OR out_of_office_until > ?)", now)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	ids := make([]int64, 0)
+	if err = db.SelectContext(ctx, &ids, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list active out of office entries")
+	}
+
+	return ids, nil
+}
+
+func mapToInternalOutOfOffice(v *types.OutOfOffice) *outOfOffice {
+	return &outOfOffice{
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		Updated:     v.Updated,
+		From:        v.From,
+		Until:       v.Until,
+		Message:     v.Message,
+	}
+}
+
+func mapOutOfOffice(v *outOfOffice) *types.OutOfOffice {
+	return &types.OutOfOffice{
+		PrincipalID: v.PrincipalID,
+		Created:     v.Created,
+		Updated:     v.Updated,
+		From:        v.From,
+		Until:       v.Until,
+		Message:     v.Message,
+	}
+}
@@ -718,6 +718,7 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 	if opts.MentionedID > 0 {
 		*stmt = stmt.InnerJoin("pullreq_activities act_ment ON act_ment.pullreq_activity_pullreq_id = pullreq_id")
 		*stmt = stmt.Where("act_ment.pullreq_activity_deleted IS NULL")
+		*stmt = stmt.Where("act_ment.pullreq_activity_pending = FALSE")
 		*stmt = stmt.Where("(" +
 			"act_ment.pullreq_activity_kind = '" + string(enum.PullReqActivityKindComment) + "' OR " +
 			"act_ment.pullreq_activity_kind = '" + string(enum.PullReqActivityKindChangeComment) + "')")
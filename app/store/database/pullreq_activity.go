@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/harness/gitness/app/store"
@@ -91,6 +92,8 @@ type pullReqActivity struct {
 	CodeCommentSpanNew      null.Int    `db:"pullreq_activity_code_comment_span_new"`
 	CodeCommentLineOld      null.Int    `db:"pullreq_activity_code_comment_line_old"`
 	CodeCommentSpanOld      null.Int    `db:"pullreq_activity_code_comment_span_old"`
+
+	Pending bool `db:"pullreq_activity_pending"`
 }
 
 const (
@@ -122,7 +125,8 @@ const (
 		,pullreq_activity_code_comment_line_new
 		,pullreq_activity_code_comment_span_new
 		,pullreq_activity_code_comment_line_old
-		,pullreq_activity_code_comment_span_old`
+		,pullreq_activity_code_comment_span_old
+		,pullreq_activity_pending`
 
 	pullreqActivitySelectBase = `
 	SELECT` + pullreqActivityColumns + `
@@ -180,6 +184,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_code_comment_span_new
 		,pullreq_activity_code_comment_line_old
 		,pullreq_activity_code_comment_span_old
+		,pullreq_activity_pending
 	) values (
 		 :pullreq_activity_version
 		,:pullreq_activity_created_by
@@ -208,6 +213,7 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_code_comment_span_new
 		,:pullreq_activity_code_comment_line_old
 		,:pullreq_activity_code_comment_span_old
+		,:pullreq_activity_pending
 	) RETURNING pullreq_activity_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -386,6 +392,8 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 		stmt = stmt.Where("pullreq_activity_created < ?", opts.Before)
 	}
 
+	stmt = applyPendingFilter(opts, stmt)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -443,7 +451,8 @@ func (s *PullReqActivityStore) ListAuthorIDs(ctx context.Context, prID int64, or
 		Select("DISTINCT pullreq_activity_created_by").
 		From("pullreq_activities").
 		Where("pullreq_activity_pullreq_id = ?", prID).
-		Where("pullreq_activity_order = ?", order)
+		Where("pullreq_activity_order = ?", order).
+		Where("pullreq_activity_pending = FALSE")
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -469,6 +478,7 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 		Where("pullreq_activity_sub_order = 0").
 		Where("pullreq_activity_resolved IS NULL").
 		Where("pullreq_activity_deleted IS NULL").
+		Where("pullreq_activity_pending = FALSE").
 		Where("pullreq_activity_kind <> ?", enum.PullReqActivityKindSystem)
 
 	sql, args, err := stmt.ToSql()
@@ -487,6 +497,66 @@ func (s *PullReqActivityStore) CountUnresolved(ctx context.Context, prID int64)
 	return count, nil
 }
 
+// PublishPending publishes all pending comments of the principal in the pull request
+// and returns the published comments.
+func (s *PullReqActivityStore) PublishPending(
+	ctx context.Context,
+	prID int64,
+	principalID int64,
+) ([]*types.PullReqActivity, error) {
+	const sqlQuery = `
+	UPDATE pullreq_activities
+	SET
+		 pullreq_activity_pending = FALSE
+		,pullreq_activity_version = pullreq_activity_version + 1
+		,pullreq_activity_updated = $1
+	WHERE pullreq_activity_pullreq_id = $2 AND
+		pullreq_activity_created_by = $3 AND
+		pullreq_activity_pending = TRUE
+	RETURNING` + pullreqActivityColumns
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReqActivity, 0)
+
+	if err := db.SelectContext(ctx, &dst, sqlQuery, time.Now().UnixMilli(), prID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to publish pending pull request comments")
+	}
+
+	sort.Slice(dst, func(i, j int) bool {
+		if dst[i].Order != dst[j].Order {
+			return dst[i].Order < dst[j].Order
+		}
+		return dst[i].SubOrder < dst[j].SubOrder
+	})
+
+	return s.mapSlicePullReqActivity(ctx, dst)
+}
+
+// DeletePending deletes all pending comments of the principal in the pull request
+// and returns the number of deleted comments.
+func (s *PullReqActivityStore) DeletePending(ctx context.Context, prID int64, principalID int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_activities
+	WHERE pullreq_activity_pullreq_id = $1 AND
+		pullreq_activity_created_by = $2 AND
+		pullreq_activity_pending = TRUE`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID, principalID)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete pending pull request comments")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count, nil
+}
+
 func mapPullReqActivity(act *pullReqActivity) (*types.PullReqActivity, error) {
 	metadata := &types.PullReqActivityMetadata{}
 	err := json.Unmarshal(act.Metadata, &metadata)
@@ -515,6 +585,7 @@ func mapPullReqActivity(act *pullReqActivity) (*types.PullReqActivity, error) {
 		Metadata:   metadata,
 		ResolvedBy: act.ResolvedBy.Ptr(),
 		Resolved:   act.Resolved.Ptr(),
+		Pending:    act.Pending,
 		Author:     types.PrincipalInfo{},
 		Resolver:   nil,
 	}
@@ -556,6 +627,7 @@ func mapInternalPullReqActivity(act *types.PullReqActivity) (*pullReqActivity, e
 		Metadata:   nil,
 		ResolvedBy: null.IntFromPtr(act.ResolvedBy),
 		Resolved:   null.IntFromPtr(act.Resolved),
+		Pending:    act.Pending,
 	}
 	if act.IsValidCodeComment() {
 		m.Outdated = null.BoolFrom(act.CodeComment.Outdated)
@@ -670,9 +742,24 @@ func applyFilter(
 		stmt = stmt.Where("pullreq_activity_created < ?", filter.Before)
 	}
 
+	stmt = applyPendingFilter(filter, stmt)
+
 	if filter.Limit > 0 {
 		stmt = stmt.Limit(database.Limit(filter.Limit))
 	}
 
 	return stmt
 }
+
+// applyPendingFilter excludes pending comments, except those authored by the principal the list is intended for.
+func applyPendingFilter(
+	filter *types.PullReqActivityFilter,
+	stmt squirrel.SelectBuilder,
+) squirrel.SelectBuilder {
+	if filter.PendingAuthorID > 0 {
+		return stmt.Where("(pullreq_activity_pending = FALSE OR pullreq_activity_created_by = ?)",
+			filter.PendingAuthorID)
+	}
+
+	return stmt.Where("pullreq_activity_pending = FALSE")
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullReqActivityStore_Pending(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullReqStore := database.NewPullReqStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+
+	now := time.Now().UnixMilli()
+	pr := &types.PullReq{
+		Number:       1,
+		CreatedBy:    userID,
+		Created:      now,
+		Updated:      now,
+		State:        enum.PullReqStateOpen,
+		Title:        "title",
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+	}
+	require.NoError(t, pullReqStore.Create(ctx, pr))
+
+	createComment := func(order int64, pending bool) *types.PullReqActivity {
+		act := &types.PullReqActivity{
+			CreatedBy: userID,
+			Created:   now,
+			Updated:   now,
+			Edited:    now,
+			RepoID:    pr.TargetRepoID,
+			PullReqID: pr.ID,
+			Order:     order,
+			Type:      enum.PullReqActivityTypeComment,
+			Kind:      enum.PullReqActivityKindComment,
+			Text:      "comment",
+			Pending:   pending,
+		}
+		require.NoError(t, act.SetPayload(types.PullRequestActivityPayloadComment{}))
+		require.NoError(t, activityStore.Create(ctx, act))
+		return act
+	}
+
+	published := createComment(1, false)
+	pending1 := createComment(2, true)
+	pending2 := createComment(3, true)
+
+	list, err := activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, published.ID, list[0].ID)
+
+	list, err = activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{PendingAuthorID: userID})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+
+	unresolved, err := activityStore.CountUnresolved(ctx, pr.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, unresolved)
+
+	comments, err := activityStore.PublishPending(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	require.Equal(t, pending1.ID, comments[0].ID)
+	require.Equal(t, pending2.ID, comments[1].ID)
+	require.False(t, comments[0].Pending)
+
+	list, err = activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+
+	createComment(4, true)
+
+	count, err := activityStore.DeletePending(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	count, err = activityStore.DeletePending(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
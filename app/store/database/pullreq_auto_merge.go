@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+var _ store.PullReqAutoMergeStore = (*PullReqAutoMergeStore)(nil)
+
+// NewPullReqAutoMergeStore returns a new PullReqAutoMergeStore.
+func NewPullReqAutoMergeStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *PullReqAutoMergeStore {
+	return &PullReqAutoMergeStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// PullReqAutoMergeStore implements store.PullReqAutoMergeStore backed by a relational database.
+type PullReqAutoMergeStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type pullReqAutoMerge struct {
+	PullReqID    int64            `db:"pullreq_auto_merge_pullreq_id"`
+	CreatedBy    int64            `db:"pullreq_auto_merge_created_by"`
+	Created      int64            `db:"pullreq_auto_merge_created"`
+	Updated      int64            `db:"pullreq_auto_merge_updated"`
+	MergeMethod  enum.MergeMethod `db:"pullreq_auto_merge_merge_method"`
+	Title        string           `db:"pullreq_auto_merge_title"`
+	Message      string           `db:"pullreq_auto_merge_message"`
+	CancelOnPush bool             `db:"pullreq_auto_merge_cancel_on_push"`
+}
+
+const (
+	pullReqAutoMergeColumns = `
+		 pullreq_auto_merge_pullreq_id
+		,pullreq_auto_merge_created_by
+		,pullreq_auto_merge_created
+		,pullreq_auto_merge_updated
+		,pullreq_auto_merge_merge_method
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message
+		,pullreq_auto_merge_cancel_on_push`
+)
+
+// Find returns the armed auto-merge of the pull request.
+func (s *PullReqAutoMergeStore) Find(ctx context.Context, pullReqID int64) (*types.PullReqAutoMerge, error) {
+	const sqlQuery = `
+	SELECT` + pullReqAutoMergeColumns + `
+	FROM pullreq_auto_merges
+	WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqAutoMerge{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request auto-merge")
+	}
+
+	return s.mapPullReqAutoMerge(ctx, dst), nil
+}
+
+// Upsert arms the auto-merge of a pull request or updates an already armed one.
+func (s *PullReqAutoMergeStore) Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_auto_merges (
+		 pullreq_auto_merge_pullreq_id
+		,pullreq_auto_merge_created_by
+		,pullreq_auto_merge_created
+		,pullreq_auto_merge_updated
+		,pullreq_auto_merge_merge_method
+		,pullreq_auto_merge_title
+		,pullreq_auto_merge_message
+		,pullreq_auto_merge_cancel_on_push
+	) VALUES (
+		 :pullreq_auto_merge_pullreq_id
+		,:pullreq_auto_merge_created_by
+		,:pullreq_auto_merge_created
+		,:pullreq_auto_merge_updated
+		,:pullreq_auto_merge_merge_method
+		,:pullreq_auto_merge_title
+		,:pullreq_auto_merge_message
+		,:pullreq_auto_merge_cancel_on_push
+	)
+	ON CONFLICT (pullreq_auto_merge_pullreq_id) DO
+	UPDATE SET
+		 pullreq_auto_merge_created_by = :pullreq_auto_merge_created_by
+		,pullreq_auto_merge_updated = :pullreq_auto_merge_updated
+		,pullreq_auto_merge_merge_method = :pullreq_auto_merge_merge_method
+		,pullreq_auto_merge_title = :pullreq_auto_merge_title
+		,pullreq_auto_merge_message = :pullreq_auto_merge_message
+		,pullreq_auto_merge_cancel_on_push = :pullreq_auto_merge_cancel_on_push
+	RETURNING pullreq_auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqAutoMerge(autoMerge))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request auto-merge object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&autoMerge.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete disarms the auto-merge of the pull request.
+func (s *PullReqAutoMergeStore) Delete(ctx context.Context, pullReqID int64) error {
+	const sqlQuery = `
+	DELETE FROM pullreq_auto_merges
+	WHERE pullreq_auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullReqID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request auto-merge")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListPullReqNumbersBySourceSHA returns numbers of open pull requests of the target repository
+// with the provided source SHA that have auto-merge armed.
+func (s *PullReqAutoMergeStore) ListPullReqNumbersBySourceSHA(
+	ctx context.Context,
+	repoID int64,
+	sourceSHA string,
+) ([]int64, error) {
+	stmt := database.Builder.
+		Select("pullreq_number").
+		From("pullreq_auto_merges").
+		InnerJoin("pullreqs ON pullreq_id = pullreq_auto_merge_pullreq_id").
+		Where("pullreq_target_repo_id = ?", repoID).
+		Where("pullreq_source_sha = ?", sourceSHA).
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		OrderBy("pullreq_number")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var numbers []int64
+	if err = db.SelectContext(ctx, &numbers, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull requests with auto-merge")
+	}
+
+	return numbers, nil
+}
+
+func mapToInternalPullReqAutoMerge(v *types.PullReqAutoMerge) *pullReqAutoMerge {
+	return &pullReqAutoMerge{
+		PullReqID:    v.PullReqID,
+		CreatedBy:    v.CreatedBy,
+		Created:      v.Created,
+		Updated:      v.Updated,
+		MergeMethod:  v.MergeMethod,
+		Title:        v.Title,
+		Message:      v.Message,
+		CancelOnPush: v.CancelOnPush,
+	}
+}
+
+func (s *PullReqAutoMergeStore) mapPullReqAutoMerge(
+	ctx context.Context,
+	v *pullReqAutoMerge,
+) *types.PullReqAutoMerge {
+	m := &types.PullReqAutoMerge{
+		PullReqID:    v.PullReqID,
+		CreatedBy:    v.CreatedBy,
+		Created:      v.Created,
+		Updated:      v.Updated,
+		MergeMethod:  v.MergeMethod,
+		Title:        v.Title,
+		Message:      v.Message,
+		CancelOnPush: v.CancelOnPush,
+	}
+
+	author, err := s.pCache.Get(ctx, v.CreatedBy)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to load pull request auto-merge author")
+	}
+	if author != nil {
+		m.Author = *author
+	}
+
+	return m
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqReactionStore = (*PullReqReactionStore)(nil)
+
+// NewPullReqReactionStore returns a new PullReqReactionStore.
+func NewPullReqReactionStore(db *sqlx.DB) *PullReqReactionStore {
+	return &PullReqReactionStore{
+		db: db,
+	}
+}
+
+// PullReqReactionStore implements store.PullReqReactionStore backed by a relational database.
+type PullReqReactionStore struct {
+	db *sqlx.DB
+}
+
+type pullReqReaction struct {
+	PullReqID   int64                `db:"pullreq_reaction_pullreq_id"`
+	ActivityID  *int64               `db:"pullreq_reaction_activity_id"`
+	PrincipalID int64                `db:"pullreq_reaction_principal_id"`
+	Reaction    enum.PullReqReaction `db:"pullreq_reaction_reaction"`
+	Created     int64                `db:"pullreq_reaction_created"`
+}
+
+type pullReqReactionSummary struct {
+	ActivityID int64                `db:"activity_id"`
+	Reaction   enum.PullReqReaction `db:"pullreq_reaction_reaction"`
+	Count      int64                `db:"reaction_count"`
+	Reacted    bool                 `db:"reacted"`
+}
+
+// Create stores a new reaction. Creating an already existing reaction isn't an error.
+func (s *PullReqReactionStore) Create(ctx context.Context, reaction *types.PullReqReaction) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_reactions (
+		 pullreq_reaction_pullreq_id
+		,pullreq_reaction_activity_id
+		,pullreq_reaction_principal_id
+		,pullreq_reaction_reaction
+		,pullreq_reaction_created
+	) VALUES (
+		 :pullreq_reaction_pullreq_id
+		,:pullreq_reaction_activity_id
+		,:pullreq_reaction_principal_id
+		,:pullreq_reaction_reaction
+		,:pullreq_reaction_created
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqReaction(reaction))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request reaction object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the reaction of the principal.
+func (s *PullReqReactionStore) Delete(ctx context.Context, reaction *types.PullReqReaction) error {
+	stmt := database.Builder.
+		Delete("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", reaction.PullReqID).
+		Where("pullreq_reaction_principal_id = ?", reaction.PrincipalID).
+		Where("pullreq_reaction_reaction = ?", reaction.Reaction)
+	if reaction.ActivityID == nil {
+		stmt = stmt.Where("pullreq_reaction_activity_id IS NULL")
+	} else {
+		stmt = stmt.Where("pullreq_reaction_activity_id = ?", *reaction.ActivityID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pull request reaction")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListSummaries returns the reactions on the pull request aggregated by the comment ID and the reaction.
+func (s *PullReqReactionStore) ListSummaries(
+	ctx context.Context,
+	pullReqID int64,
+	principalID int64,
+) (map[int64][]types.PullReqReactionSummary, error) {
+	const sqlQuery = `
+	SELECT
+		 COALESCE(pullreq_reaction_activity_id, 0) AS activity_id
+		,pullreq_reaction_reaction
+		,COUNT(*) AS reaction_count
+		,MAX(CASE WHEN pullreq_reaction_principal_id = $2 THEN 1 ELSE 0 END) = 1 AS reacted
+	FROM pullreq_reactions
+	WHERE pullreq_reaction_pullreq_id = $1
+	GROUP BY COALESCE(pullreq_reaction_activity_id, 0), pullreq_reaction_reaction
+	ORDER BY MIN(pullreq_reaction_created)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqReactionSummary
+	if err := db.SelectContext(ctx, &dst, sqlQuery, pullReqID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request reactions")
+	}
+
+	summaries := make(map[int64][]types.PullReqReactionSummary)
+	for _, v := range dst {
+		summaries[v.ActivityID] = append(summaries[v.ActivityID], types.PullReqReactionSummary{
+			Reaction: v.Reaction,
+			Count:    v.Count,
+			Reacted:  v.Reacted,
+		})
+	}
+
+	return summaries, nil
+}
+
+func mapToInternalPullReqReaction(v *types.PullReqReaction) *pullReqReaction {
+	return &pullReqReaction{
+		PullReqID:   v.PullReqID,
+		ActivityID:  v.ActivityID,
+		PrincipalID: v.PrincipalID,
+		Reaction:    v.Reaction,
+		Created:     v.Created,
+	}
+}
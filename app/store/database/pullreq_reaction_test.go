@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullReqReactionStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	const otherUserID = 2
+	require.NoError(t, principalStore.CreateUser(ctx,
+		&types.User{ID: otherUserID, UID: "user_2", Email: "user_2@example.com"}))
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	pullReqStore := database.NewPullReqStore(db, pCache)
+	activityStore := database.NewPullReqActivityStore(db, pCache)
+	reactionStore := database.NewPullReqReactionStore(db)
+
+	now := time.Now().UnixMilli()
+	pr := &types.PullReq{
+		Number:       1,
+		CreatedBy:    userID,
+		Created:      now,
+		Updated:      now,
+		State:        enum.PullReqStateOpen,
+		Title:        "title",
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+	}
+	require.NoError(t, pullReqStore.Create(ctx, pr))
+
+	comment := &types.PullReqActivity{
+		CreatedBy: userID,
+		Created:   now,
+		Updated:   now,
+		Edited:    now,
+		RepoID:    pr.TargetRepoID,
+		PullReqID: pr.ID,
+		Order:     1,
+		Type:      enum.PullReqActivityTypeComment,
+		Kind:      enum.PullReqActivityKindComment,
+		Text:      "comment",
+	}
+	require.NoError(t, comment.SetPayload(types.PullRequestActivityPayloadComment{}))
+	require.NoError(t, activityStore.Create(ctx, comment))
+
+	// the summaries are ordered by the time of the first reaction.
+	created := now
+	react := func(activityID *int64, principalID int64, reaction enum.PullReqReaction) *types.PullReqReaction {
+		created++
+		return &types.PullReqReaction{
+			PullReqID:   pr.ID,
+			ActivityID:  activityID,
+			PrincipalID: principalID,
+			Reaction:    reaction,
+			Created:     created,
+		}
+	}
+
+	// adding the same reaction twice is not an error and is stored only once,
+	// both on the pull request description and on the comment.
+	for range 2 {
+		require.NoError(t, reactionStore.Create(ctx, react(nil, userID, enum.PullReqReactionThumbsUp)))
+		require.NoError(t, reactionStore.Create(ctx, react(&comment.ID, userID, enum.PullReqReactionThumbsUp)))
+	}
+
+	require.NoError(t, reactionStore.Create(ctx, react(&comment.ID, otherUserID, enum.PullReqReactionThumbsUp)))
+	require.NoError(t, reactionStore.Create(ctx, react(&comment.ID, otherUserID, enum.PullReqReactionHeart)))
+
+	summaries, err := reactionStore.ListSummaries(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]types.PullReqReactionSummary{
+		0: {
+			{Reaction: enum.PullReqReactionThumbsUp, Count: 1, Reacted: true},
+		},
+		comment.ID: {
+			{Reaction: enum.PullReqReactionThumbsUp, Count: 2, Reacted: true},
+			{Reaction: enum.PullReqReactionHeart, Count: 1, Reacted: false},
+		},
+	}, summaries)
+
+	require.NoError(t, reactionStore.Delete(ctx, react(&comment.ID, userID, enum.PullReqReactionThumbsUp)))
+	err = reactionStore.Delete(ctx, react(&comment.ID, userID, enum.PullReqReactionThumbsUp))
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	require.NoError(t, reactionStore.Delete(ctx, react(nil, userID, enum.PullReqReactionThumbsUp)))
+	err = reactionStore.Delete(ctx, react(nil, userID, enum.PullReqReactionThumbsUp))
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	summaries, err = reactionStore.ListSummaries(ctx, pr.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]types.PullReqReactionSummary{
+		comment.ID: {
+			{Reaction: enum.PullReqReactionThumbsUp, Count: 1, Reacted: false},
+			{Reaction: enum.PullReqReactionHeart, Count: 1, Reacted: false},
+		},
+	}, summaries)
+}
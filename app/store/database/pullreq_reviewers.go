@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -219,6 +220,54 @@ func (s *PullReqReviewerStore) List(ctx context.Context, prID int64) ([]*types.P
 	return result, nil
 }
 
+// ListAssignmentStats returns the reviewer assignment statistics of the provided principals.
+func (s *PullReqReviewerStore) ListAssignmentStats(
+	ctx context.Context,
+	principalIDs []int64,
+) (map[int64]types.ReviewerAssignmentStats, error) {
+	if len(principalIDs) == 0 {
+		return map[int64]types.ReviewerAssignmentStats{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("pullreq_reviewer_principal_id").
+		Column("MAX(CASE WHEN pullreq_reviewer_type = ? THEN pullreq_reviewer_created ELSE 0 END) AS last_assigned",
+			enum.PullReqReviewerTypeDefault).
+		Column("SUM(CASE WHEN pullreq_state = ? AND pullreq_reviewer_review_decision = ? THEN 1 ELSE 0 END) AS open_reviews",
+			enum.PullReqStateOpen, enum.PullReqReviewDecisionPending).
+		From("pullreq_reviewers").
+		InnerJoin("pullreqs ON pullreq_id = pullreq_reviewer_pullreq_id").
+		Where(squirrel.Eq{"pullreq_reviewer_principal_id": principalIDs}).
+		GroupBy("pullreq_reviewer_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert reviewer assignment stats query to sql")
+	}
+
+	var dst []struct {
+		PrincipalID  int64 `db:"pullreq_reviewer_principal_id"`
+		LastAssigned int64 `db:"last_assigned"`
+		OpenReviews  int64 `db:"open_reviews"`
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing reviewer assignment stats query")
+	}
+
+	result := make(map[int64]types.ReviewerAssignmentStats, len(dst))
+	for _, v := range dst {
+		result[v.PrincipalID] = types.ReviewerAssignmentStats{
+			LastAssigned: v.LastAssigned,
+			OpenReviews:  v.OpenReviews,
+		}
+	}
+
+	return result, nil
+}
+
 func mapPullReqReviewer(v *pullReqReviewer) *types.PullReqReviewer {
 	m := &types.PullReqReviewer{
 		PullReqID:      v.PullReqID,
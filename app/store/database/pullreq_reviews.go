@@ -84,6 +84,27 @@ func (s *PullReqReviewStore) Find(ctx context.Context, id int64) (*types.PullReq
 	return mapPullReqReview(dst), nil
 }
 
+// List returns all reviews of the pull request, ordered from the oldest to the newest.
+func (s *PullReqReviewStore) List(ctx context.Context, prID int64) ([]*types.PullReqReview, error) {
+	const sqlQuery = pullreqReviewSelectBase + `
+	WHERE pullreq_review_pullreq_id = $1
+	ORDER BY pullreq_review_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReqReview, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, prID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request reviews")
+	}
+
+	result := make([]*types.PullReqReview, len(dst))
+	for i, v := range dst {
+		result[i] = mapPullReqReview(v)
+	}
+
+	return result, nil
+}
+
 // Create creates a new pull request.
 func (s *PullReqReviewStore) Create(ctx context.Context, v *types.PullReqReview) error {
 	const sqlQuery = `
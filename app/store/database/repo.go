@@ -771,6 +771,79 @@ func (s *RepoStore) ListAll(
 	return s.mapToRepos(ctx, dst)
 }
 
+// CountForks returns the number of forks of the repository.
+func (s *RepoStore) CountForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count forks query")
+	}
+	return count, nil
+}
+
+// ListForks returns the forks of the repository.
+func (s *RepoStore) ListForks(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", repoID)
+
+	stmt = applyQueryFilter(stmt, filter)
+	stmt = applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// DetachForks removes the fork lineage of all forks of the repository.
+func (s *RepoStore) DetachForks(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+		UPDATE repositories
+		SET repo_fork_id = 0
+		WHERE repo_fork_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to detach forks")
+	}
+
+	return nil
+}
+
 func (s *RepoStore) mapToRepo(
 	ctx context.Context,
 	in *repository,
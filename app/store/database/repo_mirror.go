@@ -0,0 +1,284 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoMirrorStore = (*RepoMirrorStore)(nil)
+
+// NewRepoMirrorStore returns a new RepoMirrorStore.
+func NewRepoMirrorStore(db *sqlx.DB) *RepoMirrorStore {
+	return &RepoMirrorStore{
+		db: db,
+	}
+}
+
+// RepoMirrorStore implements store.RepoMirrorStore backed by a relational database.
+type RepoMirrorStore struct {
+	db *sqlx.DB
+}
+
+type repoMirror struct {
+	RepoID                   int64                    `db:"repo_mirror_repo_id"`
+	CreatedBy                int64                    `db:"repo_mirror_created_by"`
+	Created                  int64                    `db:"repo_mirror_created"`
+	Updated                  int64                    `db:"repo_mirror_updated"`
+	URL                      string                   `db:"repo_mirror_url"`
+	Username                 string                   `db:"repo_mirror_username"`
+	PasswordSecretSpaceID    null.Int                 `db:"repo_mirror_password_secret_space_id"`
+	PasswordSecretIdentifier string                   `db:"repo_mirror_password_secret_identifier"`
+	Interval                 int64                    `db:"repo_mirror_interval"`
+	SyncState                enum.RepoMirrorSyncState `db:"repo_mirror_sync_state"`
+	LastSync                 int64                    `db:"repo_mirror_last_sync"`
+	LastSuccess              int64                    `db:"repo_mirror_last_success"`
+	LastError                string                   `db:"repo_mirror_last_error"`
+	NextSync                 int64                    `db:"repo_mirror_next_sync"`
+}
+
+const (
+	repoMirrorColumns = `
+		 repo_mirror_repo_id
+		,repo_mirror_created_by
+		,repo_mirror_created
+		,repo_mirror_updated
+		,repo_mirror_url
+		,repo_mirror_username
+		,repo_mirror_password_secret_space_id
+		,repo_mirror_password_secret_identifier
+		,repo_mirror_interval
+		,repo_mirror_sync_state
+		,repo_mirror_last_sync
+		,repo_mirror_last_success
+		,repo_mirror_last_error
+		,repo_mirror_next_sync`
+)
+
+// Find returns the mirror configuration of the repository.
+func (s *RepoMirrorStore) Find(ctx context.Context, repoID int64) (*types.RepoMirror, error) {
+	const sqlQuery = `
+	SELECT` + repoMirrorColumns + `
+	FROM repo_mirrors
+	WHERE repo_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repository mirror")
+	}
+
+	return mapRepoMirror(dst), nil
+}
+
+// Create stores the mirror configuration of a repository.
+func (s *RepoMirrorStore) Create(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+	INSERT INTO repo_mirrors (` + repoMirrorColumns + `
+	) VALUES (
+		 :repo_mirror_repo_id
+		,:repo_mirror_created_by
+		,:repo_mirror_created
+		,:repo_mirror_updated
+		,:repo_mirror_url
+		,:repo_mirror_username
+		,:repo_mirror_password_secret_space_id
+		,:repo_mirror_password_secret_identifier
+		,:repo_mirror_interval
+		,:repo_mirror_sync_state
+		,:repo_mirror_last_sync
+		,:repo_mirror_last_success
+		,:repo_mirror_last_error
+		,:repo_mirror_next_sync
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository mirror object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the mirror configuration of the repository.
+func (s *RepoMirrorStore) Update(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+	UPDATE repo_mirrors
+	SET
+		 repo_mirror_updated = :repo_mirror_updated
+		,repo_mirror_url = :repo_mirror_url
+		,repo_mirror_username = :repo_mirror_username
+		,repo_mirror_password_secret_space_id = :repo_mirror_password_secret_space_id
+		,repo_mirror_password_secret_identifier = :repo_mirror_password_secret_identifier
+		,repo_mirror_interval = :repo_mirror_interval
+		,repo_mirror_next_sync = :repo_mirror_next_sync
+	WHERE repo_mirror_repo_id = :repo_mirror_repo_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+// UpdateSyncStatus updates the synchronization status of the mirror.
+func (s *RepoMirrorStore) UpdateSyncStatus(ctx context.Context, mirror *types.RepoMirror) error {
+	const sqlQuery = `
+	UPDATE repo_mirrors
+	SET
+		 repo_mirror_sync_state = :repo_mirror_sync_state
+		,repo_mirror_last_sync = :repo_mirror_last_sync
+		,repo_mirror_last_success = :repo_mirror_last_success
+		,repo_mirror_last_error = :repo_mirror_last_error
+		,repo_mirror_next_sync = :repo_mirror_next_sync
+	WHERE repo_mirror_repo_id = :repo_mirror_repo_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+func (s *RepoMirrorStore) update(ctx context.Context, sqlQuery string, mirror *types.RepoMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repository mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the mirror configuration of the repository.
+func (s *RepoMirrorStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_mirrors
+	WHERE repo_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repository mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListDue returns the mirrors which should be synchronized before the provided time.
+func (s *RepoMirrorStore) ListDue(ctx context.Context, before int64, limit int) ([]*types.RepoMirror, error) {
+	stmt := database.Builder.
+		Select(repoMirrorColumns).
+		From("repo_mirrors").
+		Where("repo_mirror_next_sync <= ?", before).
+		OrderBy("repo_mirror_next_sync").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoMirror
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list due repository mirrors")
+	}
+
+	mirrors := make([]*types.RepoMirror, len(dst))
+	for i := range dst {
+		mirrors[i] = mapRepoMirror(dst[i])
+	}
+
+	return mirrors, nil
+}
+
+func mapToInternalRepoMirror(v *types.RepoMirror) *repoMirror {
+	m := &repoMirror{
+		RepoID:                   v.RepoID,
+		CreatedBy:                v.CreatedBy,
+		Created:                  v.Created,
+		Updated:                  v.Updated,
+		URL:                      v.URL,
+		Username:                 v.Username,
+		PasswordSecretIdentifier: v.PasswordSecretIdentifier,
+		Interval:                 v.Interval,
+		SyncState:                v.SyncState,
+		LastSync:                 v.LastSync,
+		LastSuccess:              v.LastSuccess,
+		LastError:                v.LastError,
+		NextSync:                 v.NextSync,
+	}
+
+	if v.PasswordSecretSpaceID != 0 {
+		m.PasswordSecretSpaceID = null.IntFrom(v.PasswordSecretSpaceID)
+	}
+
+	return m
+}
+
+func mapRepoMirror(v *repoMirror) *types.RepoMirror {
+	return &types.RepoMirror{
+		RepoID:                   v.RepoID,
+		CreatedBy:                v.CreatedBy,
+		Created:                  v.Created,
+		Updated:                  v.Updated,
+		URL:                      v.URL,
+		Username:                 v.Username,
+		PasswordSecretSpaceID:    v.PasswordSecretSpaceID.Int64,
+		PasswordSecretIdentifier: v.PasswordSecretIdentifier,
+		Interval:                 v.Interval,
+		SyncState:                v.SyncState,
+		LastSync:                 v.LastSync,
+		LastSuccess:              v.LastSuccess,
+		LastError:                v.LastError,
+		NextSync:                 v.NextSync,
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoPushMirrorStore = (*RepoPushMirrorStore)(nil)
+
+// NewRepoPushMirrorStore returns a new RepoPushMirrorStore.
+func NewRepoPushMirrorStore(db *sqlx.DB) *RepoPushMirrorStore {
+	return &RepoPushMirrorStore{
+		db: db,
+	}
+}
+
+// RepoPushMirrorStore implements store.RepoPushMirrorStore backed by a relational database.
+type RepoPushMirrorStore struct {
+	db *sqlx.DB
+}
+
+type repoPushMirror struct {
+	ID                       int64                    `db:"repo_push_mirror_id"`
+	RepoID                   int64                    `db:"repo_push_mirror_repo_id"`
+	CreatedBy                int64                    `db:"repo_push_mirror_created_by"`
+	Created                  int64                    `db:"repo_push_mirror_created"`
+	Updated                  int64                    `db:"repo_push_mirror_updated"`
+	URL                      string                   `db:"repo_push_mirror_url"`
+	Username                 string                   `db:"repo_push_mirror_username"`
+	PasswordSecretSpaceID    null.Int                 `db:"repo_push_mirror_password_secret_space_id"`
+	PasswordSecretIdentifier string                   `db:"repo_push_mirror_password_secret_identifier"`
+	ProtectedOnly            bool                     `db:"repo_push_mirror_protected_only"`
+	SyncState                enum.RepoMirrorSyncState `db:"repo_push_mirror_sync_state"`
+	LastSync                 int64                    `db:"repo_push_mirror_last_sync"`
+	LastSuccess              int64                    `db:"repo_push_mirror_last_success"`
+	LastError                string                   `db:"repo_push_mirror_last_error"`
+}
+
+const (
+	repoPushMirrorColumns = `
+		 repo_push_mirror_id
+		,repo_push_mirror_repo_id
+		,repo_push_mirror_created_by
+		,repo_push_mirror_created
+		,repo_push_mirror_updated
+		,repo_push_mirror_url
+		,repo_push_mirror_username
+		,repo_push_mirror_password_secret_space_id
+		,repo_push_mirror_password_secret_identifier
+		,repo_push_mirror_protected_only
+		,repo_push_mirror_sync_state
+		,repo_push_mirror_last_sync
+		,repo_push_mirror_last_success
+		,repo_push_mirror_last_error`
+)
+
+// Find returns the push mirror with the provided ID.
+func (s *RepoPushMirrorStore) Find(ctx context.Context, id int64) (*types.RepoPushMirror, error) {
+	const sqlQuery = `
+	SELECT` + repoPushMirrorColumns + `
+	FROM repo_push_mirrors
+	WHERE repo_push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoPushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repository push mirror")
+	}
+
+	return mapRepoPushMirror(dst), nil
+}
+
+// List returns all push mirrors of the repository.
+func (s *RepoPushMirrorStore) List(ctx context.Context, repoID int64) ([]*types.RepoPushMirror, error) {
+	const sqlQuery = `
+	SELECT` + repoPushMirrorColumns + `
+	FROM repo_push_mirrors
+	WHERE repo_push_mirror_repo_id = $1
+	ORDER BY repo_push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoPushMirror
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repository push mirrors")
+	}
+
+	mirrors := make([]*types.RepoPushMirror, len(dst))
+	for i := range dst {
+		mirrors[i] = mapRepoPushMirror(dst[i])
+	}
+
+	return mirrors, nil
+}
+
+// Create stores a new push mirror of a repository.
+func (s *RepoPushMirrorStore) Create(ctx context.Context, mirror *types.RepoPushMirror) error {
+	const sqlQuery = `
+	INSERT INTO repo_push_mirrors (
+		 repo_push_mirror_repo_id
+		,repo_push_mirror_created_by
+		,repo_push_mirror_created
+		,repo_push_mirror_updated
+		,repo_push_mirror_url
+		,repo_push_mirror_username
+		,repo_push_mirror_password_secret_space_id
+		,repo_push_mirror_password_secret_identifier
+		,repo_push_mirror_protected_only
+		,repo_push_mirror_sync_state
+		,repo_push_mirror_last_sync
+		,repo_push_mirror_last_success
+		,repo_push_mirror_last_error
+	) VALUES (
+		 :repo_push_mirror_repo_id
+		,:repo_push_mirror_created_by
+		,:repo_push_mirror_created
+		,:repo_push_mirror_updated
+		,:repo_push_mirror_url
+		,:repo_push_mirror_username
+		,:repo_push_mirror_password_secret_space_id
+		,:repo_push_mirror_password_secret_identifier
+		,:repo_push_mirror_protected_only
+		,:repo_push_mirror_sync_state
+		,:repo_push_mirror_last_sync
+		,:repo_push_mirror_last_success
+		,:repo_push_mirror_last_error
+	) RETURNING repo_push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository push mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the configuration of the push mirror.
+func (s *RepoPushMirrorStore) Update(ctx context.Context, mirror *types.RepoPushMirror) error {
+	const sqlQuery = `
+	UPDATE repo_push_mirrors
+	SET
+		 repo_push_mirror_updated = :repo_push_mirror_updated
+		,repo_push_mirror_url = :repo_push_mirror_url
+		,repo_push_mirror_username = :repo_push_mirror_username
+		,repo_push_mirror_password_secret_space_id = :repo_push_mirror_password_secret_space_id
+		,repo_push_mirror_password_secret_identifier = :repo_push_mirror_password_secret_identifier
+		,repo_push_mirror_protected_only = :repo_push_mirror_protected_only
+	WHERE repo_push_mirror_id = :repo_push_mirror_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+// UpdateSyncStatus updates the status of the last push to the push mirror.
+func (s *RepoPushMirrorStore) UpdateSyncStatus(ctx context.Context, mirror *types.RepoPushMirror) error {
+	const sqlQuery = `
+	UPDATE repo_push_mirrors
+	SET
+		 repo_push_mirror_sync_state = :repo_push_mirror_sync_state
+		,repo_push_mirror_last_sync = :repo_push_mirror_last_sync
+		,repo_push_mirror_last_success = :repo_push_mirror_last_success
+		,repo_push_mirror_last_error = :repo_push_mirror_last_error
+	WHERE repo_push_mirror_id = :repo_push_mirror_id`
+
+	return s.update(ctx, sqlQuery, mirror)
+}
+
+func (s *RepoPushMirrorStore) update(ctx context.Context, sqlQuery string, mirror *types.RepoPushMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository push mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repository push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the push mirror.
+func (s *RepoPushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_push_mirrors
+	WHERE repo_push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repository push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+func mapToInternalRepoPushMirror(v *types.RepoPushMirror) *repoPushMirror {
+	m := &repoPushMirror{
+		ID:                       v.ID,
+		RepoID:                   v.RepoID,
+		CreatedBy:                v.CreatedBy,
+		Created:                  v.Created,
+		Updated:                  v.Updated,
+		URL:                      v.URL,
+		Username:                 v.Username,
+		PasswordSecretIdentifier: v.PasswordSecretIdentifier,
+		ProtectedOnly:            v.ProtectedOnly,
+		SyncState:                v.SyncState,
+		LastSync:                 v.LastSync,
+		LastSuccess:              v.LastSuccess,
+		LastError:                v.LastError,
+	}
+
+	if v.PasswordSecretSpaceID != 0 {
+		m.PasswordSecretSpaceID = null.IntFrom(v.PasswordSecretSpaceID)
+	}
+
+	return m
+}
+
+func mapRepoPushMirror(v *repoPushMirror) *types.RepoPushMirror {
+	return &types.RepoPushMirror{
+		ID:                       v.ID,
+		RepoID:                   v.RepoID,
+		CreatedBy:                v.CreatedBy,
+		Created:                  v.Created,
+		Updated:                  v.Updated,
+		URL:                      v.URL,
+		Username:                 v.Username,
+		PasswordSecretSpaceID:    v.PasswordSecretSpaceID.Int64,
+		PasswordSecretIdentifier: v.PasswordSecretIdentifier,
+		ProtectedOnly:            v.ProtectedOnly,
+		SyncState:                v.SyncState,
+		LastSync:                 v.LastSync,
+		LastSuccess:              v.LastSuccess,
+		LastError:                v.LastError,
+	}
+}
@@ -17,7 +17,7 @@ package database_test
 import (
 	"context"
 	"fmt"
-	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -52,10 +52,9 @@ func New(dsn string) (*sqlx.DB, error) {
 func setupDB(t *testing.T) (*sqlx.DB, func()) {
 	t.Helper()
 	// must use file as db because in memory have only basic features
-	// file is anyway removed on every test. SQLite is fast
+	// file is anyway removed after every test. SQLite is fast
 	// so it will not affect too much performance.
-	_ = os.Remove("test.db")
-	db, err := New("test.db")
+	db, err := New(filepath.Join(t.TempDir(), "test.db"))
 	if err != nil {
 		t.Fatalf("Error opening db, err: %v", err)
 	}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -69,6 +70,20 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+
+	Format   enum.WebhookFormat `db:"webhook_format"`
+	Template string             `db:"webhook_template"`
+	Filters  json.RawMessage    `db:"webhook_filters"`
+	Delivery json.RawMessage    `db:"webhook_delivery"`
+
+	ConsecutiveFailures int    `db:"webhook_consecutive_failures"`
+	DisabledReason      string `db:"webhook_disabled_reason"`
+}
+
+// webhookDelivery is used to store the delivery options of a webhook, including the internal fields.
+type webhookDelivery struct {
+	types.WebhookDelivery
+	AuthSecretSpaceID int64 `json:"auth_secret_space_id,omitempty"`
 }
 
 const (
@@ -90,7 +105,13 @@ const (
 		,webhook_triggers
 		,webhook_latest_execution_result
 		,webhook_type
-		,webhook_scope`
+		,webhook_scope
+		,webhook_format
+		,webhook_template
+		,webhook_filters
+		,webhook_delivery
+		,webhook_consecutive_failures
+		,webhook_disabled_reason`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -179,6 +200,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_latest_execution_result
 			,webhook_type
 			,webhook_scope
+			,webhook_format
+			,webhook_template
+			,webhook_filters
+			,webhook_delivery
+			,webhook_consecutive_failures
+			,webhook_disabled_reason
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -196,6 +223,12 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_latest_execution_result
 			,:webhook_type
 			,:webhook_scope
+			,:webhook_format
+			,:webhook_template
+			,:webhook_filters
+			,:webhook_delivery
+			,:webhook_consecutive_failures
+			,:webhook_disabled_reason
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -233,6 +266,12 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
+			,webhook_format = :webhook_format
+			,webhook_template = :webhook_template
+			,webhook_filters = :webhook_filters
+			,webhook_delivery = :webhook_delivery
+			,webhook_consecutive_failures = :webhook_consecutive_failures
+			,webhook_disabled_reason = :webhook_disabled_reason
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -461,6 +500,25 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Type:                  hook.Type,
+		Format:                hook.Format,
+		Template:              hook.Template,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		DisabledReason:        hook.DisabledReason,
+	}
+
+	if len(hook.Filters) > 0 {
+		if err := json.Unmarshal(hook.Filters, &res.Filters); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal filters of hook %d: %w", hook.ID, err)
+		}
+	}
+
+	if len(hook.Delivery) > 0 {
+		delivery := webhookDelivery{}
+		if err := json.Unmarshal(hook.Delivery, &delivery); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal delivery options of hook %d: %w", hook.ID, err)
+		}
+		res.Delivery = delivery.WebhookDelivery
+		res.Delivery.AuthSecretSpaceID = delivery.AuthSecretSpaceID
 	}
 
 	switch {
@@ -498,7 +556,26 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Type:                  hook.Type,
+		Format:                hook.Format,
+		Template:              hook.Template,
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
+		DisabledReason:        hook.DisabledReason,
+	}
+
+	filters, err := json.Marshal(hook.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal filters of hook %d: %w", hook.ID, err)
+	}
+	res.Filters = filters
+
+	delivery, err := json.Marshal(webhookDelivery{
+		WebhookDelivery:   hook.Delivery,
+		AuthSecretSpaceID: hook.Delivery.AuthSecretSpaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal delivery options of hook %d: %w", hook.ID, err)
 	}
+	res.Delivery = delivery
 
 	switch hook.ParentType {
 	case enum.WebhookParentRepo:
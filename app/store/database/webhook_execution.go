@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
@@ -254,6 +255,48 @@ func (s *WebhookExecutionStore) ListForTrigger(ctx context.Context,
 	return mapToWebhookExecutions(dst), nil
 }
 
+// ListFailedSince lists the failed and retriggerable executions of the webhook created since the provided time,
+// excluding the executions of triggers that succeeded in the meantime. The newest executions are listed first.
+func (s *WebhookExecutionStore) ListFailedSince(
+	ctx context.Context,
+	webhookID int64,
+	since int64,
+	limit int,
+) ([]*types.WebhookExecution, error) {
+	stmt := database.Builder.
+		Select(webhookExecutionColumns).
+		From("webhook_executions").
+		Where("webhook_execution_webhook_id = ?", webhookID).
+		Where("webhook_execution_created >= ?", since).
+		Where("webhook_execution_retriggerable = ?", true).
+		Where("webhook_execution_trigger_id NOT LIKE ?", types.WebhookTestTriggerIDPrefix+"%").
+		Where(squirrel.Eq{"webhook_execution_result": []enum.WebhookExecutionResult{
+			enum.WebhookExecutionResultRetriableError,
+			enum.WebhookExecutionResultFatalError,
+		}}).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM webhook_executions succeeded
+			WHERE succeeded.webhook_execution_webhook_id = webhook_executions.webhook_execution_webhook_id
+			AND succeeded.webhook_execution_trigger_id = webhook_executions.webhook_execution_trigger_id
+			AND succeeded.webhook_execution_result = ?)`, enum.WebhookExecutionResultSuccess).
+		OrderBy("webhook_execution_id DESC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
 func mapToWebhookExecution(execution *webhookExecution) *types.WebhookExecution {
 	return &types.WebhookExecution{
 		ID:            execution.ID,
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookExecutionStore_ListFailedSince(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	now := time.Now()
+	hook := &types.Webhook{
+		ParentID:            1,
+		ParentType:          enum.WebhookParentSpace,
+		CreatedBy:           userID,
+		Created:             now.UnixMilli(),
+		Updated:             now.UnixMilli(),
+		Type:                enum.WebhookTypeExternal,
+		Identifier:          "hook",
+		URL:                 "https://example.com",
+		ConsecutiveFailures: 3,
+		DisabledReason:      "disabled",
+	}
+	require.NoError(t, webhookStore.Create(ctx, hook))
+
+	stored, err := webhookStore.Find(ctx, hook.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, stored.ConsecutiveFailures)
+	require.Equal(t, "disabled", stored.DisabledReason)
+
+	executions := []*types.WebhookExecution{
+		// too old
+		{TriggerID: "old", Result: enum.WebhookExecutionResultRetriableError, Created: now.Add(-time.Hour).UnixMilli()},
+		// failed, but succeeded after a retrigger
+		{TriggerID: "recovered", Result: enum.WebhookExecutionResultRetriableError},
+		{TriggerID: "recovered", Result: enum.WebhookExecutionResultSuccess},
+		// failed twice
+		{TriggerID: "failed", Result: enum.WebhookExecutionResultFatalError},
+		{TriggerID: "failed", Result: enum.WebhookExecutionResultRetriableError},
+		// not failed
+		{TriggerID: "skipped", Result: enum.WebhookExecutionResultSkipped},
+		// test delivery
+		{TriggerID: types.WebhookTestTriggerIDPrefix + "1", Result: enum.WebhookExecutionResultRetriableError},
+	}
+	for _, execution := range executions {
+		execution.WebhookID = hook.ID
+		execution.TriggerType = enum.WebhookTriggerBranchCreated
+		execution.Retriggerable = true
+		if execution.Created == 0 {
+			execution.Created = now.UnixMilli()
+		}
+		require.NoError(t, executionStore.Create(ctx, execution))
+	}
+
+	list, err := executionStore.ListFailedSince(ctx, hook.ID, now.Add(-time.Minute).UnixMilli(), 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, executions[4].ID, list[0].ID)
+	require.Equal(t, executions[3].ID, list[1].ID)
+
+	list, err = executionStore.ListFailedSince(ctx, hook.ID, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, executions[4].ID, list[0].ID)
+}
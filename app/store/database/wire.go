@@ -37,6 +37,14 @@ var WireSet = wire.NewSet(
 	ProvideSpacePathStore,
 	ProvideSpaceStore,
 	ProvideRepoStore,
+	ProvideRepoMirrorStore,
+	ProvideRepoPushMirrorStore,
+	ProvideNotificationDigestStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationWatchStore,
+	ProvideNotificationStore,
+	ProvidePullReqReactionStore,
+	ProvideOutOfOfficeStore,
 	ProvideRuleStore,
 	ProvideJobStore,
 	ProvideExecutionStore,
@@ -52,6 +60,8 @@ var WireSet = wire.NewSet(
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
+	ProvidePullReqAutoMergeStore,
+	ProvideMergeQueueEntryStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
 	ProvideSettingsStore,
@@ -75,6 +85,7 @@ var WireSet = wire.NewSet(
 	ProvideInfraProvisionedStore,
 	ProvideUsageMetricStore,
 	ProvideCDEGatewayStore,
+	ProvideAuditLogStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -150,6 +161,46 @@ func ProvideRepoStore(
 	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 }
 
+// ProvideRepoMirrorStore provides a repo mirror store.
+func ProvideRepoMirrorStore(db *sqlx.DB) store.RepoMirrorStore {
+	return NewRepoMirrorStore(db)
+}
+
+// ProvideRepoPushMirrorStore provides a repo push mirror store.
+func ProvideRepoPushMirrorStore(db *sqlx.DB) store.RepoPushMirrorStore {
+	return NewRepoPushMirrorStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationWatchStore provides a notification watch store.
+func ProvideNotificationWatchStore(db *sqlx.DB) store.NotificationWatchStore {
+	return NewNotificationWatchStore(db)
+}
+
+// ProvidePullReqReactionStore provides a pull request reaction store.
+func ProvidePullReqReactionStore(db *sqlx.DB) store.PullReqReactionStore {
+	return NewPullReqReactionStore(db)
+}
+
+// ProvideOutOfOfficeStore provides a user absence store.
+func ProvideOutOfOfficeStore(db *sqlx.DB) store.OutOfOfficeStore {
+	return NewOutOfOfficeStore(db)
+}
+
+// ProvideNotificationStore provides an in-app notification store.
+func ProvideNotificationStore(db *sqlx.DB, pCache store.PrincipalInfoCache) store.NotificationStore {
+	return NewNotificationStore(db, pCache)
+}
+
 // ProvideRuleStore provides a rule store.
 func ProvideRuleStore(
 	db *sqlx.DB,
@@ -285,6 +336,22 @@ func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
 }
 
+// ProvidePullReqAutoMergeStore provides a pull request auto-merge store.
+func ProvidePullReqAutoMergeStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) store.PullReqAutoMergeStore {
+	return NewPullReqAutoMergeStore(db, pCache)
+}
+
+// ProvideMergeQueueEntryStore provides a merge queue entry store.
+func ProvideMergeQueueEntryStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) store.MergeQueueEntryStore {
+	return NewMergeQueueEntryStore(db, pCache)
+}
+
 // ProvideWebhookStore provides a webhook store.
 func ProvideWebhookStore(db *sqlx.DB) store.WebhookStore {
 	return NewWebhookStore(db)
@@ -360,3 +427,8 @@ func ProvideUsageMetricStore(db *sqlx.DB) store.UsageMetricStore {
 func ProvideCDEGatewayStore(db *sqlx.DB) store.CDEGatewayStore {
 	return NewCDEGatewayStore(db)
 }
+
+// ProvideAuditLogStore provides an audit log store.
+func ProvideAuditLogStore(db *sqlx.DB) store.AuditLogStore {
+	return NewAuditLogStore(db)
+}
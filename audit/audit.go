@@ -72,6 +72,8 @@ type ResourceType string
 const (
 	ResourceTypeRepository            ResourceType = "repository"
 	ResourceTypeBranchRule            ResourceType = "branch_rule"
+	ResourceTypeTagRule               ResourceType = "tag_rule"
+	ResourceTypePushRule              ResourceType = "push_rule"
 	ResourceTypeBranch                ResourceType = "branch"
 	ResourceTypePullRequest           ResourceType = "pull_request"
 	ResourceTypeRepositorySettings    ResourceType = "repository_settings"
@@ -84,6 +86,8 @@ func (a ResourceType) Validate() error {
 	switch a {
 	case ResourceTypeRepository,
 		ResourceTypeBranchRule,
+		ResourceTypeTagRule,
+		ResourceTypePushRule,
 		ResourceTypeBranch,
 		ResourceTypePullRequest,
 		ResourceTypeRepositorySettings,
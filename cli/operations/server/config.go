@@ -55,6 +55,7 @@ const (
 	schemeSSH      = "ssh"
 	gitnessHomeDir = ".gitness"
 	blobDir        = "blob"
+	searchIndexDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -325,14 +326,17 @@ func ProvideEventsConfig(config *types.Config) events.Config {
 // ProvideWebhookConfig loads the webhook service config from the main config.
 func ProvideWebhookConfig(config *types.Config) webhook.Config {
 	return webhook.Config{
-		UserAgentIdentity:   config.Webhook.UserAgentIdentity,
-		HeaderIdentity:      config.Webhook.HeaderIdentity,
-		EventReaderName:     config.InstanceID,
-		Concurrency:         config.Webhook.Concurrency,
-		MaxRetries:          config.Webhook.MaxRetries,
-		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
-		AllowLoopback:       config.Webhook.AllowLoopback,
-		InternalSecret:      config.Webhook.InternalSecret,
+		UserAgentIdentity:       config.Webhook.UserAgentIdentity,
+		HeaderIdentity:          config.Webhook.HeaderIdentity,
+		EventReaderName:         config.InstanceID,
+		Concurrency:             config.Webhook.Concurrency,
+		MaxRetries:              config.Webhook.MaxRetries,
+		AllowPrivateNetwork:     config.Webhook.AllowPrivateNetwork,
+		AllowLoopback:           config.Webhook.AllowLoopback,
+		InternalSecret:          config.Webhook.InternalSecret,
+		RedeliveryMaxRetries:    config.Webhook.RedeliveryMaxRetries,
+		AutoDisableThreshold:    config.Webhook.AutoDisableThreshold,
+		RecordSkippedExecutions: config.Webhook.RecordSkippedExecutions,
 	}
 }
 
@@ -384,6 +388,7 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		AuditLogsRetentionTime:           config.Audit.RetentionTime,
 	}
 }
 
@@ -403,6 +408,18 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	}
 }
 
+// ProvideKeywordSearchIndexConfig loads the local keyword search index config from the main config.
+func ProvideKeywordSearchIndexConfig(config *types.Config) keywordsearch.IndexConfig {
+	indexDir := config.KeywordSearch.IndexDir
+	if indexDir == "" {
+		indexDir = filepath.Join(config.Git.Root, searchIndexDir)
+	}
+
+	return keywordsearch.IndexConfig{
+		Dir: indexDir,
+	}
+}
+
 func ProvideJobsConfig(config *types.Config) job.Config {
 	return job.Config{
 		InstanceID:                  config.InstanceID,
@@ -100,6 +100,20 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if system.services.RepoMirrorSyncer != nil {
+			if err := system.services.RepoMirrorSyncer.Register(gCtx); err != nil {
+				log.Error().Err(err).Msg("failed to register repo mirror syncer")
+				return err
+			}
+		}
+
+		if system.services.NotificationDigest != nil {
+			if err := system.services.NotificationDigest.Register(gCtx); err != nil {
+				log.Error().Err(err).Msg("failed to register notification digest")
+				return err
+			}
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
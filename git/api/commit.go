@@ -933,6 +933,9 @@ readLoop:
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			default:
+				// any other header (e.g. encoding, mergetag) is part of the signed payload.
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
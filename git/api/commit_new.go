@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+const separatorRecord = "\x1e"
+
+// ListNewCommits lists the commits reachable from rev that aren't reachable from any existing reference
+// of the repository (e.g. commits of a push that are still in the quarantine environment).
+// The returned commits have FileStats populated with the change type and path of each changed file,
+// without insertions and deletions. Renames are reported as deletion and addition.
+func (g *Git) ListNewCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	rev string,
+	limit int,
+) ([]*Commit, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("rev-list",
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	if limit > 0 {
+		cmd.Add(command.WithFlag("--max-count", strconv.Itoa(limit)))
+	}
+	// pseudo revisions must come before the revision, "--not" toggles the negation of all following revisions.
+	cmd.Add(
+		command.WithArg("--not"),
+		command.WithArg("--all"),
+		command.WithArg("--not"),
+		command.WithArg(rev),
+	)
+
+	revListOut := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(revListOut))
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to list new commits")
+	}
+
+	if revListOut.Len() == 0 {
+		return []*Commit{}, nil
+	}
+
+	const format = separatorRecord +
+		fmtCommitHash + fmtZero + // 0
+		fmtParentHashes + fmtZero + // 1
+		fmtAuthorName + fmtZero + // 2
+		fmtAuthorEmail + fmtZero + // 3
+		fmtCommitterName + fmtZero + // 4
+		fmtCommitterEmail + fmtZero + // 5
+		fmtSubject + fmtZero + // 6
+		fmtMessage // 7
+
+	cmd = command.New("log",
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+		command.WithFlag("--stdin"),
+		command.WithFlag("--no-walk=unsorted"),
+		command.WithFlag("--no-renames"),
+		command.WithFlag("--name-status"),
+		command.WithFlag("-z"),
+		command.WithFlag("--format="+format),
+	)
+
+	logOut := &bytes.Buffer{}
+	err = cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(revListOut),
+		command.WithStdout(logOut),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to get new commit data")
+	}
+
+	return parseNewCommits(ctx, logOut.String())
+}
+
+func parseNewCommits(ctx context.Context, output string) ([]*Commit, error) {
+	const columnCount = 8
+
+	records := strings.Split(output, separatorRecord)
+	commits := make([]*Commit, 0, len(records))
+
+	for _, record := range records {
+		if record == "" {
+			continue
+		}
+
+		fields := strings.Split(record, separatorZero)
+		if len(fields) < columnCount {
+			return nil, fmt.Errorf(
+				"unexpected git log formatted output, expected at least %d, but got %d columns",
+				columnCount, len(fields))
+		}
+
+		commitSHA, err := sha.New(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit sha: %w", err)
+		}
+
+		var parentSHAs []sha.SHA
+		if fields[1] != "" {
+			for _, parentSHA := range strings.Split(fields[1], " ") {
+				parentSHAs = append(parentSHAs, sha.Must(parentSHA))
+			}
+		}
+
+		// the remaining fields are pairs of change type and file path, the first one is prefixed with a new line.
+		changes := fields[columnCount:]
+		if len(changes) > 0 {
+			changes[0] = strings.TrimPrefix(changes[0], "\n")
+		}
+
+		var fileStats []CommitFileStats
+		for i := 0; i+1 < len(changes); i += 2 {
+			if changes[i] == "" {
+				continue
+			}
+			fileStats = append(fileStats, CommitFileStats{
+				ChangeType: convertFileDiffStatus(ctx, changes[i]),
+				Path:       changes[i+1],
+			})
+		}
+
+		commits = append(commits, &Commit{
+			SHA:        commitSHA,
+			ParentSHAs: parentSHAs,
+			Title:      fields[6],
+			Message:    fields[7],
+			Author: Signature{
+				Identity: Identity{
+					Name:  fields[2],
+					Email: fields[3],
+				},
+			},
+			Committer: Signature{
+				Identity: Identity{
+					Name:  fields[4],
+					Email: fields[5],
+				},
+			},
+			FileStats: fileStats,
+		})
+	}
+
+	return commits, nil
+}
@@ -51,6 +51,11 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	Prune          bool
+	RefSpecs       []string
+
+	// Resolve pins host names of the remote to addresses, in the format `host:port:address[,address...]`.
+	Resolve []string
 }
 
 // ObjectCount represents the parsed information from the `git count-objects -v` command.
@@ -249,12 +254,14 @@ func (g *Git) Clone(
 }
 
 // Sync synchronizes the repository to match the provided source.
+// Host names of the source can be pinned to addresses, in the format `host:port:address[,address...]`.
 // NOTE: This is a read operation and doesn't trigger any server side hooks.
 func (g *Git) Sync(
 	ctx context.Context,
 	repoPath string,
 	source string,
 	refSpecs []string,
+	resolve []string,
 ) error {
 	if repoPath == "" {
 		return ErrRepositoryPathEmpty
@@ -276,6 +283,7 @@ func (g *Git) Sync(
 		command.WithArg(source),
 		command.WithArg(refSpecs...),
 	)
+	withResolve(cmd, resolve)
 
 	err := cmd.Run(ctx, command.WithDir(repoPath))
 	if err != nil {
@@ -285,6 +293,75 @@ func (g *Git) Sync(
 	return nil
 }
 
+// withResolve makes git connect to the provided addresses instead of resolving the host names itself.
+// Redirects are disabled as well, otherwise the remote could send git to a host that isn't pinned.
+func withResolve(cmd *command.Command, resolve []string) {
+	if len(resolve) == 0 {
+		return
+	}
+
+	cmd.Add(command.WithConfig("http.followRedirects", "false"))
+	for _, r := range resolve {
+		cmd.Add(command.WithConfig("http.curloptResolve", r))
+	}
+}
+
+// FetchObjects fetches the provided objects (and their history) from the source repository
+// without updating any references.
+func (g *Git) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("credential.helper", ""),
+		command.WithFlag(
+			"--quiet",
+			"--no-tags",
+			"--no-write-fetch-head",
+		),
+		command.WithArg(source),
+		command.WithArg(objectSHAs...),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
+// Repack packs all objects of the repository into a single pack,
+// including the objects borrowed from alternate object directories.
+func (g *Git) Repack(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag("-a", "-d", "-q"),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to repack repo")
+	}
+
+	return nil
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -362,12 +439,20 @@ func (g *Git) Push(
 	if opts.Mirror {
 		cmd.Add(command.WithFlag("--mirror"))
 	}
+	if opts.Prune {
+		cmd.Add(command.WithFlag("--prune"))
+	}
+	withResolve(cmd, opts.Resolve)
 	cmd.Add(command.WithPostSepArg(opts.Remote))
 
 	if len(opts.Branch) > 0 {
 		cmd.Add(command.WithPostSepArg(opts.Branch))
 	}
 
+	if len(opts.RefSpecs) > 0 {
+		cmd.Add(command.WithPostSepArg(opts.RefSpecs...))
+	}
+
 	if g.traceGit {
 		cmd.Add(command.WithEnv(command.GitTrace, "true"))
 	}
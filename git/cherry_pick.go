@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// BaseBranch is the branch on top of which the commits are applied.
+	BaseBranch string
+
+	// CommitSHAs are the commits that are applied, in the order they are provided.
+	// For merge commits the changes relative to the first parent are applied.
+	CommitSHAs []sha.SHA
+
+	// AppendOrigin appends the "(cherry picked from commit ...)" line to the commit messages.
+	AppendOrigin bool
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+
+	// Refs are the references updated with the result of the cherry-pick.
+	// If none is provided, the operation only checks if the commits can be applied.
+	// The references are not updated if no commit has been created.
+	Refs []RefUpdate
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseBranch == "" {
+		return errors.InvalidArgument("base branch is mandatory")
+	}
+
+	if len(p.CommitSHAs) == 0 {
+		return errors.InvalidArgument("at least one commit must be provided")
+	}
+
+	for _, ref := range p.Refs {
+		if ref.Name == "" {
+			return errors.InvalidArgument("ref name has to be provided")
+		}
+	}
+
+	return nil
+}
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// BaseSHA is the sha of the latest commit on the base branch that was used for the cherry-pick.
+	BaseSHA sha.SHA
+	// CommitSHA is the sha of the last commit created by the cherry-pick.
+	// It's equal to the BaseSHA if all commits turned out to be empty, and it's empty in case of conflicts.
+	CommitSHA sha.SHA
+	// CommitCount is the number of commits that were created by the cherry-pick.
+	CommitCount int
+
+	// ConflictCommitSHA is the sha of the commit which couldn't be applied because of the conflicts.
+	ConflictCommitSHA sha.SHA
+	ConflictFiles     []string
+}
+
+// CherryPick applies the changes introduced by the provided commits on top of the base branch.
+// Commits keep their original authors and messages, while the committer is set to the actor.
+// Commits which would be empty after being applied are dropped.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	err := params.Validate()
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseCommitSHA, err := s.git.GetFullCommitID(ctx, repoPath, params.BaseBranch)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to get base branch commit SHA: %w", err)
+	}
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to create reference updater: %w", err)
+	}
+
+	output := CherryPickOutput{
+		BaseSHA: baseCommitSHA,
+	}
+
+	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(r *sharedrepo.SharedRepo) error {
+		lastCommitSHA := baseCommitSHA
+		lastTreeSHA, err := r.GetTreeSHA(ctx, baseCommitSHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha for base: %w", err)
+		}
+
+		for _, commitSHA := range params.CommitSHAs {
+			commitInfo, err := api.GetCommit(ctx, r.Directory(), commitSHA.String())
+			if err != nil {
+				return fmt.Errorf("failed to get commit data of %s: %w", commitSHA, err)
+			}
+
+			if len(commitInfo.ParentSHAs) == 0 {
+				return errors.InvalidArgument("can't cherry-pick the root commit %s", commitSHA)
+			}
+
+			// the first parent is used as the merge base to only apply the changes introduced by the commit.
+			treeSHA, conflicts, err := r.MergeTree(ctx, commitInfo.ParentSHAs[0], lastCommitSHA, commitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to merge tree of commit %s: %w", commitSHA, err)
+			}
+			if len(conflicts) > 0 {
+				output.ConflictCommitSHA = commitSHA
+				output.ConflictFiles = conflicts
+				return refUpdater.Init(ctx, nil) // update nothing
+			}
+
+			if treeSHA.Equal(lastTreeSHA) {
+				log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after cherry-pick", commitSHA)
+				continue
+			}
+
+			message := cherryPickMessage(commitInfo.Message, commitSHA, params.AppendOrigin)
+
+			lastCommitSHA, err = r.CommitTree(ctx, &commitInfo.Author, &committer, treeSHA, message, false,
+				lastCommitSHA)
+			if err != nil {
+				return fmt.Errorf("failed to commit tree of commit %s: %w", commitSHA, err)
+			}
+
+			lastTreeSHA = treeSHA
+			output.CommitCount++
+		}
+
+		output.CommitSHA = lastCommitSHA
+
+		if output.CommitCount == 0 {
+			return refUpdater.Init(ctx, nil) // update nothing
+		}
+
+		refUpdates := make([]hook.ReferenceUpdate, len(params.Refs))
+		for i, ref := range params.Refs {
+			newValue := ref.New
+			if newValue.IsEmpty() { // replace all empty new values with the result of the cherry-pick
+				newValue = lastCommitSHA
+			}
+
+			refUpdates[i] = hook.ReferenceUpdate{
+				Ref: ref.Name,
+				Old: ref.Old,
+				New: newValue,
+			}
+		}
+
+		err = refUpdater.Init(ctx, refUpdates)
+		if err != nil {
+			return fmt.Errorf("failed to init values of references (%v): %w", refUpdates, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to cherry-pick commits to %q in %q: %w",
+			params.BaseBranch, params.RepoUID, err)
+	}
+
+	return output, nil
+}
+
+// cherryPickMessage returns the message of the cherry-picked commit.
+// The original message already contains the title of the commit.
+func cherryPickMessage(message string, commitSHA sha.SHA, appendOrigin bool) string {
+	message = strings.TrimRight(message, "\n")
+	if appendOrigin {
+		message += "\n\n(cherry picked from commit " + commitSHA.String() + ")"
+	}
+	return message
+}
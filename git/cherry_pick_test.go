@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCherryPickMessage(t *testing.T) {
+	commitSHA := sha.Must("f8312d7ff0b064a81ec3f2dc8250e8c0575a0d2e")
+
+	tests := []struct {
+		name         string
+		message      string
+		appendOrigin bool
+		exp          string
+	}{
+		{
+			name:    "title-only",
+			message: "Add file\n",
+			exp:     "Add file",
+		},
+		{
+			name:    "title-and-body",
+			message: "Add file\n\nDescription of the change.\n",
+			exp:     "Add file\n\nDescription of the change.",
+		},
+		{
+			name:         "append-origin",
+			message:      "Add file\n\nDescription of the change.",
+			appendOrigin: true,
+			exp: "Add file\n\nDescription of the change.\n\n" +
+				"(cherry picked from commit f8312d7ff0b064a81ec3f2dc8250e8c0575a0d2e)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.exp, cherryPickMessage(test.message, commitSHA, test.appendOrigin))
+		})
+	}
+}
@@ -211,6 +211,48 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListNewCommitsParams struct {
+	ReadParams
+	// Rev is the git revision the new commits are reachable from (e.g. the new SHA of a pushed reference).
+	Rev string
+	// Limit is the max number of returned commits - Optional, ignored if value is 0.
+	Limit int
+}
+
+type ListNewCommitsOutput struct {
+	Commits []Commit
+}
+
+// ListNewCommits lists commits reachable from the provided revision that aren't yet reachable
+// from any reference of the repository. It's intended to be used on quarantine data in git hooks.
+// File stats of the returned commits only contain the change type and path of the changed files.
+func (s *Service) ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	gitCommits, err := s.git.ListNewCommits(ctx, repoPath, params.AlternateObjectDirs, params.Rev, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]Commit, len(gitCommits))
+	for i := range gitCommits {
+		commit, err := mapCommit(gitCommits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+
+		commits[i] = *commit
+	}
+
+	return &ListNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+)
+
+type GetCommitSignaturesParams struct {
+	ReadParams
+	CommitSHAs []string
+}
+
+// CommitSignature contains the raw signature of a commit and the payload that was signed.
+type CommitSignature struct {
+	CommitSHA sha.SHA
+	Committer Signature
+	// Signature is the armored signature, either a PGP or an SSH signature.
+	Signature string
+	// Payload is the commit object without the signature header.
+	Payload string
+}
+
+type GetCommitSignaturesOutput struct {
+	// Signatures contains the signatures of the signed commits only.
+	Signatures []CommitSignature
+}
+
+// GetCommitSignatures returns the signatures of the provided commits. Unsigned commits are skipped.
+func (s *Service) GetCommitSignatures(
+	ctx context.Context,
+	params *GetCommitSignaturesParams,
+) (*GetCommitSignaturesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	if len(params.CommitSHAs) == 0 {
+		return &GetCommitSignaturesOutput{}, nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	writer, reader, cancel := api.CatFileBatch(ctx, repoPath, params.AlternateObjectDirs)
+	defer cancel()
+	defer writer.Close()
+
+	signatures := make([]CommitSignature, 0, len(params.CommitSHAs))
+	for _, commitSHA := range params.CommitSHAs {
+		_, err := writer.Write([]byte(commitSHA + "\n"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to write to cat-file batch: %w", err)
+		}
+
+		output, err := api.ReadBatchHeaderLine(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cat-file batch header: %w", err)
+		}
+
+		if output.Type != string(TreeNodeTypeCommit) {
+			return nil, errors.InvalidArgument("object %q is not a commit", commitSHA)
+		}
+
+		commit, err := api.CommitFromReader(output.SHA, io.LimitReader(reader, output.Size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", commitSHA, err)
+		}
+
+		if _, err = reader.Discard(1); err != nil {
+			return nil, fmt.Errorf("failed to discard trailing new line: %w", err)
+		}
+
+		if commit.Signature == nil {
+			continue
+		}
+
+		committer, err := mapSignature(&commit.Committer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map committer of commit %s: %w", commitSHA, err)
+		}
+
+		signatures = append(signatures, CommitSignature{
+			CommitSHA: commit.SHA,
+			Committer: *committer,
+			Signature: commit.Signature.Signature,
+			Payload:   commit.Signature.Payload,
+		})
+	}
+
+	return &GetCommitSignaturesOutput{
+		Signatures: signatures,
+	}, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -38,6 +39,13 @@ type DiffParams struct {
 	MergeBase        bool
 	IncludePatch     bool
 	IgnoreWhitespace bool
+
+	// BaseMergeBaseSHA and HeadMergeBaseSHA make the diff rebase-aware, if both are provided and different.
+	// The changes of BaseRef since BaseMergeBaseSHA are applied on top of HeadMergeBaseSHA first,
+	// and the result is compared with HeadRef. This way the changes that came from the target branch
+	// with a rebase are excluded from the diff. It's supported only by RawDiff and Diff.
+	BaseMergeBaseSHA string
+	HeadMergeBaseSHA string
 }
 
 func (p DiffParams) Validate() error {
@@ -48,9 +56,18 @@ func (p DiffParams) Validate() error {
 	if p.HeadRef == "" {
 		return errors.InvalidArgument("head ref cannot be empty")
 	}
+
+	if p.isRebaseAware() && p.MergeBase {
+		return errors.InvalidArgument("merge base diff can't be rebase-aware")
+	}
+
 	return nil
 }
 
+func (p DiffParams) isRebaseAware() bool {
+	return p.BaseMergeBaseSHA != "" && p.HeadMergeBaseSHA != "" && p.BaseMergeBaseSHA != p.HeadMergeBaseSHA
+}
+
 func (s *Service) RawDiff(
 	ctx context.Context,
 	out io.Writer,
@@ -67,6 +84,10 @@ func (s *Service) rawDiff(ctx context.Context, w io.Writer, params *DiffParams,
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
+	if params.isRebaseAware() {
+		return s.rawDiffRebased(ctx, w, repoPath, params, files...)
+	}
+
 	err := s.git.RawDiff(ctx,
 		w,
 		repoPath,
@@ -83,6 +104,50 @@ func (s *Service) rawDiff(ctx context.Context, w io.Writer, params *DiffParams,
 	return nil
 }
 
+// rawDiffRebased writes the diff between the base ref rebased on top of the head merge base and the head ref.
+// The rebased base ref is never committed, only its tree is created in a temporary shared repository.
+// In case of conflicts the tree contains the conflict markers, so they would be visible in the diff.
+func (s *Service) rawDiffRebased(
+	ctx context.Context,
+	w io.Writer,
+	repoPath string,
+	params *DiffParams,
+	files ...api.FileDiffRequest,
+) error {
+	baseSHA, err := s.git.ResolveRev(ctx, repoPath, params.BaseRef)
+	if err != nil {
+		return fmt.Errorf("failed to resolve base ref: %w", err)
+	}
+
+	baseMergeBaseSHA, err := sha.New(params.BaseMergeBaseSHA)
+	if err != nil {
+		return errors.InvalidArgument("invalid base merge base SHA: %s", params.BaseMergeBaseSHA)
+	}
+
+	headMergeBaseSHA, err := sha.New(params.HeadMergeBaseSHA)
+	if err != nil {
+		return errors.InvalidArgument("invalid head merge base SHA: %s", params.HeadMergeBaseSHA)
+	}
+
+	return sharedrepo.Run(ctx, nil, s.sharedRepoRoot, repoPath, func(r *sharedrepo.SharedRepo) error {
+		treeSHA, _, err := r.MergeTree(ctx, baseMergeBaseSHA, headMergeBaseSHA, baseSHA)
+		if err != nil {
+			return fmt.Errorf("failed to rebase the base ref: %w", err)
+		}
+
+		return s.git.RawDiff(ctx,
+			w,
+			r.Directory(),
+			treeSHA.String(),
+			params.HeadRef,
+			false,
+			params.IgnoreWhitespace,
+			nil, // the alternate object dirs are already set up for the shared repository
+			files...,
+		)
+	}, params.AlternateObjectDirs...)
+}
+
 func (s *Service) CommitDiff(ctx context.Context, params *GetCommitParams, out io.Writer) error {
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 	err := s.git.CommitDiff(
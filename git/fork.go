@@ -0,0 +1,239 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/rs/zerolog/log"
+)
+
+// alternatesFilePath is the path of the file listing the alternate object directories of a repository.
+var alternatesFilePath = filepath.Join("objects", "info", "alternates")
+
+type ForkRepositoryParams struct {
+	// Fork operation is similar to the create operation, as UID of the fork doesn't exist yet.
+	RepoUID string
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that's being forked.
+	UpstreamRepoUID string
+	DefaultBranch   string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository uid cannot be empty")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID string
+}
+
+// ForkRepository creates a new repository containing all branches and tags of the upstream repository.
+// The fork borrows the objects of the upstream repository using git alternates,
+// so only objects created after forking are stored in the fork itself.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.RepoUID == "" {
+		uid, err := NewRepositoryUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+		params.RepoUID = uid
+	}
+
+	upstreamRepoPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamRepoPath); errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.NotFound("upstream repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to stat upstream repository: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("Fork git repository with uid '%s' into new repository with uid '%s'",
+		params.UpstreamRepoUID, params.RepoUID)
+
+	writeParams := WriteParams{
+		RepoUID: params.RepoUID,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err := s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		params.DefaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	defer func() {
+		if err != nil {
+			cleanupErr := s.DeleteRepositoryBestEffort(ctx, params.RepoUID)
+			if cleanupErr != nil && !errors.IsNotFound(cleanupErr) {
+				log.Ctx(ctx).Warn().Err(cleanupErr).Msg("failed to cleanup fork repo dir")
+			}
+		}
+	}()
+
+	err = s.preserveSharedObjects(ctx, upstreamRepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(
+		filepath.Join(repoPath, alternatesFilePath),
+		[]byte(filepath.Join(upstreamRepoPath, "objects")+"\n"),
+		0o600,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write alternates file of fork: %w", err)
+	}
+
+	// all objects are available via alternates, hence the fetch only copies the references.
+	err = s.git.Sync(ctx, repoPath, upstreamRepoPath, []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch references from upstream repo: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		UID: params.RepoUID,
+	}, nil
+}
+
+// preserveSharedObjects configures the repository to never prune unreachable objects.
+// Forks reference the objects of their upstream repository via alternates, so an object that became
+// unreachable in the upstream repository might still be reachable from one of its forks.
+func (s *Service) preserveSharedObjects(ctx context.Context, repoPath string) error {
+	err := s.git.Config(ctx, repoPath, "gc.pruneExpire", "never")
+	if err != nil {
+		return fmt.Errorf("failed to disable pruning of shared objects: %w", err)
+	}
+
+	return nil
+}
+
+type DissociateRepositoryParams struct {
+	WriteParams
+}
+
+// DissociateRepository copies all objects borrowed from the upstream repository into the repository
+// and removes the alternates. It must be called for all forks of a repository before it's deleted.
+func (s *Service) DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	alternatesPath := filepath.Join(repoPath, alternatesFilePath)
+
+	if _, err := os.Stat(alternatesPath); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to stat alternates file: %w", err)
+	}
+
+	if err := s.git.Repack(ctx, repoPath); err != nil {
+		return fmt.Errorf("failed to repack repository: %w", err)
+	}
+
+	if err := os.Remove(alternatesPath); err != nil {
+		return fmt.Errorf("failed to remove alternates file: %w", err)
+	}
+
+	return nil
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+	// SourceRepoUID is the UID of the repository from which the objects are fetched.
+	SourceRepoUID string
+	ObjectSHAs    []sha.SHA
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository uid cannot be empty")
+	}
+
+	return nil
+}
+
+// FetchObjects copies the provided objects, including their history, from the source repository.
+// No references are updated, it's up to the caller to make the objects reachable.
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if params.SourceRepoUID == params.RepoUID || len(params.ObjectSHAs) == 0 {
+		return nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourceRepoPath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	objectSHAs := make([]string, len(params.ObjectSHAs))
+	for i, objectSHA := range params.ObjectSHAs {
+		objectSHAs[i] = objectSHA.String()
+	}
+
+	if err := s.git.FetchObjects(ctx, repoPath, sourceRepoPath, objectSHAs); err != nil {
+		return fmt.Errorf("failed to fetch objects from source repo: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
+
+func requireGit(t *testing.T) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+}
+
+func TestPreserveSharedObjects(t *testing.T) {
+	requireGit(t)
+
+	adapter, err := api.New(types.Config{}, nil, nil)
+	require.NoError(t, err)
+	s := &Service{git: adapter}
+
+	root := t.TempDir()
+	upstreamPath := filepath.Join(root, "upstream")
+	forkPath := filepath.Join(root, "fork")
+
+	runGit(t, root, "init", "-q", "-b", "main", upstreamPath)
+	runGit(t, upstreamPath, "commit", "-q", "--allow-empty", "-m", "initial")
+	runGit(t, upstreamPath, "checkout", "-q", "-b", "feature")
+	runGit(t, upstreamPath, "commit", "-q", "--allow-empty", "-m", "feature")
+	featureSHA := runGit(t, upstreamPath, "rev-parse", "HEAD")
+	runGit(t, upstreamPath, "checkout", "-q", "main")
+
+	// the fork references the feature commit, which is stored only in the upstream repository.
+	runGit(t, root, "init", "-q", "--bare", forkPath)
+	err = os.WriteFile(filepath.Join(forkPath, alternatesFilePath),
+		[]byte(filepath.Join(upstreamPath, ".git", "objects")+"\n"), 0o600)
+	require.NoError(t, err)
+	runGit(t, forkPath, "update-ref", "refs/heads/feature", featureSHA)
+
+	err = s.preserveSharedObjects(context.Background(), upstreamPath)
+	require.NoError(t, err)
+
+	// make the feature commit unreachable in the upstream repository and garbage collect it.
+	runGit(t, upstreamPath, "branch", "-q", "-D", "feature")
+	runGit(t, upstreamPath, "reflog", "expire", "--expire=now", "--all")
+	runGit(t, upstreamPath, "gc", "-q")
+
+	require.Equal(t, "commit", runGit(t, forkPath, "cat-file", "-t", featureSHA))
+}
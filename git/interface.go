@@ -49,6 +49,9 @@ type Interface interface {
 	UpdateRef(ctx context.Context, params UpdateRefParams) error
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
 
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
@@ -56,12 +59,14 @@ type Interface interface {
 	 * Commits service
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
+	GetCommitSignatures(ctx context.Context, params *GetCommitSignaturesParams) (*GetCommitSignaturesOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
 	IsAncestor(ctx context.Context, params IsAncestorParams) (IsAncestorOutput, error)
+	ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error)
 
 	// TODO: remove. Kept for backwards compatibility.
 	FindOversizeFiles(
@@ -103,6 +108,7 @@ type Interface interface {
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
 
 	Revert(ctx context.Context, in *RevertParams) (RevertOutput, error)
+	CherryPick(ctx context.Context, in *CherryPickParams) (CherryPickOutput, error)
 
 	/*
 	 * Blame services
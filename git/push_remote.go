@@ -25,6 +25,15 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefSpecs limits the push to the provided refspecs. If empty, all references are mirrored.
+	RefSpecs []string
+
+	// Prune deletes the remote references that are matched by the refspecs but don't exist locally.
+	Prune bool
+
+	// Resolve [OPTIONAL] pins host names of the remote to addresses, in the format `host:port:address[,address...]`.
+	Resolve []string
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -57,10 +66,13 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 	}
 
 	err = s.git.Push(ctx, repoPath, api.PushOptions{
-		Remote: params.RemoteURL,
-		Force:  false,
-		Env:    nil,
-		Mirror: true,
+		Remote:   params.RemoteURL,
+		Force:    false,
+		Env:      nil,
+		Mirror:   len(params.RefSpecs) == 0,
+		Prune:    params.Prune,
+		RefSpecs: params.RefSpecs,
+		Resolve:  params.Resolve,
 	})
 	if err != nil {
 		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
@@ -22,12 +22,15 @@ import (
 	"os"
 	"path"
 	"runtime/debug"
+	"sort"
 	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rs/zerolog/log"
@@ -114,10 +117,16 @@ type SyncRepositoryParams struct {
 	// If empty, the default branch will be set to match the remote repository's default branch.
 	// WARNING: If the remote repo is empty and no value is provided, an api.ErrNoDefaultBranch error is returned.
 	DefaultBranch string
+	// ReportRefUpdates [OPTIONAL] requests the branch and tag updates performed by the sync to be returned.
+	ReportRefUpdates bool
+	// Resolve [OPTIONAL] pins host names of the source to addresses, in the format `host:port:address[,address...]`.
+	Resolve []string
 }
 
 type SyncRepositoryOutput struct {
 	DefaultBranch string
+	// RefUpdates contains the branch and tag updates performed by the sync (if requested).
+	RefUpdates []hook.ReferenceUpdate
 }
 
 type HashRepositoryParams struct {
@@ -287,12 +296,30 @@ func (s *Service) SyncRepository(
 		}
 	}
 
+	var refsBefore map[string]sha.SHA
+	if params.ReportRefUpdates {
+		refsBefore, err = s.listBranchAndTagRefs(ctx, repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list references before sync: %w", err)
+		}
+	}
+
 	// sync repo content
-	err = s.git.Sync(ctx, repoPath, params.Source, params.RefSpecs)
+	err = s.git.Sync(ctx, repoPath, params.Source, params.RefSpecs, params.Resolve)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync from source repo: %w", err)
 	}
 
+	var refUpdates []hook.ReferenceUpdate
+	if params.ReportRefUpdates {
+		refsAfter, err := s.listBranchAndTagRefs(ctx, repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list references after sync: %w", err)
+		}
+
+		refUpdates = diffRefs(refsBefore, refsAfter)
+	}
+
 	defaultBranch := params.DefaultBranch
 	if defaultBranch == "" {
 		// get default branch from remote repo (returns api.ErrNoDefaultBranch if repo is empty!)
@@ -310,9 +337,68 @@ func (s *Service) SyncRepository(
 
 	return &SyncRepositoryOutput{
 		DefaultBranch: defaultBranch,
+		RefUpdates:    refUpdates,
 	}, nil
 }
 
+// listBranchAndTagRefs returns all branch and tag references of the repository with the SHA they point to.
+func (s *Service) listBranchAndTagRefs(ctx context.Context, repoPath string) (map[string]sha.SHA, error) {
+	refs := map[string]sha.SHA{}
+
+	err := s.git.WalkReferences(ctx, repoPath, func(wre api.WalkReferencesEntry) error {
+		ref, ok := wre[api.GitReferenceFieldRefName]
+		if !ok {
+			return errors.New("ref entry didn't contain the ref name")
+		}
+		objectSHA, err := sha.New(wre[api.GitReferenceFieldObjectName])
+		if err != nil {
+			return fmt.Errorf("failed to parse object sha of ref %q: %w", ref, err)
+		}
+
+		refs[ref] = objectSHA
+
+		return nil
+	}, &api.WalkReferencesOptions{
+		Patterns: []string{gitReferenceNamePrefixBranch, gitReferenceNamePrefixTag},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk references: %w", err)
+	}
+
+	return refs, nil
+}
+
+// diffRefs returns the reference updates required to get from the before to the after state, sorted by ref name.
+func diffRefs(before, after map[string]sha.SHA) []hook.ReferenceUpdate {
+	var updates []hook.ReferenceUpdate
+
+	for ref, newSHA := range after {
+		oldSHA, ok := before[ref]
+		if !ok {
+			oldSHA = sha.Nil
+		}
+		if oldSHA.Equal(newSHA) {
+			continue
+		}
+
+		updates = append(updates, hook.ReferenceUpdate{Ref: ref, Old: oldSHA, New: newSHA})
+	}
+
+	for ref, oldSHA := range before {
+		if _, ok := after[ref]; ok {
+			continue
+		}
+
+		updates = append(updates, hook.ReferenceUpdate{Ref: ref, Old: oldSHA, New: sha.Nil})
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Ref < updates[j].Ref
+	})
+
+	return updates
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
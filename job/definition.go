@@ -25,6 +25,10 @@ type Definition struct {
 	MaxRetries int
 	Timeout    time.Duration
 	Data       string
+
+	// ExponentialBackoff makes the scheduler double the delay between retries of the failed job,
+	// instead of retrying it after a fixed delay.
+	ExponentialBackoff bool
 }
 
 func (def *Definition) Validate() error {
@@ -70,5 +74,6 @@ func (def *Definition) toNewJob() *Job {
 		RecurringCron:       "",
 		ConsecutiveFailures: 0,
 		LastFailureError:    "",
+		ExponentialBackoff:  def.ExponentialBackoff,
 	}
 }
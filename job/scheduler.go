@@ -616,13 +616,32 @@ func postExec(job *Job, resultData, resultErr string) {
 
 	// Reschedule the failed job if retrying is allowed
 	if job.State == JobStateFailed && job.ConsecutiveFailures <= job.MaxRetries {
-		const retryDelay = 15 * time.Second
 		job.State = JobStateScheduled
-		job.Scheduled = now.Add(retryDelay).UnixMilli()
+		job.Scheduled = now.Add(retryDelay(job.ConsecutiveFailures, job.ExponentialBackoff)).UnixMilli()
 		job.RunProgress = ProgressMin
 	}
 }
 
+// retryDelay returns the time to wait before retrying a failed job.
+// With exponential backoff the delay doubles with each consecutive failure, up to the maximum delay.
+func retryDelay(consecutiveFailures int, exponentialBackoff bool) time.Duration {
+	const (
+		minRetryDelay = 15 * time.Second
+		maxRetryDelay = time.Hour
+	)
+
+	delay := minRetryDelay
+	if !exponentialBackoff {
+		return delay
+	}
+
+	for i := 1; i < consecutiveFailures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
 func (s *Scheduler) GetJobProgress(ctx context.Context, jobUID string) (Progress, error) {
 	job, err := s.store.Find(ctx, jobUID)
 	if err != nil {
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		backoff  bool
+		exp      time.Duration
+	}{
+		{failures: 0, backoff: true, exp: 15 * time.Second},
+		{failures: 1, backoff: true, exp: 15 * time.Second},
+		{failures: 2, backoff: true, exp: 30 * time.Second},
+		{failures: 3, backoff: true, exp: time.Minute},
+		{failures: 9, backoff: true, exp: time.Hour},
+		{failures: 1000, backoff: true, exp: time.Hour},
+		{failures: 1, backoff: false, exp: 15 * time.Second},
+		{failures: 3, backoff: false, exp: 15 * time.Second},
+		{failures: 1000, backoff: false, exp: 15 * time.Second},
+	}
+
+	for _, test := range tests {
+		if want, got := test.exp, retryDelay(test.failures, test.backoff); want != got {
+			t.Errorf("failures=%d, backoff=%t: want: %s, got: %s",
+				test.failures, test.backoff, want.String(), got.String())
+		}
+	}
+}
@@ -36,6 +36,7 @@ type Job struct {
 	ConsecutiveFailures int      `db:"job_consecutive_failures"`
 	LastFailureError    string   `db:"job_last_failure_error"`
 	GroupID             string   `db:"job_group_id"`
+	ExponentialBackoff  bool     `db:"job_exponential_backoff"`
 }
 
 type StateChange struct {
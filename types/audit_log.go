@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+)
+
+// AuditLog represents a single persisted audit event.
+type AuditLog struct {
+	ID      int64 `json:"id"`
+	Created int64 `json:"created"`
+
+	Action    string        `json:"action"`
+	Principal PrincipalInfo `json:"principal"`
+	SpacePath string        `json:"space_path"`
+
+	ResourceType       string            `json:"resource_type"`
+	ResourceIdentifier string            `json:"resource_identifier"`
+	ResourceData       map[string]string `json:"resource_data"`
+
+	ClientIP      string            `json:"client_ip"`
+	RequestMethod string            `json:"request_method"`
+	RequestID     string            `json:"request_id"`
+	RequestPath   string            `json:"request_path"`
+	Data          map[string]string `json:"data"`
+
+	OldObject json.RawMessage `json:"old_object,omitempty"`
+	NewObject json.RawMessage `json:"new_object,omitempty"`
+}
+
+// AuditLogFilter stores audit log query parameters.
+type AuditLogFilter struct {
+	ListQueryFilter
+	CreatedFilter
+
+	Actions       []string `json:"actions"`
+	ResourceTypes []string `json:"resource_types"`
+	PrincipalIDs  []int64  `json:"principal_ids"`
+
+	// SpacePath restricts the result to audit logs of the space and its subspaces.
+	// Intended for internal use only.
+	SpacePath string `json:"-"`
+}
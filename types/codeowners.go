@@ -21,8 +21,18 @@ import (
 )
 
 type CodeOwnerEvaluation struct {
+	// EvaluationEntries contains the evaluation entries of all sections.
+	EvaluationEntries []CodeOwnerEvaluationEntry   `json:"evaluation_entries"`
+	Sections          []CodeOwnerEvaluationSection `json:"sections"`
+	FileSha           string                       `json:"file_sha"`
+}
+
+type CodeOwnerEvaluationSection struct {
+	// Name is the name of the section, empty for the entries that don't belong to any section.
+	Name              string                     `json:"name"`
+	Optional          bool                       `json:"optional"`
+	RequiredApprovals int                        `json:"required_approvals"`
 	EvaluationEntries []CodeOwnerEvaluationEntry `json:"evaluation_entries"`
-	FileSha           string                     `json:"file_sha"`
 }
 
 type CodeOwnerEvaluationEntry struct {
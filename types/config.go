@@ -338,6 +338,15 @@ type Config struct {
 		MaxRetries          int    `envconfig:"GITNESS_WEBHOOK_MAX_RETRIES" default:"3"`
 		AllowPrivateNetwork bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_PRIVATE_NETWORK" default:"false"`
 		AllowLoopback       bool   `envconfig:"GITNESS_WEBHOOK_ALLOW_LOOPBACK" default:"false"`
+		// RedeliveryMaxRetries is the number of times a failed delivery is retried in the background.
+		// The delay between two attempts doubles with every attempt, from 15 seconds up to an hour.
+		RedeliveryMaxRetries int `envconfig:"GITNESS_WEBHOOK_REDELIVERY_MAX_RETRIES" default:"10"`
+		// AutoDisableThreshold is the number of consecutive failed executions after which a webhook
+		// gets disabled automatically. Zero means webhooks are never disabled automatically.
+		AutoDisableThreshold int `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE_THRESHOLD" default:"50"`
+		// RecordSkippedExecutions stores an execution for every event that a webhook skipped
+		// because of its filters. It's meant for debugging the filters of webhooks.
+		RecordSkippedExecutions bool `envconfig:"GITNESS_WEBHOOK_RECORD_SKIPPED_EXECUTIONS" default:"false"`
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime  time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 		InternalSecret string        `envconfig:"GITNESS_WEBHOOK_INTERNAL_SECRET"`
@@ -369,6 +378,26 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	RepoMirror struct {
+		Enabled bool `envconfig:"GITNESS_REPO_MIRROR_ENABLED" default:"true"`
+		// CRON defines how often the repository mirrors are checked for a pending synchronization.
+		CRON        string        `envconfig:"GITNESS_REPO_MIRROR_CRON" default:"* * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_MIRROR_MAX_DURATION" default:"30m"`
+		NumWorkers  int           `envconfig:"GITNESS_REPO_MIRROR_NUM_WORKERS" default:"3"`
+		// MinInterval is the minimum time between two synchronizations of a mirror.
+		MinInterval time.Duration `envconfig:"GITNESS_REPO_MIRROR_MIN_INTERVAL" default:"5m"`
+		// DefaultInterval is the time between two synchronizations of a mirror if not configured otherwise.
+		DefaultInterval time.Duration `envconfig:"GITNESS_REPO_MIRROR_DEFAULT_INTERVAL" default:"1h"`
+		// PushMaxDuration is the maximum duration of a single push to a push mirror target.
+		PushMaxDuration time.Duration `envconfig:"GITNESS_REPO_MIRROR_PUSH_MAX_DURATION" default:"30m"`
+		// PushMaxRetries is the number of times a failed push to a push mirror target is retried.
+		PushMaxRetries int `envconfig:"GITNESS_REPO_MIRROR_PUSH_MAX_RETRIES" default:"5"`
+		// AllowLoopback allows mirrors from and to loopback addresses.
+		AllowLoopback bool `envconfig:"GITNESS_REPO_MIRROR_ALLOW_LOOPBACK" default:"false"`
+		// AllowPrivateNetwork allows mirrors from and to private network addresses.
+		AllowPrivateNetwork bool `envconfig:"GITNESS_REPO_MIRROR_ALLOW_PRIVATE_NETWORK" default:"false"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
@@ -385,11 +414,33 @@ type Config struct {
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		Digest struct {
+			// Enabled enables the pull request digest emails. Users can opt out individually.
+			Enabled bool `envconfig:"GITNESS_NOTIFICATION_DIGEST_ENABLED" default:"true"`
+			// CRON defines how often the digest job runs. It should run every hour to respect the user timezones.
+			CRON        string        `envconfig:"GITNESS_NOTIFICATION_DIGEST_CRON" default:"0 * * * *"`
+			MaxDuration time.Duration `envconfig:"GITNESS_NOTIFICATION_DIGEST_MAX_DURATION" default:"30m"`
+			// DefaultHour is the hour of the day when the digest is sent to users that haven't configured it.
+			DefaultHour int `envconfig:"GITNESS_NOTIFICATION_DIGEST_DEFAULT_HOUR" default:"9"`
+			// DefaultTimezone is the timezone used for users that haven't configured it.
+			DefaultTimezone string `envconfig:"GITNESS_NOTIFICATION_DIGEST_DEFAULT_TIMEZONE" default:"UTC"`
+			// DefaultIdleDays is the number of days after which a pull request without updates is considered idle.
+			DefaultIdleDays int `envconfig:"GITNESS_NOTIFICATION_DIGEST_DEFAULT_IDLE_DAYS" default:"7"`
+		}
 	}
 
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+
+		// IndexDir is the directory used to store the local keyword search index (defaults to a folder in git root).
+		IndexDir string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_DIR"`
+	}
+
+	Audit struct {
+		// RetentionTime is the duration after which audit logs will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"8760h"` // 365 days
 	}
 
 	Repos struct {
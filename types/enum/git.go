@@ -114,3 +114,25 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return "", fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// GitSignatureStatus represents the verification status of a signed commit.
+type GitSignatureStatus string
+
+func (GitSignatureStatus) Enum() []interface{} { return toInterfaceSlice(gitSignatureStatuses) }
+
+// GitSignatureStatus enumeration.
+const (
+	// GitSignatureStatusVerified means that the signature is valid and made with a key of the committer.
+	GitSignatureStatusVerified GitSignatureStatus = "verified"
+	// GitSignatureStatusUnverified means that the signature is invalid, unsupported,
+	// or the key doesn't belong to the committer.
+	GitSignatureStatusUnverified GitSignatureStatus = "unverified"
+	// GitSignatureStatusUnknownKey means that the signing key isn't registered.
+	GitSignatureStatusUnknownKey GitSignatureStatus = "unknown_key"
+)
+
+var gitSignatureStatuses = sortEnum([]GitSignatureStatus{
+	GitSignatureStatusVerified,
+	GitSignatureStatusUnverified,
+	GitSignatureStatusUnknownKey,
+})
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationEvent defines the pull request events users can be notified about.
+type NotificationEvent string
+
+func (NotificationEvent) Enum() []interface{} { return toInterfaceSlice(notificationEvents) }
+func (e NotificationEvent) Sanitize() (NotificationEvent, bool) {
+	return Sanitize(e, GetAllNotificationEvents)
+}
+
+func GetAllNotificationEvents() ([]NotificationEvent, NotificationEvent) {
+	return notificationEvents, "" // No default value
+}
+
+// NotificationEvent enumeration.
+const (
+	// NotificationEventPullReqCreated is sent to the watchers of the repository when a pull request is created.
+	NotificationEventPullReqCreated NotificationEvent = "pullreq_created"
+	// NotificationEventReviewerAdded is sent when a reviewer is requested on a pull request.
+	NotificationEventReviewerAdded NotificationEvent = "reviewer_added"
+	// NotificationEventCommentCreated is sent when a comment is added to a pull request.
+	NotificationEventCommentCreated NotificationEvent = "comment_created"
+	// NotificationEventCommentMentioned is sent when a user is mentioned in a pull request comment.
+	NotificationEventCommentMentioned NotificationEvent = "comment_mentioned"
+	// NotificationEventBranchUpdated is sent when new commits are pushed to the source branch of a pull request.
+	NotificationEventBranchUpdated NotificationEvent = "branch_updated"
+	// NotificationEventReviewSubmitted is sent when a review is submitted on a pull request.
+	NotificationEventReviewSubmitted NotificationEvent = "review_submitted"
+	// NotificationEventStateChanged is sent when a pull request is merged, closed or reopened.
+	NotificationEventStateChanged NotificationEvent = "state_changed"
+)
+
+var notificationEvents = sortEnum([]NotificationEvent{
+	NotificationEventPullReqCreated,
+	NotificationEventReviewerAdded,
+	NotificationEventCommentCreated,
+	NotificationEventCommentMentioned,
+	NotificationEventBranchUpdated,
+	NotificationEventReviewSubmitted,
+	NotificationEventStateChanged,
+})
+
+// NotificationChannel defines the channel through which a notification is delivered.
+type NotificationChannel string
+
+func (NotificationChannel) Enum() []interface{} { return toInterfaceSlice(notificationChannels) }
+func (c NotificationChannel) Sanitize() (NotificationChannel, bool) {
+	return Sanitize(c, GetAllNotificationChannels)
+}
+
+func GetAllNotificationChannels() ([]NotificationChannel, NotificationChannel) {
+	return notificationChannels, "" // No default value
+}
+
+// NotificationChannel enumeration.
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelInApp NotificationChannel = "in_app"
+)
+
+var notificationChannels = sortEnum([]NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelInApp,
+})
+
+// NotificationScope defines what a notification preference applies to.
+type NotificationScope string
+
+func (NotificationScope) Enum() []interface{} { return toInterfaceSlice(notificationScopes) }
+func (s NotificationScope) Sanitize() (NotificationScope, bool) {
+	return Sanitize(s, GetAllNotificationScopes)
+}
+
+func GetAllNotificationScopes() ([]NotificationScope, NotificationScope) {
+	return notificationScopes, "" // No default value
+}
+
+// NotificationScope enumeration.
+const (
+	// NotificationScopeSpace applies to all repositories in the space and its subspaces.
+	NotificationScopeSpace NotificationScope = "space"
+	// NotificationScopeRepo applies to a single repository and overrides the space preferences.
+	NotificationScopeRepo NotificationScope = "repo"
+	// NotificationScopeWatch applies to notifications received only because of a watched repository
+	// or pull request.
+	NotificationScopeWatch NotificationScope = "watch"
+)
+
+var notificationScopes = sortEnum([]NotificationScope{
+	NotificationScopeSpace,
+	NotificationScopeRepo,
+	NotificationScopeWatch,
+})
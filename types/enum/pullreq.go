@@ -91,6 +91,8 @@ const (
 	PullReqActivityTypeTargetBranchChange PullReqActivityType = "target-branch-change"
 	PullReqActivityTypeMerge              PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify        PullReqActivityType = "label-modify"
+	PullReqActivityTypeAutoMerge          PullReqActivityType = "auto-merge"
+	PullReqActivityTypeMergeQueue         PullReqActivityType = "merge-queue"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -107,6 +109,48 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeTargetBranchChange,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeAutoMerge,
+	PullReqActivityTypeMergeQueue,
+})
+
+// PullReqAutoMergeAction defines the action of a pull request auto-merge activity.
+type PullReqAutoMergeAction string
+
+func (PullReqAutoMergeAction) Enum() []interface{} { return toInterfaceSlice(pullReqAutoMergeActions) }
+
+// PullReqAutoMergeAction enumeration.
+const (
+	PullReqAutoMergeActionArmed    PullReqAutoMergeAction = "armed"
+	PullReqAutoMergeActionDisarmed PullReqAutoMergeAction = "disarmed"
+	PullReqAutoMergeActionMerged   PullReqAutoMergeAction = "merged"
+	PullReqAutoMergeActionFailed   PullReqAutoMergeAction = "failed"
+)
+
+var pullReqAutoMergeActions = sortEnum([]PullReqAutoMergeAction{
+	PullReqAutoMergeActionArmed,
+	PullReqAutoMergeActionDisarmed,
+	PullReqAutoMergeActionMerged,
+	PullReqAutoMergeActionFailed,
+})
+
+// PullReqMergeQueueAction defines the action of a pull request merge queue activity.
+type PullReqMergeQueueAction string
+
+func (PullReqMergeQueueAction) Enum() []interface{} {
+	return toInterfaceSlice(pullReqMergeQueueActions)
+}
+
+// PullReqMergeQueueAction enumeration.
+const (
+	PullReqMergeQueueActionEnqueued PullReqMergeQueueAction = "enqueued"
+	PullReqMergeQueueActionDequeued PullReqMergeQueueAction = "dequeued"
+	PullReqMergeQueueActionEjected  PullReqMergeQueueAction = "ejected"
+)
+
+var pullReqMergeQueueActions = sortEnum([]PullReqMergeQueueAction{
+	PullReqMergeQueueActionEnqueued,
+	PullReqMergeQueueActionDequeued,
+	PullReqMergeQueueActionEjected,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -224,6 +268,34 @@ var pullReqReviewerTypes = sortEnum([]PullReqReviewerType{
 	PullReqReviewerTypeDefault,
 })
 
+// ReviewerAssignmentStrategy defines how reviewers are picked when they are assigned automatically.
+type ReviewerAssignmentStrategy string
+
+func (ReviewerAssignmentStrategy) Enum() []interface{} {
+	return toInterfaceSlice(reviewerAssignmentStrategies)
+}
+
+func (s ReviewerAssignmentStrategy) Sanitize() (ReviewerAssignmentStrategy, bool) {
+	return Sanitize(s, GetAllReviewerAssignmentStrategies)
+}
+
+func GetAllReviewerAssignmentStrategies() ([]ReviewerAssignmentStrategy, ReviewerAssignmentStrategy) {
+	return reviewerAssignmentStrategies, ReviewerAssignmentStrategyRoundRobin
+}
+
+// ReviewerAssignmentStrategy enumeration.
+const (
+	// ReviewerAssignmentStrategyRoundRobin picks the reviewers who were automatically assigned the longest time ago.
+	ReviewerAssignmentStrategyRoundRobin ReviewerAssignmentStrategy = "round_robin"
+	// ReviewerAssignmentStrategyLeastLoaded picks the reviewers with the fewest pending reviews of open pull requests.
+	ReviewerAssignmentStrategyLeastLoaded ReviewerAssignmentStrategy = "least_loaded"
+)
+
+var reviewerAssignmentStrategies = sortEnum([]ReviewerAssignmentStrategy{
+	ReviewerAssignmentStrategyRoundRobin,
+	ReviewerAssignmentStrategyLeastLoaded,
+})
+
 type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PullReqReaction defines the emoji reactions available on pull request comments and descriptions.
+type PullReqReaction string
+
+func (PullReqReaction) Enum() []interface{} { return toInterfaceSlice(pullReqReactions) }
+func (r PullReqReaction) Sanitize() (PullReqReaction, bool) {
+	return Sanitize(r, GetAllPullReqReactions)
+}
+
+func GetAllPullReqReactions() ([]PullReqReaction, PullReqReaction) {
+	return pullReqReactions, "" // No default value
+}
+
+// PullReqReaction enumeration.
+const (
+	PullReqReactionThumbsUp   PullReqReaction = "thumbs_up"
+	PullReqReactionThumbsDown PullReqReaction = "thumbs_down"
+	PullReqReactionLaugh      PullReqReaction = "laugh"
+	PullReqReactionHooray     PullReqReaction = "hooray"
+	PullReqReactionConfused   PullReqReaction = "confused"
+	PullReqReactionHeart      PullReqReaction = "heart"
+	PullReqReactionRocket     PullReqReaction = "rocket"
+	PullReqReactionEyes       PullReqReaction = "eyes"
+)
+
+var pullReqReactions = sortEnum([]PullReqReaction{
+	PullReqReactionThumbsUp,
+	PullReqReactionThumbsDown,
+	PullReqReactionLaugh,
+	PullReqReactionHooray,
+	PullReqReactionConfused,
+	PullReqReactionHeart,
+	PullReqReactionRocket,
+	PullReqReactionEyes,
+})
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoMirrorSyncState defines the state of the last synchronization of a repository mirror.
+type RepoMirrorSyncState string
+
+func (RepoMirrorSyncState) Enum() []interface{} { return toInterfaceSlice(repoMirrorSyncStates) }
+
+// RepoMirrorSyncState enumeration.
+const (
+	// RepoMirrorSyncStatePending means that the mirror hasn't been synchronized yet.
+	RepoMirrorSyncStatePending RepoMirrorSyncState = "pending"
+	// RepoMirrorSyncStateRunning means that the mirror is currently being synchronized.
+	RepoMirrorSyncStateRunning RepoMirrorSyncState = "running"
+	// RepoMirrorSyncStateSuccess means that the last synchronization of the mirror succeeded.
+	RepoMirrorSyncStateSuccess RepoMirrorSyncState = "success"
+	// RepoMirrorSyncStateFailure means that the last synchronization of the mirror failed.
+	RepoMirrorSyncStateFailure RepoMirrorSyncState = "failure"
+)
+
+var repoMirrorSyncStates = sortEnum([]RepoMirrorSyncState{
+	RepoMirrorSyncStatePending,
+	RepoMirrorSyncStateRunning,
+	RepoMirrorSyncStateSuccess,
+	RepoMirrorSyncStateFailure,
+})
@@ -27,10 +27,12 @@ const (
 	SSETypeExecutionCompleted SSEType = "execution_completed"
 	SSETypeExecutionCanceled  SSEType = "execution_canceled"
 
-	// Repo import/export.
+	// Repo import/export/mirror.
 
-	SSETypeRepositoryImportCompleted SSEType = "repository_import_completed"
-	SSETypeRepositoryExportCompleted SSEType = "repository_export_completed"
+	SSETypeRepositoryImportCompleted     SSEType = "repository_import_completed"
+	SSETypeRepositoryExportCompleted     SSEType = "repository_export_completed"
+	SSETypeRepositoryMirrorSyncCompleted SSEType = "repository_mirror_sync_completed"
+	SSETypeRepositoryPushMirrorCompleted SSEType = "repository_push_mirror_completed"
 
 	// Pull reqs.
 
@@ -46,6 +48,8 @@ const (
 	SSETypePullReqCommentStatusResolved    SSEType = "pullreq_comment_status_resolved"
 	SSETypePullReqCommentStatusReactivated SSEType = "pullreq_comment_status_reactivated"
 
+	SSETypePullReqReactionsUpdated SSEType = "pullreq_reactions_updated"
+
 	SSETypePullReqOpened         SSEType = "pullreq_opened"
 	SSETypePullReqClosed         SSEType = "pullreq_closed"
 	SSETypePullReqMarkedAsDraft  SSEType = "pullreq_marked_as_draft"
@@ -84,4 +88,8 @@ const (
 	SSETypeWebhookCreated SSEType = "webhook_created"
 	SSETypeWebhookUpdated SSEType = "webhook_updated"
 	SSETypeWebhookDeleted SSEType = "webhook_deleted"
+
+	// Notifications.
+
+	SSETypeNotificationUnreadCount SSEType = "notification_unread_count"
 )
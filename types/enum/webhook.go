@@ -117,12 +117,16 @@ const (
 
 	// WebhookExecutionResultFatalError describes a webhook execution result that failed with an unrecoverable error.
 	WebhookExecutionResultFatalError WebhookExecutionResult = "fatal_error"
+
+	// WebhookExecutionResultSkipped describes a webhook execution that was skipped because of the webhook filters.
+	WebhookExecutionResultSkipped WebhookExecutionResult = "skipped"
 )
 
 var webhookExecutionResults = sortEnum([]WebhookExecutionResult{
 	WebhookExecutionResultSuccess,
 	WebhookExecutionResultRetriableError,
 	WebhookExecutionResultFatalError,
+	WebhookExecutionResultSkipped,
 })
 
 // WebhookType defines different types of a webhook.
@@ -147,6 +151,127 @@ var webhookTypes = sortEnum([]WebhookType{
 	WebhookTypeJira,
 })
 
+// WebhookFormat defines the format of the payload sent by a webhook.
+type WebhookFormat string
+
+func (WebhookFormat) Enum() []interface{}               { return toInterfaceSlice(webhookFormats) }
+func (f WebhookFormat) Sanitize() (WebhookFormat, bool) { return Sanitize(f, GetAllWebhookFormats) }
+
+func GetAllWebhookFormats() ([]WebhookFormat, WebhookFormat) {
+	return webhookFormats, WebhookFormatJSON
+}
+
+const (
+	// WebhookFormatJSON describes the generic JSON payload of a webhook.
+	WebhookFormatJSON WebhookFormat = "json"
+
+	// WebhookFormatSlack describes a Slack message using Block Kit.
+	WebhookFormatSlack WebhookFormat = "slack"
+
+	// WebhookFormatTeams describes a Microsoft Teams message using an Adaptive Card.
+	WebhookFormatTeams WebhookFormat = "teams"
+
+	// WebhookFormatDiscord describes a Discord message using an embed.
+	WebhookFormatDiscord WebhookFormat = "discord"
+
+	// WebhookFormatMattermost describes a Mattermost message using an attachment.
+	WebhookFormatMattermost WebhookFormat = "mattermost"
+)
+
+var webhookFormats = sortEnum([]WebhookFormat{
+	WebhookFormatJSON,
+	WebhookFormatSlack,
+	WebhookFormatTeams,
+	WebhookFormatDiscord,
+	WebhookFormatMattermost,
+})
+
+// WebhookCloudEventsMode defines whether and how a webhook sends its payload as a CloudEvents 1.0 event.
+type WebhookCloudEventsMode string
+
+func (WebhookCloudEventsMode) Enum() []interface{} { return toInterfaceSlice(webhookCloudEventsModes) }
+func (m WebhookCloudEventsMode) Sanitize() (WebhookCloudEventsMode, bool) {
+	return Sanitize(m, GetAllWebhookCloudEventsModes)
+}
+
+func GetAllWebhookCloudEventsModes() ([]WebhookCloudEventsMode, WebhookCloudEventsMode) {
+	return webhookCloudEventsModes, WebhookCloudEventsModeDisabled
+}
+
+const (
+	// WebhookCloudEventsModeDisabled describes a webhook that doesn't send CloudEvents.
+	WebhookCloudEventsModeDisabled WebhookCloudEventsMode = "disabled"
+
+	// WebhookCloudEventsModeBinary describes the binary HTTP content mode,
+	// where the event attributes are sent as ce-* headers and the payload is sent as is.
+	WebhookCloudEventsModeBinary WebhookCloudEventsMode = "binary"
+
+	// WebhookCloudEventsModeStructured describes the structured HTTP content mode,
+	// where the event attributes and the payload are sent together as application/cloudevents+json.
+	WebhookCloudEventsModeStructured WebhookCloudEventsMode = "structured"
+)
+
+var webhookCloudEventsModes = sortEnum([]WebhookCloudEventsMode{
+	WebhookCloudEventsModeDisabled,
+	WebhookCloudEventsModeBinary,
+	WebhookCloudEventsModeStructured,
+})
+
+// WebhookAuthType defines how a webhook authenticates against the receiver.
+type WebhookAuthType string
+
+func (WebhookAuthType) Enum() []interface{} { return toInterfaceSlice(webhookAuthTypes) }
+func (t WebhookAuthType) Sanitize() (WebhookAuthType, bool) {
+	return Sanitize(t, GetAllWebhookAuthTypes)
+}
+
+func GetAllWebhookAuthTypes() ([]WebhookAuthType, WebhookAuthType) {
+	return webhookAuthTypes, WebhookAuthTypeNone
+}
+
+const (
+	// WebhookAuthTypeNone describes a webhook without any authentication apart from the payload signature.
+	WebhookAuthTypeNone WebhookAuthType = "none"
+
+	// WebhookAuthTypeBearer describes a webhook sending a bearer token stored in a secret.
+	WebhookAuthTypeBearer WebhookAuthType = "bearer"
+
+	// WebhookAuthTypeMTLS describes a webhook using a client certificate, with the private key stored in a secret.
+	WebhookAuthTypeMTLS WebhookAuthType = "mtls"
+)
+
+var webhookAuthTypes = sortEnum([]WebhookAuthType{
+	WebhookAuthTypeNone,
+	WebhookAuthTypeBearer,
+	WebhookAuthTypeMTLS,
+})
+
+// WebhookSignatureType defines how the payload of a webhook is signed using the secret of the webhook.
+type WebhookSignatureType string
+
+func (WebhookSignatureType) Enum() []interface{} { return toInterfaceSlice(webhookSignatureTypes) }
+func (t WebhookSignatureType) Sanitize() (WebhookSignatureType, bool) {
+	return Sanitize(t, GetAllWebhookSignatureTypes)
+}
+
+func GetAllWebhookSignatureTypes() ([]WebhookSignatureType, WebhookSignatureType) {
+	return webhookSignatureTypes, WebhookSignatureTypeHMACSHA256
+}
+
+const (
+	// WebhookSignatureTypeHMACSHA256 describes a HMAC-SHA256 signature of the payload.
+	WebhookSignatureTypeHMACSHA256 WebhookSignatureType = "hmac_sha256"
+
+	// WebhookSignatureTypeHMACSHA256Timestamped describes a HMAC-SHA256 signature of the timestamp
+	// of the request and the payload, allowing receivers to reject replayed requests.
+	WebhookSignatureTypeHMACSHA256Timestamped WebhookSignatureType = "hmac_sha256_timestamped"
+)
+
+var webhookSignatureTypes = sortEnum([]WebhookSignatureType{
+	WebhookSignatureTypeHMACSHA256,
+	WebhookSignatureTypeHMACSHA256Timestamped,
+})
+
 // WebhookTrigger defines the different types of webhook triggers available.
 type WebhookTrigger string
 
@@ -197,6 +322,31 @@ const (
 	// WebhookTriggerPullReqTargetBranchChanged gets triggered when a pull request target branch is changed.
 	WebhookTriggerPullReqTargetBranchChanged = "pullreq_target_branch_changed"
 
+	// WebhookTriggerExecutionStarted gets triggered when a pipeline execution starts running.
+	WebhookTriggerExecutionStarted WebhookTrigger = "execution_started"
+	// WebhookTriggerExecutionSucceeded gets triggered when a pipeline execution finishes successfully.
+	WebhookTriggerExecutionSucceeded WebhookTrigger = "execution_succeeded"
+	// WebhookTriggerExecutionFailed gets triggered when a pipeline execution finishes with a failure or an error.
+	WebhookTriggerExecutionFailed WebhookTrigger = "execution_failed"
+	// WebhookTriggerExecutionCanceled gets triggered when a pipeline execution gets canceled.
+	WebhookTriggerExecutionCanceled WebhookTrigger = "execution_canceled"
+
+	// WebhookTriggerRepoCreated gets triggered when a repository gets created.
+	WebhookTriggerRepoCreated WebhookTrigger = "repo_created"
+	// WebhookTriggerRepoDeleted gets triggered when a repository gets deleted.
+	WebhookTriggerRepoDeleted WebhookTrigger = "repo_deleted"
+	// WebhookTriggerRepoRenamed gets triggered when a repository gets renamed.
+	WebhookTriggerRepoRenamed WebhookTrigger = "repo_renamed"
+	// WebhookTriggerRepoDefaultBranchChanged gets triggered when the default branch of a repository is changed.
+	WebhookTriggerRepoDefaultBranchChanged WebhookTrigger = "repo_default_branch_changed"
+
+	// WebhookTriggerRuleCreated gets triggered when a protection rule gets created.
+	WebhookTriggerRuleCreated WebhookTrigger = "rule_created"
+	// WebhookTriggerRuleUpdated gets triggered when a protection rule gets updated.
+	WebhookTriggerRuleUpdated WebhookTrigger = "rule_updated"
+	// WebhookTriggerRuleDeleted gets triggered when a protection rule gets deleted.
+	WebhookTriggerRuleDeleted WebhookTrigger = "rule_deleted"
+
 	// WebhookTriggerArtifactCreated gets triggered when an artifact gets created.
 	WebhookTriggerArtifactCreated WebhookTrigger = "artifact_created"
 	// WebhookTriggerArtifactDeleted gets triggered when an artifact gets deleted.
@@ -222,6 +372,17 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqLabelAssigned,
 	WebhookTriggerPullReqReviewSubmitted,
 	WebhookTriggerPullReqTargetBranchChanged,
+	WebhookTriggerExecutionStarted,
+	WebhookTriggerExecutionSucceeded,
+	WebhookTriggerExecutionFailed,
+	WebhookTriggerExecutionCanceled,
+	WebhookTriggerRepoCreated,
+	WebhookTriggerRepoDeleted,
+	WebhookTriggerRepoRenamed,
+	WebhookTriggerRepoDefaultBranchChanged,
+	WebhookTriggerRuleCreated,
+	WebhookTriggerRuleUpdated,
+	WebhookTriggerRuleDeleted,
 	WebhookTriggerArtifactCreated,
 	WebhookTriggerArtifactDeleted,
 })
@@ -96,6 +96,9 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	// SignatureStatus is the verification status of the commit signature, empty for unsigned commits.
+	SignatureStatus enum.GitSignatureStatus `json:"signature_status,omitempty"`
 }
 
 type Signature struct {
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+// The entries of a queue are merged speculatively on top of each other and the resulting
+// commits are validated by status checks before the target branch gets fast-forwarded to them.
+type MergeQueueEntry struct {
+	PullReqID     int64  `json:"-"`
+	PullReqNumber int64  `json:"pullreq_number"`
+	RepoID        int64  `json:"-"`
+	Branch        string `json:"branch"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	State       enum.MergeQueueEntryState `json:"state"`
+	MergeMethod enum.MergeMethod          `json:"merge_method"`
+	Title       string                    `json:"title"`
+	Message     string                    `json:"message"`
+
+	// SourceSHA is the pull request source branch commit that is being merged.
+	SourceSHA string `json:"source_sha"`
+	// BaseSHA is the commit on top of which the speculative merge commit has been created.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeSHA is the speculative merge commit. The target branch gets fast-forwarded to it.
+	MergeSHA string `json:"merge_sha,omitempty"`
+
+	Author PrincipalInfo `json:"author"`
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// NotificationPreference enables or disables a notification event on a delivery channel
+// for a space, a repository or for the watched resources of a user.
+type NotificationPreference struct {
+	ID          int64 `json:"id"`
+	PrincipalID int64 `json:"-"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+
+	Scope enum.NotificationScope `json:"scope"`
+	// ScopeID is the ID of the space or the repository, it is zero for the watch scope.
+	ScopeID int64 `json:"-"`
+	// ScopePath is the path of the space or the repository, it is empty for the watch scope.
+	ScopePath string `json:"scope_path,omitempty"`
+
+	Event   enum.NotificationEvent   `json:"event"`
+	Channel enum.NotificationChannel `json:"channel"`
+	Enabled bool                     `json:"enabled"`
+}
+
+// NotificationWatch marks a repository or a single pull request as watched by a user.
+type NotificationWatch struct {
+	ID          int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+	RepoID      int64 `json:"-"`
+	// PullReqID is nil if the whole repository is watched.
+	PullReqID *int64 `json:"-"`
+	Created   int64  `json:"created"`
+}
+
+// NotificationWatchStatus tells if the current user is watching a repository or a pull request.
+type NotificationWatchStatus struct {
+	Watching bool  `json:"watching"`
+	Since    int64 `json:"since,omitempty"`
+}
+
+// Notification is an entry in the in-app notification inbox of a user.
+type Notification struct {
+	ID          int64 `json:"id"`
+	PrincipalID int64 `json:"-"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+
+	Event   enum.NotificationEvent `json:"event"`
+	ActorID int64                  `json:"-"`
+	Actor   PrincipalInfo          `json:"actor"`
+
+	RepoID        int64  `json:"repo_id"`
+	RepoPath      string `json:"repo_path,omitempty"`
+	PullReqID     int64  `json:"pullreq_id"`
+	PullReqNumber int64  `json:"pullreq_number"`
+
+	// Title is the title of the pull request at the time of the event.
+	Title string `json:"title"`
+	// Text holds event specific details, like the text of a comment.
+	Text string `json:"text,omitempty"`
+
+	Read bool `json:"read"`
+}
+
+// NotificationFilter stores the in-app notification query parameters.
+type NotificationFilter struct {
+	Pagination
+	// Read filters the notifications by their read state, all notifications are returned if nil.
+	Read *bool `json:"read"`
+}
+
+// NotificationUnreadCount holds the number of unread in-app notifications of a user.
+type NotificationUnreadCount struct {
+	UnreadCount int64 `json:"unread_count"`
+}
@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// NotificationDigest holds the configuration of the pull request digest email of a user.
+type NotificationDigest struct {
+	PrincipalID int64 `json:"-"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+
+	Enabled bool `json:"enabled"`
+
+	// Hour is the hour of the day in the timezone of the user when the digest is sent.
+	Hour int `json:"hour"`
+
+	// Timezone is the IANA time zone name of the user, e.g. "Europe/Berlin".
+	Timezone string `json:"timezone"`
+
+	// IdleDays is the number of days without any update after which an open pull request is considered idle.
+	IdleDays int `json:"idle_days"`
+
+	LastSent int64 `json:"last_sent,omitempty"`
+}
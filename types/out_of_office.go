@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// OutOfOffice holds the period in which a user is unavailable,
+// the user isn't picked as a reviewer during it.
+type OutOfOffice struct {
+	PrincipalID int64 `json:"-"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+
+	// From is the start of the absence.
+	From int64 `json:"from"`
+	// Until is the end of the absence, zero if it lasts until cleared.
+	Until int64 `json:"until,omitempty"`
+
+	Message string `json:"message,omitempty"`
+}
+
+// IsActive returns true if the absence covers the provided time.
+func (o *OutOfOffice) IsActive(now int64) bool {
+	return o.From <= now && (o.Until == 0 || now < o.Until)
+}
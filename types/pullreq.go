@@ -69,6 +69,9 @@ type PullReq struct {
 	Labels       []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
 	CheckSummary *CheckCountSummary            `json:"check_summary,omitempty"`
 	Rules        []RuleInfo                    `json:"rules,omitempty"`
+
+	// Reactions holds the reactions on the pull request description, used only in response.
+	Reactions []PullReqReactionSummary `json:"reactions,omitempty"`
 }
 
 func (pr *PullReq) UpdateMergeOutcome(method enum.MergeMethod, conflictFiles []string) {
@@ -143,6 +146,25 @@ type PullReqStats struct {
 	UnresolvedCount int `json:"unresolved_count,omitempty"`
 }
 
+// PullReqStack holds the pull requests stacked below and above a pull request.
+// A pull request is stacked on another if its target branch is the source branch of the other one.
+type PullReqStack struct {
+	// Parents are the pull requests below, starting with the nearest one.
+	Parents []*PullReq `json:"parents"`
+	// Children are the pull requests directly above.
+	Children []*PullReq `json:"children"`
+}
+
+// PullReqTemplate is a template for the pull request description.
+type PullReqTemplate struct {
+	Name string `json:"name"`
+	// Path is the path of the template file in the repository, empty for templates inherited from a space.
+	Path string `json:"path,omitempty"`
+	// Space is the path of the space the template is inherited from.
+	Space   string `json:"space,omitempty"`
+	Content string `json:"content"`
+}
+
 // PullReqFilter stores pull request query parameters.
 type PullReqFilter struct {
 	Page               int                          `json:"page"`
@@ -194,6 +216,26 @@ type PullReqReview struct {
 	SHA      string                     `json:"sha"`
 }
 
+// PullReqRevision is a recorded state of the pull request source branch.
+type PullReqRevision struct {
+	// Number is the ordinal number of the revision, starting with 1.
+	Number  int    `json:"number"`
+	SHA     string `json:"sha"`
+	Created int64  `json:"created"`
+	Forced  bool   `json:"forced,omitempty"`
+
+	// Reviews are the latest reviews of the reviewers that have been submitted for the revision.
+	Reviews []PullReqRevisionReview `json:"reviews"`
+}
+
+// PullReqRevisionReview holds the latest review of a reviewer submitted for a pull request revision.
+type PullReqRevisionReview struct {
+	ReviewID int64                      `json:"review_id"`
+	Reviewed int64                      `json:"reviewed"`
+	Decision enum.PullReqReviewDecision `json:"decision"`
+	Reviewer PrincipalInfo              `json:"reviewer"`
+}
+
 // PullReqReviewer holds pull request reviewer.
 type PullReqReviewer struct {
 	PullReqID   int64 `json:"-"`
@@ -214,6 +256,14 @@ type PullReqReviewer struct {
 	AddedBy  PrincipalInfo `json:"added_by"`
 }
 
+// ReviewerAssignmentStats holds the data used to pick automatically assigned reviewers.
+type ReviewerAssignmentStats struct {
+	// LastAssigned is the time the principal was last added as a default reviewer, zero if never.
+	LastAssigned int64
+	// OpenReviews is the number of open pull requests waiting for the principal's review.
+	OpenReviews int64
+}
+
 type UserGroupReviewer struct {
 	PullReqID   int64 `json:"-"`
 	UserGroupID int64 `json:"-"`
@@ -251,6 +301,23 @@ type PullReqFileView struct {
 	Updated int64 `json:"-"`
 }
 
+// PullReqAutoMerge represents an armed auto-merge of a pull request.
+// The pull request gets merged automatically as soon as no protection rule blocks the merge.
+type PullReqAutoMerge struct {
+	PullReqID int64 `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	MergeMethod  enum.MergeMethod `json:"merge_method"`
+	Title        string           `json:"title"`
+	Message      string           `json:"message"`
+	CancelOnPush bool             `json:"cancel_on_push"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
 type DefaultReviewerApprovalsResponse struct {
 	MinimumRequiredCount       int              `json:"minimum_required_count"`
 	MinimumRequiredCountLatest int              `json:"minimum_required_count_latest"`
@@ -280,6 +347,7 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest bool `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution        bool `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests         bool `json:"requires_no_change_requests,omitempty"`
+	RequiresMergeQueue               bool `json:"requires_merge_queue,omitempty"`
 }
 
 type MergeViolations struct {
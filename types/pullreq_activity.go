@@ -51,12 +51,17 @@ type PullReqActivity struct {
 	ResolvedBy *int64 `json:"-"` // not returned, because the resolver info is in the Resolver field
 	Resolved   *int64 `json:"resolved,omitempty"`
 
+	// Pending comments are visible only to their author until published with a review.
+	Pending bool `json:"pending,omitempty"`
+
 	Author   PrincipalInfo  `json:"author"`
 	Resolver *PrincipalInfo `json:"resolver,omitempty"`
 
 	CodeComment *CodeCommentFields `json:"code_comment,omitempty"`
 
 	Mentions map[int64]*PrincipalInfo `json:"mentions,omitempty"` // used only in response
+
+	Reactions []PullReqReactionSummary `json:"reactions,omitempty"` // used only in response
 }
 
 func (a *PullReqActivity) IsValidCodeComment() bool {
@@ -165,4 +170,7 @@ type PullReqActivityFilter struct {
 
 	Types []enum.PullReqActivityType `json:"type"`
 	Kinds []enum.PullReqActivityKind `json:"kind"`
+
+	// PendingAuthorID includes pending comments of the principal, pending comments of others are never listed.
+	PendingAuthorID int64 `json:"-"`
 }
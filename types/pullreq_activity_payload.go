@@ -61,6 +61,8 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadAutoMerge{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadMergeQueue{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -102,6 +104,28 @@ func (a *PullRequestActivityPayloadMerge) ActivityType() enum.PullReqActivityTyp
 	return enum.PullReqActivityTypeMerge
 }
 
+type PullRequestActivityPayloadAutoMerge struct {
+	Action      enum.PullReqAutoMergeAction `json:"action"`
+	MergeMethod enum.MergeMethod            `json:"merge_method"`
+	// Reason explains why the auto-merge has been disarmed or why it failed.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadAutoMerge) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAutoMerge
+}
+
+type PullRequestActivityPayloadMergeQueue struct {
+	Action      enum.PullReqMergeQueueAction `json:"action"`
+	MergeMethod enum.MergeMethod             `json:"merge_method"`
+	// Reason explains why the pull request has been ejected from the merge queue.
+	Reason string `json:"reason,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMergeQueue) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMergeQueue
+}
+
 type PullRequestActivityPayloadStateChange struct {
 	Old      enum.PullReqState `json:"old"`
 	New      enum.PullReqState `json:"new"`
@@ -182,6 +206,10 @@ func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqAct
 type PullRequestActivityPayloadBranchChangeTarget struct {
 	Old string `json:"old"`
 	New string `json:"new"`
+
+	// MergedPullReqNumber is set if the target branch was changed automatically
+	// because the pull request stacked below has been merged.
+	MergedPullReqNumber int64 `json:"merged_pullreq_number,omitempty"`
 }
 
 func (a *PullRequestActivityPayloadBranchChangeTarget) ActivityType() enum.PullReqActivityType {
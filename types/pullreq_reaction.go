@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullReqReaction is an emoji reaction of a principal
+// on a pull request comment or on the pull request description.
+type PullReqReaction struct {
+	PullReqID int64 `json:"pullreq_id"`
+	// ActivityID is the ID of the comment, it is nil for reactions on the pull request description.
+	ActivityID  *int64               `json:"activity_id,omitempty"`
+	PrincipalID int64                `json:"-"`
+	Reaction    enum.PullReqReaction `json:"reaction"`
+	Created     int64                `json:"created"`
+}
+
+// PullReqReactionSummary holds the number of reactions of the same kind.
+type PullReqReactionSummary struct {
+	Reaction enum.PullReqReaction `json:"reaction"`
+	Count    int64                `json:"count"`
+	// Reacted is true if the current principal is among the principals who reacted.
+	Reacted bool `json:"reacted,omitempty"`
+}
+
+// PullReqReactionsUpdate is the payload of the event sent when reactions on a pull request change.
+type PullReqReactionsUpdate struct {
+	PullReqID     int64                    `json:"pullreq_id"`
+	PullReqNumber int64                    `json:"pullreq_number"`
+	ActivityID    *int64                   `json:"activity_id,omitempty"`
+	Reactions     []PullReqReactionSummary `json:"reactions"`
+}
@@ -25,6 +25,7 @@ type RepositoryCore struct {
 	Path          string         `json:"path" yaml:"path"`
 	GitUID        string         `json:"-" yaml:"-"`
 	DefaultBranch string         `json:"default_branch" yaml:"default_branch"`
+	ForkID        int64          `json:"-" yaml:"-"`
 	State         enum.RepoState `json:"-" yaml:"-"`
 }
 
@@ -79,6 +80,7 @@ func (r *Repository) Core() *RepositoryCore {
 		Path:          r.Path,
 		GitUID:        r.GitUID,
 		DefaultBranch: r.DefaultBranch,
+		ForkID:        r.ForkID,
 		State:         r.State,
 	}
 }
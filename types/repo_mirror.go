@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoMirror represents the configuration and the synchronization status of a repository
+// that is kept in sync with an external upstream repository.
+type RepoMirror struct {
+	RepoID int64 `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// URL is the clone URL of the upstream repository (without credentials).
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretSpaceID and PasswordSecretIdentifier reference the secret holding the password
+	// (or the access token) used to authenticate against the upstream repository.
+	PasswordSecretSpaceID    int64  `json:"-"`
+	PasswordSecretSpacePath  string `json:"password_secret_space_path,omitempty"`
+	PasswordSecretIdentifier string `json:"password_secret_identifier,omitempty"`
+
+	// Interval is the time between two synchronizations of the mirror in seconds.
+	Interval int64 `json:"interval"`
+
+	SyncState   enum.RepoMirrorSyncState `json:"sync_state"`
+	LastSync    int64                    `json:"last_sync,omitempty"`
+	LastSuccess int64                    `json:"last_success,omitempty"`
+	LastError   string                   `json:"last_error,omitempty"`
+	NextSync    int64                    `json:"next_sync"`
+}
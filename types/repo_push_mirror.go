@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoPushMirror represents a downstream repository to which all changes of the repository are pushed,
+// together with the status of the last push.
+type RepoPushMirror struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// URL is the URL of the downstream repository (without credentials).
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretSpaceID and PasswordSecretIdentifier reference the secret holding the password
+	// (or the access token) used to authenticate against the downstream repository.
+	PasswordSecretSpaceID    int64  `json:"-"`
+	PasswordSecretSpacePath  string `json:"password_secret_space_path,omitempty"`
+	PasswordSecretIdentifier string `json:"password_secret_identifier,omitempty"`
+
+	// ProtectedOnly limits the mirroring to the branches and tags protected by a rule.
+	ProtectedOnly bool `json:"protected_only"`
+
+	SyncState   enum.RepoMirrorSyncState `json:"sync_state"`
+	LastSync    int64                    `json:"last_sync,omitempty"`
+	LastSuccess int64                    `json:"last_success,omitempty"`
+	LastError   string                   `json:"last_error,omitempty"`
+}
@@ -45,9 +45,26 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
+
+	// Format defines how the payload is rendered, e.g. as generic JSON or as a native chat message.
+	Format enum.WebhookFormat `json:"format"`
+	// Template optionally overrides the text of chat messages. It's ignored for the JSON format.
+	Template string `json:"template,omitempty"`
+
+	// Filters optionally restrict the events for which the webhook is executed.
+	Filters WebhookTriggerFilters `json:"filters"`
+
+	// Delivery defines how the requests of the webhook are built and authenticated.
+	Delivery WebhookDelivery `json:"delivery"`
+
+	// ConsecutiveFailures is the number of failed executions since the last successful one.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// DisabledReason is set in case the webhook got disabled automatically.
+	DisabledReason string `json:"disabled_reason,omitempty"`
 }
 
-// MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
+// MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field
+// and to hide the values of the static delivery headers, as they might contain credentials.
 // NOTE: This is required as we don't expose the `Secret` field and thus the caller wouldn't know whether
 // the webhook contains a secret or not.
 // NOTE: This is used as an alternative to adding an `HasSecret` field to Webhook itself, which would
@@ -58,12 +75,14 @@ func (w *Webhook) MarshalJSON() ([]byte, error) {
 	type WebhookAlias Webhook
 	return json.Marshal(&struct {
 		*WebhookAlias
-		HasSecret bool `json:"has_secret"`
+		HasSecret bool            `json:"has_secret"`
+		Delivery  WebhookDelivery `json:"delivery"`
 		// TODO [CODE-1363]: remove after identifier migration.
 		UID string `json:"uid"`
 	}{
 		WebhookAlias: (*WebhookAlias)(w),
 		HasSecret:    w != nil && w.Secret != "",
+		Delivery:     w.Delivery.withoutHeaderValues(),
 		// TODO [CODE-1363]: remove after identifier migration.
 		UID: w.Identifier,
 	})
@@ -81,6 +100,67 @@ type WebhookCreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	Format      enum.WebhookFormat    `json:"format"`
+	Template    string                `json:"template"`
+	Filters     WebhookTriggerFilters `json:"filters"`
+	Delivery    WebhookDelivery       `json:"delivery"`
+}
+
+// WebhookTriggerFilters restricts the events for which a webhook is executed.
+// A filter is only evaluated for events that carry the respective information
+// (e.g. branch filters are ignored for tag events), and an empty filter matches everything.
+type WebhookTriggerFilters struct {
+	// Branches contains glob patterns of which one has to match the branch of branch and execution events.
+	Branches []string `json:"branches,omitempty"`
+	// Tags contains glob patterns of which one has to match the tag of tag events.
+	Tags []string `json:"tags,omitempty"`
+	// Paths contains glob patterns of which one has to match a file changed by a branch update.
+	// For created branches the changes are compared with the default branch of the repository,
+	// and the filter is ignored if the default branch itself gets created.
+	Paths []string `json:"paths,omitempty"`
+	// TargetBranches contains glob patterns of which one has to match the target branch of pull request events.
+	TargetBranches []string `json:"target_branches,omitempty"`
+	// Labels contains label keys of which one has to be assigned to the pull request of pull request events.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// WebhookDelivery defines how the requests of a webhook are built and authenticated.
+type WebhookDelivery struct {
+	// CloudEvents defines whether the payload is sent as a CloudEvents 1.0 event and which HTTP content mode is used.
+	CloudEvents enum.WebhookCloudEventsMode `json:"cloud_events"`
+
+	// Headers are static headers added to every request of the webhook.
+	// Their values are never returned. On update, a header without value keeps its existing value.
+	Headers []ExtraHeader `json:"headers,omitempty"`
+
+	// Auth defines how the webhook authenticates against the receiver.
+	Auth enum.WebhookAuthType `json:"auth"`
+	// AuthSecretSpaceID and AuthSecretIdentifier reference the secret holding the bearer token,
+	// or the PEM encoded private key of the client certificate.
+	AuthSecretSpaceID    int64  `json:"-"`
+	AuthSecretIdentifier string `json:"auth_secret_identifier,omitempty"`
+	// AuthSecretSpaceRef is the space of the auth secret. It's only used as input and defaults to the parent space.
+	AuthSecretSpaceRef string `json:"auth_secret_space_ref,omitempty"`
+	// ClientCertificate is the PEM encoded client certificate used for mTLS.
+	ClientCertificate string `json:"client_certificate,omitempty"`
+
+	// Signature defines how the payload is signed using the secret of the webhook.
+	Signature enum.WebhookSignatureType `json:"signature"`
+}
+
+// withoutHeaderValues returns a copy of the delivery options with the values of the static headers removed.
+func (d WebhookDelivery) withoutHeaderValues() WebhookDelivery {
+	if len(d.Headers) == 0 {
+		return d
+	}
+
+	headers := make([]ExtraHeader, len(d.Headers))
+	for i, header := range d.Headers {
+		headers[i] = ExtraHeader{Key: header.Key}
+	}
+	d.Headers = headers
+
+	return d
 }
 
 type WebhookSignatureMetadata struct {
@@ -93,15 +173,23 @@ type WebhookUpdateInput struct {
 	UID        *string `json:"uid" deprecated:"true"`
 	Identifier *string `json:"identifier"`
 	// TODO [CODE-1364]: Remove once UID/Identifier migration is completed.
-	DisplayName *string               `json:"display_name"`
-	Description *string               `json:"description"`
-	URL         *string               `json:"url"`
-	Secret      *string               `json:"secret"`
-	Enabled     *bool                 `json:"enabled"`
-	Insecure    *bool                 `json:"insecure"`
-	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	DisplayName *string                `json:"display_name"`
+	Description *string                `json:"description"`
+	URL         *string                `json:"url"`
+	Secret      *string                `json:"secret"`
+	Enabled     *bool                  `json:"enabled"`
+	Insecure    *bool                  `json:"insecure"`
+	Triggers    []enum.WebhookTrigger  `json:"triggers"`
+	Format      *enum.WebhookFormat    `json:"format"`
+	Template    *string                `json:"template"`
+	Filters     *WebhookTriggerFilters `json:"filters"`
+	Delivery    *WebhookDelivery       `json:"delivery"`
 }
 
+// WebhookTestTriggerIDPrefix is the prefix of the trigger ID of test deliveries of a webhook.
+// Test deliveries don't affect the state of the webhook and aren't redelivered.
+const WebhookTestTriggerIDPrefix = "test-"
+
 // WebhookExecution represents a single execution of a webhook.
 type WebhookExecution struct {
 	ID            int64                       `json:"id"`
@@ -177,6 +265,12 @@ type WebhookCore struct {
 	SecretIdentifier      string
 	SecretSpaceID         int64
 	ExtraHeaders          []ExtraHeader
+	Format                enum.WebhookFormat
+	Template              string
+	Filters               WebhookTriggerFilters
+	Delivery              WebhookDelivery
+	ConsecutiveFailures   int
+	DisabledReason        string
 }
 
 // WebhookExecutionCore represents a webhook execution DTO object.
@@ -199,3 +293,20 @@ type ExtraHeader struct {
 	Key   string `json:"key,omitempty"`
 	Value string `json:"value,omitempty"`
 }
+
+// WebhookRedeliverInput is used for redelivering the failed executions of a webhook.
+type WebhookRedeliverInput struct {
+	// Since is the time (in unix milliseconds) after which the failed executions are redelivered.
+	Since int64 `json:"since"`
+}
+
+// WebhookRedeliverOutput contains the number of failed executions scheduled for redelivery.
+type WebhookRedeliverOutput struct {
+	Count int `json:"count"`
+}
+
+// WebhookTestInput is used for sending a test delivery of a webhook.
+type WebhookTestInput struct {
+	// Trigger is the trigger used for the sample payload.
+	Trigger enum.WebhookTrigger `json:"trigger"`
+}
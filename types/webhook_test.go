@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWebhookMarshalJSONHidesHeaderValues(t *testing.T) {
+	hook := &Webhook{
+		Delivery: WebhookDelivery{
+			Headers: []ExtraHeader{{Key: "X-Api-Key", Value: "secret-key"}},
+		},
+	}
+
+	data, err := json.Marshal(hook)
+	if err != nil {
+		t.Fatalf("failed to marshal webhook: %s", err)
+	}
+
+	if strings.Contains(string(data), "secret-key") {
+		t.Errorf("header value must not be marshaled: %s", data)
+	}
+	if !strings.Contains(string(data), `"headers":[{"key":"X-Api-Key"}]`) {
+		t.Errorf("header key must be marshaled: %s", data)
+	}
+	if hook.Delivery.Headers[0].Value != "secret-key" {
+		t.Error("marshaling must not modify the webhook")
+	}
+}